@@ -631,9 +631,28 @@ func setDotCoreConfig(ctx *cli.Context, tomlCfg ctoml.CoreConfig, cfg *dot.CoreC
 		logger.Warn("invalid wasm interpreter set in config, defaulting to " + gssmr.DefaultWasmInterpreter)
 	}
 
+	switch tomlCfg.OffchainWorker {
+	case dot.OffchainWorkerAlways, dot.OffchainWorkerNever, dot.OffchainWorkerWhenValidating, "":
+		cfg.OffchainWorker = tomlCfg.OffchainWorker
+	default:
+		cfg.OffchainWorker = dot.OffchainWorkerWhenValidating
+		logger.Warn("invalid offchain worker mode set in config, defaulting to " + dot.OffchainWorkerWhenValidating)
+	}
+	cfg.OffchainWorkerTimeout = time.Second * time.Duration(tomlCfg.OffchainWorkerTimeout)
+
+	switch tomlCfg.SyncMode {
+	case dot.SyncModeFull, dot.SyncModeWarp, dot.SyncModeState, "":
+		cfg.SyncMode = tomlCfg.SyncMode
+	default:
+		cfg.SyncMode = dot.SyncModeFull
+		logger.Warn("invalid sync mode set in config, defaulting to " + dot.SyncModeFull)
+	}
+
 	logger.Debugf(
-		"core configuration: babe-authority=%t, grandpa-authority=%t wasm-interpreter=%s grandpa-interval=%s",
-		cfg.BabeAuthority, cfg.GrandpaAuthority, cfg.WasmInterpreter, cfg.GrandpaInterval)
+		"core configuration: babe-authority=%t, grandpa-authority=%t wasm-interpreter=%s grandpa-interval=%s "+
+			"offchain-worker=%s offchain-worker-timeout=%s sync-mode=%s",
+		cfg.BabeAuthority, cfg.GrandpaAuthority, cfg.WasmInterpreter, cfg.GrandpaInterval,
+		cfg.OffchainWorker, cfg.OffchainWorkerTimeout, cfg.SyncMode)
 }
 
 // setDotNetworkConfig sets dot.NetworkConfig using flag values from the cli context
@@ -647,6 +666,12 @@ func setDotNetworkConfig(ctx *cli.Context, tomlCfg ctoml.NetworkConfig, cfg *dot
 	cfg.MaxPeers = tomlCfg.MaxPeers
 	cfg.PersistentPeers = tomlCfg.PersistentPeers
 	cfg.DiscoveryInterval = time.Second * time.Duration(tomlCfg.DiscoveryInterval)
+	cfg.GrandpaInPeers = tomlCfg.GrandpaInPeers
+	cfg.GrandpaOutPeers = tomlCfg.GrandpaOutPeers
+	cfg.GrandpaReservedOnly = tomlCfg.GrandpaReservedOnly
+	cfg.TransactionsInPeers = tomlCfg.TransactionsInPeers
+	cfg.TransactionsOutPeers = tomlCfg.TransactionsOutPeers
+	cfg.TransactionsReservedOnly = tomlCfg.TransactionsReservedOnly
 
 	// check --port flag and update node configuration
 	if port := ctx.GlobalUint(PortFlag.Name); port != 0 {
@@ -695,10 +720,14 @@ func setDotNetworkConfig(ctx *cli.Context, tomlCfg ctoml.NetworkConfig, cfg *dot
 	logger.Debugf(
 		"network configuration: port=%d bootnodes=%s protocol=%s nobootstrap=%t "+
 			"nomdns=%t minpeers=%d maxpeers=%d persistent-peers=%s "+
-			"discovery-interval=%s",
+			"discovery-interval=%s grandpa-in-peers=%d grandpa-out-peers=%d "+
+			"grandpa-reserved-only=%t transactions-in-peers=%d transactions-out-peers=%d "+
+			"transactions-reserved-only=%t",
 		cfg.Port, strings.Join(cfg.Bootnodes, ","), cfg.ProtocolID, cfg.NoBootstrap,
 		cfg.NoMDNS, cfg.MinPeers, cfg.MaxPeers, strings.Join(cfg.PersistentPeers, ","),
-		cfg.DiscoveryInterval,
+		cfg.DiscoveryInterval, cfg.GrandpaInPeers, cfg.GrandpaOutPeers,
+		cfg.GrandpaReservedOnly, cfg.TransactionsInPeers, cfg.TransactionsOutPeers,
+		cfg.TransactionsReservedOnly,
 	)
 }
 
@@ -935,4 +964,6 @@ func setStateConfig(ctx *cli.Context, tomlCfg ctoml.StateConfig, cfg *dot.StateC
 	} else if tomlCfg.Rewind > 0 {
 		cfg.Rewind = tomlCfg.Rewind
 	}
+
+	cfg.TransactionIndexRetention = tomlCfg.TransactionIndexRetention
 }
@@ -101,10 +101,13 @@ func dotConfigToToml(dcfg *dot.Config) *ctoml.Config {
 	}
 
 	cfg.Core = ctoml.CoreConfig{
-		Roles:            byte(dcfg.Core.Roles),
-		BabeAuthority:    dcfg.Core.BabeAuthority,
-		GrandpaAuthority: dcfg.Core.GrandpaAuthority,
-		GrandpaInterval:  uint32(dcfg.Core.GrandpaInterval / time.Second),
+		Roles:                 byte(dcfg.Core.Roles),
+		BabeAuthority:         dcfg.Core.BabeAuthority,
+		GrandpaAuthority:      dcfg.Core.GrandpaAuthority,
+		GrandpaInterval:       uint32(dcfg.Core.GrandpaInterval / time.Second),
+		OffchainWorker:        dcfg.Core.OffchainWorker,
+		OffchainWorkerTimeout: uint32(dcfg.Core.OffchainWorkerTimeout / time.Second),
+		SyncMode:              dcfg.Core.SyncMode,
 	}
 
 	cfg.Network = ctoml.NetworkConfig{
@@ -116,6 +119,13 @@ func dotConfigToToml(dcfg *dot.Config) *ctoml.Config {
 		DiscoveryInterval: int(dcfg.Network.DiscoveryInterval / time.Second),
 		MinPeers:          dcfg.Network.MinPeers,
 		MaxPeers:          dcfg.Network.MaxPeers,
+
+		GrandpaInPeers:           dcfg.Network.GrandpaInPeers,
+		GrandpaOutPeers:          dcfg.Network.GrandpaOutPeers,
+		GrandpaReservedOnly:      dcfg.Network.GrandpaReservedOnly,
+		TransactionsInPeers:      dcfg.Network.TransactionsInPeers,
+		TransactionsOutPeers:     dcfg.Network.TransactionsOutPeers,
+		TransactionsReservedOnly: dcfg.Network.TransactionsReservedOnly,
 	}
 
 	cfg.RPC = ctoml.RPCConfig{
@@ -0,0 +1,534 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const scalePkgPath = "github.com/ChainSafe/gossamer/pkg/scale"
+
+var (
+	errTypeNotFound       = errors.New("type not found")
+	errTypeNotStruct      = errors.New("type is not a struct")
+	errUnsupportedType    = errors.New("unsupported type")
+	errUnsupportedPackage = errors.New("unsupported package")
+)
+
+type generator struct {
+	pkg *types.Package
+	// generated contains the types for which methods are generated,
+	// whose encodeSCALE method can be called directly.
+	generated map[*types.Named]struct{}
+	// imports maps import paths to package names.
+	imports map[string]string
+	body    bytes.Buffer
+	// variables is used to give unique names to the variables declared.
+	variables int
+}
+
+// generate returns the formatted Go source code of the SCALE encoding
+// and decoding methods of the struct types named in the package given.
+func generate(pkg *types.Package, typeNames []string) (code []byte, err error) {
+	if pkg.Path() == scalePkgPath {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedPackage, pkg.Path())
+	}
+
+	g := &generator{
+		pkg:       pkg,
+		generated: make(map[*types.Named]struct{}, len(typeNames)),
+		imports: map[string]string{
+			"bytes": "bytes",
+			"io":    "io",
+		},
+	}
+
+	namedTypes := make([]*types.Named, len(typeNames))
+	for i, typeName := range typeNames {
+		typeName = strings.TrimSpace(typeName)
+		object := pkg.Scope().Lookup(typeName)
+		typeObject, ok := object.(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errTypeNotFound, typeName)
+		}
+
+		named, ok := typeObject.Type().(*types.Named)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errTypeNotStruct, typeName)
+		}
+
+		_, ok = named.Underlying().(*types.Struct)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", errTypeNotStruct, typeName)
+		}
+
+		namedTypes[i] = named
+		g.generated[named] = struct{}{}
+	}
+
+	for _, named := range namedTypes {
+		err = g.generateType(named)
+		if err != nil {
+			return nil, fmt.Errorf("generating methods for %s: %w", named.Obj().Name(), err)
+		}
+	}
+
+	if bytes.Contains(g.body.Bytes(), []byte("fmt.")) {
+		g.imports["fmt"] = "fmt"
+	}
+	if bytes.Contains(g.body.Bytes(), []byte("scale.")) {
+		g.imports[scalePkgPath] = "scale"
+	}
+
+	// standard library imports are grouped before other imports.
+	var standardImports, otherImports []string
+	for importPath := range g.imports {
+		firstElement := strings.Split(importPath, "/")[0]
+		if strings.Contains(firstElement, ".") {
+			otherImports = append(otherImports, importPath)
+		} else {
+			standardImports = append(standardImports, importPath)
+		}
+	}
+	sort.Strings(standardImports)
+	sort.Strings(otherImports)
+
+	source := bytes.NewBuffer(nil)
+	fmt.Fprintf(source, "// Code generated by scalegen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(source, "package %s\n\n", pkg.Name())
+	fmt.Fprintf(source, "import (\n")
+	for _, importPath := range standardImports {
+		fmt.Fprintf(source, "%q\n", importPath)
+	}
+	if len(otherImports) > 0 {
+		fmt.Fprintf(source, "\n")
+	}
+	for _, importPath := range otherImports {
+		fmt.Fprintf(source, "%q\n", importPath)
+	}
+	fmt.Fprintf(source, ")\n")
+	_, _ = source.Write(g.body.Bytes())
+
+	code, err = format.Source(source.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return code, nil
+}
+
+// structField is a struct field to encode and decode.
+type structField struct {
+	name      string
+	fieldType types.Type
+	index     int
+	// scaleIndex is the value of the scale struct tag,
+	// or nil if the field has no scale struct tag.
+	scaleIndex *string
+}
+
+// orderedFields returns the exported fields of the struct to encode,
+// ordered as done by the scale package: fields with a scale tag come
+// first ordered by their tag, followed by the other fields in their
+// declaration order. Fields with the scale tag "-" are ignored.
+func orderedFields(structType *types.Struct) (fields []structField) {
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+		if !field.Exported() {
+			continue
+		}
+
+		tag := reflect.StructTag(structType.Tag(i)).Get("scale")
+		var scaleIndex *string
+		switch strings.TrimSpace(tag) {
+		case "":
+		case "-":
+			continue
+		default:
+			scaleIndex = &tag
+		}
+
+		fields = append(fields, structField{
+			name:       field.Name(),
+			fieldType:  field.Type(),
+			index:      i,
+			scaleIndex: scaleIndex,
+		})
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		switch {
+		case fields[i].scaleIndex == nil && fields[j].scaleIndex != nil:
+			return false
+		case fields[i].scaleIndex != nil && fields[j].scaleIndex == nil:
+			return true
+		case fields[i].scaleIndex == nil && fields[j].scaleIndex == nil:
+			return fields[i].index < fields[j].index
+		default:
+			return *fields[i].scaleIndex < *fields[j].scaleIndex
+		}
+	})
+
+	return fields
+}
+
+// receiverName returns the receiver name used by the existing methods
+// of the type, or the lower cased first letter of the type name.
+func receiverName(named *types.Named) string {
+	for i := 0; i < named.NumMethods(); i++ {
+		signature := named.Method(i).Type().(*types.Signature)
+		name := signature.Recv().Name()
+		if name != "" && name != "_" {
+			return name
+		}
+	}
+	return string(unicode.ToLower(rune(named.Obj().Name()[0])))
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.body, format, args...)
+}
+
+func (g *generator) newVariable(prefix string) string {
+	name := fmt.Sprintf("%s%d", prefix, g.variables)
+	g.variables++
+	return name
+}
+
+func (g *generator) qualifier(pkg *types.Package) string {
+	if pkg == g.pkg {
+		return ""
+	}
+	g.imports[pkg.Path()] = pkg.Name()
+	return pkg.Name()
+}
+
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, g.qualifier)
+}
+
+func (g *generator) generateType(named *types.Named) (err error) {
+	typeName := named.Obj().Name()
+	receiver := receiverName(named)
+	fields := orderedFields(named.Underlying().(*types.Struct))
+	g.variables = 0
+
+	g.printf("\n// MarshalSCALE returns the SCALE encoding of the %s.\n", typeName)
+	g.printf("func (%s %s) MarshalSCALE() ([]byte, error) {\n", receiver, typeName)
+	g.printf("buffer := bytes.NewBuffer(nil)\n")
+	g.printf("err := %s.encodeSCALE(buffer)\n", receiver)
+	g.printf("if err != nil {\nreturn nil, err\n}\n")
+	g.printf("return buffer.Bytes(), nil\n}\n")
+
+	g.printf("\n// encodeSCALE writes the SCALE encoding of the %s to the writer.\n", typeName)
+	g.printf("func (%s %s) encodeSCALE(writer io.Writer) (err error) {\n", receiver, typeName)
+	for _, field := range fields {
+		err = g.encode(receiver+"."+field.name, field.fieldType, field.name)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.name, err)
+		}
+	}
+	g.printf("return nil\n}\n")
+
+	g.printf("\n// UnmarshalSCALE decodes the SCALE encoding read from the reader into the %s.\n", typeName)
+	g.printf("func (%s *%s) UnmarshalSCALE(reader io.Reader) (err error) {\n", receiver, typeName)
+	g.printf("decoded := %s{}\n", typeName)
+	for _, field := range fields {
+		// As done by the scale package, structs and options are decoded
+		// into their existing value, which is needed to decode some types
+		// such as scale.VaryingDataTypeSlice.
+		switch field.fieldType.Underlying().(type) {
+		case *types.Basic, *types.Slice, *types.Array:
+		default:
+			g.printf("decoded.%[1]s = %[2]s.%[1]s\n", field.name, receiver)
+		}
+	}
+	for _, field := range fields {
+		err = g.decode("decoded."+field.name, field.fieldType, field.name)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.name, err)
+		}
+	}
+	g.printf("*%s = decoded\n", receiver)
+	g.printf("return nil\n}\n")
+
+	return nil
+}
+
+func (g *generator) isGenerated(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	_, ok = g.generated[named]
+	return ok
+}
+
+// isReflective returns true if the type is encoded and decoded
+// using the reflection based scale encoder and decoder.
+func (g *generator) isReflective(t types.Type) bool {
+	if g.isGenerated(t) || hasMethod(t, "MarshalSCALE") {
+		return false
+	}
+
+	switch t := t.(type) {
+	case *types.Named:
+		pkg := t.Obj().Pkg()
+		if pkg != nil && (pkg.Path() == scalePkgPath || pkg.Path() == "math/big") {
+			return true
+		}
+	case *types.Pointer:
+		// *big.Int and *scale.Uint128 are not encoded as options.
+		return g.isReflective(t.Elem())
+	}
+
+	switch t.Underlying().(type) {
+	case *types.Struct, *types.Interface, *types.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+// hasMethod returns true if the non pointer type given,
+// or a pointer to it, has a method with the name given.
+func hasMethod(t types.Type, name string) bool {
+	if _, ok := t.Underlying().(*types.Pointer); ok {
+		return false
+	}
+	methodSet := types.NewMethodSet(types.NewPointer(t))
+	return methodSet.Lookup(nil, name) != nil
+}
+
+var byteSliceType = types.NewSlice(types.Universe.Lookup("byte").Type())
+
+func isByte(t types.Type) bool {
+	return types.Identical(t, types.Typ[types.Byte])
+}
+
+// operand returns the expression given enclosed in parentheses if
+// it is a pointer indirection, so it can be indexed or sliced.
+func operand(expr string) string {
+	if strings.HasPrefix(expr, "*") {
+		return "(" + expr + ")"
+	}
+	return expr
+}
+
+// receiverOperand returns the expression to call methods on, removing
+// a pointer indirection since methods are called on pointers as well.
+func receiverOperand(expr string) string {
+	return strings.TrimPrefix(expr, "*")
+}
+
+// convert returns the expression converted to the type string given,
+// unless the expression type is already this type.
+func (g *generator) convert(expr string, from types.Type, to types.Type) string {
+	if types.Identical(from, to) {
+		return expr
+	}
+	return g.typeString(to) + "(" + expr + ")"
+}
+
+func (g *generator) checkError(operation, context string) {
+	g.printf("if err != nil {\nreturn fmt.Errorf(\"%s %s: %%w\", err)\n}\n", operation, context)
+}
+
+// basicTypeFunctions maps basic kinds to the names of the
+// scale functions used to encode and decode them, and to the
+// basic type used as argument to these functions.
+var basicTypeFunctions = map[types.BasicKind]struct {
+	suffix    string
+	basicType types.Type
+}{
+	types.Bool:   {suffix: "Bool", basicType: types.Typ[types.Bool]},
+	types.Int:    {suffix: "CompactUint", basicType: types.Typ[types.Uint]},
+	types.Uint:   {suffix: "CompactUint", basicType: types.Typ[types.Uint]},
+	types.Int8:   {suffix: "Uint8", basicType: types.Typ[types.Uint8]},
+	types.Uint8:  {suffix: "Uint8", basicType: types.Typ[types.Uint8]},
+	types.Int16:  {suffix: "Uint16", basicType: types.Typ[types.Uint16]},
+	types.Uint16: {suffix: "Uint16", basicType: types.Typ[types.Uint16]},
+	types.Int32:  {suffix: "Uint32", basicType: types.Typ[types.Uint32]},
+	types.Uint32: {suffix: "Uint32", basicType: types.Typ[types.Uint32]},
+	types.Int64:  {suffix: "Uint64", basicType: types.Typ[types.Uint64]},
+	types.Uint64: {suffix: "Uint64", basicType: types.Typ[types.Uint64]},
+	types.String: {suffix: "Bytes", basicType: byteSliceType},
+}
+
+// encode writes the statements encoding the expression of the type given.
+func (g *generator) encode(expr string, t types.Type, context string) (err error) {
+	switch {
+	case g.isGenerated(t):
+		g.printf("err = %s.encodeSCALE(writer)\n", receiverOperand(expr))
+		g.checkError("encoding", context)
+		return nil
+	case hasMethod(t, "MarshalSCALE"):
+		encoding := g.newVariable("encoding")
+		g.printf("var %s []byte\n", encoding)
+		g.printf("%s, err = %s.MarshalSCALE()\n", encoding, receiverOperand(expr))
+		g.checkError("encoding", context)
+		g.printf("_, err = writer.Write(%s)\n", encoding)
+		g.checkError("encoding", context)
+		return nil
+	case g.isReflective(t):
+		g.printf("err = scale.NewEncoder(writer).Encode(%s)\n", expr)
+		g.checkError("encoding", context)
+		return nil
+	}
+
+	switch underlying := t.Underlying().(type) {
+	case *types.Basic:
+		functions, ok := basicTypeFunctions[underlying.Kind()]
+		if !ok {
+			return fmt.Errorf("%w: %s", errUnsupportedType, t)
+		}
+		g.printf("err = scale.Encode%s(writer, %s)\n", functions.suffix,
+			g.convert(expr, t, functions.basicType))
+		g.checkError("encoding", context)
+	case *types.Slice:
+		if isByte(underlying.Elem()) {
+			g.printf("err = scale.EncodeBytes(writer, %s)\n",
+				g.convert(expr, t, byteSliceType))
+			g.checkError("encoding", context)
+			return nil
+		}
+
+		g.printf("err = scale.EncodeCompactUint(writer, uint(len(%s)))\n", expr)
+		g.checkError("encoding", context)
+		element := g.newVariable("v")
+		g.printf("for _, %s := range %s {\n", element, expr)
+		err = g.encode(element, underlying.Elem(), context)
+		if err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case *types.Array:
+		if isByte(underlying.Elem()) {
+			g.printf("_, err = writer.Write(%s[:])\n", operand(expr))
+			g.checkError("encoding", context)
+			return nil
+		}
+
+		element := g.newVariable("v")
+		g.printf("for _, %s := range %s {\n", element, expr)
+		err = g.encode(element, underlying.Elem(), context)
+		if err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case *types.Pointer:
+		// pointers are encoded as options
+		g.printf("if %s == nil {\n", expr)
+		g.printf("_, err = writer.Write([]byte{0})\n")
+		g.checkError("encoding", context)
+		g.printf("} else {\n")
+		g.printf("_, err = writer.Write([]byte{1})\n")
+		g.checkError("encoding", context)
+		err = g.encode("*"+expr, underlying.Elem(), context)
+		if err != nil {
+			return err
+		}
+		g.printf("}\n")
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedType, t)
+	}
+	return nil
+}
+
+// decode writes the statements decoding into the addressable
+// expression of the type given.
+func (g *generator) decode(expr string, t types.Type, context string) (err error) {
+	switch {
+	case g.isGenerated(t), hasMethod(t, "UnmarshalSCALE"):
+		g.printf("err = %s.UnmarshalSCALE(reader)\n", receiverOperand(expr))
+		g.checkError("decoding", context)
+		return nil
+	case g.isReflective(t):
+		g.printf("err = scale.NewDecoder(reader).Decode(&%s)\n", expr)
+		g.checkError("decoding", context)
+		return nil
+	}
+
+	switch underlying := t.Underlying().(type) {
+	case *types.Basic:
+		functions, ok := basicTypeFunctions[underlying.Kind()]
+		if !ok {
+			return fmt.Errorf("%w: %s", errUnsupportedType, t)
+		}
+		value := g.newVariable("v")
+		g.printf("var %s %s\n", value, g.typeString(functions.basicType))
+		g.printf("%s, err = scale.Decode%s(reader)\n", value, functions.suffix)
+		g.checkError("decoding", context)
+		g.printf("%s = %s\n", expr, g.convert(value, functions.basicType, t))
+	case *types.Slice:
+		if isByte(underlying.Elem()) {
+			value := g.newVariable("v")
+			g.printf("var %s []byte\n", value)
+			g.printf("%s, err = scale.DecodeBytes(reader)\n", value)
+			g.checkError("decoding", context)
+			g.printf("%s = %s\n", expr, g.convert(value, byteSliceType, t))
+			return nil
+		}
+
+		length := g.newVariable("length")
+		g.printf("var %s uint\n", length)
+		g.printf("%s, err = scale.DecodeLength(reader)\n", length)
+		g.checkError("decoding", context)
+		if strings.HasPrefix(expr, "*") {
+			// reset the slice of an existing option value
+			g.printf("%s = nil\n", expr)
+		}
+		index := g.newVariable("i")
+		g.printf("for %[1]s := uint(0); %[1]s < %[2]s; %[1]s++ {\n", index, length)
+		element := g.newVariable("v")
+		g.printf("var %s %s\n", element, g.typeString(underlying.Elem()))
+		err = g.decode(element, underlying.Elem(), context)
+		if err != nil {
+			return err
+		}
+		g.printf("%[1]s = append(%[1]s, %[2]s)\n", expr, element)
+		g.printf("}\n")
+	case *types.Array:
+		if isByte(underlying.Elem()) {
+			g.printf("_, err = io.ReadFull(reader, %s[:])\n", operand(expr))
+			g.checkError("decoding", context)
+			return nil
+		}
+
+		index := g.newVariable("i")
+		g.printf("for %s := range %s {\n", index, expr)
+		err = g.decode(operand(expr)+"["+index+"]", underlying.Elem(), context)
+		if err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case *types.Pointer:
+		// pointers are decoded as options
+		option := g.newVariable("option")
+		g.printf("var %s uint8\n", option)
+		g.printf("%s, err = scale.DecodeUint8(reader)\n", option)
+		g.checkError("decoding", context)
+		g.printf("switch %s {\n", option)
+		g.printf("case 0:\n%s = nil\n", expr)
+		g.printf("case 1:\n")
+		g.printf("if %s == nil {\n%s = new(%s)\n}\n", expr, expr, g.typeString(underlying.Elem()))
+		err = g.decode("*"+expr, underlying.Elem(), context)
+		if err != nil {
+			return err
+		}
+		g.printf("default:\n")
+		g.printf("return fmt.Errorf(\"decoding %s: unsupported Option value: %%d\", %s)\n", context, option)
+		g.printf("}\n")
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedType, t)
+	}
+	return nil
+}
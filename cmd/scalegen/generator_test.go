@@ -0,0 +1,143 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPackage type checks the source given as a single file package.
+func newTestPackage(t *testing.T, source string) *types.Package {
+	t.Helper()
+
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "test.go", source, 0)
+	require.NoError(t, err)
+
+	config := &types.Config{}
+	pkg, err := config.Check("example.com/test", fileSet, []*ast.File{file}, nil)
+	require.NoError(t, err)
+	return pkg
+}
+
+func Test_orderedFields(t *testing.T) {
+	t.Parallel()
+
+	pkg := newTestPackage(t, `package test
+
+type Fields struct {
+	A uint8
+	B uint8 `+"`scale:\"2\"`"+`
+	c uint8
+	D uint8 `+"`scale:\"-\"`"+`
+	E uint8
+	F uint8 `+"`scale:\"1\"`"+`
+}
+`)
+
+	structType := pkg.Scope().Lookup("Fields").Type().Underlying().(*types.Struct)
+
+	fields := orderedFields(structType)
+
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.name
+	}
+	assert.Equal(t, []string{"F", "B", "A", "E"}, names)
+}
+
+func Test_generate(t *testing.T) {
+	t.Parallel()
+
+	pkg := newTestPackage(t, `package test
+
+type Kind byte
+
+type Inner struct {
+	Kind Kind
+}
+
+type Outer struct {
+	Number  uint
+	Inner   *Inner
+	Numbers []uint16
+	Hash    [32]byte
+}
+
+func (o *Outer) String() string { return "" }
+`)
+
+	code, err := generate(pkg, []string{"Outer", "Inner"})
+	require.NoError(t, err)
+
+	// check the generated code is valid Go source code.
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "scale_generated.go", code, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "test", file.Name.Name)
+
+	source := string(code)
+	assert.Contains(t, source, "// Code generated by scalegen. DO NOT EDIT.")
+	assert.Contains(t, source, "func (o Outer) MarshalSCALE() ([]byte, error) {")
+	assert.Contains(t, source, "func (o *Outer) UnmarshalSCALE(reader io.Reader) (err error) {")
+	assert.Contains(t, source, "func (i Inner) encodeSCALE(writer io.Writer) (err error) {")
+	assert.Contains(t, source, "err = o.Inner.encodeSCALE(writer)")
+	assert.Contains(t, source, "err = scale.EncodeUint8(writer, uint8(i.Kind))")
+	assert.Contains(t, source, "_, err = io.ReadFull(reader, decoded.Hash[:])")
+}
+
+func Test_generate_errors(t *testing.T) {
+	t.Parallel()
+
+	pkg := newTestPackage(t, `package test
+
+type Number uint
+
+type Unsupported struct {
+	Float float64
+}
+`)
+
+	testCases := map[string]struct {
+		typeName   string
+		errWrapped error
+		errMessage string
+	}{
+		"type not found": {
+			typeName:   "Missing",
+			errWrapped: errTypeNotFound,
+			errMessage: "type not found: Missing",
+		},
+		"type not struct": {
+			typeName:   "Number",
+			errWrapped: errTypeNotStruct,
+			errMessage: "type is not a struct: Number",
+		},
+		"unsupported field type": {
+			typeName:   "Unsupported",
+			errWrapped: errUnsupportedType,
+			errMessage: "generating methods for Unsupported: field Float: unsupported type: float64",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			code, err := generate(pkg, []string{testCase.typeName})
+
+			assert.Nil(t, code)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.EqualError(t, err, testCase.errMessage)
+		})
+	}
+}
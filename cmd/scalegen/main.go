@@ -0,0 +1,101 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Command scalegen generates reflection free SCALE encoding and decoding
+// methods for struct types. For each type given, it generates the
+// MarshalSCALE and UnmarshalSCALE methods implementing the scale.Marshaler
+// and scale.Unmarshaler interfaces, producing the same encoding as the
+// reflection based scale.Marshal and scale.Unmarshal functions.
+//
+// It is meant to be used with go generate, for example:
+//
+//	//go:generate go run github.com/ChainSafe/gossamer/cmd/scalegen -type=Header,Block
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma separated list of struct type names to generate methods for")
+	output := flag.String("output", "scale_generated.go", "output file name")
+	flag.Parse()
+
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	err := run(".", strings.Split(*typeNames, ","), *output)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "scalegen:", err)
+		os.Exit(1)
+	}
+}
+
+var errPackageLoad = errors.New("cannot load package")
+
+func run(dir string, typeNames []string, output string) (err error) {
+	outputPath, err := filepath.Abs(filepath.Join(dir, output))
+	if err != nil {
+		return fmt.Errorf("getting absolute output path: %w", err)
+	}
+
+	// Replace a previously generated file with its package clause only,
+	// so the package type checks even if the generated methods no longer
+	// match the types, and so the types are not seen as already
+	// implementing the scale.Marshaler and scale.Unmarshaler interfaces.
+	overlay := make(map[string][]byte)
+	file, err := parser.ParseFile(token.NewFileSet(), outputPath, nil, parser.PackageClauseOnly)
+	if err == nil {
+		overlay[outputPath] = []byte("package " + file.Name.Name + "\n")
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("parsing output file: %w", err)
+	}
+
+	config := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo |
+			packages.NeedSyntax | packages.NeedImports | packages.NeedDeps,
+		Dir:     dir,
+		Overlay: overlay,
+	}
+	pkgs, err := packages.Load(config, ".")
+	if err != nil {
+		return fmt.Errorf("loading package: %w", err)
+	}
+
+	if len(pkgs) != 1 {
+		return fmt.Errorf("%w: expected 1 package but got %d", errPackageLoad, len(pkgs))
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return fmt.Errorf("%w: %s", errPackageLoad, pkg.Errors[0])
+	}
+
+	code, err := generate(pkg.Types, typeNames)
+	if err != nil {
+		return err
+	}
+
+	outputFile, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("creating output file: %w", err)
+	}
+
+	_, err = outputFile.Write(code)
+	if err != nil {
+		_ = outputFile.Close()
+		return fmt.Errorf("writing output file: %w", err)
+	}
+
+	return outputFile.Close()
+}
@@ -103,16 +103,53 @@ type NetworkConfig struct {
 	DiscoveryInterval time.Duration
 	PublicIP          string
 	PublicDNS         string
+	// GrandpaInPeers, GrandpaOutPeers and GrandpaReservedOnly configure the
+	// slots of the GRANDPA peer set, using the default slots if all unset.
+	GrandpaInPeers      uint32
+	GrandpaOutPeers     uint32
+	GrandpaReservedOnly bool
+	// TransactionsInPeers, TransactionsOutPeers and TransactionsReservedOnly configure
+	// the slots of the transactions peer set, using the default slots if all unset.
+	TransactionsInPeers      uint32
+	TransactionsOutPeers     uint32
+	TransactionsReservedOnly bool
 }
 
+// Offchain worker modes, deciding when the runtime offchain worker runs
+const (
+	// OffchainWorkerAlways runs the offchain worker for any node role
+	OffchainWorkerAlways = "always"
+	// OffchainWorkerNever never runs the offchain worker
+	OffchainWorkerNever = "never"
+	// OffchainWorkerWhenValidating runs the offchain worker only for authority nodes
+	OffchainWorkerWhenValidating = "when-validating"
+)
+
+// Sync modes, deciding how the node syncs the chain on startup
+const (
+	// SyncModeFull imports and executes every block from the highest finalised block
+	SyncModeFull = "full"
+	// SyncModeWarp jumps to the latest finalised block using warp sync proofs
+	// and downloads its state from peers, since the blocks following it cannot
+	// be executed without it
+	SyncModeWarp = "warp"
+	// SyncModeState jumps to a recent finalised block justified by the current
+	// authority set, without warp sync proofs, and downloads its state from
+	// peers instead of executing every block.
+	SyncModeState = "state"
+)
+
 // CoreConfig is to marshal/unmarshal toml core config vars
 type CoreConfig struct {
-	Roles            common.Roles
-	BabeAuthority    bool
-	BABELead         bool
-	GrandpaAuthority bool
-	WasmInterpreter  string
-	GrandpaInterval  time.Duration
+	Roles                 common.Roles
+	BabeAuthority         bool
+	BABELead              bool
+	GrandpaAuthority      bool
+	WasmInterpreter       string
+	GrandpaInterval       time.Duration
+	OffchainWorker        string
+	OffchainWorkerTimeout time.Duration
+	SyncMode              string
 }
 
 // RPCConfig is to marshal/unmarshal toml RPC config vars
@@ -160,10 +197,14 @@ func (r *RPCConfig) String() string {
 // StateConfig is the config for the State service
 type StateConfig struct {
 	Rewind uint
+	// TransactionIndexRetention is the number of finalised blocks for which
+	// indexed transactions are kept. Zero means they are kept forever.
+	TransactionIndexRetention uint32
 }
 
 func (s *StateConfig) String() string {
-	return "rewind " + fmt.Sprint(s.Rewind)
+	return "rewind " + fmt.Sprint(s.Rewind) +
+		" transaction-index-retention " + fmt.Sprint(s.TransactionIndexRetention)
 }
 
 // networkServiceEnabled returns true if the network service is enabled
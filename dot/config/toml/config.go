@@ -64,23 +64,34 @@ type NetworkConfig struct {
 	DiscoveryInterval int      `toml:"discovery-interval,omitempty"`
 	PublicIP          string   `toml:"public-ip,omitempty"`
 	PublicDNS         string   `toml:"public-dns,omitempty"`
+
+	GrandpaInPeers           uint32 `toml:"grandpa-in-peers,omitempty"`
+	GrandpaOutPeers          uint32 `toml:"grandpa-out-peers,omitempty"`
+	GrandpaReservedOnly      bool   `toml:"grandpa-reserved-only,omitempty"`
+	TransactionsInPeers      uint32 `toml:"transactions-in-peers,omitempty"`
+	TransactionsOutPeers     uint32 `toml:"transactions-out-peers,omitempty"`
+	TransactionsReservedOnly bool   `toml:"transactions-reserved-only,omitempty"`
 }
 
 // CoreConfig is to marshal/unmarshal toml core config vars
 type CoreConfig struct {
-	Roles            byte   `toml:"roles,omitempty"`
-	BabeAuthority    bool   `toml:"babe-authority"`
-	GrandpaAuthority bool   `toml:"grandpa-authority"`
-	SlotDuration     uint64 `toml:"slot-duration,omitempty"`
-	EpochLength      uint64 `toml:"epoch-length,omitempty"`
-	WasmInterpreter  string `toml:"wasm-interpreter,omitempty"`
-	GrandpaInterval  uint32 `toml:"grandpa-interval,omitempty"`
-	BABELead         bool   `toml:"babe-lead,omitempty"`
+	Roles                 byte   `toml:"roles,omitempty"`
+	BabeAuthority         bool   `toml:"babe-authority"`
+	GrandpaAuthority      bool   `toml:"grandpa-authority"`
+	SlotDuration          uint64 `toml:"slot-duration,omitempty"`
+	EpochLength           uint64 `toml:"epoch-length,omitempty"`
+	WasmInterpreter       string `toml:"wasm-interpreter,omitempty"`
+	GrandpaInterval       uint32 `toml:"grandpa-interval,omitempty"`
+	BABELead              bool   `toml:"babe-lead,omitempty"`
+	OffchainWorker        string `toml:"offchain-worker,omitempty"`
+	OffchainWorkerTimeout uint32 `toml:"offchain-worker-timeout,omitempty"`
+	SyncMode              string `toml:"sync-mode,omitempty"`
 }
 
 // StateConfig contains the configuration for the state.
 type StateConfig struct {
-	Rewind uint `toml:"rewind,omitempty"`
+	Rewind                    uint   `toml:"rewind,omitempty"`
+	TransactionIndexRetention uint32 `toml:"transaction-index-retention,omitempty"`
 }
 
 // RPCConfig is to marshal/unmarshal toml RPC config vars
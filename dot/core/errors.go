@@ -21,4 +21,10 @@
 
 	// ErrEmptyRuntimeCode is returned when the storage :code is empty
 	ErrEmptyRuntimeCode = errors.New("new :code is empty")
+
+	// ErrExtrinsicBanned is returned when a submitted extrinsic is temporarily banned
+	ErrExtrinsicBanned = errors.New("extrinsic is temporarily banned")
+
+	// ErrOffchainWorkerTimeout is returned when the offchain worker does not complete in time
+	ErrOffchainWorkerTimeout = errors.New("offchain worker timed out")
 )
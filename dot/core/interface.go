@@ -12,6 +12,8 @@
 	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
@@ -28,11 +30,22 @@ type RuntimeInstance interface {
 	Validator() bool
 	Exec(function string, data []byte) ([]byte, error)
 	SetContextStorage(s runtime.Storage)
+	ContextStorage() runtime.Storage
 	GetCodeHash() common.Hash
 	Version() (version runtime.Version)
 	Metadata() ([]byte, error)
 	BabeConfiguration() (*types.BabeConfiguration, error)
+	BabeCurrentEpoch() (*types.BabeEpoch, error)
+	BabeNextEpoch() (*types.BabeEpoch, error)
+	BabeGenerateKeyOwnershipProof(slot uint64, authorityID [sr25519.PublicKeyLength]byte) (
+		types.BabeOpaqueKeyOwnershipProof, error)
+	BabeSubmitReportEquivocationUnsignedExtrinsic(equivocationProof types.BabeEquivocationProof,
+		keyOwnershipProof types.BabeOpaqueKeyOwnershipProof) error
 	GrandpaAuthorities() ([]types.Authority, error)
+	GrandpaGenerateKeyOwnershipProof(authSetID uint64, authorityID ed25519.PublicKeyBytes) (
+		types.GrandpaOpaqueKeyOwnershipProof, error)
+	GrandpaSubmitReportEquivocationUnsignedExtrinsic(equivocationProof types.GrandpaEquivocationProof,
+		keyOwnershipProof types.GrandpaOpaqueKeyOwnershipProof) error
 	ValidateTransaction(e types.Extrinsic) (*transaction.Validity, error)
 	InitializeBlock(header *types.Header) error
 	InherentExtrinsics(data []byte) ([]byte, error)
@@ -40,11 +53,11 @@ type RuntimeInstance interface {
 	FinalizeBlock() (*types.Header, error)
 	ExecuteBlock(block *types.Block) ([]byte, error)
 	DecodeSessionKeys(enc []byte) ([]byte, error)
+	GenerateSessionKeys(seed *[]byte) ([]byte, error)
 	PaymentQueryInfo(ext []byte) (*types.TransactionPaymentQueryInfo, error)
+	OffchainWorker(header *types.Header) error
 	CheckInherents()
 	RandomSeed()
-	OffchainWorker()
-	GenerateSessionKeys()
 }
 
 // BlockState interface for block state methods
@@ -72,6 +85,7 @@ type BlockState interface {
 	HandleRuntimeChanges(newState *rtstorage.TrieState, in runtime.Instance, bHash common.Hash) error
 	GetRuntime(*common.Hash) (runtime.Instance, error)
 	StoreRuntime(common.Hash, runtime.Instance)
+	StoreIndexedTransactions(block *types.Block, operations []rtstorage.TransactionIndexOperation) error
 }
 
 // StorageState interface for storage state methods
@@ -94,6 +108,7 @@ type TransactionState interface {
 	RemoveExtrinsicFromPool(ext types.Extrinsic)
 	PendingInPool() []*transaction.ValidTransaction
 	Exists(ext types.Extrinsic) bool
+	IsBanned(hash common.Hash) bool
 }
 
 // Network is the interface for the network service
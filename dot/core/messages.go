@@ -72,6 +72,12 @@ func (s *Service) HandleTransactionMessage(peerID peer.ID, msg *network.Transact
 
 	allTxnsAreValid := true
 	for _, tx := range txs {
+		if s.transactionState.IsBanned(tx.Hash()) {
+			logger.Debugf("ignoring banned transaction %s", tx.Hash())
+			allTxnsAreValid = false
+			continue
+		}
+
 		txnIsValid := true
 		validity, err := s.validateTransaction(head, rt, tx)
 		if err != nil {
@@ -140,6 +140,7 @@ type args struct {
 		mockStorageState *mockStorageState
 		mockTxnState     *mockTxnState
 		mockRuntime      *mockRuntime
+		banned           bool
 	}{
 		{
 			name: "not synced",
@@ -206,6 +207,27 @@ type args struct {
 				msg:    &network.TransactionMessage{Extrinsics: []types.Extrinsic{}},
 			},
 		},
+		{
+			name: "banned transaction",
+			mockNetwork: &mockNetwork{
+				IsSynced: true,
+			},
+			mockBlockState: &mockBlockState{
+				bestHeader: &mockBestHeader{
+					header: testEmptyHeader,
+				},
+				getRuntime: &mockGetRuntime{
+					runtime: runtimeMock,
+				},
+			},
+			banned: true,
+			args: args{
+				peerID: peer.ID("jimbo"),
+				msg: &network.TransactionMessage{
+					Extrinsics: []types.Extrinsic{{1, 2, 3}},
+				},
+			},
+		},
 		{
 			name: "trie state error",
 			mockNetwork: &mockNetwork{
@@ -355,11 +377,12 @@ type args struct {
 					tt.mockStorageState.err)
 				s.storageState = storageState
 			}
+			txnState := NewMockTransactionState(ctrl)
+			txnState.EXPECT().IsBanned(gomock.Any()).Return(tt.banned).AnyTimes()
 			if tt.mockTxnState != nil {
-				txnState := NewMockTransactionState(ctrl)
 				txnState.EXPECT().AddToPool(tt.mockTxnState.input).Return(tt.mockTxnState.hash)
-				s.transactionState = txnState
 			}
+			s.transactionState = txnState
 			if tt.mockRuntime != nil {
 				rt := tt.mockRuntime.runtime
 				rt.EXPECT().SetContextStorage(tt.mockRuntime.setContextStorage.trieState)
@@ -11,6 +11,7 @@
 	peerset "github.com/ChainSafe/gossamer/dot/peerset"
 	types "github.com/ChainSafe/gossamer/dot/types"
 	common "github.com/ChainSafe/gossamer/lib/common"
+	ed25519 "github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	keystore "github.com/ChainSafe/gossamer/lib/keystore"
 	runtime "github.com/ChainSafe/gossamer/lib/runtime"
 	storage "github.com/ChainSafe/gossamer/lib/runtime/storage"
@@ -344,6 +345,20 @@ func (mr *MockBlockStateMockRecorder) HighestCommonAncestor(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HighestCommonAncestor", reflect.TypeOf((*MockBlockState)(nil).HighestCommonAncestor), arg0, arg1)
 }
 
+// StoreIndexedTransactions mocks base method.
+func (m *MockBlockState) StoreIndexedTransactions(arg0 *types.Block, arg1 []storage.TransactionIndexOperation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreIndexedTransactions", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreIndexedTransactions indicates an expected call of StoreIndexedTransactions.
+func (mr *MockBlockStateMockRecorder) StoreIndexedTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreIndexedTransactions", reflect.TypeOf((*MockBlockState)(nil).StoreIndexedTransactions), arg0, arg1)
+}
+
 // StoreRuntime mocks base method.
 func (m *MockBlockState) StoreRuntime(arg0 common.Hash, arg1 runtime.Instance) {
 	m.ctrl.T.Helper()
@@ -573,6 +588,20 @@ func (mr *MockTransactionStateMockRecorder) Exists(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockTransactionState)(nil).Exists), arg0)
 }
 
+// IsBanned mocks base method.
+func (m *MockTransactionState) IsBanned(arg0 common.Hash) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBanned", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsBanned indicates an expected call of IsBanned.
+func (mr *MockTransactionStateMockRecorder) IsBanned(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBanned", reflect.TypeOf((*MockTransactionState)(nil).IsBanned), arg0)
+}
+
 // PendingInPool mocks base method.
 func (m *MockTransactionState) PendingInPool() []*transaction.ValidTransaction {
 	m.ctrl.T.Helper()
@@ -858,6 +887,65 @@ func (mr *MockRuntimeInstanceMockRecorder) BabeConfiguration() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BabeConfiguration", reflect.TypeOf((*MockRuntimeInstance)(nil).BabeConfiguration))
 }
 
+// BabeCurrentEpoch mocks base method.
+func (m *MockRuntimeInstance) BabeCurrentEpoch() (*types.BabeEpoch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BabeCurrentEpoch")
+	ret0, _ := ret[0].(*types.BabeEpoch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BabeCurrentEpoch indicates an expected call of BabeCurrentEpoch.
+func (mr *MockRuntimeInstanceMockRecorder) BabeCurrentEpoch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BabeCurrentEpoch", reflect.TypeOf((*MockRuntimeInstance)(nil).BabeCurrentEpoch))
+}
+
+// BabeGenerateKeyOwnershipProof mocks base method.
+func (m *MockRuntimeInstance) BabeGenerateKeyOwnershipProof(arg0 uint64, arg1 [32]byte) (types.BabeOpaqueKeyOwnershipProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BabeGenerateKeyOwnershipProof", arg0, arg1)
+	ret0, _ := ret[0].(types.BabeOpaqueKeyOwnershipProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BabeGenerateKeyOwnershipProof indicates an expected call of BabeGenerateKeyOwnershipProof.
+func (mr *MockRuntimeInstanceMockRecorder) BabeGenerateKeyOwnershipProof(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BabeGenerateKeyOwnershipProof", reflect.TypeOf((*MockRuntimeInstance)(nil).BabeGenerateKeyOwnershipProof), arg0, arg1)
+}
+
+// BabeNextEpoch mocks base method.
+func (m *MockRuntimeInstance) BabeNextEpoch() (*types.BabeEpoch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BabeNextEpoch")
+	ret0, _ := ret[0].(*types.BabeEpoch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BabeNextEpoch indicates an expected call of BabeNextEpoch.
+func (mr *MockRuntimeInstanceMockRecorder) BabeNextEpoch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BabeNextEpoch", reflect.TypeOf((*MockRuntimeInstance)(nil).BabeNextEpoch))
+}
+
+// BabeSubmitReportEquivocationUnsignedExtrinsic mocks base method.
+func (m *MockRuntimeInstance) BabeSubmitReportEquivocationUnsignedExtrinsic(arg0 types.BabeEquivocationProof, arg1 types.BabeOpaqueKeyOwnershipProof) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BabeSubmitReportEquivocationUnsignedExtrinsic", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BabeSubmitReportEquivocationUnsignedExtrinsic indicates an expected call of BabeSubmitReportEquivocationUnsignedExtrinsic.
+func (mr *MockRuntimeInstanceMockRecorder) BabeSubmitReportEquivocationUnsignedExtrinsic(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BabeSubmitReportEquivocationUnsignedExtrinsic", reflect.TypeOf((*MockRuntimeInstance)(nil).BabeSubmitReportEquivocationUnsignedExtrinsic), arg0, arg1)
+}
+
 // CheckInherents mocks base method.
 func (m *MockRuntimeInstance) CheckInherents() {
 	m.ctrl.T.Helper()
@@ -870,6 +958,20 @@ func (mr *MockRuntimeInstanceMockRecorder) CheckInherents() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckInherents", reflect.TypeOf((*MockRuntimeInstance)(nil).CheckInherents))
 }
 
+// ContextStorage mocks base method.
+func (m *MockRuntimeInstance) ContextStorage() runtime.Storage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContextStorage")
+	ret0, _ := ret[0].(runtime.Storage)
+	return ret0
+}
+
+// ContextStorage indicates an expected call of ContextStorage.
+func (mr *MockRuntimeInstanceMockRecorder) ContextStorage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContextStorage", reflect.TypeOf((*MockRuntimeInstance)(nil).ContextStorage))
+}
+
 // DecodeSessionKeys mocks base method.
 func (m *MockRuntimeInstance) DecodeSessionKeys(arg0 []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -931,15 +1033,18 @@ func (mr *MockRuntimeInstanceMockRecorder) FinalizeBlock() *gomock.Call {
 }
 
 // GenerateSessionKeys mocks base method.
-func (m *MockRuntimeInstance) GenerateSessionKeys() {
+func (m *MockRuntimeInstance) GenerateSessionKeys(arg0 *[]byte) ([]byte, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "GenerateSessionKeys")
+	ret := m.ctrl.Call(m, "GenerateSessionKeys", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GenerateSessionKeys indicates an expected call of GenerateSessionKeys.
-func (mr *MockRuntimeInstanceMockRecorder) GenerateSessionKeys() *gomock.Call {
+func (mr *MockRuntimeInstanceMockRecorder) GenerateSessionKeys(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSessionKeys", reflect.TypeOf((*MockRuntimeInstance)(nil).GenerateSessionKeys))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSessionKeys", reflect.TypeOf((*MockRuntimeInstance)(nil).GenerateSessionKeys), arg0)
 }
 
 // GetCodeHash mocks base method.
@@ -971,6 +1076,35 @@ func (mr *MockRuntimeInstanceMockRecorder) GrandpaAuthorities() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrandpaAuthorities", reflect.TypeOf((*MockRuntimeInstance)(nil).GrandpaAuthorities))
 }
 
+// GrandpaGenerateKeyOwnershipProof mocks base method.
+func (m *MockRuntimeInstance) GrandpaGenerateKeyOwnershipProof(arg0 uint64, arg1 ed25519.PublicKeyBytes) (types.GrandpaOpaqueKeyOwnershipProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrandpaGenerateKeyOwnershipProof", arg0, arg1)
+	ret0, _ := ret[0].(types.GrandpaOpaqueKeyOwnershipProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrandpaGenerateKeyOwnershipProof indicates an expected call of GrandpaGenerateKeyOwnershipProof.
+func (mr *MockRuntimeInstanceMockRecorder) GrandpaGenerateKeyOwnershipProof(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrandpaGenerateKeyOwnershipProof", reflect.TypeOf((*MockRuntimeInstance)(nil).GrandpaGenerateKeyOwnershipProof), arg0, arg1)
+}
+
+// GrandpaSubmitReportEquivocationUnsignedExtrinsic mocks base method.
+func (m *MockRuntimeInstance) GrandpaSubmitReportEquivocationUnsignedExtrinsic(arg0 types.GrandpaEquivocationProof, arg1 types.GrandpaOpaqueKeyOwnershipProof) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrandpaSubmitReportEquivocationUnsignedExtrinsic", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrandpaSubmitReportEquivocationUnsignedExtrinsic indicates an expected call of GrandpaSubmitReportEquivocationUnsignedExtrinsic.
+func (mr *MockRuntimeInstanceMockRecorder) GrandpaSubmitReportEquivocationUnsignedExtrinsic(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrandpaSubmitReportEquivocationUnsignedExtrinsic", reflect.TypeOf((*MockRuntimeInstance)(nil).GrandpaSubmitReportEquivocationUnsignedExtrinsic), arg0, arg1)
+}
+
 // InherentExtrinsics mocks base method.
 func (m *MockRuntimeInstance) InherentExtrinsics(arg0 []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -1058,15 +1192,17 @@ func (mr *MockRuntimeInstanceMockRecorder) NodeStorage() *gomock.Call {
 }
 
 // OffchainWorker mocks base method.
-func (m *MockRuntimeInstance) OffchainWorker() {
+func (m *MockRuntimeInstance) OffchainWorker(arg0 *types.Header) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "OffchainWorker")
+	ret := m.ctrl.Call(m, "OffchainWorker", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // OffchainWorker indicates an expected call of OffchainWorker.
-func (mr *MockRuntimeInstanceMockRecorder) OffchainWorker() *gomock.Call {
+func (mr *MockRuntimeInstanceMockRecorder) OffchainWorker(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OffchainWorker", reflect.TypeOf((*MockRuntimeInstance)(nil).OffchainWorker))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OffchainWorker", reflect.TypeOf((*MockRuntimeInstance)(nil).OffchainWorker), arg0)
 }
 
 // PaymentQueryInfo mocks base method.
@@ -0,0 +1,180 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime/wasmer"
+)
+
+const defaultOffchainWorkerTimeout = 30 * time.Second
+
+// newRuntimeInstanceFunc creates a new runtime instance from the code and configuration given.
+type newRuntimeInstanceFunc func(code []byte, cfg wasmer.Config) (RuntimeInstance, error)
+
+func newWasmerInstance(code []byte, cfg wasmer.Config) (RuntimeInstance, error) {
+	return wasmer.NewInstance(code, cfg)
+}
+
+// runOffchainWorker runs the runtime offchain worker for the given block header.
+// The offchain worker is executed in a separate runtime instance so it does not
+// lock the block runtime instance. This instance is reused for the next blocks as
+// long as the runtime code does not change. As in Substrate, the block is skipped if
+// the offchain worker of a previous block is still running. If the offchain worker
+// does not complete before the offchain worker timeout, its blocking host calls are
+// interrupted and its instance is stopped as soon as it returns.
+func (s *Service) runOffchainWorker(header *types.Header) error {
+	blockHash := header.Hash()
+
+	s.offchainWorkerLock.Lock()
+	if s.offchainWorkerRunning {
+		s.offchainWorkerLock.Unlock()
+		logger.Debugf("skipping offchain worker for block %s: previous offchain worker is still running", blockHash)
+		return nil
+	}
+	s.offchainWorkerRunning = true
+	s.offchainWorkerLock.Unlock()
+
+	instance, err := s.offchainWorkerInstance(header)
+	if err != nil {
+		s.offchainWorkerLock.Lock()
+		s.offchainWorkerRunning = false
+		s.offchainWorkerLock.Unlock()
+		return err
+	}
+
+	logger.Debugf("running offchain worker for block %s", blockHash)
+
+	errCh := make(chan error, 1)
+	go func() {
+		err := instance.OffchainWorker(header)
+
+		s.offchainWorkerLock.Lock()
+		defer s.offchainWorkerLock.Unlock()
+		s.offchainWorkerRunning = false
+		select {
+		case <-s.offchainInterrupt:
+			instance.Stop()
+			s.offchainInstance = nil
+		default:
+		}
+
+		errCh <- err
+	}()
+
+	timer := time.NewTimer(s.offchainWorkerTimeout)
+	defer timer.Stop()
+
+	select {
+	case err = <-errCh:
+		if err != nil {
+			return fmt.Errorf("executing offchain worker: %w", err)
+		}
+		return nil
+	case <-timer.C:
+		s.offchainWorkerLock.Lock()
+		s.interruptOffchainWorker()
+		s.offchainWorkerLock.Unlock()
+		return fmt.Errorf("%w: after %s", ErrOffchainWorkerTimeout, s.offchainWorkerTimeout)
+	case <-s.ctx.Done():
+		s.offchainWorkerLock.Lock()
+		s.interruptOffchainWorker()
+		s.offchainWorkerLock.Unlock()
+		return nil
+	}
+}
+
+// offchainWorkerInstance returns the runtime instance to run the offchain worker
+// for the given block header. The current offchain worker instance is reused with
+// the block state if its runtime code is the block runtime code, otherwise it is
+// stopped and a new instance is created.
+func (s *Service) offchainWorkerInstance(header *types.Header) (RuntimeInstance, error) {
+	blockHash := header.Hash()
+
+	ts, err := s.storageState.TrieState(&header.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	rt, err := s.blockState.GetRuntime(&blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting runtime: %w", err)
+	}
+
+	codeHash := rt.GetCodeHash()
+
+	s.offchainWorkerLock.Lock()
+	defer s.offchainWorkerLock.Unlock()
+
+	if s.offchainInstance != nil {
+		if s.offchainInstance.GetCodeHash() == codeHash {
+			s.offchainInstance.SetContextStorage(ts)
+			return s.offchainInstance, nil
+		}
+
+		s.offchainInstance.Stop()
+		s.offchainInstance = nil
+	}
+
+	interrupt := make(chan struct{})
+	cfg := wasmer.Config{
+		Storage:     ts,
+		Keystore:    rt.Keystore(),
+		LogLvl:      s.runtimeLogLvl,
+		NodeStorage: rt.NodeStorage(),
+		Network:     rt.NetworkService(),
+		Transaction: s.transactionState,
+		CodeHash:    codeHash,
+		Interrupt:   interrupt,
+	}
+
+	if rt.Validator() {
+		cfg.Role = common.AuthorityRole
+	}
+
+	instance, err := s.newRuntimeInstance(ts.LoadCode(), cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating runtime instance: %w", err)
+	}
+
+	s.offchainInstance = instance
+	s.offchainInterrupt = interrupt
+	return instance, nil
+}
+
+// stopOffchainWorker stops the offchain worker instance, or interrupts
+// the offchain worker if it is running.
+func (s *Service) stopOffchainWorker() {
+	s.offchainWorkerLock.Lock()
+	defer s.offchainWorkerLock.Unlock()
+
+	if s.offchainWorkerRunning {
+		s.interruptOffchainWorker()
+		return
+	}
+
+	if s.offchainInstance != nil {
+		s.offchainInstance.Stop()
+		s.offchainInstance = nil
+	}
+}
+
+// interruptOffchainWorker interrupts the running offchain worker, its instance
+// is then stopped once the offchain worker returns. It must be called with the
+// offchain worker lock held.
+func (s *Service) interruptOffchainWorker() {
+	if !s.offchainWorkerRunning {
+		return
+	}
+
+	select {
+	case <-s.offchainInterrupt:
+	default:
+		close(s.offchainInterrupt)
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/runtime/wasmer"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_runOffchainWorker(t *testing.T) {
+	t.Parallel()
+
+	header := &types.Header{
+		Number:    1,
+		StateRoot: common.Hash{1},
+	}
+	blockHash := header.Hash()
+	code := []byte{1, 2, 3}
+
+	newTrieState := func() *rtstorage.TrieState {
+		ts := rtstorage.NewTrieState(trie.NewEmptyTrie())
+		ts.Set(common.CodeKey, code)
+		return ts
+	}
+
+	newBlockRuntime := func(ctrl *gomock.Controller) *MockRuntimeInstance {
+		blockRuntime := NewMockRuntimeInstance(ctrl)
+		blockRuntime.EXPECT().Keystore().Return((*keystore.GlobalKeystore)(nil))
+		blockRuntime.EXPECT().NodeStorage().Return(runtime.NodeStorage{})
+		blockRuntime.EXPECT().NetworkService().Return(nil)
+		blockRuntime.EXPECT().GetCodeHash().Return(common.Hash{2})
+		blockRuntime.EXPECT().Validator().Return(true)
+		return blockRuntime
+	}
+
+	execTest := func(t *testing.T, s *Service, expErr error, expErrMsg string) {
+		err := s.runOffchainWorker(header)
+		assert.ErrorIs(t, err, expErr)
+		if expErr != nil {
+			assert.EqualError(t, err, expErrMsg)
+		}
+	}
+
+	t.Run("previous offchain worker running", func(t *testing.T) {
+		t.Parallel()
+		service := &Service{
+			offchainWorkerRunning: true,
+		}
+		execTest(t, service, nil, "")
+	})
+
+	t.Run("trie state error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().TrieState(&common.Hash{1}).Return(nil, errDummyErr)
+		service := &Service{
+			storageState: mockStorageState,
+		}
+		execTest(t, service, errDummyErr, "getting trie state: dummy error for testing")
+	})
+
+	t.Run("get runtime error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().TrieState(&common.Hash{1}).Return(newTrieState(), nil)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetRuntime(&blockHash).Return(nil, errDummyErr)
+		service := &Service{
+			storageState: mockStorageState,
+			blockState:   mockBlockState,
+		}
+		execTest(t, service, errDummyErr, "getting runtime: dummy error for testing")
+	})
+
+	t.Run("new runtime instance error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().TrieState(&common.Hash{1}).Return(newTrieState(), nil)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetRuntime(&blockHash).Return(newBlockRuntime(ctrl), nil)
+		service := &Service{
+			storageState: mockStorageState,
+			blockState:   mockBlockState,
+			newRuntimeInstance: func(code []byte, cfg wasmer.Config) (RuntimeInstance, error) {
+				return nil, errDummyErr
+			},
+		}
+		execTest(t, service, errDummyErr, "creating runtime instance: dummy error for testing")
+	})
+
+	t.Run("offchain worker error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().TrieState(&common.Hash{1}).Return(newTrieState(), nil)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetRuntime(&blockHash).Return(newBlockRuntime(ctrl), nil)
+		offchainRuntime := NewMockRuntimeInstance(ctrl)
+		offchainRuntime.EXPECT().OffchainWorker(header).Return(errDummyErr)
+		service := &Service{
+			ctx:                   context.Background(),
+			storageState:          mockStorageState,
+			blockState:            mockBlockState,
+			offchainWorkerTimeout: time.Second,
+			newRuntimeInstance: func(code []byte, cfg wasmer.Config) (RuntimeInstance, error) {
+				return offchainRuntime, nil
+			},
+		}
+		execTest(t, service, errDummyErr, "executing offchain worker: dummy error for testing")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().TrieState(&common.Hash{1}).Return(newTrieState(), nil)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetRuntime(&blockHash).Return(newBlockRuntime(ctrl), nil)
+		var interrupt <-chan struct{}
+		stopped := make(chan struct{})
+		offchainRuntime := NewMockRuntimeInstance(ctrl)
+		offchainRuntime.EXPECT().OffchainWorker(header).DoAndReturn(func(*types.Header) error {
+			<-interrupt
+			return nil
+		})
+		offchainRuntime.EXPECT().Stop().Do(func() { close(stopped) })
+		service := &Service{
+			ctx:                   context.Background(),
+			storageState:          mockStorageState,
+			blockState:            mockBlockState,
+			offchainWorkerTimeout: time.Millisecond,
+			newRuntimeInstance: func(code []byte, cfg wasmer.Config) (RuntimeInstance, error) {
+				interrupt = cfg.Interrupt
+				return offchainRuntime, nil
+			},
+		}
+		execTest(t, service, ErrOffchainWorkerTimeout, "offchain worker timed out: after 1ms")
+
+		<-stopped
+	})
+
+	t.Run("reuse instance", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		trieState := newTrieState()
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().TrieState(&common.Hash{1}).Return(trieState, nil)
+		blockRuntime := NewMockRuntimeInstance(ctrl)
+		blockRuntime.EXPECT().GetCodeHash().Return(common.Hash{2})
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetRuntime(&blockHash).Return(blockRuntime, nil)
+		offchainRuntime := NewMockRuntimeInstance(ctrl)
+		offchainRuntime.EXPECT().GetCodeHash().Return(common.Hash{2})
+		offchainRuntime.EXPECT().SetContextStorage(trieState)
+		offchainRuntime.EXPECT().OffchainWorker(header).Return(nil)
+		service := &Service{
+			ctx:                   context.Background(),
+			storageState:          mockStorageState,
+			blockState:            mockBlockState,
+			offchainWorkerTimeout: time.Second,
+			offchainInstance:      offchainRuntime,
+		}
+		execTest(t, service, nil, "")
+	})
+
+	t.Run("replace instance with different code", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().TrieState(&common.Hash{1}).Return(newTrieState(), nil)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetRuntime(&blockHash).Return(newBlockRuntime(ctrl), nil)
+		previousRuntime := NewMockRuntimeInstance(ctrl)
+		previousRuntime.EXPECT().GetCodeHash().Return(common.Hash{3})
+		previousRuntime.EXPECT().Stop()
+		offchainRuntime := NewMockRuntimeInstance(ctrl)
+		offchainRuntime.EXPECT().OffchainWorker(header).Return(nil)
+		service := &Service{
+			ctx:                   context.Background(),
+			storageState:          mockStorageState,
+			blockState:            mockBlockState,
+			offchainWorkerTimeout: time.Second,
+			offchainInstance:      previousRuntime,
+			newRuntimeInstance: func(code []byte, cfg wasmer.Config) (RuntimeInstance, error) {
+				return offchainRuntime, nil
+			},
+		}
+		execTest(t, service, nil, "")
+		assert.Equal(t, offchainRuntime, service.offchainInstance)
+	})
+
+	t.Run("happy path", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		trieState := newTrieState()
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().TrieState(&common.Hash{1}).Return(trieState, nil)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetRuntime(&blockHash).Return(newBlockRuntime(ctrl), nil)
+		mockTransactionState := NewMockTransactionState(ctrl)
+		offchainRuntime := NewMockRuntimeInstance(ctrl)
+		offchainRuntime.EXPECT().OffchainWorker(header).Return(nil)
+		service := &Service{
+			ctx:                   context.Background(),
+			storageState:          mockStorageState,
+			blockState:            mockBlockState,
+			transactionState:      mockTransactionState,
+			offchainWorkerTimeout: time.Second,
+			newRuntimeInstance: func(instanceCode []byte, cfg wasmer.Config) (RuntimeInstance, error) {
+				expectedCfg := wasmer.Config{
+					Storage:     trieState,
+					Role:        common.AuthorityRole,
+					NodeStorage: runtime.NodeStorage{},
+					Transaction: mockTransactionState,
+					CodeHash:    common.Hash{2},
+				}
+				assert.NotNil(t, cfg.Interrupt)
+				cfg.Interrupt = nil
+				assert.Equal(t, code, instanceCode)
+				assert.Equal(t, expectedCfg, cfg)
+				return offchainRuntime, nil
+			},
+		}
+		execTest(t, service, nil, "")
+	})
+}
@@ -9,6 +9,7 @@
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -56,6 +57,16 @@ type Service struct {
 
 	// Keystore
 	keys *keystore.GlobalKeystore
+
+	// offchain workers
+	offchainWorker        bool
+	offchainWorkerTimeout time.Duration
+	runtimeLogLvl         log.Level
+	newRuntimeInstance    newRuntimeInstanceFunc
+	offchainWorkerLock    sync.Mutex
+	offchainWorkerRunning bool
+	offchainInstance      RuntimeInstance // reused while the runtime code does not change
+	offchainInterrupt     chan struct{}   // closed to interrupt the offchain instance
 }
 
 // Config holds the configuration for the core Service.
@@ -72,6 +83,16 @@ type Config struct {
 
 	CodeSubstitutes      map[common.Hash]string
 	CodeSubstitutedState CodeSubstitutedState
+
+	// OffchainWorker enables running the runtime offchain worker
+	// for each newly imported best block.
+	OffchainWorker bool
+	// OffchainWorkerTimeout is the maximum duration to wait for an
+	// offchain worker execution. It defaults to 30 seconds if left unset.
+	OffchainWorkerTimeout time.Duration
+	// RuntimeLogLvl is the log level of the runtime instances
+	// created to run the offchain workers.
+	RuntimeLogLvl log.Level
 }
 
 // NewService returns a new core service that connects the runtime, BABE
@@ -81,19 +102,28 @@ func NewService(cfg *Config) (*Service, error) {
 
 	blockAddCh := make(chan *types.Block, 256)
 
+	offchainWorkerTimeout := cfg.OffchainWorkerTimeout
+	if offchainWorkerTimeout == 0 {
+		offchainWorkerTimeout = defaultOffchainWorkerTimeout
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	srv := &Service{
-		ctx:                  ctx,
-		cancel:               cancel,
-		keys:                 cfg.Keystore,
-		blockState:           cfg.BlockState,
-		epochState:           cfg.EpochState,
-		storageState:         cfg.StorageState,
-		transactionState:     cfg.TransactionState,
-		net:                  cfg.Network,
-		blockAddCh:           blockAddCh,
-		codeSubstitute:       cfg.CodeSubstitutes,
-		codeSubstitutedState: cfg.CodeSubstitutedState,
+		ctx:                   ctx,
+		cancel:                cancel,
+		keys:                  cfg.Keystore,
+		blockState:            cfg.BlockState,
+		epochState:            cfg.EpochState,
+		storageState:          cfg.StorageState,
+		transactionState:      cfg.TransactionState,
+		net:                   cfg.Network,
+		blockAddCh:            blockAddCh,
+		codeSubstitute:        cfg.CodeSubstitutes,
+		codeSubstitutedState:  cfg.CodeSubstitutedState,
+		offchainWorker:        cfg.OffchainWorker,
+		offchainWorkerTimeout: offchainWorkerTimeout,
+		runtimeLogLvl:         cfg.RuntimeLogLvl,
+		newRuntimeInstance:    newWasmerInstance,
 	}
 
 	return srv, nil
@@ -112,6 +142,7 @@ func (s *Service) Stop() error {
 
 	s.cancel()
 	close(s.blockAddCh)
+	s.stopOffchainWorker()
 	return nil
 }
 
@@ -187,6 +218,14 @@ func (s *Service) handleBlock(block *types.Block, state *rtstorage.TrieState) er
 		}
 	}
 
+	// store the data indexed by the block extrinsics
+	if operations := state.TransactionIndexOperations(); len(operations) > 0 {
+		err = s.blockState.StoreIndexedTransactions(block, operations)
+		if err != nil {
+			return fmt.Errorf("storing indexed transactions: %w", err)
+		}
+	}
+
 	logger.Debugf("imported block %s and stored state trie with root %s",
 		block.Header.Hash(), state.MustRoot())
 
@@ -246,6 +285,7 @@ func (s *Service) handleCodeSubstitution(hash common.Hash,
 		Keystore:    rt.Keystore(),
 		NodeStorage: rt.NodeStorage(),
 		Network:     rt.NetworkService(),
+		LogLvl:      s.runtimeLogLvl,
 	}
 
 	if rt.Validator() {
@@ -287,6 +327,15 @@ func (s *Service) handleBlocksAsync() {
 			}
 
 			s.maintainTransactionPool(block)
+
+			if s.offchainWorker && block.Header.Hash() == s.blockState.BestBlockHash() {
+				go func(header types.Header) {
+					err := s.runOffchainWorker(&header)
+					if err != nil {
+						logger.Warnf("failed to run offchain worker for block %s: %s", header.Hash(), err)
+					}
+				}(block.Header)
+			}
 		case <-s.ctx.Done():
 			return
 		}
@@ -433,6 +482,23 @@ func (s *Service) DecodeSessionKeys(enc []byte) ([]byte, error) {
 	return rt.DecodeSessionKeys(enc)
 }
 
+// GenerateSessionKeys executes the runtime GenerateSessionKeys at the best block, which inserts
+// newly generated session keys into the keystore, and returns the scale encoded public keys
+func (s *Service) GenerateSessionKeys() ([]byte, error) {
+	ts, err := s.storageState.TrieState(nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	rt, err := s.blockState.GetRuntime(nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting runtime: %w", err)
+	}
+
+	rt.SetContextStorage(ts)
+	return rt.GenerateSessionKeys(nil)
+}
+
 // GetRuntimeVersion gets the current RuntimeVersion
 func (s *Service) GetRuntimeVersion(bhash *common.Hash) (
 	version runtime.Version, err error) {
@@ -471,6 +537,10 @@ func (s *Service) HandleSubmittedExtrinsic(ext types.Extrinsic) error {
 		return nil
 	}
 
+	if s.transactionState.IsBanned(ext.Hash()) {
+		return ErrExtrinsicBanned
+	}
+
 	bestBlockHash := s.blockState.BestBlockHash()
 
 	stateRoot, err := s.storageState.GetStateRootFromBlock(&bestBlockHash)
@@ -535,6 +605,41 @@ func (s *Service) GetMetadata(bhash *common.Hash) ([]byte, error) {
 	return rt.Metadata()
 }
 
+// CallRuntime executes the given runtime API method with the SCALE encoded data
+// against the state of the block with the given hash. If the block hash is nil,
+// the best block is used.
+func (s *Service) CallRuntime(bhash *common.Hash, method string, data []byte) ([]byte, error) {
+	var (
+		stateRootHash *common.Hash
+		err           error
+	)
+
+	if bhash != nil {
+		stateRootHash, err = s.storageState.GetStateRootFromBlock(bhash)
+		if err != nil {
+			return nil, fmt.Errorf("getting state root from block: %w", err)
+		}
+	}
+
+	ts, err := s.storageState.TrieState(stateRootHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	rt, err := s.blockState.GetRuntime(bhash)
+	if err != nil {
+		return nil, fmt.Errorf("getting runtime: %w", err)
+	}
+
+	rt.SetContextStorage(ts)
+	result, err := rt.Exec(method, data)
+	if err != nil {
+		return nil, fmt.Errorf("executing runtime method %s: %w", method, err)
+	}
+
+	return result, nil
+}
+
 // GetReadProofAt will return an array with the proofs for the keys passed as params
 // based on the block hash passed as param as well, if block hash is nil then the current state will take place
 func (s *Service) GetReadProofAt(block common.Hash, keys [][]byte) (
@@ -1107,6 +1107,19 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 		execTest(t, service, nil, nil)
 	})
 
+	t.Run("banned extrinsic", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockTxnState := NewMockTransactionState(ctrl)
+		mockTxnState.EXPECT().Exists(types.Extrinsic{})
+		mockTxnState.EXPECT().IsBanned(types.Extrinsic{}.Hash()).Return(true)
+		service := &Service{
+			transactionState: mockTxnState,
+			net:              NewMockNetwork(ctrl),
+		}
+		execTest(t, service, types.Extrinsic{}, ErrExtrinsicBanned)
+	})
+
 	t.Run("trie state err", func(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
@@ -1118,6 +1131,7 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{})
 		mockTxnState := NewMockTransactionState(ctrl)
 		mockTxnState.EXPECT().Exists(nil)
+		mockTxnState.EXPECT().IsBanned(gomock.Any()).Return(false)
 		service := &Service{
 			blockState:       mockBlockState,
 			storageState:     mockStorageState,
@@ -1141,6 +1155,7 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 
 		mockTxnState := NewMockTransactionState(ctrl)
 		mockTxnState.EXPECT().Exists(nil).MaxTimes(2)
+		mockTxnState.EXPECT().IsBanned(gomock.Any()).Return(false)
 		service := &Service{
 			storageState:     mockStorageState,
 			transactionState: mockTxnState,
@@ -1164,6 +1179,7 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 
 		mockTxnState := NewMockTransactionState(ctrl)
 		mockTxnState.EXPECT().Exists(types.Extrinsic{})
+		mockTxnState.EXPECT().IsBanned(gomock.Any()).Return(false)
 
 		runtimeMockErr.EXPECT().SetContextStorage(&rtstorage.TrieState{})
 		runtimeMockErr.EXPECT().ValidateTransaction(externalExt).Return(nil, errDummyErr)
@@ -1193,6 +1209,7 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 
 		mockTxnState := NewMockTransactionState(ctrl)
 		mockTxnState.EXPECT().Exists(types.Extrinsic{}).MaxTimes(2)
+		mockTxnState.EXPECT().IsBanned(gomock.Any()).Return(false)
 		mockTxnState.EXPECT().AddToPool(transaction.NewValidTransaction(ext, &transaction.Validity{Propagate: true}))
 		mockNetState := NewMockNetwork(ctrl)
 		mockNetState.EXPECT().GossipMessage(&network.TransactionMessage{Extrinsics: []types.Extrinsic{ext}})
@@ -1271,6 +1288,148 @@ func TestServiceGetMetadata(t *testing.T) {
 	})
 }
 
+func TestService_GenerateSessionKeys(t *testing.T) {
+	t.Parallel()
+
+	t.Run("trie state error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().TrieState(nil).Return(nil, errDummyErr)
+		service := &Service{
+			storageState: mockStorageState,
+		}
+		res, err := service.GenerateSessionKeys()
+		assert.ErrorIs(t, err, errDummyErr)
+		assert.EqualError(t, err, "getting trie state: dummy error for testing")
+		assert.Nil(t, res)
+	})
+
+	t.Run("get runtime error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().TrieState(nil).Return(&rtstorage.TrieState{}, nil)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetRuntime(nil).Return(nil, errDummyErr)
+		service := &Service{
+			storageState: mockStorageState,
+			blockState:   mockBlockState,
+		}
+		res, err := service.GenerateSessionKeys()
+		assert.ErrorIs(t, err, errDummyErr)
+		assert.EqualError(t, err, "getting runtime: dummy error for testing")
+		assert.Nil(t, res)
+	})
+
+	t.Run("happy path", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().TrieState(nil).Return(&rtstorage.TrieState{}, nil)
+		runtimeMock := NewMockRuntimeInstance(ctrl)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetRuntime(nil).Return(runtimeMock, nil)
+		runtimeMock.EXPECT().SetContextStorage(&rtstorage.TrieState{})
+		runtimeMock.EXPECT().GenerateSessionKeys(nil).Return([]byte{1, 2, 3}, nil)
+		service := &Service{
+			storageState: mockStorageState,
+			blockState:   mockBlockState,
+		}
+		res, err := service.GenerateSessionKeys()
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{1, 2, 3}, res)
+	})
+}
+
+func TestService_CallRuntime(t *testing.T) {
+	t.Parallel()
+	execTest := func(t *testing.T, s *Service, bhash *common.Hash, exp []byte,
+		expErr error, expErrMsg string) {
+		res, err := s.CallRuntime(bhash, "Test_method", []byte{1})
+		assert.ErrorIs(t, err, expErr)
+		if expErr != nil {
+			assert.EqualError(t, err, expErrMsg)
+		}
+		assert.Equal(t, exp, res)
+	}
+
+	t.Run("get state root error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().GetStateRootFromBlock(&common.Hash{}).Return(nil, errDummyErr)
+		service := &Service{
+			storageState: mockStorageState,
+		}
+		execTest(t, service, &common.Hash{}, nil, errDummyErr,
+			"getting state root from block: dummy error for testing")
+	})
+
+	t.Run("trie state error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().TrieState(nil).Return(nil, errDummyErr)
+		service := &Service{
+			storageState: mockStorageState,
+		}
+		execTest(t, service, nil, nil, errDummyErr,
+			"getting trie state: dummy error for testing")
+	})
+
+	t.Run("get runtime error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().TrieState(nil).Return(&rtstorage.TrieState{}, nil)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetRuntime(nil).Return(nil, errDummyErr)
+		service := &Service{
+			storageState: mockStorageState,
+			blockState:   mockBlockState,
+		}
+		execTest(t, service, nil, nil, errDummyErr,
+			"getting runtime: dummy error for testing")
+	})
+
+	t.Run("exec error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().TrieState(nil).Return(&rtstorage.TrieState{}, nil)
+		runtimeMock := NewMockRuntimeInstance(ctrl)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetRuntime(nil).Return(runtimeMock, nil)
+		runtimeMock.EXPECT().SetContextStorage(&rtstorage.TrieState{})
+		runtimeMock.EXPECT().Exec("Test_method", []byte{1}).Return(nil, errDummyErr)
+		service := &Service{
+			storageState: mockStorageState,
+			blockState:   mockBlockState,
+		}
+		execTest(t, service, nil, nil, errDummyErr,
+			"executing runtime method Test_method: dummy error for testing")
+	})
+
+	t.Run("happy path", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().GetStateRootFromBlock(&common.Hash{1}).Return(&common.Hash{2}, nil)
+		mockStorageState.EXPECT().TrieState(&common.Hash{2}).Return(&rtstorage.TrieState{}, nil)
+		runtimeMock := NewMockRuntimeInstance(ctrl)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetRuntime(&common.Hash{1}).Return(runtimeMock, nil)
+		runtimeMock.EXPECT().SetContextStorage(&rtstorage.TrieState{})
+		runtimeMock.EXPECT().Exec("Test_method", []byte{1}).Return([]byte{1, 2, 3}, nil)
+		service := &Service{
+			storageState: mockStorageState,
+			blockState:   mockBlockState,
+		}
+		execTest(t, service, &common.Hash{1}, []byte{1, 2, 3}, nil, "")
+	})
+}
+
 func TestService_GetReadProofAt(t *testing.T) {
 	t.Parallel()
 	execTest := func(t *testing.T, s *Service, block common.Hash, keys [][]byte,
@@ -239,6 +239,11 @@ func (h *Handler) handleBlockImport(ctx context.Context) {
 			if err != nil {
 				h.logger.Errorf("failed to apply forced changes: %s", err)
 			}
+
+			err = h.grandpaState.ApplyScheduledResume(&block.Header)
+			if err != nil {
+				h.logger.Errorf("failed to apply scheduled resume: %s", err)
+			}
 		case <-ctx.Done():
 			return
 		}
@@ -268,6 +273,11 @@ func (h *Handler) handleBlockFinalisation(ctx context.Context) {
 				h.logger.Errorf("failed to apply scheduled change: %s", err)
 			}
 
+			err = h.grandpaState.ApplyScheduledPause(&info.Header)
+			if err != nil {
+				h.logger.Errorf("failed to apply scheduled pause: %s", err)
+			}
+
 		case <-ctx.Done():
 			return
 		}
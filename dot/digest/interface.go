@@ -44,4 +44,6 @@ type GrandpaState interface {
 	HandleGRANDPADigest(header *types.Header, digest scale.VaryingDataType) error
 	ApplyScheduledChanges(finalizedHeader *types.Header) error
 	ApplyForcedChanges(importedHeader *types.Header) error
+	ApplyScheduledPause(finalizedHeader *types.Header) error
+	ApplyScheduledResume(importedHeader *types.Header) error
 }
@@ -63,6 +63,34 @@ func (mr *MockGrandpaStateMockRecorder) ApplyScheduledChanges(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyScheduledChanges", reflect.TypeOf((*MockGrandpaState)(nil).ApplyScheduledChanges), arg0)
 }
 
+// ApplyScheduledPause mocks base method.
+func (m *MockGrandpaState) ApplyScheduledPause(arg0 *types.Header) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyScheduledPause", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyScheduledPause indicates an expected call of ApplyScheduledPause.
+func (mr *MockGrandpaStateMockRecorder) ApplyScheduledPause(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyScheduledPause", reflect.TypeOf((*MockGrandpaState)(nil).ApplyScheduledPause), arg0)
+}
+
+// ApplyScheduledResume mocks base method.
+func (m *MockGrandpaState) ApplyScheduledResume(arg0 *types.Header) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyScheduledResume", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyScheduledResume indicates an expected call of ApplyScheduledResume.
+func (mr *MockGrandpaStateMockRecorder) ApplyScheduledResume(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyScheduledResume", reflect.TypeOf((*MockGrandpaState)(nil).ApplyScheduledResume), arg0)
+}
+
 // GetCurrentSetID mocks base method.
 func (m *MockGrandpaState) GetCurrentSetID() (uint64, error) {
 	m.ctrl.T.Helper()
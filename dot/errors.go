@@ -14,3 +14,9 @@
 var ErrInvalidKeystoreType = errors.New("invalid keystore type")
 
 var ErrWasmInterpreterName = errors.New("unknown wasm interpreter name")
+
+// ErrOffchainWorkerMode is returned when the offchain worker mode is unknown
+var ErrOffchainWorkerMode = errors.New("unknown offchain worker mode")
+
+// ErrSyncMode is returned when the sync mode is unknown
+var ErrSyncMode = errors.New("unknown sync mode")
@@ -9,6 +9,7 @@
 
 	types "github.com/ChainSafe/gossamer/dot/types"
 	common "github.com/ChainSafe/gossamer/lib/common"
+	runtime "github.com/ChainSafe/gossamer/lib/runtime"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -94,6 +95,21 @@ func (mr *MockBlockStateMockRecorder) GetHashByNumber(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHashByNumber", reflect.TypeOf((*MockBlockState)(nil).GetHashByNumber), arg0)
 }
 
+// GetHeader mocks base method.
+func (m *MockBlockState) GetHeader(arg0 common.Hash) (*types.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeader", arg0)
+	ret0, _ := ret[0].(*types.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeader indicates an expected call of GetHeader.
+func (mr *MockBlockStateMockRecorder) GetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockBlockState)(nil).GetHeader), arg0)
+}
+
 // GetHighestFinalisedHeader mocks base method.
 func (m *MockBlockState) GetHighestFinalisedHeader() (*types.Header, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +125,21 @@ func (mr *MockBlockStateMockRecorder) GetHighestFinalisedHeader() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHighestFinalisedHeader", reflect.TypeOf((*MockBlockState)(nil).GetHighestFinalisedHeader))
 }
 
+// GetRuntime mocks base method.
+func (m *MockBlockState) GetRuntime(arg0 *common.Hash) (runtime.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuntime", arg0)
+	ret0, _ := ret[0].(runtime.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuntime indicates an expected call of GetRuntime.
+func (mr *MockBlockStateMockRecorder) GetRuntime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuntime", reflect.TypeOf((*MockBlockState)(nil).GetRuntime), arg0)
+}
+
 // HasBlockBody mocks base method.
 func (m *MockBlockState) HasBlockBody(arg0 common.Hash) (bool, error) {
 	m.ctrl.T.Helper()
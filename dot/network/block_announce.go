@@ -171,6 +171,15 @@ func (s *Service) validateBlockAnnounceHandshake(from peer.ID, hs Handshake) err
 		return errors.New("genesis hash mismatch")
 	}
 
+	// authorities vote in GRANDPA, so they are reserved in the GRANDPA set
+	// to exchange GRANDPA messages with them even when its slots are full.
+	if bhs.Roles == common.AuthorityRole {
+		_, loaded := s.host.cm.authorityPeers.LoadOrStore(from, struct{}{})
+		if !loaded {
+			s.host.cm.peerSetHandler.AddReservedPeer(grandpaSetID, from)
+		}
+	}
+
 	np, ok := s.notificationsProtocols[blockAnnounceMsgType]
 	if !ok {
 		// this should never happen.
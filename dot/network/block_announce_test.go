@@ -5,12 +5,14 @@
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/peerstore"
 	"github.com/stretchr/testify/require"
 )
 
@@ -171,3 +173,48 @@ func TestValidateBlockAnnounceHandshake(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestValidateBlockAnnounceHandshake_Authority(t *testing.T) {
+	t.Parallel()
+
+	configA := &Config{
+		BasePath:    t.TempDir(),
+		Port:        availablePort(t),
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+
+	nodeA := createTestService(t, configA)
+	nodeA.noGossip = true
+	nodeA.notificationsProtocols[blockAnnounceMsgType] = &notificationsProtocol{
+		peersData: newPeersData(),
+	}
+
+	configB := &Config{
+		BasePath:    t.TempDir(),
+		Port:        availablePort(t),
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+
+	nodeB := createTestService(t, configB)
+	testPeerID := nodeB.host.id()
+	nodeA.host.p2pHost.Peerstore().AddAddrs(testPeerID, nodeB.host.multiaddrs(), peerstore.PermanentAddrTTL)
+	nodeA.notificationsProtocols[blockAnnounceMsgType].peersData.setInboundHandshakeData(testPeerID, &handshakeData{})
+
+	err := nodeA.validateBlockAnnounceHandshake(testPeerID, &BlockAnnounceHandshake{
+		Roles:           common.AuthorityRole,
+		BestBlockNumber: 100,
+		GenesisHash:     nodeA.blockState.GenesisHash(),
+	})
+	require.NoError(t, err)
+
+	_, isAuthority := nodeA.host.cm.authorityPeers.Load(testPeerID)
+	require.True(t, isAuthority)
+
+	// the authority is reserved in the GRANDPA set only
+	require.Eventually(t, func() bool {
+		return nodeA.host.cm.peerSetHandler.IsConnected(grandpaSetID, testPeerID)
+	}, time.Second, 10*time.Millisecond)
+	require.False(t, nodeA.host.cm.peerSetHandler.IsConnected(transactionsSetID, testPeerID))
+}
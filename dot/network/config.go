@@ -63,6 +63,7 @@ type Config struct {
 
 	// Service interfaces
 	BlockState         BlockState
+	StorageState       StorageState
 	Syncer             Syncer
 	TransactionHandler TransactionHandler
 
@@ -87,6 +88,14 @@ type Config struct {
 	MinPeers int
 	MaxPeers int
 
+	// GrandpaPeerSet is the slots configuration of the GRANDPA peer set. Its reserved
+	// peers, and the peers announcing the authority role, do not occupy slots.
+	// If left empty, it has the default slots given by defaultPeerSetConfig.
+	GrandpaPeerSet PeerSetConfig
+	// TransactionsPeerSet is the slots configuration of the transactions peer set.
+	// If left empty, it has the default slots given by defaultPeerSetConfig.
+	TransactionsPeerSet PeerSetConfig
+
 	DiscoveryInterval time.Duration
 
 	// PersistentPeers is a list of multiaddrs which the node should remain connected to
@@ -107,6 +116,27 @@ type Config struct {
 	Metrics   metrics.IntervalConfig
 }
 
+// PeerSetConfig is the slots configuration of a peer set, a group of peers
+// sharing the same notifications protocol.
+type PeerSetConfig struct {
+	// MaxInPeers is the maximum number of slots for incoming connections.
+	MaxInPeers uint32
+	// MaxOutPeers is the maximum number of slots for outgoing connections.
+	MaxOutPeers uint32
+	// ReservedOnly only accepts the reserved peers in the peer set if true.
+	ReservedOnly bool
+}
+
+// defaultPeerSetConfig returns the slots configuration of a peer set other than
+// the default set, when none is configured. Every connection is offered to every
+// set, so it has as many incoming slots as the default set has peers, and no
+// outgoing slots, so it does not open connections the default set dropped.
+func defaultPeerSetConfig(maxPeers int) PeerSetConfig {
+	return PeerSetConfig{
+		MaxInPeers: uint32(maxPeers),
+	}
+}
+
 // build checks the configuration, sets up the private key for the network service,
 // and applies default values where appropriate
 func (c *Config) build() error {
@@ -30,6 +30,10 @@ type ConnManager struct {
 	// persistentPeers contains peers we should remain connected to.
 	persistentPeers *sync.Map // map[peer.ID]struct{}
 
+	// authorityPeers contains the connected peers announcing the authority
+	// role, which are reserved peers of the GRANDPA set.
+	authorityPeers *sync.Map // map[peer.ID]struct{}
+
 	peerSetHandler PeerSetHandler
 }
 
@@ -44,6 +48,7 @@ func newConnManager(min, max int, peerSetCfg *peerset.ConfigSet) (*ConnManager,
 		max:             max,
 		protectedPeers:  new(sync.Map),
 		persistentPeers: new(sync.Map),
+		authorityPeers:  new(sync.Map),
 		peerSetHandler:  psh,
 	}, nil
 }
@@ -16,4 +16,12 @@
 	errInvalidStartingBlockType      = errors.New("invalid StartingBlock in messsage")
 	errInboundHanshakeExists         = errors.New("an inbound handshake already exists for given peer")
 	errInvalidRole                   = errors.New("invalid role")
+	errLightStorageStateNotSet       = errors.New("storage state not set to serve light requests")
+	errInvalidLightBlockHash         = errors.New("invalid light request block hash")
+	errChangesTriesNotSupported      = errors.New("changes tries are not supported")
+	errStateStorageStateNotSet       = errors.New("storage state not set to serve state requests")
+	errInvalidChildStorageKey        = errors.New("invalid child storage key")
+	errInvalidStateRequestStart      = errors.New("invalid state request start")
+	errStateRequestBlockInvalid      = errors.New("state request block hash is invalid")
+	errStateResponseLevels           = errors.New("state response has more than one trie level")
 )
@@ -65,6 +65,32 @@ func init() {
 	connectTimeout       = time.Second * 5
 )
 
+// ids of the peer sets, each having its own slots in the peerset.
+const (
+	// blockAnnounceSetID is the id of the default peer set, used for block announces
+	// and syncing. The connection to a peer is closed once it leaves this set and
+	// it isn't connected in any other set.
+	blockAnnounceSetID = iota
+	// grandpaSetID is the id of the peer set used for GRANDPA messages.
+	grandpaSetID
+	// transactionsSetID is the id of the peer set used for transactions.
+	transactionsSetID
+	numPeerSets
+)
+
+// peerSetID returns the id of the peer set of the notifications
+// protocol with the given message type.
+func peerSetID(messageID byte) int {
+	switch messageID {
+	case ConsensusMsgType:
+		return grandpaSetID
+	case transactionMsgType:
+		return transactionsSetID
+	default:
+		return blockAnnounceSetID
+	}
+}
+
 // host wraps libp2p host with network host configuration and services
 type host struct {
 	ctx             context.Context
@@ -141,6 +167,15 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 		peerSetSlotAllocTime,
 	)
 
+	// the default set is the first set of the config set, the others are added in order.
+	setCfgs := [numPeerSets]PeerSetConfig{
+		grandpaSetID:      cfg.GrandpaPeerSet,
+		transactionsSetID: cfg.TransactionsPeerSet,
+	}
+	for _, setCfg := range setCfgs[grandpaSetID:] {
+		peerCfgSet.AddSet(setCfg.MaxInPeers, setCfg.MaxOutPeers, setCfg.ReservedOnly)
+	}
+
 	// create connection manager
 	cm, err := newConnManager(cfg.MinPeers, cfg.MaxPeers, peerCfgSet)
 	if err != nil {
@@ -276,13 +311,15 @@ func (h *host) connect(p peer.AddrInfo) (err error) {
 func (h *host) bootstrap() {
 	for _, info := range h.persistentPeers {
 		h.p2pHost.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.PermanentAddrTTL)
-		h.cm.peerSetHandler.AddReservedPeer(0, info.ID)
+		for setID := 0; setID < numPeerSets; setID++ {
+			h.cm.peerSetHandler.AddReservedPeer(setID, info.ID)
+		}
 	}
 
 	for _, addrInfo := range h.bootnodes {
 		logger.Debugf("bootstrapping to peer %s", addrInfo.ID)
 		h.p2pHost.Peerstore().AddAddrs(addrInfo.ID, addrInfo.Addrs, peerstore.PermanentAddrTTL)
-		h.cm.peerSetHandler.AddPeer(0, addrInfo.ID)
+		h.cm.peerSetHandler.AddPeer(blockAnnounceSetID, addrInfo.ID)
 	}
 }
 
@@ -355,7 +392,9 @@ func (h *host) addReservedPeers(addrs ...string) error {
 			return err
 		}
 		h.p2pHost.Peerstore().AddAddrs(addrInfo.ID, addrInfo.Addrs, peerstore.PermanentAddrTTL)
-		h.cm.peerSetHandler.AddReservedPeer(0, addrInfo.ID)
+		for setID := 0; setID < numPeerSets; setID++ {
+			h.cm.peerSetHandler.AddReservedPeer(setID, addrInfo.ID)
+		}
 	}
 
 	return nil
@@ -368,7 +407,9 @@ func (h *host) removeReservedPeers(ids ...string) error {
 		if err != nil {
 			return err
 		}
-		h.cm.peerSetHandler.RemoveReservedPeer(0, peerID)
+		for setID := 0; setID < numPeerSets; setID++ {
+			h.cm.peerSetHandler.RemoveReservedPeer(setID, peerID)
+		}
 		h.p2pHost.ConnManager().Unprotect(peerID, "")
 	}
 
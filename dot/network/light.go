@@ -8,6 +8,7 @@
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 
 	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
@@ -46,18 +47,24 @@ func (s *Service) handleLightMsg(stream libp2pnetwork.Stream, msg Message) (err
 		return nil
 	}
 
+	if s.storageState == nil {
+		return errLightStorageStateNotSet
+	}
+
+	// A decoded light request contains all the request kinds, so the request
+	// kind is the one referencing a block.
 	resp := NewLightResponse()
 	switch {
-	case lr.RemoteCallRequest != nil:
-		resp.RemoteCallResponse, err = remoteCallResp(lr.RemoteCallRequest)
-	case lr.RemoteHeaderRequest != nil:
-		resp.RemoteHeaderResponse, err = remoteHeaderResp(lr.RemoteHeaderRequest)
-	case lr.RemoteChangesRequest != nil:
-		resp.RemoteChangesResponse, err = remoteChangeResp(lr.RemoteChangesRequest)
-	case lr.RemoteReadRequest != nil:
-		resp.RemoteReadResponse, err = remoteReadResp(lr.RemoteReadRequest)
-	case lr.RemoteReadChildRequest != nil:
-		resp.RemoteReadResponse, err = remoteReadChildResp(lr.RemoteReadChildRequest)
+	case lr.RemoteCallRequest != nil && len(lr.RemoteCallRequest.Block) > 0:
+		resp.RemoteCallResponse, err = s.remoteCallResp(lr.RemoteCallRequest)
+	case lr.RemoteHeaderRequest != nil && len(lr.RemoteHeaderRequest.Block) > 0:
+		resp.RemoteHeaderResponse, err = s.remoteHeaderResp(lr.RemoteHeaderRequest)
+	case lr.RemoteChangesRequest != nil && lr.RemoteChangesRequest.FirstBlock != nil:
+		resp.RemoteChangesResponse, err = s.remoteChangeResp(lr.RemoteChangesRequest)
+	case lr.RemoteReadRequest != nil && len(lr.RemoteReadRequest.Block) > 0:
+		resp.RemoteReadResponse, err = s.remoteReadResp(lr.RemoteReadRequest)
+	case lr.RemoteReadChildRequest != nil && len(lr.RemoteReadChildRequest.Block) > 0:
+		resp.RemoteReadResponse, err = s.remoteReadChildResp(lr.RemoteReadChildRequest)
 	default:
 		logger.Warn("ignoring LightRequest without request data")
 		return nil
@@ -67,8 +74,7 @@ func (s *Service) handleLightMsg(stream libp2pnetwork.Stream, msg Message) (err
 		return err
 	}
 
-	// TODO(arijit): Remove once we implement the internal APIs. Added to increase code coverage. (#1856)
-	logger.Debugf("LightResponse message: %s", resp)
+	logger.Tracef("LightResponse message: %s", resp)
 
 	err = s.host.writeToStream(stream, resp)
 	if err != nil {
@@ -446,18 +452,171 @@ func (rh *RemoteHeaderResponse) String() string {
 	return fmt.Sprintf("Header =%+v Proof =%s", rh.Header, string(rh.proof))
 }
 
-func remoteCallResp(_ *RemoteCallRequest) (*RemoteCallResponse, error) {
-	return &RemoteCallResponse{}, nil
+// remoteCallResp executes the runtime call requested against the state of the
+// requested block, and responds with the proof of the storage the call accessed.
+// The runtime instance is shared, so the call is done holding the storage state
+// lock, as for block production and import, and the storage of the runtime is
+// restored after the call.
+func (s *Service) remoteCallResp(req *RemoteCallRequest) (*RemoteCallResponse, error) {
+	blockHash, err := lightBlockHash(req.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := s.blockState.GetHeader(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	s.storageState.Lock()
+	defer s.storageState.Unlock()
+
+	trieState, err := s.storageState.TrieState(&header.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	instance, err := s.blockState.GetRuntime(&blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting runtime: %w", err)
+	}
+
+	recorder := newStorageRecorder(trieState)
+	previousStorage := instance.ContextStorage()
+	instance.SetContextStorage(recorder)
+	defer instance.SetContextStorage(previousStorage)
+
+	_, err = instance.Exec(req.Method, req.Data)
+	if err != nil {
+		return nil, fmt.Errorf("executing runtime method %s: %w", req.Method, err)
+	}
+
+	encodedProofNodes, err := recorder.generateProof(header.StateRoot, s.storageState)
+	if err != nil {
+		return nil, fmt.Errorf("generating execution proof: %w", err)
+	}
+
+	proof, err := scale.Marshal(encodedProofNodes)
+	if err != nil {
+		return nil, fmt.Errorf("encoding execution proof: %w", err)
+	}
+
+	return &RemoteCallResponse{
+		Proof: proof,
+	}, nil
 }
-func remoteChangeResp(_ *RemoteChangesRequest) (*RemoteChangesResponse, error) {
-	return &RemoteChangesResponse{}, nil
+
+// remoteChangeResp always fails since changes tries are not supported.
+func (*Service) remoteChangeResp(_ *RemoteChangesRequest) (*RemoteChangesResponse, error) {
+	return nil, errChangesTriesNotSupported
 }
-func remoteHeaderResp(_ *RemoteHeaderRequest) (*RemoteHeaderResponse, error) {
-	return &RemoteHeaderResponse{}, nil
+
+// remoteHeaderResp responds with the header of the requested block number,
+// which is SCALE encoded in the request block field.
+func (s *Service) remoteHeaderResp(req *RemoteHeaderRequest) (*RemoteHeaderResponse, error) {
+	var blockNumber uint32
+	err := scale.Unmarshal(req.Block, &blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("decoding block number: %w", err)
+	}
+
+	blockHash, err := s.blockState.GetHashByNumber(uint(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("getting block hash for number %d: %w", blockNumber, err)
+	}
+
+	header, err := s.blockState.GetHeader(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	return &RemoteHeaderResponse{
+		Header: []*types.Header{header},
+	}, nil
 }
-func remoteReadChildResp(_ *RemoteReadChildRequest) (*RemoteReadResponse, error) {
-	return &RemoteReadResponse{}, nil
+
+// remoteReadChildResp responds with the proof of the child trie keys requested,
+// including the proof of the child trie root in the state trie of the requested block.
+func (s *Service) remoteReadChildResp(req *RemoteReadChildRequest) (*RemoteReadResponse, error) {
+	blockHash, err := lightBlockHash(req.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := s.blockState.GetHeader(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	trieState, err := s.storageState.TrieState(&header.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	child, err := trieState.GetChild(req.StorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("getting child trie: %w", err)
+	}
+
+	childRoot, err := child.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("computing child trie root: %w", err)
+	}
+
+	childKey := append(append([]byte{}, trie.ChildStorageKeyPrefix...), req.StorageKey...)
+	encodedProofNodes, err := s.storageState.GenerateTrieProof(header.StateRoot, [][]byte{childKey})
+	if err != nil {
+		return nil, fmt.Errorf("generating child trie root proof: %w", err)
+	}
+
+	childEncodedProofNodes, err := s.storageState.GenerateTrieProof(childRoot, req.Keys)
+	if err != nil {
+		return nil, fmt.Errorf("generating child trie proof: %w", err)
+	}
+	encodedProofNodes = append(encodedProofNodes, childEncodedProofNodes...)
+
+	proof, err := scale.Marshal(encodedProofNodes)
+	if err != nil {
+		return nil, fmt.Errorf("encoding read proof: %w", err)
+	}
+
+	return &RemoteReadResponse{
+		Proof: proof,
+	}, nil
+}
+
+// remoteReadResp responds with the proof of the state trie keys requested.
+func (s *Service) remoteReadResp(req *RemoteReadRequest) (*RemoteReadResponse, error) {
+	blockHash, err := lightBlockHash(req.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := s.blockState.GetHeader(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	encodedProofNodes, err := s.storageState.GenerateTrieProof(header.StateRoot, req.Keys)
+	if err != nil {
+		return nil, fmt.Errorf("generating read proof: %w", err)
+	}
+
+	proof, err := scale.Marshal(encodedProofNodes)
+	if err != nil {
+		return nil, fmt.Errorf("encoding read proof: %w", err)
+	}
+
+	return &RemoteReadResponse{
+		Proof: proof,
+	}, nil
 }
-func remoteReadResp(_ *RemoteReadRequest) (*RemoteReadResponse, error) {
-	return &RemoteReadResponse{}, nil
+
+func lightBlockHash(block []byte) (blockHash common.Hash, err error) {
+	if len(block) != len(blockHash) {
+		return blockHash, fmt.Errorf("%w: expected %d bytes but got %d bytes",
+			errInvalidLightBlockHash, len(blockHash), len(block))
+	}
+	copy(blockHash[:], block)
+	return blockHash, nil
 }
@@ -0,0 +1,170 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/trie"
+)
+
+// storageRecorder wraps the storage given to a runtime call and records
+// the keys of the state the call accesses, in order to generate the
+// execution proof of the call. Keys absent from the state are recorded
+// as well, since the proof must contain the trie path proving their absence
+// for the call to be re-executed against the proof.
+type storageRecorder struct {
+	runtime.Storage
+	keys      map[string]struct{}
+	childKeys map[string]map[string]struct{}
+}
+
+func newStorageRecorder(storage runtime.Storage) *storageRecorder {
+	return &storageRecorder{
+		Storage:   storage,
+		keys:      make(map[string]struct{}),
+		childKeys: make(map[string]map[string]struct{}),
+	}
+}
+
+func (r *storageRecorder) record(key []byte) {
+	r.keys[string(key)] = struct{}{}
+}
+
+func (r *storageRecorder) recordChild(keyToChild, key []byte) {
+	keys, ok := r.childKeys[string(keyToChild)]
+	if !ok {
+		keys = make(map[string]struct{})
+		r.childKeys[string(keyToChild)] = keys
+	}
+	keys[string(key)] = struct{}{}
+}
+
+// Get records the key and returns the value at the key.
+func (r *storageRecorder) Get(key []byte) []byte {
+	r.record(key)
+	return r.Storage.Get(key)
+}
+
+// Set records the key and sets the value at the key.
+func (r *storageRecorder) Set(key, value []byte) {
+	r.record(key)
+	r.Storage.Set(key, value)
+}
+
+// Delete records the key and deletes the key.
+func (r *storageRecorder) Delete(key []byte) {
+	r.record(key)
+	r.Storage.Delete(key)
+}
+
+// NextKey records the key given and the key following it, and returns the key following it.
+func (r *storageRecorder) NextKey(key []byte) []byte {
+	r.record(key)
+	next := r.Storage.NextKey(key)
+	if next != nil {
+		r.record(next)
+	}
+	return next
+}
+
+// LoadCode records the runtime code key and returns the runtime code.
+func (r *storageRecorder) LoadCode() []byte {
+	r.record(common.CodeKey)
+	return r.Storage.LoadCode()
+}
+
+// GetChildStorage records the child key and returns the value at the child key.
+func (r *storageRecorder) GetChildStorage(keyToChild, key []byte) ([]byte, error) {
+	r.recordChild(keyToChild, key)
+	return r.Storage.GetChildStorage(keyToChild, key)
+}
+
+// SetChildStorage records the child key and sets the value at the child key.
+func (r *storageRecorder) SetChildStorage(keyToChild, key, value []byte) error {
+	r.recordChild(keyToChild, key)
+	return r.Storage.SetChildStorage(keyToChild, key, value)
+}
+
+// ClearChildStorage records the child key and deletes the child key.
+func (r *storageRecorder) ClearChildStorage(keyToChild, key []byte) error {
+	r.recordChild(keyToChild, key)
+	return r.Storage.ClearChildStorage(keyToChild, key)
+}
+
+// GetChildNextKey records the child key given and the child key following it,
+// and returns the child key following it.
+func (r *storageRecorder) GetChildNextKey(keyToChild, key []byte) ([]byte, error) {
+	r.recordChild(keyToChild, key)
+	next, err := r.Storage.GetChildNextKey(keyToChild, key)
+	if err == nil && next != nil {
+		r.recordChild(keyToChild, next)
+	}
+	return next, err
+}
+
+// generateProof generates the proof of the keys recorded using the state root
+// the recorded storage was created from. Child trie keys are proven in their child
+// trie, together with the child trie root key in the state trie.
+func (r *storageRecorder) generateProof(stateRoot common.Hash, storageState StorageState) (
+	encodedProofNodes [][]byte, err error) {
+	// The child trie roots are read from the state trie before any write
+	// done during the call, so use a state at the original state root.
+	originalState, err := storageState.TrieState(&stateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	keys := sortedKeys(r.keys)
+	for _, keyToChild := range sortedKeys(r.childKeys) {
+		keys = append(keys, append(append([]byte{}, trie.ChildStorageKeyPrefix...), keyToChild...))
+	}
+
+	encodedProofNodes, err = storageState.GenerateTrieProofWithAbsentKeys(stateRoot, keys)
+	if err != nil {
+		return nil, fmt.Errorf("generating state trie proof: %w", err)
+	}
+
+	for _, keyToChild := range sortedKeys(r.childKeys) {
+		child, err := originalState.GetChild(keyToChild)
+		if errors.Is(err, trie.ErrChildTrieDoesNotExist) {
+			// the absence of the child trie is proven in the state trie proof
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("getting child trie: %w", err)
+		}
+
+		childRoot, err := child.Hash()
+		if err != nil {
+			return nil, fmt.Errorf("computing child trie root: %w", err)
+		}
+
+		childKeys := sortedKeys(r.childKeys[string(keyToChild)])
+		childEncodedProofNodes, err := storageState.GenerateTrieProofWithAbsentKeys(childRoot, childKeys)
+		if err != nil {
+			return nil, fmt.Errorf("generating child trie proof: %w", err)
+		}
+		encodedProofNodes = append(encodedProofNodes, childEncodedProofNodes...)
+	}
+
+	return encodedProofNodes, nil
+}
+
+func sortedKeys[T any](set map[string]T) (keys [][]byte) {
+	keyStrings := make([]string, 0, len(set))
+	for key := range set {
+		keyStrings = append(keyStrings, key)
+	}
+	sort.Strings(keyStrings)
+
+	keys = make([][]byte, len(keyStrings))
+	for i, key := range keyStrings {
+		keys[i] = []byte(key)
+	}
+	return keys
+}
@@ -9,11 +9,19 @@
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	mocksruntime "github.com/ChainSafe/gossamer/lib/runtime/mocks"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/trie"
 
+	"github.com/golang/mock/gomock"
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+//go:generate mockgen -destination=mock_storage_state_test.go -package $GOPACKAGE . StorageState
+
 func TestEncodeLightRequest(t *testing.T) {
 	t.Parallel()
 	exp := common.MustHexToBytes("0x0000000000000000000000000000")
@@ -119,42 +127,219 @@ func TestHandleLightMessage_Response(t *testing.T) {
 	// Testing empty request
 	msg := &LightRequest{}
 	err = s.handleLightMsg(stream, msg)
-	require.NoError(t, err)
-
-	expectedErr := "failed to find any peer in table"
+	require.ErrorIs(t, err, errLightStorageStateNotSet)
 
-	// Testing remoteCallResp()
-	msg = &LightRequest{
-		RemoteCallRequest: &RemoteCallRequest{},
-	}
-	err = s.handleLightMsg(stream, msg)
-	require.Error(t, err, expectedErr, msg.String())
+	ctrl := gomock.NewController(t)
+	s.storageState = NewMockStorageState(ctrl)
 
-	// Testing remoteHeaderResp()
-	msg = &LightRequest{
-		RemoteHeaderRequest: &RemoteHeaderRequest{},
-	}
 	err = s.handleLightMsg(stream, msg)
-	require.Error(t, err, expectedErr, msg.String())
+	require.NoError(t, err)
 
 	// Testing remoteChangeResp()
 	msg = &LightRequest{
-		RemoteChangesRequest: &RemoteChangesRequest{},
+		RemoteChangesRequest: &RemoteChangesRequest{
+			FirstBlock: &common.Hash{},
+		},
 	}
 	err = s.handleLightMsg(stream, msg)
-	require.Error(t, err, expectedErr, msg.String())
+	require.ErrorIs(t, err, errChangesTriesNotSupported)
 
 	// Testing remoteReadResp()
 	msg = &LightRequest{
-		RemoteReadRequest: &RemoteReadRequest{},
+		RemoteReadRequest: &RemoteReadRequest{
+			Block: []byte{1},
+		},
 	}
 	err = s.handleLightMsg(stream, msg)
-	require.Error(t, err, expectedErr, msg.String())
+	require.ErrorIs(t, err, errInvalidLightBlockHash)
+}
 
-	// Testing remoteReadChildResp()
-	msg = &LightRequest{
-		RemoteReadChildRequest: &RemoteReadChildRequest{},
+func Test_Service_remoteHeaderResp(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	header := &types.Header{
+		Number: 2,
+		Digest: types.NewDigest(),
 	}
-	err = s.handleLightMsg(stream, msg)
-	require.Error(t, err, expectedErr, msg.String())
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{2}, nil)
+	blockState.EXPECT().GetHeader(common.Hash{2}).Return(header, nil)
+
+	s := &Service{
+		blockState: blockState,
+	}
+
+	req := &RemoteHeaderRequest{
+		Block: []byte{2, 0, 0, 0},
+	}
+	resp, err := s.remoteHeaderResp(req)
+	require.NoError(t, err)
+
+	expected := &RemoteHeaderResponse{
+		Header: []*types.Header{header},
+	}
+	require.Equal(t, expected, resp)
+}
+
+func Test_Service_remoteReadResp(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	stateRoot := common.Hash{3}
+	keys := [][]byte{{1}, {2}}
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().GetHeader(common.Hash{1}).
+		Return(&types.Header{StateRoot: stateRoot}, nil)
+	storageState := NewMockStorageState(ctrl)
+	storageState.EXPECT().GenerateTrieProof(stateRoot, keys).
+		Return([][]byte{{4}, {5, 6}}, nil)
+
+	s := &Service{
+		blockState:   blockState,
+		storageState: storageState,
+	}
+
+	req := &RemoteReadRequest{
+		Block: common.Hash{1}.ToBytes(),
+		Keys:  keys,
+	}
+	resp, err := s.remoteReadResp(req)
+	require.NoError(t, err)
+
+	expected := &RemoteReadResponse{
+		Proof: []byte{8, 4, 4, 8, 5, 6},
+	}
+	require.Equal(t, expected, resp)
+}
+
+func Test_Service_remoteReadChildResp(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	keyToChild := []byte("child")
+	child := trie.NewEmptyTrie()
+	child.Put([]byte{1}, []byte{1})
+	childRoot, err := child.Hash()
+	require.NoError(t, err)
+
+	stateTrie := trie.NewEmptyTrie()
+	err = stateTrie.PutChild(keyToChild, child)
+	require.NoError(t, err)
+	stateRoot, err := stateTrie.Hash()
+	require.NoError(t, err)
+
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().GetHeader(common.Hash{1}).
+		Return(&types.Header{StateRoot: stateRoot}, nil)
+	storageState := NewMockStorageState(ctrl)
+	storageState.EXPECT().TrieState(&stateRoot).
+		Return(rtstorage.NewTrieState(stateTrie), nil)
+	childKey := append(append([]byte{}, trie.ChildStorageKeyPrefix...), keyToChild...)
+	storageState.EXPECT().GenerateTrieProof(stateRoot, [][]byte{childKey}).
+		Return([][]byte{{4}}, nil)
+	storageState.EXPECT().GenerateTrieProof(childRoot, [][]byte{{1}}).
+		Return([][]byte{{5}}, nil)
+
+	s := &Service{
+		blockState:   blockState,
+		storageState: storageState,
+	}
+
+	req := &RemoteReadChildRequest{
+		Block:      common.Hash{1}.ToBytes(),
+		StorageKey: keyToChild,
+		Keys:       [][]byte{{1}},
+	}
+	resp, err := s.remoteReadChildResp(req)
+	require.NoError(t, err)
+
+	expected := &RemoteReadResponse{
+		Proof: []byte{8, 4, 4, 4, 5},
+	}
+	require.Equal(t, expected, resp)
+}
+
+func Test_Service_remoteCallResp(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	stateTrie := trie.NewEmptyTrie()
+	stateTrie.Put([]byte("read"), []byte{1})
+	stateTrie.Put([]byte("written"), []byte{2})
+	stateTrie.Put([]byte("unused"), []byte{3})
+	stateRoot, err := stateTrie.Hash()
+	require.NoError(t, err)
+
+	blockHash := common.Hash{1}
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().GetHeader(blockHash).
+		Return(&types.Header{StateRoot: stateRoot}, nil)
+
+	instance := new(mocksruntime.Instance)
+	blockState.EXPECT().GetRuntime(&blockHash).Return(instance, nil)
+
+	storageState := NewMockStorageState(ctrl)
+	storageState.EXPECT().Lock()
+	storageState.EXPECT().TrieState(&stateRoot).
+		Return(rtstorage.NewTrieState(stateTrie), nil)
+	storageState.EXPECT().TrieState(&stateRoot).
+		Return(rtstorage.NewTrieState(stateTrie), nil)
+	// absent keys are proven as well
+	expectedKeys := [][]byte{[]byte("absent"), []byte("new"), []byte("read"), []byte("written")}
+	storageState.EXPECT().GenerateTrieProofWithAbsentKeys(stateRoot, expectedKeys).
+		Return([][]byte{{4}}, nil)
+	storageState.EXPECT().Unlock()
+
+	previousStorage := rtstorage.NewTrieState(nil)
+	instance.On("ContextStorage").Return(previousStorage).Once()
+
+	var storage runtime.Storage
+	instance.On("SetContextStorage", mock.AnythingOfType("*network.storageRecorder")).
+		Run(func(args mock.Arguments) {
+			storage = args.Get(0).(runtime.Storage)
+		}).Once()
+	instance.On("Exec", "Core_version", []byte{9}).Run(func(args mock.Arguments) {
+		_ = storage.Get([]byte("read"))
+		_ = storage.Get([]byte("absent"))
+		storage.Set([]byte("written"), []byte{5})
+		storage.Set([]byte("new"), []byte{6})
+	}).Return([]byte{}, nil)
+	// the storage of the instance is restored after the call
+	instance.On("SetContextStorage", previousStorage).Once()
+
+	s := &Service{
+		blockState:   blockState,
+		storageState: storageState,
+	}
+
+	req := &RemoteCallRequest{
+		Block:  blockHash.ToBytes(),
+		Method: "Core_version",
+		Data:   []byte{9},
+	}
+	resp, err := s.remoteCallResp(req)
+	require.NoError(t, err)
+
+	expected := &RemoteCallResponse{
+		Proof: []byte{4, 4, 4},
+	}
+	require.Equal(t, expected, resp)
+	instance.AssertExpectations(t)
+}
+
+func Test_lightBlockHash(t *testing.T) {
+	t.Parallel()
+
+	blockHash, err := lightBlockHash(common.Hash{1}.ToBytes())
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{1}, blockHash)
+
+	_, err = lightBlockHash([]byte{1})
+	require.ErrorIs(t, err, errInvalidLightBlockHash)
+	require.EqualError(t, err, "invalid light request block hash: expected 32 bytes but got 1 bytes")
 }
@@ -91,5 +91,5 @@ func (n Notifee) HandlePeerFound(p peer.AddrInfo) {
 
 	n.host.p2pHost.Peerstore().AddAddrs(p.ID, p.Addrs, peerstore.PermanentAddrTTL)
 	// connect to found peer
-	n.host.cm.peerSetHandler.AddPeer(0, p.ID)
+	n.host.cm.peerSetHandler.AddPeer(blockAnnounceSetID, p.ID)
 }
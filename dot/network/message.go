@@ -4,6 +4,7 @@
 package network
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -45,6 +46,7 @@ type NotificationsMessage interface {
 	RequestedDataReceipt       = byte(4)
 	RequestedDataMessageQueue  = byte(8)
 	RequestedDataJustification = byte(16)
+	RequestedDataIndexedBody   = byte(32)
 )
 
 var _ Message = &BlockRequestMessage{}
@@ -71,6 +73,8 @@ func (sd SyncDirection) String() string {
 	}
 }
 
+//go:generate go run github.com/ChainSafe/gossamer/cmd/scalegen -type=BlockRequestMessage,BlockResponseMessage -output=scale_generated.go
+
 // BlockRequestMessage is sent to request some blocks from a peer
 type BlockRequestMessage struct {
 	RequestedData byte
@@ -289,6 +293,10 @@ func blockDataToProtobuf(bd *types.BlockData) (*pb.BlockData, error) {
 		}
 	}
 
+	if bd.IndexedBody != nil {
+		p.IndexedBody = *bd.IndexedBody
+	}
+
 	return p, nil
 }
 
@@ -298,8 +306,13 @@ func protobufToBlockData(pbd *pb.BlockData) (*types.BlockData, error) {
 	}
 
 	if pbd.Header != nil {
+		// Each item of a collection in the header encoding takes at least
+		// one byte, so a length prefix larger than the encoding is invalid
+		// and is rejected before allocating memory for it.
+		decoder := scale.NewDecoder(bytes.NewReader(pbd.Header),
+			scale.MaxCollectionLength(uint(len(pbd.Header))))
 		header := types.NewEmptyHeader()
-		err := scale.Unmarshal(pbd.Header, header)
+		err := decoder.Decode(header)
 		if err != nil {
 			return nil, err
 		}
@@ -340,6 +353,10 @@ func protobufToBlockData(pbd *pb.BlockData) (*types.BlockData, error) {
 		bd.Justification = &[]byte{}
 	}
 
+	if pbd.IndexedBody != nil {
+		bd.IndexedBody = &pbd.IndexedBody
+	}
+
 	return bd, nil
 }
 
@@ -0,0 +1,105 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ChainSafe/gossamer/dot/network (interfaces: StorageState)
+
+// Package network is a generated GoMock package.
+package network
+
+import (
+	reflect "reflect"
+
+	common "github.com/ChainSafe/gossamer/lib/common"
+	storage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStorageState is a mock of StorageState interface.
+type MockStorageState struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageStateMockRecorder
+}
+
+// MockStorageStateMockRecorder is the mock recorder for MockStorageState.
+type MockStorageStateMockRecorder struct {
+	mock *MockStorageState
+}
+
+// NewMockStorageState creates a new mock instance.
+func NewMockStorageState(ctrl *gomock.Controller) *MockStorageState {
+	mock := &MockStorageState{ctrl: ctrl}
+	mock.recorder = &MockStorageStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorageState) EXPECT() *MockStorageStateMockRecorder {
+	return m.recorder
+}
+
+// GenerateTrieProof mocks base method.
+func (m *MockStorageState) GenerateTrieProof(arg0 common.Hash, arg1 [][]byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateTrieProof", arg0, arg1)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateTrieProof indicates an expected call of GenerateTrieProof.
+func (mr *MockStorageStateMockRecorder) GenerateTrieProof(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateTrieProof", reflect.TypeOf((*MockStorageState)(nil).GenerateTrieProof), arg0, arg1)
+}
+
+// GenerateTrieProofWithAbsentKeys mocks base method.
+func (m *MockStorageState) GenerateTrieProofWithAbsentKeys(arg0 common.Hash, arg1 [][]byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateTrieProofWithAbsentKeys", arg0, arg1)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateTrieProofWithAbsentKeys indicates an expected call of GenerateTrieProofWithAbsentKeys.
+func (mr *MockStorageStateMockRecorder) GenerateTrieProofWithAbsentKeys(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateTrieProofWithAbsentKeys", reflect.TypeOf((*MockStorageState)(nil).GenerateTrieProofWithAbsentKeys), arg0, arg1)
+}
+
+// Lock mocks base method.
+func (m *MockStorageState) Lock() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Lock")
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockStorageStateMockRecorder) Lock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockStorageState)(nil).Lock))
+}
+
+// TrieState mocks base method.
+func (m *MockStorageState) TrieState(arg0 *common.Hash) (*storage.TrieState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrieState", arg0)
+	ret0, _ := ret[0].(*storage.TrieState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrieState indicates an expected call of TrieState.
+func (mr *MockStorageStateMockRecorder) TrieState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrieState", reflect.TypeOf((*MockStorageState)(nil).TrieState), arg0)
+}
+
+// Unlock mocks base method.
+func (m *MockStorageState) Unlock() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unlock")
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockStorageStateMockRecorder) Unlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockStorageState)(nil).Unlock))
+}
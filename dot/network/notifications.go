@@ -57,7 +57,10 @@ type handshakeReader struct {
 }
 
 type notificationsProtocol struct {
-	protocolID         protocol.ID
+	protocolID protocol.ID
+	// setID is the id of the peer set in which peers must be
+	// connected to exchange messages on this protocol.
+	setID              int
 	getHandshake       HandshakeGetter
 	handshakeDecoder   HandshakeDecoder
 	handshakeValidator HandshakeValidator
@@ -65,10 +68,11 @@ type notificationsProtocol struct {
 	maxSize            uint64
 }
 
-func newNotificationsProtocol(protocolID protocol.ID, handshakeGetter HandshakeGetter,
+func newNotificationsProtocol(protocolID protocol.ID, setID int, handshakeGetter HandshakeGetter,
 	handshakeDecoder HandshakeDecoder, handshakeValidator HandshakeValidator, maxSize uint64) *notificationsProtocol {
 	return &notificationsProtocol{
 		protocolID:         protocolID,
+		setID:              setID,
 		getHandshake:       handshakeGetter,
 		handshakeValidator: handshakeValidator,
 		handshakeDecoder:   handshakeDecoder,
@@ -150,6 +154,12 @@ func (s *Service) createNotificationsMessageHandler(
 			return fmt.Errorf("%w: expected %T but got %T", errMessageTypeNotValid, (NotificationsMessage)(nil), msg)
 		}
 
+		if !s.isInPeerSet(info, peer) {
+			logger.Tracef("ignoring message on notifications sub-protocol %s from peer %s not in peer set %d",
+				info.protocolID, peer, info.setID)
+			return nil
+		}
+
 		hasSeen, err := s.gossip.hasSeen(msg)
 		if err != nil {
 			return fmt.Errorf("could not check if message was seen before: %w", err)
@@ -254,6 +264,12 @@ func (s *Service) sendData(peer peer.ID, hs Handshake, info *notificationsProtoc
 		return
 	}
 
+	if !s.isInPeerSet(info, peer) {
+		logger.Tracef("not sending message on protocol %s to peer %s not in peer set %d",
+			info.protocolID, peer, info.setID)
+		return
+	}
+
 	support, err := s.host.supportsProtocol(peer, info.protocolID)
 	if err != nil {
 		logger.Errorf("could not check if protocol %s is supported by peer %s: %s", info.protocolID, peer, err)
@@ -306,6 +322,16 @@ func (s *Service) sendData(peer peer.ID, hs Handshake, info *notificationsProtoc
 	}, peer)
 }
 
+// isInPeerSet returns true if messages of the notifications protocol can be exchanged
+// with the peer. The connection to a peer is closed once it leaves the default set,
+// so only the membership of the other sets is checked.
+func (s *Service) isInPeerSet(info *notificationsProtocol, peerID peer.ID) bool {
+	if info.setID == blockAnnounceSetID {
+		return true
+	}
+	return s.host.cm.peerSetHandler.IsConnected(info.setID, peerID)
+}
+
 var errPeerDisconnected = errors.New("peer disconnected")
 
 func (s *Service) sendHandshake(peer peer.ID, hs Handshake, info *notificationsProtocol) (network.Stream, error) {
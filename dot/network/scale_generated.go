@@ -0,0 +1,217 @@
+// Code generated by scalegen. DO NOT EDIT.
+
+package network
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// MarshalSCALE returns the SCALE encoding of the BlockRequestMessage.
+func (bm BlockRequestMessage) MarshalSCALE() ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+	err := bm.encodeSCALE(buffer)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// encodeSCALE writes the SCALE encoding of the BlockRequestMessage to the writer.
+func (bm BlockRequestMessage) encodeSCALE(writer io.Writer) (err error) {
+	err = scale.EncodeUint8(writer, bm.RequestedData)
+	if err != nil {
+		return fmt.Errorf("encoding RequestedData: %w", err)
+	}
+	var encoding0 []byte
+	encoding0, err = bm.StartingBlock.MarshalSCALE()
+	if err != nil {
+		return fmt.Errorf("encoding StartingBlock: %w", err)
+	}
+	_, err = writer.Write(encoding0)
+	if err != nil {
+		return fmt.Errorf("encoding StartingBlock: %w", err)
+	}
+	if bm.EndBlockHash == nil {
+		_, err = writer.Write([]byte{0})
+		if err != nil {
+			return fmt.Errorf("encoding EndBlockHash: %w", err)
+		}
+	} else {
+		_, err = writer.Write([]byte{1})
+		if err != nil {
+			return fmt.Errorf("encoding EndBlockHash: %w", err)
+		}
+		_, err = writer.Write((*bm.EndBlockHash)[:])
+		if err != nil {
+			return fmt.Errorf("encoding EndBlockHash: %w", err)
+		}
+	}
+	err = scale.EncodeUint8(writer, uint8(bm.Direction))
+	if err != nil {
+		return fmt.Errorf("encoding Direction: %w", err)
+	}
+	if bm.Max == nil {
+		_, err = writer.Write([]byte{0})
+		if err != nil {
+			return fmt.Errorf("encoding Max: %w", err)
+		}
+	} else {
+		_, err = writer.Write([]byte{1})
+		if err != nil {
+			return fmt.Errorf("encoding Max: %w", err)
+		}
+		err = scale.EncodeUint32(writer, *bm.Max)
+		if err != nil {
+			return fmt.Errorf("encoding Max: %w", err)
+		}
+	}
+	return nil
+}
+
+// UnmarshalSCALE decodes the SCALE encoding read from the reader into the BlockRequestMessage.
+func (bm *BlockRequestMessage) UnmarshalSCALE(reader io.Reader) (err error) {
+	decoded := BlockRequestMessage{}
+	decoded.StartingBlock = bm.StartingBlock
+	decoded.EndBlockHash = bm.EndBlockHash
+	decoded.Max = bm.Max
+	var v1 uint8
+	v1, err = scale.DecodeUint8(reader)
+	if err != nil {
+		return fmt.Errorf("decoding RequestedData: %w", err)
+	}
+	decoded.RequestedData = v1
+	err = decoded.StartingBlock.UnmarshalSCALE(reader)
+	if err != nil {
+		return fmt.Errorf("decoding StartingBlock: %w", err)
+	}
+	var option2 uint8
+	option2, err = scale.DecodeUint8(reader)
+	if err != nil {
+		return fmt.Errorf("decoding EndBlockHash: %w", err)
+	}
+	switch option2 {
+	case 0:
+		decoded.EndBlockHash = nil
+	case 1:
+		if decoded.EndBlockHash == nil {
+			decoded.EndBlockHash = new(common.Hash)
+		}
+		_, err = io.ReadFull(reader, (*decoded.EndBlockHash)[:])
+		if err != nil {
+			return fmt.Errorf("decoding EndBlockHash: %w", err)
+		}
+	default:
+		return fmt.Errorf("decoding EndBlockHash: unsupported Option value: %d", option2)
+	}
+	var v3 uint8
+	v3, err = scale.DecodeUint8(reader)
+	if err != nil {
+		return fmt.Errorf("decoding Direction: %w", err)
+	}
+	decoded.Direction = SyncDirection(v3)
+	var option4 uint8
+	option4, err = scale.DecodeUint8(reader)
+	if err != nil {
+		return fmt.Errorf("decoding Max: %w", err)
+	}
+	switch option4 {
+	case 0:
+		decoded.Max = nil
+	case 1:
+		if decoded.Max == nil {
+			decoded.Max = new(uint32)
+		}
+		var v5 uint32
+		v5, err = scale.DecodeUint32(reader)
+		if err != nil {
+			return fmt.Errorf("decoding Max: %w", err)
+		}
+		*decoded.Max = v5
+	default:
+		return fmt.Errorf("decoding Max: unsupported Option value: %d", option4)
+	}
+	*bm = decoded
+	return nil
+}
+
+// MarshalSCALE returns the SCALE encoding of the BlockResponseMessage.
+func (bm BlockResponseMessage) MarshalSCALE() ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+	err := bm.encodeSCALE(buffer)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// encodeSCALE writes the SCALE encoding of the BlockResponseMessage to the writer.
+func (bm BlockResponseMessage) encodeSCALE(writer io.Writer) (err error) {
+	err = scale.EncodeCompactUint(writer, uint(len(bm.BlockData)))
+	if err != nil {
+		return fmt.Errorf("encoding BlockData: %w", err)
+	}
+	for _, v0 := range bm.BlockData {
+		if v0 == nil {
+			_, err = writer.Write([]byte{0})
+			if err != nil {
+				return fmt.Errorf("encoding BlockData: %w", err)
+			}
+		} else {
+			_, err = writer.Write([]byte{1})
+			if err != nil {
+				return fmt.Errorf("encoding BlockData: %w", err)
+			}
+			var encoding1 []byte
+			encoding1, err = v0.MarshalSCALE()
+			if err != nil {
+				return fmt.Errorf("encoding BlockData: %w", err)
+			}
+			_, err = writer.Write(encoding1)
+			if err != nil {
+				return fmt.Errorf("encoding BlockData: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// UnmarshalSCALE decodes the SCALE encoding read from the reader into the BlockResponseMessage.
+func (bm *BlockResponseMessage) UnmarshalSCALE(reader io.Reader) (err error) {
+	decoded := BlockResponseMessage{}
+	var length2 uint
+	length2, err = scale.DecodeLength(reader)
+	if err != nil {
+		return fmt.Errorf("decoding BlockData: %w", err)
+	}
+	for i3 := uint(0); i3 < length2; i3++ {
+		var v4 *types.BlockData
+		var option5 uint8
+		option5, err = scale.DecodeUint8(reader)
+		if err != nil {
+			return fmt.Errorf("decoding BlockData: %w", err)
+		}
+		switch option5 {
+		case 0:
+			v4 = nil
+		case 1:
+			if v4 == nil {
+				v4 = new(types.BlockData)
+			}
+			err = v4.UnmarshalSCALE(reader)
+			if err != nil {
+				return fmt.Errorf("decoding BlockData: %w", err)
+			}
+		default:
+			return fmt.Errorf("decoding BlockData: unsupported Option value: %d", option5)
+		}
+		decoded.BlockData = append(decoded.BlockData, v4)
+	}
+	*bm = decoded
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/common/variadic"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The types below have no method, so they are encoded and decoded
+// using the reflection based encoder and decoder of the scale package.
+type (
+	reflectiveBlockRequestMessage  BlockRequestMessage
+	reflectiveBlockResponseMessage BlockResponseMessage
+)
+
+func Test_BlockRequestMessage_generatedSCALE(t *testing.T) {
+	t.Parallel()
+
+	endBlockHash := common.Hash{1}
+	max := uint32(128)
+
+	testCases := map[string]BlockRequestMessage{
+		"block number": {
+			RequestedData: RequestedDataHeader + RequestedDataBody,
+			StartingBlock: *variadic.MustNewUint32OrHash(uint32(1000)),
+			Direction:     Descending,
+		},
+		"block hash with optionals": {
+			RequestedData: RequestedDataHeader,
+			StartingBlock: *variadic.MustNewUint32OrHash(common.Hash{2}),
+			EndBlockHash:  &endBlockHash,
+			Direction:     Ascending,
+			Max:           &max,
+		},
+	}
+
+	for name, message := range testCases {
+		message := message
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			encoding, err := message.MarshalSCALE()
+			require.NoError(t, err)
+
+			reflectiveEncoding, err := scale.Marshal(reflectiveBlockRequestMessage(message))
+			require.NoError(t, err)
+			assert.Equal(t, reflectiveEncoding, encoding)
+
+			var decoded BlockRequestMessage
+			err = scale.Unmarshal(encoding, &decoded)
+			require.NoError(t, err)
+
+			var reflectiveDecoded reflectiveBlockRequestMessage
+			err = scale.Unmarshal(encoding, &reflectiveDecoded)
+			require.NoError(t, err)
+
+			assert.Equal(t, message, decoded)
+			assert.Equal(t, BlockRequestMessage(reflectiveDecoded), decoded)
+		})
+	}
+}
+
+func Test_BlockResponseMessage_generatedSCALE(t *testing.T) {
+	t.Parallel()
+
+	message := BlockResponseMessage{
+		BlockData: []*types.BlockData{
+			{
+				Hash: common.Hash{1},
+				Header: &types.Header{
+					ParentHash: common.Hash{2},
+					Number:     3,
+					Digest:     types.NewDigest(),
+				},
+				Body:          types.NewBody([]types.Extrinsic{{4, 5}}),
+				Justification: &[]byte{6},
+			},
+			nil,
+			{
+				Hash: common.Hash{7},
+			},
+		},
+	}
+
+	encoding, err := message.MarshalSCALE()
+	require.NoError(t, err)
+
+	reflectiveEncoding, err := scale.Marshal(reflectiveBlockResponseMessage(message))
+	require.NoError(t, err)
+	assert.Equal(t, reflectiveEncoding, encoding)
+
+	var decoded BlockResponseMessage
+	err = scale.Unmarshal(encoding, &decoded)
+	require.NoError(t, err)
+
+	var reflectiveDecoded reflectiveBlockResponseMessage
+	err = scale.Unmarshal(encoding, &reflectiveDecoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, BlockResponseMessage(reflectiveDecoded), decoded)
+
+	reencoding, err := decoded.MarshalSCALE()
+	require.NoError(t, err)
+	assert.Equal(t, encoding, reencoding)
+}
@@ -31,6 +31,8 @@
 	// the following are sub-protocols used by the node
 	syncID          = "/sync/2"
 	lightID         = "/light/2"
+	warpSyncID      = "/sync/warp"
+	stateID         = "/state/2"
 	blockAnnounceID = "/block-announces/1"
 	transactionsID  = "/transactions/1"
 
@@ -118,6 +120,7 @@ type Service struct {
 
 	// Service interfaces
 	blockState         BlockState
+	storageState       StorageState
 	syncer             Syncer
 	transactionHandler TransactionHandler
 
@@ -166,6 +169,14 @@ func NewService(cfg *Config) (*Service, error) {
 		cfg.MaxPeers = DefaultMaxPeerCount
 	}
 
+	if cfg.GrandpaPeerSet == (PeerSetConfig{}) {
+		cfg.GrandpaPeerSet = defaultPeerSetConfig(cfg.MaxPeers)
+	}
+
+	if cfg.TransactionsPeerSet == (PeerSetConfig{}) {
+		cfg.TransactionsPeerSet = defaultPeerSetConfig(cfg.MaxPeers)
+	}
+
 	if cfg.DiscoveryInterval > 0 {
 		connectToPeersTimeout = cfg.DiscoveryInterval
 	}
@@ -196,6 +207,7 @@ func NewService(cfg *Config) (*Service, error) {
 		mdns:                   newMDNS(host),
 		gossip:                 newGossip(),
 		blockState:             cfg.BlockState,
+		storageState:           cfg.StorageState,
 		transactionHandler:     cfg.TransactionHandler,
 		noBootstrap:            cfg.NoBootstrap,
 		noMDNS:                 cfg.NoMDNS,
@@ -240,6 +252,8 @@ func (s *Service) Start() error {
 
 	s.host.registerStreamHandler(s.host.protocolID+syncID, s.handleSyncStream)
 	s.host.registerStreamHandler(s.host.protocolID+lightID, s.handleLightStream)
+	s.host.registerStreamHandler(s.host.protocolID+warpSyncID, s.handleWarpSyncStream)
+	s.host.registerStreamHandler(s.host.protocolID+stateID, s.handleStateStream)
 
 	// register block announce protocol
 	err := s.RegisterNotificationsProtocol(
@@ -283,9 +297,11 @@ func (s *Service) Start() error {
 		for _, prtl := range s.notificationsProtocols {
 			prtl.peersData.setMutex(peerID)
 		}
-		// TODO: currently we only have one set so setID is 0, change this once we have more set in peerSet
-		const setID = 0
-		s.host.cm.peerSetHandler.Incoming(setID, peerID)
+		// the default set is the last to handle the connection, so that once it
+		// rejects the peer, we know if the peer was accepted in any other set.
+		for setID := numPeerSets - 1; setID >= blockAnnounceSetID; setID-- {
+			s.host.cm.peerSetHandler.Incoming(setID, peerID)
+		}
 	}
 
 	// when a peer gets disconnected, we should clear all handshake data we have for it.
@@ -295,6 +311,14 @@ func (s *Service) Start() error {
 			prtl.peersData.deleteInboundHandshakeData(peerID)
 			prtl.peersData.deleteOutboundHandshakeData(peerID)
 		}
+
+		s.syncer.HandlePeerDisconnected(peerID)
+
+		_, isAuthority := s.host.cm.authorityPeers.LoadAndDelete(peerID)
+		_, isPersistent := s.host.cm.persistentPeers.Load(peerID)
+		if isAuthority && !isPersistent {
+			s.host.cm.peerSetHandler.RemoveReservedPeer(grandpaSetID, peerID)
+		}
 	}
 
 	// log listening addresses to console
@@ -492,7 +516,8 @@ func (s *Service) RegisterNotificationsProtocol(
 		return errors.New("notifications protocol with message type already exists")
 	}
 
-	np := newNotificationsProtocol(protocolID, handshakeGetter, handshakeDecoder, handshakeValidator, maxSize)
+	np := newNotificationsProtocol(protocolID, peerSetID(messageID),
+		handshakeGetter, handshakeDecoder, handshakeValidator, maxSize)
 	s.notificationsProtocols[messageID] = np
 	decoder := createDecoder(np, handshakeDecoder, messageDecoder)
 	handlerWithValidate := s.createNotificationsMessageHandler(np, messageHandler, batchHandler)
@@ -677,6 +702,25 @@ func (s *Service) processMessage(msg peerset.Message) {
 		}
 		logger.Debugf("connection successful with peer %s", peerID)
 	case peerset.Drop, peerset.Reject:
+		// a peer rejected by the other sets may still be accepted by the default set,
+		// which handles incoming connections last.
+		if msg.Status == peerset.Reject && msg.SetID() != blockAnnounceSetID {
+			return
+		}
+
+		if msg.Status == peerset.Drop && msg.SetID() == blockAnnounceSetID {
+			// the connection is governed by the default set, so
+			// the peer leaves the other sets along with it.
+			for setID := blockAnnounceSetID + 1; setID < numPeerSets; setID++ {
+				s.host.cm.peerSetHandler.DisconnectPeer(setID, peerID)
+			}
+		} else if s.isConnectedInPeerSets(peerID) {
+			// the connection is shared by all the sets, so it is kept as long as
+			// the peer is connected in any set.
+			logger.Debugf("peer %s dropped from set %d but still connected in another set", peerID, msg.SetID())
+			return
+		}
+
 		err := s.host.closePeer(peerID)
 		if err != nil {
 			logger.Warnf("failed to close connection with peer %s: %s", peerID, err)
@@ -686,6 +730,16 @@ func (s *Service) processMessage(msg peerset.Message) {
 	}
 }
 
+// isConnectedInPeerSets returns true if the peer is connected in any of the peer sets.
+func (s *Service) isConnectedInPeerSets(peerID peer.ID) bool {
+	for setID := 0; setID < numPeerSets; setID++ {
+		if s.host.cm.peerSetHandler.IsConnected(setID, peerID) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Service) startProcessingMsg() {
 	msgCh := s.host.cm.peerSetHandler.Messages()
 	for {
@@ -151,6 +151,9 @@ func createTestService(t *testing.T, cfg *Config) (srvc *Service) {
 			Return(newTestBlockResponseMessage(t), nil).AnyTimes()
 
 		syncer.EXPECT().IsSynced().Return(false).AnyTimes()
+		syncer.EXPECT().
+			HandlePeerDisconnected(gomock.AssignableToTypeOf(peer.ID(""))).
+			AnyTimes()
 		cfg.Syncer = syncer
 	}
 
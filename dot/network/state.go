@@ -5,12 +5,15 @@
 
 import (
 	"context"
+	"sync"
 
 	"github.com/libp2p/go-libp2p-core/peer"
 
 	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 )
 
 // BlockState interface for block state methods
@@ -21,6 +24,17 @@ type BlockState interface {
 	HasBlockBody(common.Hash) (bool, error)
 	GetHighestFinalisedHeader() (*types.Header, error)
 	GetHashByNumber(num uint) (common.Hash, error)
+	GetHeader(common.Hash) (*types.Header, error)
+	GetRuntime(*common.Hash) (runtime.Instance, error)
+}
+
+// StorageState is the interface for the storage state methods
+// used to serve light client requests
+type StorageState interface {
+	TrieState(root *common.Hash) (*rtstorage.TrieState, error)
+	GenerateTrieProof(stateRoot common.Hash, keys [][]byte) ([][]byte, error)
+	GenerateTrieProofWithAbsentKeys(stateRoot common.Hash, keys [][]byte) ([][]byte, error)
+	sync.Locker
 }
 
 // Syncer is implemented by the syncing service
@@ -31,11 +45,18 @@ type Syncer interface {
 	// If a request needs to be sent to the peer to retrieve the full block, this function will return it.
 	HandleBlockAnnounce(from peer.ID, msg *BlockAnnounceMessage) error
 
+	// HandlePeerDisconnected is called when the connection to the peer is closed.
+	HandlePeerDisconnected(who peer.ID)
+
 	// IsSynced exposes the internal synced state
 	IsSynced() bool
 
 	// CreateBlockResponse is called upon receipt of a BlockRequestMessage to create the response
 	CreateBlockResponse(*BlockRequestMessage) (*BlockResponseMessage, error)
+
+	// CreateWarpSyncProof is called upon receipt of a WarpSyncRequest to create the
+	// warp sync proof starting from the finalised block with the given hash
+	CreateWarpSyncProof(begin common.Hash) (*WarpSyncProof, error)
 }
 
 // TransactionHandler is the interface used by the transactions sub-protocol
@@ -71,6 +92,7 @@ type PeerRemove interface {
 // Peer is the interface used by the PeerSetHandler to get the peer data from peerSet.
 type Peer interface {
 	PeerReputation(peer.ID) (peerset.Reputation, error)
+	IsConnected(int, peer.ID) bool
 	SortedPeers(idx int) chan peer.IDSlice
 	Messages() chan peerset.Message
 }
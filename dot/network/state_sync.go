@@ -0,0 +1,365 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/ChainSafe/gossamer/dot/network/proto"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+const (
+	// MaxStateResponseSize is the maximum size of an encoded state response
+	MaxStateResponseSize = 16 * 1024 * 1024
+
+	// maxStateResponseEntriesSize is the size of the keys and values after
+	// which no more entries are added to a state response. The entry reaching
+	// it is still added, so a response always contains at least one entry.
+	maxStateResponseEntriesSize = 2 * 1024 * 1024
+
+	maxStateRequestSize = 64 * 1024
+)
+
+var (
+	stateRequestTimeout = time.Second * 30
+)
+
+var _ Message = &StateRequest{}
+
+// StateRequest is sent to request the storage entries of the state
+// at the block with the given hash, in lexicographic key order.
+type StateRequest struct {
+	// Block is the hash of the block to read the state of
+	Block common.Hash
+	// Start is the path of the last key received. It is empty to request the
+	// state trie from its first key, contains the state trie key to continue
+	// the state trie after it, or contains the child storage key of a child
+	// trie in the state trie followed by the child trie key to continue the
+	// child trie after it, which is empty to request the child trie from its first key.
+	Start [][]byte
+	// NoProof is true if the response must not contain the proof of the entries
+	NoProof bool
+}
+
+// Encode returns the protobuf encoded StateRequest
+func (sr *StateRequest) Encode() ([]byte, error) {
+	msg := &pb.StateRequest{
+		Block:   sr.Block.ToBytes(),
+		Start:   sr.Start,
+		NoProof: sr.NoProof,
+	}
+
+	return proto.Marshal(msg)
+}
+
+// Decode decodes the protobuf encoded input to a StateRequest
+func (sr *StateRequest) Decode(in []byte) error {
+	msg := &pb.StateRequest{}
+	err := proto.Unmarshal(in, msg)
+	if err != nil {
+		return err
+	}
+
+	if len(msg.Block) != common.HashLength {
+		return fmt.Errorf("%w: expected %d bytes, got %d bytes",
+			errStateRequestBlockInvalid, common.HashLength, len(msg.Block))
+	}
+
+	sr.Block = common.BytesToHash(msg.Block)
+	sr.Start = msg.Start
+	sr.NoProof = msg.NoProof
+	return nil
+}
+
+// String formats a StateRequest as a string
+func (sr *StateRequest) String() string {
+	return fmt.Sprintf("StateRequest Block=%s Start=0x%x NoProof=%t", sr.Block, sr.Start, sr.NoProof)
+}
+
+// StateEntry is a storage entry of a state response
+type StateEntry struct {
+	Key   []byte
+	Value []byte
+}
+
+var _ Message = &StateResponse{}
+
+// StateResponse is sent in response to a StateRequest. It contains the entries
+// of the requested trie following the requested start key, and the proof of
+// these entries in the requested trie, unless no proof was requested.
+// Complete is true if the entries reach the last key of the requested trie.
+type StateResponse struct {
+	Entries  []StateEntry
+	Complete bool
+	Proof    [][]byte
+}
+
+// Encode returns the protobuf encoded StateResponse. The entries are encoded
+// as a single key value state entry, and the proof nodes are SCALE encoded.
+func (sr *StateResponse) Encode() ([]byte, error) {
+	entries := make([]*pb.StateEntry, len(sr.Entries))
+	for i, entry := range sr.Entries {
+		entries[i] = &pb.StateEntry{
+			Key:   entry.Key,
+			Value: entry.Value,
+		}
+	}
+
+	msg := &pb.StateResponse{
+		Entries: []*pb.KeyValueStateEntry{{
+			Entries:  entries,
+			Complete: sr.Complete,
+		}},
+	}
+
+	if len(sr.Proof) > 0 {
+		proof, err := scale.Marshal(sr.Proof)
+		if err != nil {
+			return nil, fmt.Errorf("encoding proof: %w", err)
+		}
+		msg.Proof = proof
+	}
+
+	return proto.Marshal(msg)
+}
+
+// Decode decodes the protobuf encoded input to a StateResponse
+func (sr *StateResponse) Decode(in []byte) error {
+	msg := &pb.StateResponse{}
+	err := proto.Unmarshal(in, msg)
+	if err != nil {
+		return err
+	}
+
+	sr.Entries = nil
+	sr.Complete = false
+	sr.Proof = nil
+
+	switch len(msg.Entries) {
+	case 0:
+	case 1:
+		stateEntries := msg.Entries[0]
+		sr.Complete = stateEntries.Complete
+		if len(stateEntries.Entries) > 0 {
+			sr.Entries = make([]StateEntry, len(stateEntries.Entries))
+		}
+		for i, entry := range stateEntries.Entries {
+			sr.Entries[i] = StateEntry{
+				Key:   entry.Key,
+				Value: entry.Value,
+			}
+		}
+	default:
+		return fmt.Errorf("%w: %d", errStateResponseLevels, len(msg.Entries))
+	}
+
+	if len(msg.Proof) > 0 {
+		err = scale.Unmarshal(msg.Proof, &sr.Proof)
+		if err != nil {
+			return fmt.Errorf("decoding proof: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// String formats a StateResponse as a string
+func (sr *StateResponse) String() string {
+	if sr == nil {
+		return "StateResponse=nil"
+	}
+
+	return fmt.Sprintf("StateResponse Entries=%d Complete=%t Proof=%d",
+		len(sr.Entries), sr.Complete, len(sr.Proof))
+}
+
+// DoStateRequest sends a state request to the given peer.
+// If a state response is received within a certain time period,
+// it is returned, otherwise an error is returned.
+func (s *Service) DoStateRequest(to peer.ID, req *StateRequest) (*StateResponse, error) {
+	fullStateID := s.host.protocolID + stateID
+
+	s.host.p2pHost.ConnManager().Protect(to, "")
+	defer s.host.p2pHost.ConnManager().Unprotect(to, "")
+
+	ctx, cancel := context.WithTimeout(s.ctx, stateRequestTimeout)
+	defer cancel()
+
+	stream, err := s.host.p2pHost.NewStream(ctx, to, fullStateID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err := stream.Close()
+		if err != nil {
+			logger.Warnf("failed to close stream: %s", err)
+		}
+	}()
+
+	if err = s.host.writeToStream(stream, req); err != nil {
+		return nil, err
+	}
+
+	return s.receiveStateResponse(stream)
+}
+
+func (s *Service) receiveStateResponse(stream libp2pnetwork.Stream) (*StateResponse, error) {
+	// state responses are only requested when the node starts,
+	// so the buffer is not pooled unlike block responses.
+	buf := make([]byte, MaxStateResponseSize)
+
+	n, err := readStream(stream, &buf, MaxStateResponseSize)
+	if err != nil {
+		return nil, fmt.Errorf("read stream error: %w", err)
+	}
+
+	if n == 0 {
+		return nil, fmt.Errorf("received empty message")
+	}
+
+	// the response is not reported if it cannot be decoded, since
+	// the peer may be honest and support a different encoding.
+	resp := new(StateResponse)
+	err = resp.Decode(buf[:n])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode state response: %w", err)
+	}
+
+	return resp, nil
+}
+
+// handleStateStream handles streams with the <protocol-id>/state/2 protocol ID
+func (s *Service) handleStateStream(stream libp2pnetwork.Stream) {
+	if stream == nil {
+		return
+	}
+
+	s.readStream(stream, decodeStateMessage, s.handleStateMessage, maxStateRequestSize)
+}
+
+func decodeStateMessage(in []byte, _ peer.ID, _ bool) (Message, error) {
+	msg := new(StateRequest)
+	err := msg.Decode(in)
+	return msg, err
+}
+
+// handleStateMessage handles inbound state streams, which only carry StateRequests
+func (s *Service) handleStateMessage(stream libp2pnetwork.Stream, msg Message) error {
+	if msg == nil {
+		return nil
+	}
+
+	defer func() {
+		err := stream.Close()
+		if err != nil {
+			logger.Warnf("failed to close stream: %s", err)
+		}
+	}()
+
+	req, ok := msg.(*StateRequest)
+	if !ok {
+		return nil
+	}
+
+	resp, err := s.createStateResponse(req)
+	if err != nil {
+		logger.Debugf("cannot create state response for request: %s", err)
+		return nil
+	}
+
+	err = s.host.writeToStream(stream, resp)
+	if err != nil {
+		logger.Debugf("failed to send StateResponse message to peer %s: %s", stream.Conn().RemotePeer(), err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) createStateResponse(req *StateRequest) (*StateResponse, error) {
+	if s.storageState == nil {
+		return nil, errStateStorageStateNotSet
+	}
+
+	header, err := s.blockState.GetHeader(req.Block)
+	if err != nil {
+		return nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	trieState, err := s.storageState.TrieState(&header.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	t := trieState.Trie()
+	root := header.StateRoot
+	var start []byte
+	switch len(req.Start) {
+	case 0:
+	case 1:
+		start = req.Start[0]
+	case 2:
+		childStorageKey, childStart := req.Start[0], req.Start[1]
+		if !bytes.HasPrefix(childStorageKey, trie.ChildStorageKeyPrefix) {
+			return nil, fmt.Errorf("%w: 0x%x", errInvalidChildStorageKey, childStorageKey)
+		}
+
+		t, err = trieState.GetChild(childStorageKey[len(trie.ChildStorageKeyPrefix):])
+		if err != nil {
+			return nil, fmt.Errorf("getting child trie: %w", err)
+		}
+
+		root, err = t.Hash()
+		if err != nil {
+			return nil, fmt.Errorf("computing child trie root: %w", err)
+		}
+		start = childStart
+	default:
+		return nil, fmt.Errorf("%w: %d keys", errInvalidStateRequestStart, len(req.Start))
+	}
+
+	resp := &StateResponse{}
+	entriesSize := 0
+	key := t.NextKey(start)
+	if len(start) == 0 && t.Get(nil) != nil {
+		// the empty key is not returned by NextKey
+		key = []byte{}
+	}
+
+	keys := make([][]byte, 0)
+	for key != nil && entriesSize < maxStateResponseEntriesSize {
+		value := t.Get(key)
+		resp.Entries = append(resp.Entries, StateEntry{
+			Key:   key,
+			Value: value,
+		})
+		keys = append(keys, key)
+		entriesSize += len(key) + len(value)
+		key = t.NextKey(key)
+	}
+	resp.Complete = key == nil
+
+	if req.NoProof || len(keys) == 0 {
+		return resp, nil
+	}
+
+	resp.Proof, err = s.storageState.GenerateTrieProof(root, keys)
+	if err != nil {
+		return nil, fmt.Errorf("generating trie proof: %w", err)
+	}
+
+	return resp, nil
+}
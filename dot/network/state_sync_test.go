@@ -0,0 +1,294 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateRequest_EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	req := &StateRequest{
+		Block:   common.Hash{1},
+		Start:   [][]byte{{2, 3}, {4}},
+		NoProof: true,
+	}
+
+	encoded, err := req.Encode()
+	require.NoError(t, err)
+
+	decoded := new(StateRequest)
+	err = decoded.Decode(encoded)
+	require.NoError(t, err)
+	require.Equal(t, req, decoded)
+}
+
+func TestStateResponse_EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	resp := &StateResponse{
+		Entries: []StateEntry{
+			{Key: []byte{1}, Value: []byte{2}},
+			{Key: []byte{3}, Value: []byte{4, 5}},
+		},
+		Complete: true,
+		Proof:    [][]byte{{6}},
+	}
+
+	encoded, err := resp.Encode()
+	require.NoError(t, err)
+
+	decoded := new(StateResponse)
+	err = decoded.Decode(encoded)
+	require.NoError(t, err)
+	require.Equal(t, resp, decoded)
+}
+
+func TestStateRequest_Decode(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		encoded    []byte
+		request    *StateRequest
+		errWrapped error
+		errMessage string
+	}{
+		"protobuf encoded": {
+			// block hash 0x01..., start keys 0x0203 and 0x04, no proof
+			encoded: append(append([]byte{0x0a, 0x20, 0x01}, make([]byte, 31)...),
+				0x12, 0x02, 0x02, 0x03, 0x12, 0x01, 0x04, 0x18, 0x01),
+			request: &StateRequest{
+				Block:   common.Hash{1},
+				Start:   [][]byte{{2, 3}, {4}},
+				NoProof: true,
+			},
+		},
+		"invalid block hash": {
+			encoded:    []byte{0x0a, 0x01, 0x01},
+			request:    &StateRequest{},
+			errWrapped: errStateRequestBlockInvalid,
+			errMessage: "state request block hash is invalid: expected 32 bytes, got 1 bytes",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			request := new(StateRequest)
+			err := request.Decode(testCase.encoded)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.request, request)
+		})
+	}
+}
+
+func TestStateResponse_Decode(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		encoded    []byte
+		response   *StateResponse
+		errWrapped error
+		errMessage string
+	}{
+		"empty": {
+			response: &StateResponse{},
+		},
+		"protobuf encoded": {
+			// one key value state entry with the entry 0x01 -> 0x02 and complete set,
+			// and the SCALE encoded proof with the single node 0x06
+			encoded: []byte{0x0a, 0x0a, 0x12, 0x06, 0x0a, 0x01, 0x01, 0x12, 0x01, 0x02, 0x18, 0x01,
+				0x12, 0x03, 0x04, 0x04, 0x06},
+			response: &StateResponse{
+				Entries:  []StateEntry{{Key: []byte{1}, Value: []byte{2}}},
+				Complete: true,
+				Proof:    [][]byte{{6}},
+			},
+		},
+		"multiple trie levels": {
+			encoded:    []byte{0x0a, 0x00, 0x0a, 0x00},
+			response:   &StateResponse{},
+			errWrapped: errStateResponseLevels,
+			errMessage: "state response has more than one trie level: 2",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			response := new(StateResponse)
+			err := response.Decode(testCase.encoded)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.response, response)
+		})
+	}
+}
+
+func Test_Service_createStateResponse(t *testing.T) {
+	t.Parallel()
+
+	keyToChild := []byte("child")
+	childStorageKey := append(append([]byte{}, trie.ChildStorageKeyPrefix...), keyToChild...)
+
+	child := trie.NewEmptyTrie()
+	child.Put([]byte{1}, []byte{1})
+	child.Put([]byte{2}, []byte{2})
+	childRoot, err := child.Hash()
+	require.NoError(t, err)
+
+	stateTrie := trie.NewEmptyTrie()
+	stateTrie.Put([]byte{1}, []byte{10})
+	stateTrie.Put([]byte{2}, []byte{20})
+	err = stateTrie.PutChild(keyToChild, child)
+	require.NoError(t, err)
+	stateRoot, err := stateTrie.Hash()
+	require.NoError(t, err)
+
+	blockHash := common.Hash{1}
+
+	testCases := map[string]struct {
+		req          *StateRequest
+		storageState func(ctrl *gomock.Controller) StorageState
+		resp         *StateResponse
+		errWrapped   error
+		errMessage   string
+	}{
+		"state trie from first key": {
+			req: &StateRequest{Block: blockHash},
+			storageState: func(ctrl *gomock.Controller) StorageState {
+				storageState := NewMockStorageState(ctrl)
+				storageState.EXPECT().TrieState(&stateRoot).
+					Return(rtstorage.NewTrieState(stateTrie), nil)
+				storageState.EXPECT().GenerateTrieProof(stateRoot,
+					[][]byte{{1}, {2}, childStorageKey}).
+					Return([][]byte{{4}}, nil)
+				return storageState
+			},
+			resp: &StateResponse{
+				Entries: []StateEntry{
+					{Key: []byte{1}, Value: []byte{10}},
+					{Key: []byte{2}, Value: []byte{20}},
+					{Key: childStorageKey, Value: childRoot.ToBytes()},
+				},
+				Complete: true,
+				Proof:    [][]byte{{4}},
+			},
+		},
+		"state trie after key without proof": {
+			req: &StateRequest{
+				Block:   blockHash,
+				Start:   [][]byte{{1}},
+				NoProof: true,
+			},
+			storageState: func(ctrl *gomock.Controller) StorageState {
+				storageState := NewMockStorageState(ctrl)
+				storageState.EXPECT().TrieState(&stateRoot).
+					Return(rtstorage.NewTrieState(stateTrie), nil)
+				return storageState
+			},
+			resp: &StateResponse{
+				Entries: []StateEntry{
+					{Key: []byte{2}, Value: []byte{20}},
+					{Key: childStorageKey, Value: childRoot.ToBytes()},
+				},
+				Complete: true,
+			},
+		},
+		"child trie after key": {
+			req: &StateRequest{
+				Block: blockHash,
+				Start: [][]byte{childStorageKey, {1}},
+			},
+			storageState: func(ctrl *gomock.Controller) StorageState {
+				storageState := NewMockStorageState(ctrl)
+				storageState.EXPECT().TrieState(&stateRoot).
+					Return(rtstorage.NewTrieState(stateTrie), nil)
+				storageState.EXPECT().GenerateTrieProof(childRoot, [][]byte{{2}}).
+					Return([][]byte{{5}}, nil)
+				return storageState
+			},
+			resp: &StateResponse{
+				Entries: []StateEntry{
+					{Key: []byte{2}, Value: []byte{2}},
+				},
+				Complete: true,
+				Proof:    [][]byte{{5}},
+			},
+		},
+		"invalid child storage key": {
+			req: &StateRequest{
+				Block: blockHash,
+				Start: [][]byte{keyToChild, nil},
+			},
+			storageState: func(ctrl *gomock.Controller) StorageState {
+				storageState := NewMockStorageState(ctrl)
+				storageState.EXPECT().TrieState(&stateRoot).
+					Return(rtstorage.NewTrieState(stateTrie), nil)
+				return storageState
+			},
+			errWrapped: errInvalidChildStorageKey,
+			errMessage: "invalid child storage key: 0x6368696c64",
+		},
+		"invalid start": {
+			req: &StateRequest{
+				Block: blockHash,
+				Start: [][]byte{{1}, {2}, {3}},
+			},
+			storageState: func(ctrl *gomock.Controller) StorageState {
+				storageState := NewMockStorageState(ctrl)
+				storageState.EXPECT().TrieState(&stateRoot).
+					Return(rtstorage.NewTrieState(stateTrie), nil)
+				return storageState
+			},
+			errWrapped: errInvalidStateRequestStart,
+			errMessage: "invalid state request start: 3 keys",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockState := NewMockBlockState(ctrl)
+			blockState.EXPECT().GetHeader(blockHash).
+				Return(&types.Header{StateRoot: stateRoot}, nil)
+
+			s := &Service{
+				blockState:   blockState,
+				storageState: testCase.storageState(ctrl),
+			}
+
+			resp, err := s.createStateResponse(testCase.req)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.resp, resp)
+		})
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+const (
+	// MaxWarpSyncProofSize is the maximum size of an encoded warp sync proof
+	MaxWarpSyncProofSize = 8 * 1024 * 1024
+
+	maxWarpSyncRequestSize = 1024
+)
+
+var (
+	warpSyncRequestTimeout = time.Second * 30
+)
+
+var _ Message = &WarpSyncRequest{}
+
+// WarpSyncRequest is sent to request the warp sync proof of the authority
+// set changes finalised after the block with the given hash.
+type WarpSyncRequest struct {
+	// Begin is the hash of the finalised block to start the proof from
+	Begin common.Hash
+}
+
+// Encode returns the SCALE encoded WarpSyncRequest
+func (wr *WarpSyncRequest) Encode() ([]byte, error) {
+	return scale.Marshal(*wr)
+}
+
+// Decode decodes the SCALE encoded input to a WarpSyncRequest
+func (wr *WarpSyncRequest) Decode(in []byte) error {
+	return scale.Unmarshal(in, wr)
+}
+
+// String formats a WarpSyncRequest as a string
+func (wr *WarpSyncRequest) String() string {
+	return fmt.Sprintf("WarpSyncRequest Begin=%s", wr.Begin)
+}
+
+// WarpSyncFragment is a block header with the GRANDPA justification finalising it.
+// The justification is encoded inline, without length prefix, as done by Substrate.
+type WarpSyncFragment struct {
+	Header        types.Header
+	Justification types.GrandpaJustification
+}
+
+var _ Message = &WarpSyncProof{}
+
+// WarpSyncProof is sent in response to a WarpSyncRequest. It contains the headers
+// enacting the authority set changes following the requested block, each finalised
+// by the authority set preceding it. The last fragment is the latest finalised block
+// of the responding node if IsFinished is true.
+type WarpSyncProof struct {
+	Fragments  []WarpSyncFragment
+	IsFinished bool
+}
+
+// Encode returns the SCALE encoded WarpSyncProof
+func (wp *WarpSyncProof) Encode() ([]byte, error) {
+	return scale.Marshal(*wp)
+}
+
+// Decode decodes the SCALE encoded input to a WarpSyncProof
+func (wp *WarpSyncProof) Decode(in []byte) error {
+	decoder := scale.NewDecoder(bytes.NewReader(in))
+
+	var fragmentsCount uint
+	err := decoder.Decode(&fragmentsCount)
+	if err != nil {
+		return fmt.Errorf("decoding fragments count: %w", err)
+	}
+
+	wp.Fragments = make([]WarpSyncFragment, fragmentsCount)
+	for i := range wp.Fragments {
+		header := types.NewEmptyHeader()
+		err = decoder.Decode(header)
+		if err != nil {
+			return fmt.Errorf("decoding header of fragment %d: %w", i, err)
+		}
+		wp.Fragments[i].Header = *header
+
+		err = decoder.Decode(&wp.Fragments[i].Justification)
+		if err != nil {
+			return fmt.Errorf("decoding justification of fragment %d: %w", i, err)
+		}
+	}
+
+	err = decoder.Decode(&wp.IsFinished)
+	if err != nil {
+		return fmt.Errorf("decoding is finished: %w", err)
+	}
+
+	return nil
+}
+
+// String formats a WarpSyncProof as a string
+func (wp *WarpSyncProof) String() string {
+	if wp == nil {
+		return "WarpSyncProof=nil"
+	}
+
+	return fmt.Sprintf("WarpSyncProof Fragments=%d IsFinished=%t", len(wp.Fragments), wp.IsFinished)
+}
+
+// DoWarpSyncRequest sends a warp sync request to the given peer.
+// If a warp sync proof is received within a certain time period,
+// it is returned, otherwise an error is returned.
+func (s *Service) DoWarpSyncRequest(to peer.ID, req *WarpSyncRequest) (*WarpSyncProof, error) {
+	fullWarpSyncID := s.host.protocolID + warpSyncID
+
+	s.host.p2pHost.ConnManager().Protect(to, "")
+	defer s.host.p2pHost.ConnManager().Unprotect(to, "")
+
+	ctx, cancel := context.WithTimeout(s.ctx, warpSyncRequestTimeout)
+	defer cancel()
+
+	stream, err := s.host.p2pHost.NewStream(ctx, to, fullWarpSyncID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		err := stream.Close()
+		if err != nil {
+			logger.Warnf("failed to close stream: %s", err)
+		}
+	}()
+
+	if err = s.host.writeToStream(stream, req); err != nil {
+		return nil, err
+	}
+
+	return s.receiveWarpSyncProof(stream)
+}
+
+func (s *Service) receiveWarpSyncProof(stream libp2pnetwork.Stream) (*WarpSyncProof, error) {
+	// warp sync proofs are only requested when the node starts,
+	// so the buffer is not pooled unlike block responses.
+	buf := make([]byte, MaxWarpSyncProofSize)
+
+	n, err := readStream(stream, &buf, MaxWarpSyncProofSize)
+	if err != nil {
+		return nil, fmt.Errorf("read stream error: %w", err)
+	}
+
+	if n == 0 {
+		return nil, fmt.Errorf("received empty message")
+	}
+
+	proof := new(WarpSyncProof)
+	err = proof.Decode(buf[:n])
+	if err != nil {
+		s.host.cm.peerSetHandler.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.BadMessageValue,
+			Reason: peerset.BadMessageReason,
+		}, stream.Conn().RemotePeer())
+		return nil, fmt.Errorf("failed to decode warp sync proof: %w", err)
+	}
+
+	return proof, nil
+}
+
+// handleWarpSyncStream handles streams with the <protocol-id>/sync/warp protocol ID
+func (s *Service) handleWarpSyncStream(stream libp2pnetwork.Stream) {
+	if stream == nil {
+		return
+	}
+
+	s.readStream(stream, decodeWarpSyncMessage, s.handleWarpSyncMessage, maxWarpSyncRequestSize)
+}
+
+func decodeWarpSyncMessage(in []byte, _ peer.ID, _ bool) (Message, error) {
+	msg := new(WarpSyncRequest)
+	err := msg.Decode(in)
+	return msg, err
+}
+
+// handleWarpSyncMessage handles inbound warp sync streams, which only carry WarpSyncRequests
+func (s *Service) handleWarpSyncMessage(stream libp2pnetwork.Stream, msg Message) error {
+	if msg == nil {
+		return nil
+	}
+
+	defer func() {
+		err := stream.Close()
+		if err != nil {
+			logger.Warnf("failed to close stream: %s", err)
+		}
+	}()
+
+	req, ok := msg.(*WarpSyncRequest)
+	if !ok {
+		return nil
+	}
+
+	proof, err := s.syncer.CreateWarpSyncProof(req.Begin)
+	if err != nil {
+		logger.Debugf("cannot create warp sync proof for request: %s", err)
+		return nil
+	}
+
+	err = s.host.writeToStream(stream, proof)
+	if err != nil {
+		logger.Debugf("failed to send WarpSyncProof message to peer %s: %s", stream.Conn().RemotePeer(), err)
+		return err
+	}
+
+	return nil
+}
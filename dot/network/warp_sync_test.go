@@ -0,0 +1,178 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarpSyncRequest_EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	req := &WarpSyncRequest{
+		Begin: common.Hash{1, 2},
+	}
+
+	encoded, err := req.Encode()
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{1, 2}.ToBytes(), encoded)
+
+	decoded := new(WarpSyncRequest)
+	err = decoded.Decode(encoded)
+	require.NoError(t, err)
+	require.Equal(t, req, decoded)
+}
+
+func TestWarpSyncProof_EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	digest := types.NewDigest()
+	err := digest.Add(types.PreRuntimeDigest{
+		ConsensusEngineID: types.BabeEngineID,
+		Data:              []byte{1, 2, 3},
+	})
+	require.NoError(t, err)
+
+	proof := &WarpSyncProof{
+		Fragments: []WarpSyncFragment{
+			{
+				Header: types.Header{
+					ParentHash: common.Hash{1},
+					Number:     5,
+					Digest:     digest,
+				},
+				Justification: types.GrandpaJustification{
+					Round: 4,
+					Commit: types.GrandpaCommit{
+						Hash:   common.Hash{5},
+						Number: 5,
+						Precommits: []types.GrandpaSignedVote{{
+							Vote:        types.GrandpaVote{Hash: common.Hash{6}, Number: 6},
+							Signature:   [64]byte{7},
+							AuthorityID: [32]byte{8},
+						}},
+					},
+					VotesAncestries: []types.Header{{
+						ParentHash: common.Hash{5},
+						Number:     6,
+						Digest:     types.NewDigest(),
+					}},
+				},
+			},
+			{
+				Header: types.Header{
+					ParentHash: common.Hash{2},
+					Number:     10,
+					Digest:     types.NewDigest(),
+				},
+				Justification: types.GrandpaJustification{
+					Round: 9,
+					Commit: types.GrandpaCommit{
+						Hash:   common.Hash{10},
+						Number: 10,
+					},
+				},
+			},
+		},
+		IsFinished: true,
+	}
+
+	encoded, err := proof.Encode()
+	require.NoError(t, err)
+
+	decoded := new(WarpSyncProof)
+	err = decoded.Decode(encoded)
+	require.NoError(t, err)
+	require.Equal(t, proof, decoded)
+
+	err = decoded.Decode(encoded[:len(encoded)-1])
+	require.EqualError(t, err, "decoding is finished: EOF")
+}
+
+func TestWarpSyncProof_Decode_substrate(t *testing.T) {
+	t.Parallel()
+
+	// warp sync proof with one fragment, following the encoding of the
+	// Substrate `WarpSyncProof` type, where the fragment justification
+	// is encoded inline after the fragment header.
+	encoded := []byte{0x04} // fragments count
+	// fragment header
+	encoded = append(encoded, bytes.Repeat([]byte{0x11}, 32)...) // parent hash
+	encoded = append(encoded, 0x14)                              // number 5
+	encoded = append(encoded, bytes.Repeat([]byte{0x22}, 32)...) // state root
+	encoded = append(encoded, bytes.Repeat([]byte{0x33}, 32)...) // extrinsics root
+	encoded = append(encoded, 0x00)                              // digest logs count
+	// fragment justification
+	encoded = append(encoded, 0x07, 0, 0, 0, 0, 0, 0, 0)         // round
+	encoded = append(encoded, bytes.Repeat([]byte{0x44}, 32)...) // commit target hash
+	encoded = append(encoded, 0x05, 0, 0, 0)                     // commit target number
+	encoded = append(encoded, 0x04)                              // precommits count
+	encoded = append(encoded, bytes.Repeat([]byte{0x55}, 32)...) // precommit target hash
+	encoded = append(encoded, 0x06, 0, 0, 0)                     // precommit target number
+	encoded = append(encoded, bytes.Repeat([]byte{0x66}, 64)...) // precommit signature
+	encoded = append(encoded, bytes.Repeat([]byte{0x77}, 32)...) // precommit authority id
+	encoded = append(encoded, 0x04)                              // votes ancestries count
+	encoded = append(encoded, bytes.Repeat([]byte{0x44}, 32)...) // ancestry parent hash
+	encoded = append(encoded, 0x18)                              // ancestry number 6
+	encoded = append(encoded, bytes.Repeat([]byte{0x88}, 32)...) // ancestry state root
+	encoded = append(encoded, bytes.Repeat([]byte{0x99}, 32)...) // ancestry extrinsics root
+	encoded = append(encoded, 0x00)                              // ancestry digest logs count
+	encoded = append(encoded, 0x01)                              // is finished
+
+	hashOf := func(b byte) (hash common.Hash) {
+		copy(hash[:], bytes.Repeat([]byte{b}, common.HashLength))
+		return hash
+	}
+
+	var signature [64]byte
+	copy(signature[:], bytes.Repeat([]byte{0x66}, len(signature)))
+
+	expected := &WarpSyncProof{
+		Fragments: []WarpSyncFragment{{
+			Header: types.Header{
+				ParentHash:     hashOf(0x11),
+				Number:         5,
+				StateRoot:      hashOf(0x22),
+				ExtrinsicsRoot: hashOf(0x33),
+				Digest:         types.NewDigest(),
+			},
+			Justification: types.GrandpaJustification{
+				Round: 7,
+				Commit: types.GrandpaCommit{
+					Hash:   hashOf(0x44),
+					Number: 5,
+					Precommits: []types.GrandpaSignedVote{{
+						Vote:        types.GrandpaVote{Hash: hashOf(0x55), Number: 6},
+						Signature:   signature,
+						AuthorityID: ed25519.PublicKeyBytes(hashOf(0x77)),
+					}},
+				},
+				VotesAncestries: []types.Header{{
+					ParentHash:     hashOf(0x44),
+					Number:         6,
+					StateRoot:      hashOf(0x88),
+					ExtrinsicsRoot: hashOf(0x99),
+					Digest:         types.NewDigest(),
+				}},
+			},
+		}},
+		IsFinished: true,
+	}
+
+	proof := new(WarpSyncProof)
+	err := proof.Decode(encoded)
+	require.NoError(t, err)
+	require.Equal(t, expected, proof)
+
+	reencoded, err := proof.Encode()
+	require.NoError(t, err)
+	require.Equal(t, encoded, reencoded)
+}
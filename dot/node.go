@@ -326,6 +326,7 @@ func newNode(cfg *Config,
 	}
 
 	ver := builder.createBlockVerifier(stateSrvc)
+	nodeSrvcs = append(nodeSrvcs, ver)
 
 	dh, err := builder.createDigestHandler(cfg.Log.DigestLvl, stateSrvc)
 	if err != nil {
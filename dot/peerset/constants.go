@@ -63,6 +63,41 @@
 	// BadJustificationReason is used when peer send invalid justification.
 	BadJustificationReason = "Bad justification"
 
+	// BadSignatureValue is used when peer sends a GRANDPA message with an invalid signature.
+	BadSignatureValue Reputation = -100
+	// BadSignatureReason is used when peer sends a GRANDPA message with an invalid signature.
+	BadSignatureReason = "Bad signature"
+
+	// UnknownVoterValue is used when peer sends a GRANDPA vote from a voter not in the voter set.
+	UnknownVoterValue Reputation = -150
+	// UnknownVoterReason is used when peer sends a GRANDPA vote from a voter not in the voter set.
+	UnknownVoterReason = "Unknown voter"
+
+	// PastRejectionValue is used when peer sends a GRANDPA message which is already expired in its own neighbour view.
+	PastRejectionValue Reputation = -50
+	// PastRejectionReason is used when peer sends a GRANDPA message which is already expired in its own neighbour view.
+	PastRejectionReason = "Past message"
+
+	// FutureMessageValue is used when peer sends a GRANDPA message too far ahead of our round or set.
+	FutureMessageValue Reputation = -(1 << 2)
+	// FutureMessageReason is used when peer sends a GRANDPA message too far ahead of our round or set.
+	FutureMessageReason = "Future message"
+
+	// MalformedCommitValue is used when peer sends a GRANDPA commit with mismatched precommits and signatures.
+	MalformedCommitValue Reputation = -1000
+	// MalformedCommitReason is used when peer sends a GRANDPA commit with mismatched precommits and signatures.
+	MalformedCommitReason = "Malformed commit"
+
+	// InvalidCommitValue is used when peer sends a GRANDPA commit which cannot be verified.
+	InvalidCommitValue Reputation = -5000
+	// InvalidCommitReason is used when peer sends a GRANDPA commit which cannot be verified.
+	InvalidCommitReason = "Invalid commit"
+
+	// InvalidCatchUpValue is used when peer sends a GRANDPA catch up response which cannot be verified.
+	InvalidCatchUpValue Reputation = -5000
+	// InvalidCatchUpReason is used when peer sends a GRANDPA catch up response which cannot be verified.
+	InvalidCatchUpReason = "Invalid catch up"
+
 	// GenesisMismatch is used when peer has a different genesis
 	GenesisMismatch Reputation = math.MinInt32
 	// GenesisMismatchReason used when a peer has a different genesis
@@ -10,6 +10,8 @@
 
 	ErrConfigSetIsEmpty = errors.New("config set is empty")
 
+	ErrSetDoesNotExist = errors.New("set doesn't exist")
+
 	ErrPeerDoesNotExist = errors.New("peer doesn't exist")
 
 	ErrPeerDisconnected = errors.New("node is already disconnected")
@@ -57,6 +57,15 @@ func (h *Handler) SetReservedPeer(setID int, peers ...peer.ID) {
 	}
 }
 
+// SetReservedOnly sets whether the given set only accepts its reserved peers.
+func (h *Handler) SetReservedOnly(setID int, reservedOnly bool) {
+	h.actionQueue <- action{
+		actionCall:   setReservedOnly,
+		setID:        setID,
+		reservedOnly: reservedOnly,
+	}
+}
+
 // AddPeer adds peer to peerSet.
 func (h *Handler) AddPeer(setID int, peers ...peer.ID) {
 	h.actionQueue <- action{
@@ -120,6 +129,14 @@ func (h *Handler) PeerReputation(peerID peer.ID) (Reputation, error) {
 	return n.reputation, nil
 }
 
+// IsConnected returns true if the peer is connected in the given set.
+func (h *Handler) IsConnected(setID int, peerID peer.ID) bool {
+	if setID < 0 || setID >= h.peerSet.peerState.getSetLength() {
+		return false
+	}
+	return h.peerSet.peerState.peerStatus(setID, peerID) == connectedPeer
+}
+
 // Start starts peerSet processing
 func (h *Handler) Start(ctx context.Context) {
 	ctx, cancel := context.WithCancel(ctx)
@@ -5,6 +5,7 @@
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"strings"
@@ -87,6 +88,7 @@ func (a ActionReceiver) String() string {
 type action struct {
 	actionCall    ActionReceiver
 	setID         int
+	reservedOnly  bool
 	reputation    ReputationChange
 	peers         peer.IDSlice
 	resultPeersCh chan peer.IDSlice
@@ -124,6 +126,11 @@ type Message struct {
 	PeerID peer.ID
 }
 
+// SetID returns the id of the set the message is about.
+func (m Message) SetID() uint64 {
+	return m.setID
+}
+
 // Reputation represents reputation value of the node
 type Reputation int32
 
@@ -179,11 +186,11 @@ type PeerSet struct {
 	peerState *PeersState
 
 	reservedLock sync.RWMutex
-	reservedNode map[peer.ID]struct{}
-	// TODO: this will be useful for reserved only mode
-	// this is for future purpose if reserved-only flag is enabled (#1888).
-	isReservedOnly bool
-	resultMsgCh    chan Message
+	// reservedNodes contains the reserved nodes of each set.
+	reservedNodes []map[peer.ID]struct{}
+	// reservedOnly is true for the sets which only accept their reserved nodes.
+	reservedOnly []bool
+	resultMsgCh  chan Message
 	// time when the PeerSet was created.
 	created time.Time
 	// last time when we updated the reputations of connected nodes.
@@ -202,8 +209,7 @@ type config struct {
 	// maximum number of slot occupying nodes for outgoing connections.
 	maxOutPeers uint32
 
-	// TODO Use in future for reserved only peers
-	// if true, we only accept reservedNodes (#1888).
+	// if true, we only accept reservedNodes.
 	reservedOnly bool
 
 	// time duration for a peerSet to periodically call allocSlots.
@@ -225,12 +231,26 @@ func NewConfigSet(maxInPeers, maxOutPeers uint32, reservedOnly bool, allocTime t
 	}
 
 	return &ConfigSet{
-		// Why are we using an array of config in the set, when we are
-		// using just one config
 		Set: []*config{set},
 	}
 }
 
+// AddSet adds a set with its own slots to the config set. The id of the
+// added set is its index in the config set, the first set having the id 0.
+func (cs *ConfigSet) AddSet(maxInPeers, maxOutPeers uint32, reservedOnly bool) {
+	var allocTime time.Duration
+	if len(cs.Set) > 0 {
+		allocTime = cs.Set[0].periodicAllocTime
+	}
+
+	cs.Set = append(cs.Set, &config{
+		maxInPeers:        maxInPeers,
+		maxOutPeers:       maxOutPeers,
+		reservedOnly:      reservedOnly,
+		periodicAllocTime: allocTime,
+	})
+}
+
 func newPeerSet(cfg *ConfigSet) (*PeerSet, error) {
 	if len(cfg.Set) == 0 {
 		return nil, ErrConfigSetIsEmpty
@@ -241,17 +261,22 @@ func newPeerSet(cfg *ConfigSet) (*PeerSet, error) {
 		return nil, err
 	}
 
-	// TODO: currently we only have one set, change this once we have more (#1886).
-	cfgSet := cfg.Set[0]
+	reservedNodes := make([]map[peer.ID]struct{}, len(cfg.Set))
+	reservedOnly := make([]bool, len(cfg.Set))
+	for i, set := range cfg.Set {
+		reservedNodes[i] = make(map[peer.ID]struct{})
+		reservedOnly[i] = set.reservedOnly
+	}
+
 	now := time.Now()
 
 	ps := &PeerSet{
 		peerState:              peerState,
-		reservedNode:           make(map[peer.ID]struct{}),
-		isReservedOnly:         cfgSet.reservedOnly,
+		reservedNodes:          reservedNodes,
+		reservedOnly:           reservedOnly,
 		created:                now,
 		latestTimeUpdate:       now,
-		nextPeriodicAllocSlots: cfgSet.periodicAllocTime,
+		nextPeriodicAllocSlots: cfg.Set[0].periodicAllocTime,
 	}
 
 	return ps, nil
@@ -381,7 +406,7 @@ func (ps *PeerSet) allocSlots(setIdx int) error {
 	}
 
 	peerState := ps.peerState
-	for reservePeer := range ps.reservedNode {
+	for reservePeer := range ps.reservedNodes[setIdx] {
 		status := peerState.peerStatus(setIdx, reservePeer)
 		switch status {
 		case connectedPeer:
@@ -413,7 +438,7 @@ func (ps *PeerSet) allocSlots(setIdx int) error {
 	}
 
 	// nothing more to do if we're in reserved mode.
-	if ps.isReservedOnly {
+	if ps.reservedOnly[setIdx] {
 		return nil
 	}
 
@@ -449,15 +474,16 @@ func (ps *PeerSet) addReservedPeers(setID int, peers ...peer.ID) error {
 	ps.reservedLock.Lock()
 	defer ps.reservedLock.Unlock()
 
+	reservedNodes := ps.reservedNodes[setID]
 	for _, peerID := range peers {
-		if _, ok := ps.reservedNode[peerID]; ok {
+		if _, ok := reservedNodes[peerID]; ok {
 			logger.Debugf("peer %s already exists in peerSet", peerID)
 			return nil
 		}
 
 		ps.peerState.discover(setID, peerID)
 
-		ps.reservedNode[peerID] = struct{}{}
+		reservedNodes[peerID] = struct{}{}
 		if err := ps.peerState.addNoSlotNode(setID, peerID); err != nil {
 			return fmt.Errorf("could not add to list of no-slot nodes: %w", err)
 		}
@@ -472,24 +498,24 @@ func (ps *PeerSet) removeReservedPeers(setID int, peers ...peer.ID) error {
 	ps.reservedLock.Lock()
 	defer ps.reservedLock.Unlock()
 
+	reservedNodes := ps.reservedNodes[setID]
 	for _, peerID := range peers {
-		if _, ok := ps.reservedNode[peerID]; !ok {
+		if _, ok := reservedNodes[peerID]; !ok {
 			logger.Debugf("peer %s doesn't exist in the peerSet", peerID)
 			return nil
 		}
 
-		delete(ps.reservedNode, peerID)
+		delete(reservedNodes, peerID)
 		if err := ps.peerState.removeNoSlotNode(setID, peerID); err != nil {
 			return fmt.Errorf("could not remove from the list of no-slot nodes: %w", err)
 		}
 
 		// nothing more to do if not in reservedOnly mode.
-		if !ps.isReservedOnly {
-			return nil
+		if !ps.reservedOnly[setID] {
+			continue
 		}
 
-		// reservedOnly mode is not yet implemented for future this code will help.
-		// If however the peerSet is in reserved-only mode, then non-reserved node peers needs to be
+		// If however the set is in reserved-only mode, then non-reserved node peers needs to be
 		// disconnected.
 		if ps.peerState.peerStatus(setID, peerID) == connectedPeer {
 			err := ps.peerState.disconnect(setID, peerID)
@@ -514,15 +540,16 @@ func (ps *PeerSet) setReservedPeer(setID int, peers ...peer.ID) error {
 
 	peerIDMap := make(map[peer.ID]struct{}, len(peers))
 
+	reservedNodes := ps.reservedNodes[setID]
 	for _, pid := range peers {
 		peerIDMap[pid] = struct{}{}
-		if _, ok := ps.reservedNode[pid]; ok {
+		if _, ok := reservedNodes[pid]; ok {
 			continue
 		}
 		toInsert = append(toInsert, pid)
 	}
 
-	for pid := range ps.reservedNode {
+	for pid := range reservedNodes {
 		if _, ok := peerIDMap[pid]; ok {
 			continue
 		}
@@ -558,7 +585,7 @@ func (ps *PeerSet) addPeer(setID int, peers peer.IDSlice) error {
 
 func (ps *PeerSet) removePeer(setID int, peers ...peer.ID) error {
 	for _, pid := range peers {
-		if _, ok := ps.reservedNode[pid]; ok {
+		if _, ok := ps.reservedNodes[setID][pid]; ok {
 			logger.Debugf("peer %s is reserved and cannot be removed", pid)
 			return nil
 		}
@@ -598,8 +625,8 @@ func (ps *PeerSet) incoming(setID int, peers ...peer.ID) error {
 	}
 
 	for _, pid := range peers {
-		if ps.isReservedOnly {
-			_, has := ps.reservedNode[pid]
+		if ps.reservedOnly[setID] {
+			_, has := ps.reservedNodes[setID][pid]
 			if !has {
 				ps.resultMsgCh <- Message{
 					Status: Reject,
@@ -640,8 +667,12 @@ func (ps *PeerSet) incoming(setID int, peers ...peer.ID) error {
 			message.Status = Reject
 		} else {
 			err := state.tryAcceptIncoming(setID, pid)
-			if err != nil {
-				logger.Errorf("cannot accept incomming peer %s: %s", pid, err)
+			if errors.Is(err, ErrIncomingSlotsUnavailable) {
+				// rejecting peers once the slots are full is expected
+				logger.Debugf("rejecting incoming peer %s in set %d: %s", pid, setID, err)
+				message.Status = Reject
+			} else if err != nil {
+				logger.Errorf("cannot accept incoming peer %s in set %d: %s", pid, setID, err)
 				message.Status = Reject
 			} else {
 				logger.Debugf("incoming connection accepted from peer %s", pid)
@@ -655,6 +686,35 @@ func (ps *PeerSet) incoming(setID int, peers ...peer.ID) error {
 	return nil
 }
 
+// setReservedOnly sets whether the given set only accepts its reserved nodes. When switching
+// to reserved-only mode, the connected nodes of the set which are not reserved are dropped,
+// and when switching out of it, the free slots of the set are allocated.
+func (ps *PeerSet) setReservedOnly(setID int, reservedOnly bool) error {
+	ps.reservedOnly[setID] = reservedOnly
+	if !reservedOnly {
+		return ps.allocSlots(setID)
+	}
+
+	for _, pid := range ps.peerState.sortedPeers(setID) {
+		if _, ok := ps.reservedNodes[setID][pid]; ok {
+			continue
+		}
+
+		err := ps.peerState.disconnect(setID, pid)
+		if err != nil {
+			return fmt.Errorf("cannot disconnect: %w", err)
+		}
+
+		ps.resultMsgCh <- Message{
+			Status: Drop,
+			setID:  uint64(setID),
+			PeerID: pid,
+		}
+	}
+
+	return nil
+}
+
 // DropReason represents reason for disconnection of the peer
 type DropReason int
 
@@ -739,6 +799,14 @@ func (ps *PeerSet) listenActionAllocSlots(ctx context.Context) {
 				return
 			}
 
+			if act.actionCall != reportPeer && (act.setID < 0 || act.setID >= ps.peerState.getSetLength()) {
+				logger.Errorf("failed to do action %s on peerSet: %s", act, ErrSetDoesNotExist)
+				if act.actionCall == sortedPeers {
+					act.resultPeersCh <- nil
+				}
+				continue
+			}
+
 			var err error
 			switch act.actionCall {
 			case addReservedPeer:
@@ -749,8 +817,7 @@ func (ps *PeerSet) listenActionAllocSlots(ctx context.Context) {
 				// TODO: this is not used yet, might required to implement RPC Call for this.
 				err = ps.setReservedPeer(act.setID, act.peers...)
 			case setReservedOnly:
-				// TODO: not yet implemented (#1888)
-				err = fmt.Errorf("not implemented yet")
+				err = ps.setReservedOnly(act.setID, act.reservedOnly)
 			case reportPeer:
 				err = ps.reportPeer(act.reputation, act.peers...)
 			case addToPeerSet:
@@ -4,6 +4,7 @@
 package peerset
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -301,7 +302,7 @@ func TestSetReservePeer(t *testing.T) {
 		checkMessageStatus(t, <-ps.resultMsgCh, Connect)
 	}
 
-	require.Len(t, ps.reservedNode, 2)
+	require.Len(t, ps.reservedNodes[0], 2)
 
 	newRsrPeerSet := peer.IDSlice{reservedPeer, peer.ID("newRsrPeer")}
 	// add newRsrPeer but remove reservedPeer2
@@ -314,6 +315,82 @@ func TestSetReservePeer(t *testing.T) {
 	}
 }
 
+func TestSetReservedOnly(t *testing.T) {
+	const testSetID = 0
+
+	t.Parallel()
+	handler := newTestPeerSet(t, 2, 0, nil, []peer.ID{reservedPeer}, false)
+
+	ps := handler.peerSet
+	checkMessageStatus(t, <-ps.resultMsgCh, Connect)
+
+	handler.Incoming(testSetID, incomingPeer)
+	checkMessageStatus(t, <-ps.resultMsgCh, Accept)
+
+	// the non-reserved peer gets dropped, but not the reserved one.
+	handler.SetReservedOnly(testSetID, true)
+	msg := <-ps.resultMsgCh
+	require.Equal(t, Message{Status: Drop, setID: testSetID, PeerID: incomingPeer}, msg)
+
+	time.Sleep(100 * time.Millisecond)
+	require.Len(t, ps.resultMsgCh, 0)
+	require.Equal(t, notConnectedPeer, ps.peerState.peerStatus(testSetID, incomingPeer))
+	require.Equal(t, connectedPeer, ps.peerState.peerStatus(testSetID, reservedPeer))
+
+	handler.Incoming(testSetID, incoming2)
+	checkMessageStatus(t, <-ps.resultMsgCh, Reject)
+
+	handler.SetReservedOnly(testSetID, false)
+	handler.Incoming(testSetID, incoming2)
+	checkMessageStatus(t, <-ps.resultMsgCh, Accept)
+}
+
+func TestMultipleSets(t *testing.T) {
+	const (
+		defaultSetID  = 0
+		reservedSetID = 1
+	)
+
+	t.Parallel()
+
+	cfg := NewConfigSet(1, 0, false, allocTimeDuration)
+	cfg.AddSet(0, 0, false)
+
+	handler, err := NewPeerSetHandler(cfg)
+	require.NoError(t, err)
+	handler.Start(context.Background())
+	t.Cleanup(handler.Stop)
+
+	ps := handler.peerSet
+
+	handler.AddReservedPeer(reservedSetID, reservedPeer)
+	msg := <-ps.resultMsgCh
+	require.Equal(t, Message{Status: Connect, setID: reservedSetID, PeerID: reservedPeer}, msg)
+	require.Equal(t, uint64(reservedSetID), msg.SetID())
+
+	// the only slot of the default set is taken by the first incoming peer.
+	for _, pid := range []peer.ID{incomingPeer, reservedPeer} {
+		handler.Incoming(reservedSetID, pid)
+		handler.Incoming(defaultSetID, pid)
+	}
+
+	expectedMsgs := []Message{
+		{Status: Reject, setID: reservedSetID, PeerID: incomingPeer},
+		{Status: Accept, setID: defaultSetID, PeerID: incomingPeer},
+		{Status: Reject, setID: defaultSetID, PeerID: reservedPeer},
+	}
+	for _, expected := range expectedMsgs {
+		require.Equal(t, expected, <-ps.resultMsgCh)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	require.True(t, handler.IsConnected(defaultSetID, incomingPeer))
+	require.False(t, handler.IsConnected(reservedSetID, incomingPeer))
+	require.False(t, handler.IsConnected(defaultSetID, reservedPeer))
+	require.True(t, handler.IsConnected(reservedSetID, reservedPeer))
+	require.False(t, handler.IsConnected(2, reservedPeer))
+}
+
 func getNodePeer(ps *PeersState, pid peer.ID) (node, bool) {
 	ps.RLock()
 	defer ps.RUnlock()
@@ -355,7 +432,7 @@ func checkReservedNodePeerExists(t *testing.T, ps *PeerSet, pid peer.ID) {
 	ps.Lock()
 	defer ps.Unlock()
 
-	_, exists := ps.reservedNode[pid]
+	_, exists := ps.reservedNodes[0][pid]
 	require.True(t, exists)
 }
 
@@ -404,5 +481,5 @@ func checkPeerSetReservedNodeCount(t *testing.T, ps *PeerSet, expectedCount int)
 	ps.reservedLock.RLock()
 	defer ps.reservedLock.RUnlock()
 
-	require.Equal(t, expectedCount, len(ps.reservedNode))
+	require.Equal(t, expectedCount, len(ps.reservedNodes[0]))
 }
@@ -373,17 +373,25 @@ func (ps *PeersState) disconnect(idx int, peerID peer.ID) error {
 
 // discover takes input for set id and create a node and insert in the list.
 // the initial Reputation of the peer will be 0 and ingoing notMember state.
+// If the node is already known, it becomes a not connected member of the set.
 func (ps *PeersState) discover(set int, peerID peer.ID) {
 	ps.Lock()
 	defer ps.Unlock()
 
 	numSet := len(ps.sets)
 
-	_, has := ps.nodes[peerID]
+	n, has := ps.nodes[peerID]
 	if !has {
-		n := newNode(numSet)
+		n = newNode(numSet)
 		n.state[set] = notConnected
 		ps.nodes[peerID] = n
+		return
+	}
+
+	// the node may already be known from another set.
+	if n.state[set] == notMember {
+		n.state[set] = notConnected
+		n.lastConnected[set] = time.Now()
 	}
 }
 
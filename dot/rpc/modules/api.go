@@ -51,6 +51,7 @@ type BlockAPI interface {
 	RegisterRuntimeUpdatedChannel(ch chan<- runtime.Version) (uint32, error)
 	UnregisterRuntimeUpdatedChannel(id uint32) bool
 	GetRuntime(hash *common.Hash) (runtime.Instance, error)
+	GetIndexedTransaction(hash common.Hash) (data []byte, err error)
 }
 
 //go:generate mockery --name NetworkAPI --structname NetworkAPI --case underscore --keeptree
@@ -86,6 +87,7 @@ type TransactionStateAPI interface {
 	Pending() []*transaction.ValidTransaction
 	GetStatusNotifierChannel(ext types.Extrinsic) chan transaction.Status
 	FreeStatusNotifierChannel(ch chan transaction.Status)
+	RemoveExtrinsicAndBan(hash common.Hash) (removed bool)
 }
 
 //go:generate mockery --name CoreAPI --structname CoreAPI --case underscore --keeptree
@@ -98,7 +100,9 @@ type CoreAPI interface {
 	HandleSubmittedExtrinsic(types.Extrinsic) error
 	GetMetadata(bhash *common.Hash) ([]byte, error)
 	DecodeSessionKeys(enc []byte) ([]byte, error)
+	GenerateSessionKeys() ([]byte, error)
 	GetReadProofAt(block common.Hash, keys [][]byte) (common.Hash, [][]byte, error)
+	CallRuntime(bhash *common.Hash, method string, data []byte) ([]byte, error)
 }
 
 //go:generate mockery --name RPCAPI --structname RPCAPI --case underscore --keeptree
@@ -15,7 +15,10 @@
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
-var ErrProvidedKeyDoesNotMatch = errors.New("generated public key does not equal provided public key")
+var (
+	ErrProvidedKeyDoesNotMatch = errors.New("generated public key does not equal provided public key")
+	ErrEmptyExtrinsicOrHash    = errors.New("either an extrinsic or its hash must be provided")
+)
 
 // AuthorModule holds a pointer to the API
 type AuthorModule struct {
@@ -41,10 +44,10 @@ type Extrinsic struct {
 	Data string
 }
 
-// ExtrinsicOrHash is a type for Hash and Extrinsic array of bytes
+// ExtrinsicOrHash is either the hash of an extrinsic or a hex-encoded extrinsic
 type ExtrinsicOrHash struct {
-	Hash      common.Hash
-	Extrinsic []byte
+	Hash      *common.Hash `json:"hash"`
+	Extrinsic string       `json:"extrinsic"`
 }
 
 // ExtrinsicOrHashRequest is a array of ExtrinsicOrHash
@@ -59,8 +62,8 @@ type ExtrinsicOrHash struct {
 // RemoveExtrinsicsResponse is a array of hash used to Remove extrinsics
 type RemoveExtrinsicsResponse []common.Hash
 
-// KeyRotateResponse is a byte array used to rotate
-type KeyRotateResponse []byte
+// KeyRotateResponse is the hex encoded concatenation of the newly generated session public keys
+type KeyRotateResponse string
 
 // HasSessionKeyResponse is the response to the RPC call author_hasSessionKeys
 type HasSessionKeyResponse bool
@@ -193,12 +196,42 @@ func (am *AuthorModule) PendingExtrinsics(r *http.Request, req *EmptyRequest, re
 }
 
 // RemoveExtrinsic Remove given extrinsic from the pool and temporarily ban it to prevent reimporting
-func (am *AuthorModule) RemoveExtrinsic(r *http.Request, _ *ExtrinsicOrHashRequest, _ *RemoveExtrinsicsResponse) error {
+func (am *AuthorModule) RemoveExtrinsic(r *http.Request, req *ExtrinsicOrHashRequest,
+	res *RemoveExtrinsicsResponse) error {
+	removed := make([]common.Hash, 0, len(*req))
+	for _, extOrHash := range *req {
+		var hash common.Hash
+		switch {
+		case extOrHash.Hash != nil:
+			hash = *extOrHash.Hash
+		case extOrHash.Extrinsic != "":
+			extBytes, err := common.HexToBytes(extOrHash.Extrinsic)
+			if err != nil {
+				return err
+			}
+			hash = types.Extrinsic(extBytes).Hash()
+		default:
+			return ErrEmptyExtrinsicOrHash
+		}
+
+		if am.txStateAPI.RemoveExtrinsicAndBan(hash) {
+			removed = append(removed, hash)
+		}
+	}
+
+	*res = removed
 	return nil
 }
 
 // RotateKeys Generate new session keys and returns the corresponding public keys
 func (am *AuthorModule) RotateKeys(r *http.Request, req *EmptyRequest, res *KeyRotateResponse) error {
+	publicKeys, err := am.coreAPI.GenerateSessionKeys()
+	if err != nil {
+		return err
+	}
+
+	*res = KeyRotateResponse(common.BytesToHex(publicKeys))
+	am.logger.Info("rotated session keys")
 	return nil
 }
 
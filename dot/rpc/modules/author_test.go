@@ -291,6 +291,111 @@ type args struct {
 	}
 }
 
+func TestAuthorModule_RemoveExtrinsic(t *testing.T) {
+	ext := types.Extrinsic{0x01, 0x02}
+	extHash := ext.Hash()
+	unknownHash := common.Hash{1}
+
+	mockTransactionStateAPI := mocks.NewTransactionStateAPI(t)
+	mockTransactionStateAPI.On("RemoveExtrinsicAndBan", extHash).Return(true)
+	mockTransactionStateAPI.On("RemoveExtrinsicAndBan", unknownHash).Return(false)
+
+	tests := []struct {
+		name    string
+		req     ExtrinsicOrHashRequest
+		expErr  error
+		wantRes RemoveExtrinsicsResponse
+	}{
+		{
+			name: "remove by extrinsic",
+			req: ExtrinsicOrHashRequest{
+				{Extrinsic: common.BytesToHex(ext)},
+			},
+			wantRes: RemoveExtrinsicsResponse{extHash},
+		},
+		{
+			name: "remove by hash",
+			req: ExtrinsicOrHashRequest{
+				{Hash: &extHash},
+				{Hash: &unknownHash},
+			},
+			wantRes: RemoveExtrinsicsResponse{extHash},
+		},
+		{
+			name: "invalid extrinsic hex",
+			req: ExtrinsicOrHashRequest{
+				{Extrinsic: "0102"},
+			},
+			expErr: errors.New("could not byteify non 0x prefixed string: 0102"),
+		},
+		{
+			name: "empty extrinsic or hash",
+			req: ExtrinsicOrHashRequest{
+				{},
+			},
+			expErr: ErrEmptyExtrinsicOrHash,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			am := &AuthorModule{
+				logger:     log.New(log.SetWriter(io.Discard)),
+				txStateAPI: mockTransactionStateAPI,
+			}
+			var res RemoveExtrinsicsResponse
+			err := am.RemoveExtrinsic(nil, &tt.req, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
+func TestAuthorModule_RotateKeys(t *testing.T) {
+	mockCoreAPI := mocks.NewCoreAPI(t)
+	mockCoreAPI.On("GenerateSessionKeys").Return([]byte{1, 2, 3}, nil)
+
+	mockCoreAPIErr := mocks.NewCoreAPI(t)
+	mockCoreAPIErr.On("GenerateSessionKeys").Return(nil, errors.New("generate session keys error"))
+
+	tests := []struct {
+		name    string
+		coreAPI CoreAPI
+		expErr  error
+		wantRes KeyRotateResponse
+	}{
+		{
+			name:    "happy path",
+			coreAPI: mockCoreAPI,
+			wantRes: KeyRotateResponse("0x010203"),
+		},
+		{
+			name:    "generate session keys error",
+			coreAPI: mockCoreAPIErr,
+			expErr:  errors.New("generate session keys error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			am := &AuthorModule{
+				logger:  log.New(log.SetWriter(io.Discard)),
+				coreAPI: tt.coreAPI,
+			}
+			var res KeyRotateResponse
+			err := am.RotateKeys(nil, nil, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
 func TestAuthorModule_InsertKey(t *testing.T) {
 	kp1, err := sr25519.NewKeypairFromSeed(
 		common.MustHexToBytes("0x6246ddf254e0b4b4e7dffefc8adf69d212b98ac2b579c362b473fec8c40b4c0a"))
@@ -4,11 +4,13 @@
 package modules
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
 	"strconv"
 
+	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/scale"
@@ -58,6 +60,15 @@ type ChainBlockResponse struct {
 // ChainHashResponse interface to handle response
 type ChainHashResponse interface{}
 
+// ChainIndexedTransactionRequest is the hash of an indexed transaction
+type ChainIndexedTransactionRequest struct {
+	Hash common.Hash
+}
+
+// ChainIndexedTransactionResponse is the hex encoded data of an indexed transaction,
+// or nil if the indexed transaction is not stored
+type ChainIndexedTransactionResponse interface{}
+
 // ChainModule is an RPC module providing access to storage API points.
 type ChainModule struct {
 	blockAPI BlockAPI
@@ -139,6 +150,22 @@ func (cm *ChainModule) GetFinalizedHeadByRound(
 	return nil
 }
 
+// GetIndexedTransaction returns the data of the indexed transaction with the given hash,
+//  or nil if it is not stored.
+func (cm *ChainModule) GetIndexedTransaction(_ *http.Request, req *ChainIndexedTransactionRequest,
+	res *ChainIndexedTransactionResponse) error {
+	data, err := cm.blockAPI.GetIndexedTransaction(req.Hash)
+	if errors.Is(err, state.ErrIndexedTransactionNotFound) {
+		*res = nil
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	*res = common.BytesToHex(data)
+	return nil
+}
+
 //GetHeader Get header of a relay chain block. If no block hash is provided, the latest block header will be returned.
 func (cm *ChainModule) GetHeader(r *http.Request, req *ChainHashRequest, res *ChainBlockHeaderResponse) error {
 	hash := cm.hashLookup(req)
@@ -5,10 +5,12 @@
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
+	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 
@@ -221,6 +223,56 @@ type args struct {
 	}
 }
 
+func TestChainModule_GetIndexedTransaction(t *testing.T) {
+	testHash := common.Hash{1}
+	mockBlockAPI := mocks.NewBlockAPI(t)
+	mockBlockAPI.On("GetIndexedTransaction", testHash).Return([]byte{1, 2}, nil)
+
+	mockBlockAPINotFound := mocks.NewBlockAPI(t)
+	mockBlockAPINotFound.On("GetIndexedTransaction", testHash).
+		Return(nil, fmt.Errorf("%w: for hash %s", state.ErrIndexedTransactionNotFound, testHash))
+
+	mockBlockAPIErr := mocks.NewBlockAPI(t)
+	mockBlockAPIErr.On("GetIndexedTransaction", testHash).Return(nil, errors.New("GetIndexedTransaction Error"))
+
+	tests := []struct {
+		name     string
+		blockAPI BlockAPI
+		expErr   error
+		exp      ChainIndexedTransactionResponse
+	}{
+		{
+			name:     "happy path",
+			blockAPI: mockBlockAPI,
+			exp:      ChainIndexedTransactionResponse("0x0102"),
+		},
+		{
+			name:     "not found",
+			blockAPI: mockBlockAPINotFound,
+		},
+		{
+			name:     "error case",
+			blockAPI: mockBlockAPIErr,
+			expErr:   errors.New("GetIndexedTransaction Error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cm := &ChainModule{
+				blockAPI: tt.blockAPI,
+			}
+			res := ChainIndexedTransactionResponse(nil)
+			err := cm.GetIndexedTransaction(nil, &ChainIndexedTransactionRequest{Hash: testHash}, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
+
 func TestChainModule_GetFinalizedHead(t *testing.T) {
 	testHash := common.NewHash([]byte{0x01, 0x02})
 	mockBlockAPI := mocks.NewBlockAPI(t)
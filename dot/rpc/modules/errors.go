@@ -8,4 +8,5 @@
 var (
 	ErrSubscriptionTransport = errors.New("subscriptions are not available on this transport")
 	ErrStartBlockHashEmpty   = errors.New("the start block hash cannot be an empty value")
+	ErrEmptyRuntimeMethod    = errors.New("the runtime method cannot be empty")
 )
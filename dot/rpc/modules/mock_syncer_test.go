@@ -8,6 +8,7 @@
 	reflect "reflect"
 
 	network "github.com/ChainSafe/gossamer/dot/network"
+	common "github.com/ChainSafe/gossamer/lib/common"
 	gomock "github.com/golang/mock/gomock"
 	peer "github.com/libp2p/go-libp2p-core/peer"
 )
@@ -50,6 +51,21 @@ func (mr *MockSyncerMockRecorder) CreateBlockResponse(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlockResponse", reflect.TypeOf((*MockSyncer)(nil).CreateBlockResponse), arg0)
 }
 
+// CreateWarpSyncProof mocks base method.
+func (m *MockSyncer) CreateWarpSyncProof(arg0 common.Hash) (*network.WarpSyncProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWarpSyncProof", arg0)
+	ret0, _ := ret[0].(*network.WarpSyncProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWarpSyncProof indicates an expected call of CreateWarpSyncProof.
+func (mr *MockSyncerMockRecorder) CreateWarpSyncProof(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWarpSyncProof", reflect.TypeOf((*MockSyncer)(nil).CreateWarpSyncProof), arg0)
+}
+
 // HandleBlockAnnounce mocks base method.
 func (m *MockSyncer) HandleBlockAnnounce(arg0 peer.ID, arg1 *network.BlockAnnounceMessage) error {
 	m.ctrl.T.Helper()
@@ -78,6 +94,18 @@ func (mr *MockSyncerMockRecorder) HandleBlockAnnounceHandshake(arg0, arg1 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlockAnnounceHandshake", reflect.TypeOf((*MockSyncer)(nil).HandleBlockAnnounceHandshake), arg0, arg1)
 }
 
+// HandlePeerDisconnected mocks base method.
+func (m *MockSyncer) HandlePeerDisconnected(arg0 peer.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "HandlePeerDisconnected", arg0)
+}
+
+// HandlePeerDisconnected indicates an expected call of HandlePeerDisconnected.
+func (mr *MockSyncerMockRecorder) HandlePeerDisconnected(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandlePeerDisconnected", reflect.TypeOf((*MockSyncer)(nil).HandlePeerDisconnected), arg0)
+}
+
 // IsSynced mocks base method.
 func (m *MockSyncer) IsSynced() bool {
 	m.ctrl.T.Helper()
@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	common "github.com/ChainSafe/gossamer/lib/common"
 	mock "github.com/stretchr/testify/mock"
 
 	transaction "github.com/ChainSafe/gossamer/lib/transaction"
@@ -52,6 +53,20 @@ func (_m *TransactionStateAPI) Pending() []*transaction.ValidTransaction {
 	return r0
 }
 
+// RemoveExtrinsicAndBan provides a mock function with given fields: hash
+func (_m *TransactionStateAPI) RemoveExtrinsicAndBan(hash common.Hash) bool {
+	ret := _m.Called(hash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(common.Hash) bool); ok {
+		r0 = rf(hash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewTransactionStateAPI interface {
 	mock.TestingT
 	Cleanup(func())
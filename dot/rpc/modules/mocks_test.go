@@ -331,6 +331,21 @@ func (mr *MockBlockAPIMockRecorder) GetImportedBlockNotifierChannel() *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportedBlockNotifierChannel", reflect.TypeOf((*MockBlockAPI)(nil).GetImportedBlockNotifierChannel))
 }
 
+// GetIndexedTransaction mocks base method.
+func (m *MockBlockAPI) GetIndexedTransaction(arg0 common.Hash) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIndexedTransaction", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIndexedTransaction indicates an expected call of GetIndexedTransaction.
+func (mr *MockBlockAPIMockRecorder) GetIndexedTransaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndexedTransaction", reflect.TypeOf((*MockBlockAPI)(nil).GetIndexedTransaction), arg0)
+}
+
 // GetJustification mocks base method.
 func (m *MockBlockAPI) GetJustification(arg0 common.Hash) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -23,7 +23,7 @@ type StateGetReadProofRequest struct {
 // StateCallRequest holds json fields
 type StateCallRequest struct {
 	Method string       `json:"method"`
-	Data   []byte       `json:"data"`
+	Data   string       `json:"data"`
 	Block  *common.Hash `json:"block"`
 }
 
@@ -79,8 +79,8 @@ type StateStorageQueryRangeRequest struct {
 // StateStorageKeysQuery field to store storage keys
 type StateStorageKeysQuery [][]byte
 
-// StateCallResponse holds json fields
-type StateCallResponse []byte
+// StateCallResponse is the hex encoded result of the runtime call
+type StateCallResponse string
 
 // StateKeysResponse field to store the state keys
 type StateKeysResponse [][]byte
@@ -239,10 +239,24 @@ func (sm *StateModule) GetPairs(_ *http.Request, req *StatePairRequest, res *Sta
 	return nil
 }
 
-// Call isn't implemented properly yet.
-func (sm *StateModule) Call(_ *http.Request, _ *StateCallRequest, _ *StateCallResponse) error {
-	_ = sm.networkAPI
-	_ = sm.storageAPI
+// Call executes the runtime API method with the given hex encoded SCALE data
+// at the given block, or at the best block if no block hash is provided.
+func (sm *StateModule) Call(_ *http.Request, req *StateCallRequest, res *StateCallResponse) error {
+	if req.Method == "" {
+		return ErrEmptyRuntimeMethod
+	}
+
+	data, err := common.HexToBytes(req.Data)
+	if err != nil {
+		return fmt.Errorf("cannot convert hex data %s to bytes: %w", req.Data, err)
+	}
+
+	result, err := sm.coreAPI.CallRuntime(req.Block, req.Method, data)
+	if err != nil {
+		return err
+	}
+
+	*res = StateCallResponse(common.BytesToHex(result))
 	return nil
 }
 
@@ -275,14 +275,74 @@ type args struct {
 	}
 }
 
-// Implement Tests once function is implemented
-func TestCall(t *testing.T) {
-	mockNetworkAPI := mocks.NewNetworkAPI(t)
-	mockStorageAPI := mocks.NewStorageAPI(t)
-	sm := NewStateModule(mockNetworkAPI, mockStorageAPI, nil, nil)
+func TestStateModuleCall(t *testing.T) {
+	hash := common.MustHexToHash("0x3aa96b0149b6ca3688878bdbd19464448624136398e3ce45b9e755d3ab61355a")
 
-	err := sm.Call(nil, nil, nil)
-	assert.NoError(t, err)
+	mockCoreAPI := mocks.NewCoreAPI(t)
+	mockCoreAPI.On("CallRuntime", &hash, "AccountNonceApi_account_nonce", []byte{1, 2}).
+		Return([]byte{3, 4}, nil)
+
+	mockCoreAPIErr := mocks.NewCoreAPI(t)
+	mockCoreAPIErr.On("CallRuntime", (*common.Hash)(nil), "Unknown_method", []byte{}).
+		Return(nil, errors.New("CallRuntime Error"))
+
+	tests := []struct {
+		name    string
+		coreAPI CoreAPI
+		req     *StateCallRequest
+		exp     StateCallResponse
+		expErr  error
+	}{
+		{
+			name:    "OK Case",
+			coreAPI: mockCoreAPI,
+			req: &StateCallRequest{
+				Method: "AccountNonceApi_account_nonce",
+				Data:   "0x0102",
+				Block:  &hash,
+			},
+			exp: StateCallResponse("0x0304"),
+		},
+		{
+			name: "empty method",
+			req: &StateCallRequest{
+				Data: "0x",
+			},
+			expErr: ErrEmptyRuntimeMethod,
+		},
+		{
+			name: "invalid hex data",
+			req: &StateCallRequest{
+				Method: "AccountNonceApi_account_nonce",
+				Data:   "0102",
+			},
+			expErr: errors.New("cannot convert hex data 0102 to bytes: could not byteify non 0x prefixed string: 0102"),
+		},
+		{
+			name:    "CallRuntime Error",
+			coreAPI: mockCoreAPIErr,
+			req: &StateCallRequest{
+				Method: "Unknown_method",
+				Data:   "0x",
+			},
+			expErr: errors.New("CallRuntime Error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := &StateModule{
+				coreAPI: tt.coreAPI,
+			}
+			var res StateCallResponse
+			err := sm.Call(nil, tt.req, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.exp, res)
+		})
+	}
 }
 
 func TestStateModuleGetMetadata(t *testing.T) {
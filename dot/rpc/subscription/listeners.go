@@ -395,6 +395,10 @@ type RuntimeVersionListener struct {
 	runtimeUpdate chan runtime.Version
 	channelID     uint32
 	coreAPI       modules.CoreAPI
+	blockAPI      modules.BlockAPI
+	done          chan struct{}
+	cancel        chan struct{}
+	cancelTimeout time.Duration
 }
 
 // VersionListener interface defining methods that version listener must implement
@@ -405,40 +409,71 @@ type VersionListener interface {
 // Listen implementation of Listen interface to listen for runtime version changes
 func (l *RuntimeVersionListener) Listen() {
 	// This sends current runtime version once when subscription is created
-	rtVersion, err := l.coreAPI.GetRuntimeVersion(nil)
+	lastVersion, err := l.coreAPI.GetRuntimeVersion(nil)
 	if err != nil {
-		return
+		logger.Warnf("failed to get runtime version: %s", err)
+	} else {
+		versionResponse := modules.NewStateRuntimeVersionResponse(lastVersion)
+		subscriptionResponse := newSubscriptionResponse(
+			stateRuntimeVersionMethod, l.subID, versionResponse)
+		go l.wsconn.safeSend(subscriptionResponse)
 	}
 
-	versionResponse := modules.NewStateRuntimeVersionResponse(rtVersion)
-	subscriptionResponse := newSubscriptionResponse(
-		stateRuntimeVersionMethod, l.subID, versionResponse)
-	go l.wsconn.safeSend(subscriptionResponse)
-
 	// listen for runtime updates
 	go func() {
+		defer func() {
+			// keep draining the channel since the block state could be
+			// notifying it, until it gets closed by the unregistration.
+			go func() {
+				for range l.runtimeUpdate { //nolint:revive
+				}
+			}()
+			l.blockAPI.UnregisterRuntimeUpdatedChannel(l.channelID)
+			close(l.done)
+		}()
+
 		for {
-			info, ok := <-l.runtimeUpdate
-			if !ok {
+			select {
+			case <-l.cancel:
 				return
-			}
 
-			versionResponse := modules.NewStateRuntimeVersionResponse(info)
-			subscriptionResponse := newSubscriptionResponse(
-				stateRuntimeVersionMethod, l.subID, versionResponse)
-			l.wsconn.safeSend(subscriptionResponse)
+			case info, ok := <-l.runtimeUpdate:
+				if !ok {
+					return
+				}
+
+				if !versionChanged(lastVersion, info) {
+					continue
+				}
+				lastVersion = info
+
+				versionResponse := modules.NewStateRuntimeVersionResponse(info)
+				subscriptionResponse := newSubscriptionResponse(
+					stateRuntimeVersionMethod, l.subID, versionResponse)
+				l.wsconn.safeSend(subscriptionResponse)
+			}
 		}
 	}()
 }
 
+// versionChanged returns true if the spec, implementation or transaction
+// version differ between the two runtime versions given.
+func versionChanged(previous, current runtime.Version) bool {
+	return previous.SpecVersion != current.SpecVersion ||
+		previous.ImplVersion != current.ImplVersion ||
+		previous.TransactionVersion != current.TransactionVersion
+}
+
 // GetChannelID function that returns listener's channel ID
 func (l *RuntimeVersionListener) GetChannelID() uint32 {
 	return l.channelID
 }
 
-// Stop to runtimeVersionListener not implemented yet because the listener
-// does not need to be stoped
-func (*RuntimeVersionListener) Stop() error { return nil }
+// Stop to cancel the running goroutines to this listener
+// and unregister the runtime updated channel from the block state
+func (l *RuntimeVersionListener) Stop() error {
+	return cancelWithTimeout(l.cancel, l.done, l.cancelTimeout)
+}
 
 // GrandpaJustificationListener struct has the finalisedCh and the context to stop the goroutines
 type GrandpaJustificationListener struct {
@@ -487,7 +522,13 @@ func (g *GrandpaJustificationListener) Stop() error {
 }
 
 func cancelWithTimeout(cancel, done chan struct{}, t time.Duration) error {
-	close(cancel)
+	select {
+	case <-cancel:
+		// already cancelled, for example by an unsubscribe
+		// call before the websocket connection got closed.
+	default:
+		close(cancel)
+	}
 
 	timeout := time.NewTimer(t)
 	defer timeout.Stop()
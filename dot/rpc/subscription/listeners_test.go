@@ -38,6 +38,14 @@ func (m *mockWSConnAPI) safeSend(msg interface{}) {
 	m.lastMessage = msg.(BaseResponseJSON)
 }
 
+type channelWSConnAPI struct {
+	messages chan BaseResponseJSON
+}
+
+func (c *channelWSConnAPI) safeSend(msg interface{}) {
+	c.messages <- msg.(BaseResponseJSON)
+}
+
 func TestStorageObserver_Update(t *testing.T) {
 	wsconn, ws, cancel := setupWSConn(t)
 	defer cancel()
@@ -332,6 +340,10 @@ func TestRuntimeChannelListener_Listen(t *testing.T) {
 		subID:         0,
 		runtimeUpdate: notifyChan,
 		coreAPI:       modules.NewMockCoreAPI(t),
+		blockAPI:      modules.NewMockeryBlockAPI(t),
+		cancel:        make(chan struct{}, 1),
+		done:          make(chan struct{}, 1),
+		cancelTimeout: defaultCancelTimeout,
 	}
 
 	expectedInitialVersion := modules.StateRuntimeVersionResponse{
@@ -388,3 +400,50 @@ func TestRuntimeChannelListener_Listen(t *testing.T) {
 	time.Sleep(time.Millisecond * 10)
 	require.Equal(t, expectedUpdateResponse, mockConnection.lastMessage)
 }
+
+func TestRuntimeVersionListener_OnlyNotifiesChangesAndStops(t *testing.T) {
+	const channelID uint32 = 7
+	notifyChan := make(chan runtime.Version)
+	wsconn := &channelWSConnAPI{messages: make(chan BaseResponseJSON)}
+
+	initialVersion := runtime.Version{SpecName: []byte("mock-spec"), SpecVersion: 1}
+	coreAPI := mocks.NewCoreAPI(t)
+	coreAPI.On("GetRuntimeVersion", (*common.Hash)(nil)).Return(initialVersion, nil)
+
+	blockAPI := mocks.NewBlockAPI(t)
+	blockAPI.On("UnregisterRuntimeUpdatedChannel", channelID).
+		Run(func(mock.Arguments) { close(notifyChan) }).
+		Return(true)
+
+	rvl := RuntimeVersionListener{
+		wsconn:        wsconn,
+		subID:         1,
+		runtimeUpdate: notifyChan,
+		channelID:     channelID,
+		coreAPI:       coreAPI,
+		blockAPI:      blockAPI,
+		cancel:        make(chan struct{}, 1),
+		done:          make(chan struct{}, 1),
+		cancelTimeout: defaultCancelTimeout,
+	}
+
+	rvl.Listen()
+
+	message := <-wsconn.messages
+	require.Equal(t, modules.NewStateRuntimeVersionResponse(initialVersion), message.Params.Result)
+
+	// same spec, impl and transaction versions should not be notified
+	sameVersion := initialVersion
+	sameVersion.AuthoringVersion = 2
+	notifyChan <- sameVersion
+
+	updatedVersion := initialVersion
+	updatedVersion.SpecVersion = 2
+	notifyChan <- updatedVersion
+
+	message = <-wsconn.messages
+	require.Equal(t, modules.NewStateRuntimeVersionResponse(updatedVersion), message.Params.Result)
+
+	err := rvl.Stop()
+	require.NoError(t, err)
+}
@@ -57,6 +57,9 @@ func (c *WSConn) getUnsubListener(params interface{}) (Listener, error) {
 		return nil, err
 	}
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	listener, ok := c.Subscriptions[subscribeID]
 	if !ok {
 		return nil, fmt.Errorf("subscriber id %v: %w", subscribeID, errCannotFindListener)
@@ -83,6 +83,7 @@ func (c *WSConn) HandleConn() {
 		if err != nil {
 			logger.Debugf("websocket failed to read message: %s", err)
 			if errors.Is(err, errCannotReadFromWebsocket) {
+				c.stopListeners()
 				return
 			}
 
@@ -137,6 +138,23 @@ func (c *WSConn) HandleConn() {
 	}
 }
 
+// stopListeners removes and stops all the subscriptions of the connection.
+func (c *WSConn) stopListeners() {
+	c.mu.Lock()
+	subscriptions := c.Subscriptions
+	c.Subscriptions = make(map[uint32]Listener)
+	c.mu.Unlock()
+
+	// listeners are stopped without holding the lock since
+	// they may need it to send their last messages.
+	for id, listener := range subscriptions {
+		err := listener.Stop()
+		if err != nil {
+			logger.Warnf("failed to stop listener with subscription id %d: %s", id, err)
+		}
+	}
+}
+
 func (c *WSConn) executeRPCCall(data []byte) {
 	request, err := c.prepareRequest(data)
 	if err != nil {
@@ -359,6 +377,10 @@ func (c *WSConn) initRuntimeVersionListener(reqID float64, _ interface{}) (Liste
 		wsconn:        c,
 		runtimeUpdate: make(chan runtime.Version),
 		coreAPI:       c.CoreAPI,
+		blockAPI:      c.BlockAPI,
+		cancel:        make(chan struct{}, 1),
+		done:          make(chan struct{}, 1),
+		cancelTimeout: defaultCancelTimeout,
 	}
 
 	chanID, err := c.BlockAPI.RegisterRuntimeUpdatedChannel(rvl.runtimeUpdate)
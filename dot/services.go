@@ -58,6 +58,9 @@ func (nodeBuilder) createStateService(cfg *Config) (*state.Service, error) {
 		Path:     cfg.Global.BasePath,
 		LogLevel: cfg.Log.StateLvl,
 		Metrics:  metrics.NewIntervalConfig(cfg.Global.PublishMetrics),
+
+		TransactionIndexRetention: cfg.State.TransactionIndexRetention,
+		RuntimeLogLevel:           cfg.Log.RuntimeLvl,
 	}
 
 	stateSrvc := state.NewService(config)
@@ -237,17 +240,25 @@ func (nodeBuilder) createCoreService(cfg *Config, ks *keystore.GlobalKeystore,
 		codeSubs[common.MustHexToHash(k)] = v
 	}
 
+	offchainWorker, err := offchainWorkerEnabled(cfg.Core.OffchainWorker, cfg.Core.Roles)
+	if err != nil {
+		return nil, err
+	}
+
 	// set core configuration
 	coreConfig := &core.Config{
-		LogLvl:               cfg.Log.CoreLvl,
-		BlockState:           st.Block,
-		EpochState:           st.Epoch,
-		StorageState:         st.Storage,
-		TransactionState:     st.Transaction,
-		Keystore:             ks,
-		Network:              net,
-		CodeSubstitutes:      codeSubs,
-		CodeSubstitutedState: st.Base,
+		LogLvl:                cfg.Log.CoreLvl,
+		BlockState:            st.Block,
+		EpochState:            st.Epoch,
+		StorageState:          st.Storage,
+		TransactionState:      st.Transaction,
+		Keystore:              ks,
+		Network:               net,
+		CodeSubstitutes:       codeSubs,
+		CodeSubstitutedState:  st.Base,
+		OffchainWorker:        offchainWorker,
+		OffchainWorkerTimeout: cfg.Core.OffchainWorkerTimeout,
+		RuntimeLogLvl:         cfg.Log.RuntimeLvl,
 	}
 
 	// create new core service
@@ -260,6 +271,22 @@ func (nodeBuilder) createCoreService(cfg *Config, ks *keystore.GlobalKeystore,
 	return coreSrvc, nil
 }
 
+// offchainWorkerEnabled returns true if the offchain worker should run
+// given the offchain worker mode and the roles of the node.
+// An empty mode defaults to running the offchain worker for authority nodes only.
+func offchainWorkerEnabled(mode string, roles common.Roles) (enabled bool, err error) {
+	switch mode {
+	case OffchainWorkerAlways:
+		return true, nil
+	case OffchainWorkerNever:
+		return false, nil
+	case OffchainWorkerWhenValidating, "":
+		return roles == common.AuthorityRole, nil
+	default:
+		return false, fmt.Errorf("%w: %s", ErrOffchainWorkerMode, mode)
+	}
+}
+
 // Network Service
 
 // createNetworkService creates a network service from the command configuration and genesis data
@@ -279,6 +306,7 @@ func (nodeBuilder) createNetworkService(cfg *Config, stateSrvc *state.Service,
 	networkConfig := network.Config{
 		LogLvl:            cfg.Log.NetworkLvl,
 		BlockState:        stateSrvc.Block,
+		StorageState:      stateSrvc.Storage,
 		BasePath:          cfg.Global.BasePath,
 		Roles:             cfg.Core.Roles,
 		Port:              cfg.Network.Port,
@@ -295,6 +323,16 @@ func (nodeBuilder) createNetworkService(cfg *Config, stateSrvc *state.Service,
 		Telemetry:         telemetryMailer,
 		PublicDNS:         cfg.Network.PublicDNS,
 		Metrics:           metrics.NewIntervalConfig(cfg.Global.PublishMetrics),
+		GrandpaPeerSet: network.PeerSetConfig{
+			MaxInPeers:   cfg.Network.GrandpaInPeers,
+			MaxOutPeers:  cfg.Network.GrandpaOutPeers,
+			ReservedOnly: cfg.Network.GrandpaReservedOnly,
+		},
+		TransactionsPeerSet: network.PeerSetConfig{
+			MaxInPeers:   cfg.Network.TransactionsInPeers,
+			MaxOutPeers:  cfg.Network.TransactionsOutPeers,
+			ReservedOnly: cfg.Network.TransactionsReservedOnly,
+		},
 	}
 
 	networkSrvc, err := network.NewService(&networkConfig)
@@ -400,6 +438,7 @@ func (nodeBuilder) createGRANDPAService(cfg *Config, st *state.Service, ks keyst
 	gsCfg := &grandpa.Config{
 		LogLvl:       cfg.Log.FinalityGadgetLvl,
 		BlockState:   st.Block,
+		StorageState: st.Storage,
 		GrandpaState: st.Grandpa,
 		Voters:       voters,
 		Authority:    cfg.Core.GrandpaAuthority,
@@ -416,7 +455,7 @@ func (nodeBuilder) createGRANDPAService(cfg *Config, st *state.Service, ks keyst
 }
 
 func (nodeBuilder) createBlockVerifier(st *state.Service) *babe.VerificationManager {
-	return babe.NewVerificationManager(st.Block, st.Epoch)
+	return babe.NewVerificationManager(st.Block, st.Storage, st.Epoch)
 }
 
 func (nodeBuilder) newSyncService(cfg *Config, st *state.Service, fg sync.FinalityGadget,
@@ -427,11 +466,18 @@ func (nodeBuilder) newSyncService(cfg *Config, st *state.Service, fg sync.Finali
 		return nil, err
 	}
 
+	warpSync, stateSync, err := syncModeEnabled(cfg.Core.SyncMode)
+	if err != nil {
+		return nil, err
+	}
+
 	syncCfg := &sync.Config{
 		LogLvl:             cfg.Log.SyncLvl,
 		Network:            net,
 		BlockState:         st.Block,
 		StorageState:       st.Storage,
+		EpochState:         st.Epoch,
+		GrandpaState:       st.Grandpa,
 		TransactionState:   st.Transaction,
 		FinalityGadget:     fg,
 		BabeVerifier:       verifier,
@@ -440,11 +486,26 @@ func (nodeBuilder) newSyncService(cfg *Config, st *state.Service, fg sync.Finali
 		MaxPeers:           cfg.Network.MaxPeers,
 		SlotDuration:       slotDuration,
 		Telemetry:          telemetryMailer,
+		WarpSync:           warpSync,
+		StateSync:          stateSync,
 	}
 
 	return sync.NewService(syncCfg)
 }
 
+func syncModeEnabled(mode string) (warpSync, stateSync bool, err error) {
+	switch mode {
+	case SyncModeWarp:
+		return true, true, nil
+	case SyncModeState:
+		return false, true, nil
+	case SyncModeFull, "":
+		return false, false, nil
+	default:
+		return false, false, fmt.Errorf("%w: %s", ErrSyncMode, mode)
+	}
+}
+
 func (nodeBuilder) createDigestHandler(lvl log.Level, st *state.Service) (*digest.Handler, error) {
 	return digest.NewHandler(lvl, st.Block, st.Epoch, st.Grandpa)
 }
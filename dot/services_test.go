@@ -16,6 +16,7 @@
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/babe"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
@@ -327,6 +328,109 @@ type args struct {
 	}
 }
 
+func Test_offchainWorkerEnabled(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		mode       string
+		roles      common.Roles
+		enabled    bool
+		errWrapped error
+		errMessage string
+	}{
+		"always_for_full_node": {
+			mode:    OffchainWorkerAlways,
+			roles:   common.FullNodeRole,
+			enabled: true,
+		},
+		"never_for_authority": {
+			mode:  OffchainWorkerNever,
+			roles: common.AuthorityRole,
+		},
+		"when_validating_for_full_node": {
+			mode:  OffchainWorkerWhenValidating,
+			roles: common.FullNodeRole,
+		},
+		"when_validating_for_authority": {
+			mode:    OffchainWorkerWhenValidating,
+			roles:   common.AuthorityRole,
+			enabled: true,
+		},
+		"default_for_authority": {
+			roles:   common.AuthorityRole,
+			enabled: true,
+		},
+		"unknown_mode": {
+			mode:       "sometimes",
+			roles:      common.AuthorityRole,
+			errWrapped: ErrOffchainWorkerMode,
+			errMessage: "unknown offchain worker mode: sometimes",
+		},
+	}
+
+	for name, testCase := range tests {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			enabled, err := offchainWorkerEnabled(testCase.mode, testCase.roles)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.enabled, enabled)
+		})
+	}
+}
+
+func Test_syncModeEnabled(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		mode       string
+		warpSync   bool
+		stateSync  bool
+		errWrapped error
+		errMessage string
+	}{
+		"full": {
+			mode: SyncModeFull,
+		},
+		"warp": {
+			mode:      SyncModeWarp,
+			warpSync:  true,
+			stateSync: true,
+		},
+		"state": {
+			mode:      SyncModeState,
+			stateSync: true,
+		},
+		"default": {},
+		"unknown_mode": {
+			mode:       "slow",
+			errWrapped: ErrSyncMode,
+			errMessage: "unknown sync mode: slow",
+		},
+	}
+
+	for name, testCase := range tests {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			warpSync, stateSync, err := syncModeEnabled(testCase.mode)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.warpSync, warpSync)
+			assert.Equal(t, testCase.stateSync, stateSync)
+		})
+	}
+}
+
 func Test_nodeBuilder_createNetworkService(t *testing.T) {
 	t.Parallel()
 
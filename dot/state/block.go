@@ -14,6 +14,7 @@
 	"github.com/ChainSafe/chaindb"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
@@ -68,6 +69,14 @@ type BlockState struct {
 	runtimeUpdateSubscriptionsLock sync.RWMutex
 	runtimeUpdateSubscriptions     map[uint32]chan<- runtime.Version
 
+	// transactionIndexRetention is the number of finalised blocks for which
+	// indexed transactions are kept. Zero means they are kept forever.
+	transactionIndexRetention uint32
+
+	// runtimeLogLevel is the log level of the runtime instances
+	// created on runtime code changes.
+	runtimeLogLevel log.Level
+
 	telemetry telemetry.Client
 }
 
@@ -615,6 +624,7 @@ func (bs *BlockState) HandleRuntimeChanges(newState *rtstorage.TrieState,
 		NodeStorage: rt.NodeStorage(),
 		Network:     rt.NetworkService(),
 		CodeHash:    currCodeHash,
+		LogLvl:      bs.runtimeLogLevel,
 	}
 
 	if rt.Validator() {
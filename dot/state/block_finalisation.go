@@ -6,9 +6,11 @@
 import (
 	"encoding/binary"
 	"fmt"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/common"
 )
 
@@ -144,6 +146,11 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 
 	pruned := bs.bt.Prune(hash)
 	for _, hash := range pruned {
+		err = bs.deleteIndexedTransactions(hash)
+		if err != nil {
+			return fmt.Errorf("failed to delete indexed transactions of pruned block %s: %w", hash, err)
+		}
+
 		blockHeader := bs.unfinalisedBlocks.delete(hash)
 		if blockHeader == nil {
 			continue
@@ -167,6 +174,11 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 		return fmt.Errorf("failed to get finalised header, hash: %s, error: %s", hash, err)
 	}
 
+	err = bs.pruneIndexedTransactions(bs.lastFinalised, header.Number)
+	if err != nil {
+		return fmt.Errorf("failed to prune indexed transactions: %w", err)
+	}
+
 	bs.telemetry.SendMessage(
 		telemetry.NewNotifyFinalized(
 			header.Hash(),
@@ -187,6 +199,54 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 	return nil
 }
 
+// SetWarpSyncFinalisedHeader sets the given header, proven final by a warp sync proof,
+// as the highest finalised block and as the root of the block tree. Unlike SetFinalisedHash,
+// the header does not need to descend from a known block, and the blocks between the
+// previously finalised block and the header are not stored.
+func (bs *BlockState) SetWarpSyncFinalisedHeader(header *types.Header, round, setID uint64) error {
+	bs.Lock()
+	defer bs.Unlock()
+
+	hash := header.Hash()
+
+	if err := bs.SetHeader(header); err != nil {
+		return fmt.Errorf("failed to set header: %w", err)
+	}
+
+	if err := bs.db.Put(headerHashKey(uint64(header.Number)), hash.ToBytes()); err != nil {
+		return fmt.Errorf("failed to set header hash key: %w", err)
+	}
+
+	if err := bs.setArrivalTime(hash, time.Now()); err != nil {
+		return fmt.Errorf("failed to set arrival time: %w", err)
+	}
+
+	if err := bs.db.Put(finalisedHashKey(round, setID), hash[:]); err != nil {
+		return fmt.Errorf("failed to set finalised hash key: %w", err)
+	}
+
+	if err := bs.setHighestRoundAndSetID(round, setID); err != nil {
+		return fmt.Errorf("failed to set highest round and set ID: %w", err)
+	}
+
+	// keep the runtime of the previous finalised block, since the
+	// runtime code of the new one is only known once its state is.
+	instance, err := bs.bt.GetBlockRuntime(bs.lastFinalised)
+	if err != nil {
+		return fmt.Errorf("failed to get runtime of last finalised block: %w", err)
+	}
+
+	bs.bt = blocktree.NewBlockTreeFromRoot(header)
+	bs.bt.StoreRuntime(hash, instance)
+	bs.unfinalisedBlocks = newHashToBlockMap()
+	bs.lastFinalised = hash
+
+	bs.notifyFinalized(hash, round, setID)
+
+	logger.Debugf("set warp sync finalised header with hash %s and number %d", hash, header.Number)
+	return nil
+}
+
 func (bs *BlockState) deleteFromTries(lastFinalised common.Hash) error {
 	lastFinalisedHeader, err := bs.GetHeader(lastFinalised)
 	if err != nil {
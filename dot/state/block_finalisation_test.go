@@ -8,6 +8,7 @@
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime/mocks"
 	"github.com/ChainSafe/gossamer/lib/trie"
 
 	"github.com/stretchr/testify/require"
@@ -152,3 +153,40 @@ func TestSetFinalisedHash_setFirstSlotOnFinalisation(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, firstSlot, res)
 }
+
+func TestBlockState_SetWarpSyncFinalisedHeader(t *testing.T) {
+	bs := newTestBlockState(t, newTriesEmpty())
+
+	instance := new(mocks.Instance)
+	bs.StoreRuntime(bs.GenesisHash(), instance)
+
+	header := &types.Header{
+		ParentHash: common.Hash{1},
+		Number:     100,
+		StateRoot:  trie.EmptyHash,
+		Digest:     types.NewDigest(),
+	}
+
+	err := bs.SetWarpSyncFinalisedHeader(header, 3, 2)
+	require.NoError(t, err)
+
+	finalised, err := bs.GetHighestFinalisedHeader()
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), finalised.Hash())
+
+	require.Equal(t, header.Hash(), bs.BestBlockHash())
+
+	hash, err := bs.GetHashByNumber(100)
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), hash)
+
+	round, setID, err := bs.GetHighestRoundAndSetID()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), round)
+	require.Equal(t, uint64(2), setID)
+
+	hash = header.Hash()
+	rt, err := bs.GetRuntime(&hash)
+	require.NoError(t, err)
+	require.Equal(t, instance, rt)
+}
@@ -24,6 +24,7 @@
 	errEpochNotInDatabase = errors.New("epoch data not found in the database")
 	errHashNotPersisted   = errors.New("hash with next epoch not found in database")
 	errNoPreRuntimeDigest = errors.New("header does not contain pre-runtime digest")
+	errInvalidBabeEpoch   = errors.New("invalid BABE epoch")
 )
 
 var (
@@ -478,6 +479,48 @@ func (s *EpochState) SetFirstSlot(slot uint64) error {
 	return s.baseState.storeFirstSlot(slot)
 }
 
+// ImportBabeEpochs stores the current and next BABE epochs given, as returned by
+// the runtime at a block whose state was downloaded instead of being built from
+// the previous blocks. It sets the first slot and the current epoch accordingly,
+// so the blocks following the downloaded state can be verified.
+func (s *EpochState) ImportBabeEpochs(current, next *types.BabeEpoch) error {
+	if current.StartSlot < current.EpochIndex*current.Duration {
+		return fmt.Errorf("%w: epoch %d starting at slot %d with duration %d",
+			errInvalidBabeEpoch, current.EpochIndex, current.StartSlot, current.Duration)
+	}
+
+	firstSlot := current.StartSlot - current.EpochIndex*current.Duration
+	err := s.baseState.storeFirstSlot(firstSlot)
+	if err != nil {
+		return fmt.Errorf("storing first slot: %w", err)
+	}
+
+	for _, epoch := range []*types.BabeEpoch{current, next} {
+		epochData, err := epoch.ToEpochData()
+		if err != nil {
+			return fmt.Errorf("converting epoch %d data: %w", epoch.EpochIndex, err)
+		}
+
+		err = s.SetEpochData(epoch.EpochIndex, epochData)
+		if err != nil {
+			return fmt.Errorf("setting epoch %d data: %w", epoch.EpochIndex, err)
+		}
+
+		configData := epoch.Config
+		err = s.SetConfigData(epoch.EpochIndex, &configData)
+		if err != nil {
+			return fmt.Errorf("setting epoch %d config data: %w", epoch.EpochIndex, err)
+		}
+	}
+
+	err = s.SetCurrentEpoch(current.EpochIndex)
+	if err != nil {
+		return fmt.Errorf("setting current epoch: %w", err)
+	}
+
+	return nil
+}
+
 // SkipVerify returns whether verification for the given header should be skipped or not.
 // Only used in the case of imported state.
 func (s *EpochState) SkipVerify(header *types.Header) (bool, error) {
@@ -134,6 +134,52 @@ func TestEpochState_ConfigData(t *testing.T) {
 	require.Equal(t, data, ret)
 }
 
+func TestEpochState_ImportBabeEpochs(t *testing.T) {
+	s := newEpochStateFromGenesis(t)
+
+	current := &types.BabeEpoch{
+		EpochIndex:  3,
+		StartSlot:   1000,
+		Duration:    300,
+		Authorities: []types.AuthorityRaw{},
+		Randomness:  [32]byte{1},
+		Config:      types.ConfigData{C1: 1, C2: 4, SecondarySlots: 1},
+	}
+	next := &types.BabeEpoch{
+		EpochIndex:  4,
+		StartSlot:   1300,
+		Duration:    300,
+		Authorities: []types.AuthorityRaw{},
+		Randomness:  [32]byte{2},
+		Config:      types.ConfigData{C1: 1, C2: 8, SecondarySlots: 2},
+	}
+
+	err := s.ImportBabeEpochs(current, next)
+	require.NoError(t, err)
+
+	epoch, err := s.GetCurrentEpoch()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), epoch)
+
+	firstSlot, err := s.baseState.loadFirstSlot()
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), firstSlot)
+
+	for _, babeEpoch := range []*types.BabeEpoch{current, next} {
+		epochData, err := s.GetEpochData(babeEpoch.EpochIndex, nil)
+		require.NoError(t, err)
+		require.Equal(t, babeEpoch.Randomness, epochData.Randomness)
+
+		configData, err := s.GetConfigData(babeEpoch.EpochIndex, nil)
+		require.NoError(t, err)
+		require.Equal(t, &babeEpoch.Config, configData)
+	}
+
+	invalid := &types.BabeEpoch{EpochIndex: 2, StartSlot: 100, Duration: 200}
+	err = s.ImportBabeEpochs(invalid, next)
+	require.ErrorIs(t, err, errInvalidBabeEpoch)
+}
+
 func TestEpochState_GetEpochForBlock(t *testing.T) {
 	s := newEpochStateFromGenesis(t)
 
@@ -10,6 +10,7 @@
 
 	"github.com/ChainSafe/chaindb"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
@@ -17,7 +18,7 @@
 var (
 	errPendingScheduledChanges = errors.New("pending scheduled changes needs to be applied")
 	errDuplicateHashes         = errors.New("duplicated hashes")
-	errAlreadyHasForcedChange  = errors.New("already has a forced change")
+	errAlreadyHasPendingChange = errors.New("already has a pending change")
 	errUnfinalizedAncestor     = errors.New("unfinalized ancestor")
 
 	ErrNoNextAuthorityChange = errors.New("no next authority change")
@@ -29,7 +30,9 @@
 	authoritiesPrefix = []byte("auth")
 	setIDChangePrefix = []byte("change")
 	pauseKey          = []byte("pause")
+	pauseHashKey      = []byte("pausehash")
 	resumeKey         = []byte("resume")
+	resumeHashKey     = []byte("resumehash")
 	currentSetIDKey   = []byte("setID")
 )
 
@@ -40,6 +43,8 @@ type GrandpaState struct {
 
 	forcedChanges        *orderedPendingChanges
 	scheduledChangeRoots *changeTree
+	scheduledPauses      *orderedPendingChanges
+	scheduledResumes     *orderedPendingChanges
 }
 
 // NewGrandpaStateFromGenesis returns a new GrandpaState given the grandpa genesis authorities
@@ -51,6 +56,8 @@ func NewGrandpaStateFromGenesis(db chaindb.Database, bs *BlockState,
 		blockState:           bs,
 		scheduledChangeRoots: new(changeTree),
 		forcedChanges:        new(orderedPendingChanges),
+		scheduledPauses:      new(orderedPendingChanges),
+		scheduledResumes:     new(orderedPendingChanges),
 	}
 
 	if err := s.setCurrentSetID(genesisSetID); err != nil {
@@ -79,6 +86,8 @@ func NewGrandpaState(db chaindb.Database, bs *BlockState) *GrandpaState {
 		blockState:           bs,
 		scheduledChangeRoots: new(changeTree),
 		forcedChanges:        new(orderedPendingChanges),
+		scheduledPauses:      new(orderedPendingChanges),
+		scheduledResumes:     new(orderedPendingChanges),
 	}
 }
 
@@ -96,11 +105,9 @@ func (s *GrandpaState) HandleGRANDPADigest(header *types.Header, digest scale.Va
 	case types.GrandpaOnDisabled:
 		return nil
 	case types.GrandpaPause:
-		logger.Warn("GRANDPA Pause consensus message not implemented yet")
-		return nil
+		return s.addPause(header, val)
 	case types.GrandpaResume:
-		logger.Warn("GRANDPA Resume consensus message not implemented yet")
-		return nil
+		return s.addResume(header, val)
 	default:
 		return fmt.Errorf("not supported digest")
 	}
@@ -149,6 +156,36 @@ func (s *GrandpaState) addScheduledChange(header *types.Header, sc types.Grandpa
 	return nil
 }
 
+func (s *GrandpaState) addPause(header *types.Header, pause types.GrandpaPause) error {
+	pendingPause := pendingChange{
+		announcingHeader: header,
+		delay:            pause.Delay,
+	}
+
+	err := s.scheduledPauses.importChange(pendingPause, s.blockState.IsDescendantOf)
+	if err != nil {
+		return fmt.Errorf("cannot import pause: %w", err)
+	}
+
+	logger.Debugf("there are now %d possible pauses", s.scheduledPauses.Len())
+	return nil
+}
+
+func (s *GrandpaState) addResume(header *types.Header, resume types.GrandpaResume) error {
+	pendingResume := pendingChange{
+		announcingHeader: header,
+		delay:            resume.Delay,
+	}
+
+	err := s.scheduledResumes.importChange(pendingResume, s.blockState.IsDescendantOf)
+	if err != nil {
+		return fmt.Errorf("cannot import resume: %w", err)
+	}
+
+	logger.Debugf("there are now %d possible resumes", s.scheduledResumes.Len())
+	return nil
+}
+
 // ApplyScheduledChanges will check the schedules changes in order to find a root
 // equal or behind the finalized number and will apply its authority set changes
 func (s *GrandpaState) ApplyScheduledChanges(finalizedHeader *types.Header) error {
@@ -263,6 +300,149 @@ func (s *GrandpaState) ApplyForcedChanges(importedBlockHeader *types.Header) err
 	return nil
 }
 
+// ApplyScheduledPause prunes the scheduled pauses and resumes not on the chain of the
+// finalized header, and applies the pause on this chain if its effective block is finalized.
+// Once applied, the authorities stop voting until a resume is applied.
+func (s *GrandpaState) ApplyScheduledPause(finalizedHeader *types.Header) error {
+	onChainResumes, err := s.pendingChangesOnChain(*s.scheduledResumes, finalizedHeader)
+	if err != nil {
+		return fmt.Errorf("cannot prune resumes: %w", err)
+	}
+	*s.scheduledResumes = onChainResumes
+
+	pause, err := s.finalizedPendingChange(s.scheduledPauses, finalizedHeader)
+	if err != nil {
+		return fmt.Errorf("cannot find finalized pause: %w", err)
+	} else if pause == nil {
+		return nil
+	}
+
+	logger.Debugf("applying pause: %s", pause)
+	err = s.setEnactingBlock(pauseKey, pauseHashKey, pause.effectiveNumber())
+	if err != nil {
+		return fmt.Errorf("cannot set pause: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyScheduledResume applies the scheduled resume announced on the chain of the
+// imported header if its effective block is reached, and if the imported header is
+// our best block. Resumes are applied on import, since no block can be finalized
+// while the authorities are paused. Once applied, the authorities start voting again.
+func (s *GrandpaState) ApplyScheduledResume(importedHeader *types.Header) error {
+	if s.scheduledResumes.Len() == 0 {
+		return nil
+	}
+
+	importedHash := importedHeader.Hash()
+	if !s.blockState.BestBlockHash().Equal(importedHash) {
+		return nil
+	}
+
+	var resume *pendingChange
+	remainingResumes := make(orderedPendingChanges, 0, s.scheduledResumes.Len())
+	for i, scheduledResume := range *s.scheduledResumes {
+		if scheduledResume.effectiveNumber() > importedHeader.Number {
+			remainingResumes = append(remainingResumes, scheduledResume)
+			continue
+		}
+
+		// the imported header is our best block, so the resume
+		// is on its chain if it is announced on our best chain.
+		announcingHeader := scheduledResume.announcingHeader
+		bestChainHash, err := s.blockState.GetHashByNumber(announcingHeader.Number)
+		if err != nil {
+			return fmt.Errorf("cannot get hash by number: %w", err)
+		}
+
+		if !bestChainHash.Equal(announcingHeader.Hash()) {
+			remainingResumes = append(remainingResumes, scheduledResume)
+			continue
+		}
+
+		resume = &(*s.scheduledResumes)[i]
+	}
+
+	if resume == nil {
+		return nil
+	}
+
+	logger.Debugf("applying resume: %s", resume)
+	err := s.setEnactingBlock(resumeKey, resumeHashKey, resume.effectiveNumber())
+	if err != nil {
+		return fmt.Errorf("cannot set resume: %w", err)
+	}
+	*s.scheduledResumes = remainingResumes
+
+	return nil
+}
+
+// finalizedPendingChange prunes the pending changes not on the chain of the finalized
+// header and removes the changes whose effective block is finalized, returning the
+// last of them. It returns nil if no change has its effective block finalized.
+func (s *GrandpaState) finalizedPendingChange(changes *orderedPendingChanges,
+	finalizedHeader *types.Header) (finalized *pendingChange, err error) {
+	if changes.Len() == 0 {
+		return nil, nil
+	}
+
+	onChainChanges, err := s.pendingChangesOnChain(*changes, finalizedHeader)
+	if err != nil {
+		return nil, fmt.Errorf("cannot prune pending changes: %w", err)
+	}
+
+	remainingChanges := make(orderedPendingChanges, 0, len(onChainChanges))
+	for i, change := range onChainChanges {
+		if change.effectiveNumber() > finalizedHeader.Number {
+			remainingChanges = append(remainingChanges, change)
+			continue
+		}
+
+		finalized = &onChainChanges[i]
+	}
+	*changes = remainingChanges
+
+	return finalized, nil
+}
+
+// pendingChangesOnChain returns the pending changes announced in an ancestor
+// of the finalized header, or in a descendant of the finalized header.
+func (s *GrandpaState) pendingChangesOnChain(changes orderedPendingChanges,
+	finalizedHeader *types.Header) (onChain orderedPendingChanges, err error) {
+	finalizedHash := finalizedHeader.Hash()
+	onChain = make(orderedPendingChanges, 0, len(changes))
+	for _, change := range changes {
+		announcingHash := change.announcingHeader.Hash()
+
+		var isOnChain bool
+		if change.announcingHeader.Number <= finalizedHeader.Number {
+			// the ancestors of the finalized header may be pruned
+			// from the block tree, so use the canonical chain.
+			canonicalHash, err := s.blockState.GetHashByNumber(change.announcingHeader.Number)
+			if err != nil {
+				return nil, fmt.Errorf("cannot get hash by number: %w", err)
+			}
+			isOnChain = canonicalHash.Equal(announcingHash)
+		} else {
+			isOnChain, err = s.blockState.IsDescendantOf(finalizedHash, announcingHash)
+			if errors.Is(err, blocktree.ErrEndNodeNotFound) {
+				// the announcing block was pruned from the block tree
+				// since it is not a descendant of the finalized header.
+				isOnChain = false
+			} else if err != nil {
+				return nil, fmt.Errorf("cannot verify ancestry: %w", err)
+			}
+		}
+
+		if isOnChain {
+			onChain = append(onChain, change)
+		}
+	}
+
+	return onChain, nil
+}
+
 // NextGrandpaAuthorityChange returns the block number of the next upcoming grandpa authorities change.
 // It returns 0 if no change is scheduled.
 func (s *GrandpaState) NextGrandpaAuthorityChange(bestBlockHash common.Hash, bestBlockNumber uint) (
@@ -519,6 +699,75 @@ func (s *GrandpaState) GetNextResume() (blockNumber uint, err error) {
 	return common.BytesToUint(value), nil
 }
 
+// setEnactingBlock stores the number and the hash of the block on our best chain
+// enacting a pause or a resume, using the number key and hash key given.
+func (s *GrandpaState) setEnactingBlock(numberKey, hashKey []byte, number uint) error {
+	hash, err := s.blockState.GetHashByNumber(number)
+	if err != nil {
+		return fmt.Errorf("cannot get hash by number: %w", err)
+	}
+
+	err = s.db.Put(numberKey, common.UintToBytes(number))
+	if err != nil {
+		return fmt.Errorf("cannot store block number: %w", err)
+	}
+
+	err = s.db.Put(hashKey, hash.ToBytes())
+	if err != nil {
+		return fmt.Errorf("cannot store block hash: %w", err)
+	}
+
+	return nil
+}
+
+// getEnactingBlock returns the number of the block enacting a pause or a resume
+// stored using the number key and hash key given. It returns false if no block
+// is stored, or if the block stored is not on our best chain.
+func (s *GrandpaState) getEnactingBlock(numberKey, hashKey []byte) (number uint, onChain bool, err error) {
+	numberValue, err := s.db.Get(numberKey)
+	if errors.Is(err, chaindb.ErrKeyNotFound) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, fmt.Errorf("cannot get block number: %w", err)
+	}
+
+	hashValue, err := s.db.Get(hashKey)
+	if errors.Is(err, chaindb.ErrKeyNotFound) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, fmt.Errorf("cannot get block hash: %w", err)
+	}
+
+	number = common.BytesToUint(numberValue)
+	bestChainHash, err := s.blockState.GetHashByNumber(number)
+	if err != nil {
+		return 0, false, fmt.Errorf("cannot get hash by number: %w", err)
+	}
+
+	return number, bestChainHash.Equal(common.BytesToHash(hashValue)), nil
+}
+
+// IsVotingPaused returns true if the last pause applied on the finalized
+// chain is not followed by a resume applied on our best chain, in which
+// case authorities must not vote.
+func (s *GrandpaState) IsVotingPaused() (paused bool, err error) {
+	pause, onChain, err := s.getEnactingBlock(pauseKey, pauseHashKey)
+	if err != nil {
+		return false, fmt.Errorf("cannot get pause: %w", err)
+	} else if !onChain {
+		return false, nil
+	}
+
+	resume, onChain, err := s.getEnactingBlock(resumeKey, resumeHashKey)
+	if err != nil {
+		return false, fmt.Errorf("cannot get resume: %w", err)
+	} else if !onChain {
+		return true, nil
+	}
+
+	return resume < pause, nil
+}
+
 func prevotesKey(round, setID uint64) []byte {
 	prevotesPrefix := []byte("pv")
 	k := roundAndSetIDToBytes(round, setID)
@@ -74,7 +74,7 @@ func (oc orderedPendingChanges) lookupChangeWhere(condition conditionFunc[pendin
 }
 
 // importChange only tracks the pending change if and only if it is the
-// unique forced change in its fork, otherwise will return an error
+// unique pending change in its fork, otherwise will return an error
 func (oc *orderedPendingChanges) importChange(pendingChange pendingChange, isDescendantOf isDescendantOfFunc) error {
 	announcingHeader := pendingChange.announcingHeader.Hash()
 
@@ -91,7 +91,7 @@ func (oc *orderedPendingChanges) importChange(pendingChange pendingChange, isDes
 		}
 
 		if isDescendant {
-			return fmt.Errorf("%w: for block hash %s", errAlreadyHasForcedChange, changeBlockHash)
+			return fmt.Errorf("%w: for block hash %s", errAlreadyHasPendingChange, changeBlockHash)
 		}
 	}
 
@@ -377,7 +377,7 @@ func TestShouldNotAddMoreThanOneForcedChangeInTheSameFork(t *testing.T) {
 
 	err = gs.addForcedChange(aliceHeaders[4], someForcedChange)
 	require.Error(t, err)
-	require.ErrorIs(t, err, errAlreadyHasForcedChange)
+	require.ErrorIs(t, err, errAlreadyHasPendingChange)
 
 	// adding the same forced change twice
 	err = gs.addForcedChange(bobHeaders[2], someForcedChange)
@@ -1364,3 +1364,135 @@ func TestApplyScheduledChange(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyScheduledPauseAndResume(t *testing.T) {
+	t.Parallel()
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+
+	db := NewInMemoryDB(t)
+	blockState := testBlockState(t, db)
+
+	gs, err := NewGrandpaStateFromGenesis(db, blockState, testAuths)
+	require.NoError(t, err)
+
+	/*
+	* create chainA and a fork chainB
+	*
+	*      / -> 3 -> 4 -> 5 -> 6 -> 7 -> 8 -> 9 -> 10 -> 11 -> 12 (B)
+	* 1 -> 2 -> 3 -> 4 -> 5 -> 6 -> 7 -> 8 -> 9 -> 10 -> 11 (A)
+	 */
+	const sizeOfChain = 10
+	chainA := issueBlocksWithBABEPrimary(t, keyring.KeyAlice, blockState, testGenesisHeader, sizeOfChain)
+	chainB := issueBlocksWithBABEPrimary(t, keyring.KeyBob, blockState, chainA[1], sizeOfChain)
+
+	chainABlock4 := chainA[3]
+	err = gs.addPause(chainABlock4, types.GrandpaPause{Delay: 2})
+	require.NoError(t, err)
+
+	err = gs.addPause(chainABlock4, types.GrandpaPause{Delay: 3})
+	require.ErrorIs(t, err, errDuplicateHashes)
+
+	chainABlock5 := chainA[4]
+	err = gs.addPause(chainABlock5, types.GrandpaPause{Delay: 2})
+	require.ErrorIs(t, err, errAlreadyHasPendingChange)
+
+	chainBBlock5 := chainB[2]
+	err = gs.addPause(chainBBlock5, types.GrandpaPause{Delay: 1})
+	require.NoError(t, err)
+
+	chainABlock8 := chainA[7]
+	err = gs.addResume(chainABlock8, types.GrandpaResume{Delay: 1})
+	require.NoError(t, err)
+
+	chainBBlock8 := chainB[5]
+	err = gs.addResume(chainBBlock8, types.GrandpaResume{Delay: 1})
+	require.NoError(t, err)
+
+	paused, err := gs.IsVotingPaused()
+	require.NoError(t, err)
+	require.False(t, paused)
+
+	// finalising chain A block 5 prunes the changes on chain B
+	// but the pause on chain A is not effective yet
+	chainABlock5Hash := chainABlock5.Hash()
+	err = blockState.SetFinalisedHash(chainABlock5Hash, 1, 0)
+	require.NoError(t, err)
+
+	err = gs.ApplyScheduledPause(chainABlock5)
+	require.NoError(t, err)
+	require.Len(t, *gs.scheduledPauses, 1)
+	require.Len(t, *gs.scheduledResumes, 1)
+
+	paused, err = gs.IsVotingPaused()
+	require.NoError(t, err)
+	require.False(t, paused)
+
+	chainABlock6 := chainA[5]
+	err = blockState.SetFinalisedHash(chainABlock6.Hash(), 2, 0)
+	require.NoError(t, err)
+
+	err = gs.ApplyScheduledPause(chainABlock6)
+	require.NoError(t, err)
+	require.Empty(t, *gs.scheduledPauses)
+
+	pause, err := gs.GetNextPause()
+	require.NoError(t, err)
+	require.Equal(t, uint(6), pause)
+
+	paused, err = gs.IsVotingPaused()
+	require.NoError(t, err)
+	require.True(t, paused)
+
+	// importing a block before the resume is effective does nothing
+	err = gs.ApplyScheduledResume(chainABlock8)
+	require.NoError(t, err)
+	require.Len(t, *gs.scheduledResumes, 1)
+
+	paused, err = gs.IsVotingPaused()
+	require.NoError(t, err)
+	require.True(t, paused)
+
+	// a resume on a fork which is not our best chain is not applied
+	chainC := issueBlocksWithBABEPrimary(t, keyring.KeyCharlie, blockState, chainA[6], 2)
+	chainCBlock8 := chainC[0]
+	err = gs.addResume(chainCBlock8, types.GrandpaResume{Delay: 0})
+	require.NoError(t, err)
+
+	err = gs.ApplyScheduledResume(chainCBlock8)
+	require.NoError(t, err)
+	require.Len(t, *gs.scheduledResumes, 2)
+
+	paused, err = gs.IsVotingPaused()
+	require.NoError(t, err)
+	require.True(t, paused)
+
+	// importing the best block reaching the effective block of the resume
+	// applies it without any block being finalized, so voting starts again
+	chainABlock9 := chainA[8]
+	require.Equal(t, chainA[len(chainA)-1].Hash(), blockState.BestBlockHash())
+	err = gs.ApplyScheduledResume(chainA[len(chainA)-1])
+	require.NoError(t, err)
+	require.Len(t, *gs.scheduledResumes, 1)
+
+	resume, err := gs.GetNextResume()
+	require.NoError(t, err)
+	require.Equal(t, uint(9), resume)
+
+	paused, err = gs.IsVotingPaused()
+	require.NoError(t, err)
+	require.False(t, paused)
+
+	resumeHash, err := gs.db.Get(resumeHashKey)
+	require.NoError(t, err)
+	require.Equal(t, chainABlock9.Hash(), common.BytesToHash(resumeHash))
+
+	// a resume stored for a block which is not on our best chain is ignored
+	err = gs.db.Put(resumeHashKey, chainC[1].Hash().ToBytes())
+	require.NoError(t, err)
+
+	paused, err = gs.IsVotingPaused()
+	require.NoError(t, err)
+	require.True(t, paused)
+}
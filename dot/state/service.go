@@ -40,6 +40,9 @@ type Service struct {
 	PrunerCfg pruner.Config
 	Telemetry telemetry.Client
 
+	transactionIndexRetention uint32
+	runtimeLogLevel           log.Level
+
 	// Below are for testing only.
 	BabeThresholdNumerator   uint64
 	BabeThresholdDenominator uint64
@@ -52,6 +55,12 @@ type Config struct {
 	PrunerCfg pruner.Config
 	Telemetry telemetry.Client
 	Metrics   metrics.IntervalConfig
+	// TransactionIndexRetention is the number of finalised blocks for which
+	// indexed transactions are kept. Zero means they are kept forever.
+	TransactionIndexRetention uint32
+	// RuntimeLogLevel is the log level of the runtime instances
+	// created on runtime code changes.
+	RuntimeLogLevel log.Level
 }
 
 // NewService create a new instance of Service
@@ -68,6 +77,9 @@ func NewService(config Config) *Service {
 		closeCh:   make(chan interface{}),
 		PrunerCfg: config.PrunerCfg,
 		Telemetry: config.Telemetry,
+
+		transactionIndexRetention: config.TransactionIndexRetention,
+		runtimeLogLevel:           config.RuntimeLogLevel,
 	}
 }
 
@@ -121,6 +133,8 @@ func (s *Service) Start() (err error) {
 	if err != nil {
 		return fmt.Errorf("failed to create block state: %w", err)
 	}
+	s.Block.transactionIndexRetention = s.transactionIndexRetention
+	s.Block.runtimeLogLevel = s.runtimeLogLevel
 
 	// retrieve latest header
 	bestHeader, err := s.Block.GetHighestFinalisedHeader()
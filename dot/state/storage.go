@@ -305,3 +305,10 @@ func (s *StorageState) GenerateTrieProof(stateRoot common.Hash, keys [][]byte) (
 	encodedProofNodes [][]byte, err error) {
 	return proof.Generate(stateRoot[:], keys, s.db)
 }
+
+// GenerateTrieProofWithAbsentKeys returns the proofs related to the keys on the state
+// root trie, where the keys may be absent from the trie.
+func (s *StorageState) GenerateTrieProofWithAbsentKeys(stateRoot common.Hash, keys [][]byte) (
+	encodedProofNodes [][]byte, err error) {
+	return proof.GenerateWithAbsentKeys(stateRoot[:], keys, s.db)
+}
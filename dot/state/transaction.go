@@ -14,11 +14,19 @@
 	"github.com/ChainSafe/gossamer/lib/transaction"
 )
 
+// banDuration is the duration an extrinsic removed through the RPC stays banned from
+// being re-imported into the transaction pool and queue.
+const banDuration = 30 * time.Minute
+
 // TransactionState represents the queue of transactions
 type TransactionState struct {
 	queue *transaction.PriorityQueue
 	pool  *transaction.Pool
 
+	// banned maps the hash of a banned extrinsic to the time its ban expires.
+	banned     map[common.Hash]time.Time
+	bannedLock sync.Mutex
+
 	// notifierChannels are used to notify transaction status. It maps a channel to
 	// hex string of the extrinsic it is supposed to notify about.
 	notifierChannels map[chan transaction.Status]string
@@ -32,6 +40,7 @@ func NewTransactionState(telemetry telemetry.Client) *TransactionState {
 	return &TransactionState{
 		queue:            transaction.NewPriorityQueue(),
 		pool:             transaction.NewPool(),
+		banned:           make(map[common.Hash]time.Time),
 		notifierChannels: make(map[chan transaction.Status]string),
 		telemetry:        telemetry,
 	}
@@ -81,6 +90,43 @@ func (s *TransactionState) RemoveExtrinsic(ext types.Extrinsic) {
 	s.queue.RemoveExtrinsic(ext)
 }
 
+// RemoveExtrinsicAndBan removes the extrinsic with the given hash from the queue and pool,
+// and temporarily bans it from being re-imported. It returns true if the extrinsic was
+// present in the queue or pool.
+func (s *TransactionState) RemoveExtrinsicAndBan(hash common.Hash) (removed bool) {
+	now := time.Now()
+	s.bannedLock.Lock()
+	for bannedHash, expiry := range s.banned {
+		if now.After(expiry) {
+			delete(s.banned, bannedHash)
+		}
+	}
+	s.banned[hash] = now.Add(banDuration)
+	s.bannedLock.Unlock()
+
+	removedFromPool := s.pool.Remove(hash)
+	removedFromQueue := s.queue.RemoveExtrinsicByHash(hash)
+	return removedFromPool || removedFromQueue
+}
+
+// IsBanned returns true if the extrinsic with the given hash is temporarily banned.
+func (s *TransactionState) IsBanned(hash common.Hash) bool {
+	s.bannedLock.Lock()
+	defer s.bannedLock.Unlock()
+
+	expiry, ok := s.banned[hash]
+	if !ok {
+		return false
+	}
+
+	if time.Now().After(expiry) {
+		delete(s.banned, hash)
+		return false
+	}
+
+	return true
+}
+
 // RemoveExtrinsicFromPool removes an extrinsic from the pool
 func (s *TransactionState) RemoveExtrinsicFromPool(ext types.Extrinsic) {
 	s.pool.Remove(ext.Hash())
@@ -0,0 +1,319 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+var (
+	// indexedTransactionPrefix + data hash -> indexed transaction
+	indexedTransactionPrefix = []byte("itx")
+	// blockIndexedTransactionsPrefix + block hash -> hashes of the data indexed by the block
+	blockIndexedTransactionsPrefix = []byte("bit")
+
+	// ErrIndexedTransactionNotFound is returned when no indexed transaction is stored for a hash.
+	ErrIndexedTransactionNotFound = errors.New("indexed transaction not found")
+
+	errExtrinsicIndexOutOfRange = errors.New("extrinsic index out of range")
+	errIndexedDataSizeTooLarge  = errors.New("indexed data size is larger than the extrinsic")
+	errIndexedDataHashMismatch  = errors.New("indexed data hash mismatch")
+)
+
+// indexedTransaction is the data indexed by one or more blocks,
+// together with the number of blocks referencing it.
+type indexedTransaction struct {
+	References uint32
+	Data       []byte
+}
+
+// StoreIndexedTransactions stores the data indexed by the extrinsics of the given block,
+// as recorded by the runtime in the transaction index operations given.
+func (bs *BlockState) StoreIndexedTransactions(block *types.Block,
+	operations []rtstorage.TransactionIndexOperation) error {
+	bs.Lock()
+	defer bs.Unlock()
+
+	blockHash := block.Header.Hash()
+	stored, err := bs.db.Has(prefixKey(blockHash, blockIndexedTransactionsPrefix))
+	if err != nil {
+		return err
+	} else if stored {
+		return nil
+	}
+
+	extrinsics, err := block.Body.AsEncodedExtrinsics()
+	if err != nil {
+		return fmt.Errorf("encoding extrinsics: %w", err)
+	}
+
+	hashes := make([]common.Hash, 0, len(operations))
+	updated := make(map[common.Hash]*indexedTransaction, len(operations))
+	for _, operation := range operations {
+		if operation.Extrinsic >= uint32(len(extrinsics)) {
+			return fmt.Errorf("%w: %d for block with %d extrinsics",
+				errExtrinsicIndexOutOfRange, operation.Extrinsic, len(extrinsics))
+		}
+
+		indexed, err := bs.getIndexedTransactionToUpdate(updated, operation.Hash)
+		if err != nil {
+			return err
+		}
+
+		if indexed == nil {
+			if operation.Renew {
+				logger.Debugf("cannot renew unknown indexed data with hash %s", operation.Hash)
+				continue
+			}
+
+			data, err := indexedData(extrinsics[operation.Extrinsic], operation)
+			if err != nil {
+				return err
+			}
+			indexed = &indexedTransaction{Data: data}
+			updated[operation.Hash] = indexed
+		}
+
+		indexed.References++
+		hashes = append(hashes, operation.Hash)
+	}
+
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	batch := bs.db.NewBatch()
+	for hash, indexed := range updated {
+		encoded, err := scale.Marshal(*indexed)
+		if err != nil {
+			return fmt.Errorf("encoding indexed transaction: %w", err)
+		}
+
+		err = batch.Put(prefixKey(hash, indexedTransactionPrefix), encoded)
+		if err != nil {
+			return err
+		}
+	}
+
+	encodedHashes, err := scale.Marshal(hashes)
+	if err != nil {
+		return fmt.Errorf("encoding indexed transaction hashes: %w", err)
+	}
+
+	err = batch.Put(prefixKey(blockHash, blockIndexedTransactionsPrefix), encodedHashes)
+	if err != nil {
+		return err
+	}
+
+	return batch.Flush()
+}
+
+// GetIndexedTransaction returns the indexed data with the given hash.
+func (bs *BlockState) GetIndexedTransaction(hash common.Hash) (data []byte, err error) {
+	indexed, err := bs.getIndexedTransaction(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return indexed.Data, nil
+}
+
+// GetIndexedBody returns the data indexed by the extrinsics of the block with the given hash,
+// in the order of the extrinsics. It returns nil if the block does not index any data.
+func (bs *BlockState) GetIndexedBody(hash common.Hash) (indexedBody [][]byte, err error) {
+	hashes, err := bs.getBlockIndexedTransactionHashes(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	indexedBody = make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		indexedBody[i], err = bs.GetIndexedTransaction(hash)
+		if err != nil {
+			return nil, fmt.Errorf("getting indexed transaction %d: %w", i, err)
+		}
+	}
+
+	return indexedBody, nil
+}
+
+// deleteIndexedTransactions removes the references of the block with the given hash
+// to its indexed data, deleting data no longer referenced by any block.
+func (bs *BlockState) deleteIndexedTransactions(blockHash common.Hash) error {
+	hashes, err := bs.getBlockIndexedTransactionHashes(blockHash)
+	if err != nil {
+		return err
+	}
+
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	updated := make(map[common.Hash]*indexedTransaction, len(hashes))
+	for _, hash := range hashes {
+		indexed, err := bs.getIndexedTransactionToUpdate(updated, hash)
+		if err != nil {
+			return err
+		}
+
+		if indexed == nil || indexed.References == 0 {
+			continue
+		}
+		indexed.References--
+	}
+
+	batch := bs.db.NewBatch()
+	for hash, indexed := range updated {
+		key := prefixKey(hash, indexedTransactionPrefix)
+		if indexed.References == 0 {
+			err = batch.Del(key)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		encoded, err := scale.Marshal(*indexed)
+		if err != nil {
+			return fmt.Errorf("encoding indexed transaction: %w", err)
+		}
+
+		err = batch.Put(key, encoded)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = batch.Del(prefixKey(blockHash, blockIndexedTransactionsPrefix))
+	if err != nil {
+		return err
+	}
+
+	return batch.Flush()
+}
+
+// pruneIndexedTransactions deletes the indexed data references of the finalised blocks
+// which fell out of the transaction index retention period when finalising blocks
+// from the previously finalised block hash given to the finalised block number given.
+func (bs *BlockState) pruneIndexedTransactions(previousFinalisedHash common.Hash, finalised uint) error {
+	retention := uint(bs.transactionIndexRetention)
+	if retention == 0 || finalised < retention {
+		return nil
+	}
+
+	previousFinalisedHeader, err := bs.GetHeader(previousFinalisedHash)
+	if err != nil {
+		return fmt.Errorf("getting previously finalised header: %w", err)
+	}
+
+	start := uint(0)
+	if previousFinalisedHeader.Number >= retention {
+		start = previousFinalisedHeader.Number - retention + 1
+	}
+
+	for number := start; number <= finalised-retention; number++ {
+		hash, err := bs.GetHashByNumber(number)
+		if err != nil {
+			return fmt.Errorf("getting hash of block %d: %w", number, err)
+		}
+
+		err = bs.deleteIndexedTransactions(hash)
+		if err != nil {
+			return fmt.Errorf("deleting indexed transactions of block %d: %w", number, err)
+		}
+	}
+
+	return nil
+}
+
+func (bs *BlockState) getIndexedTransaction(hash common.Hash) (indexed *indexedTransaction, err error) {
+	data, err := bs.db.Get(prefixKey(hash, indexedTransactionPrefix))
+	if err != nil {
+		if errors.Is(err, chaindb.ErrKeyNotFound) {
+			return nil, fmt.Errorf("%w: for hash %s", ErrIndexedTransactionNotFound, hash)
+		}
+		return nil, err
+	}
+
+	indexed = new(indexedTransaction)
+	err = scale.Unmarshal(data, indexed)
+	if err != nil {
+		return nil, fmt.Errorf("decoding indexed transaction: %w", err)
+	}
+
+	return indexed, nil
+}
+
+// getIndexedTransactionToUpdate returns the indexed transaction with the given hash from
+// the updated map, or from the database in which case it is added to the updated map.
+// It returns nil if the indexed transaction is not found.
+func (bs *BlockState) getIndexedTransactionToUpdate(updated map[common.Hash]*indexedTransaction,
+	hash common.Hash) (indexed *indexedTransaction, err error) {
+	indexed, ok := updated[hash]
+	if ok {
+		return indexed, nil
+	}
+
+	indexed, err = bs.getIndexedTransaction(hash)
+	if errors.Is(err, ErrIndexedTransactionNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	updated[hash] = indexed
+	return indexed, nil
+}
+
+func (bs *BlockState) getBlockIndexedTransactionHashes(blockHash common.Hash) (hashes []common.Hash, err error) {
+	data, err := bs.db.Get(prefixKey(blockHash, blockIndexedTransactionsPrefix))
+	if err != nil {
+		if errors.Is(err, chaindb.ErrKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	err = scale.Unmarshal(data, &hashes)
+	if err != nil {
+		return nil, fmt.Errorf("decoding indexed transaction hashes: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// indexedData returns the data indexed by the operation given, located at the end
+// of the encoded extrinsic given, and verifies it matches the operation hash.
+func indexedData(encodedExtrinsic []byte, operation rtstorage.TransactionIndexOperation) (
+	data []byte, err error) {
+	if operation.Size > uint32(len(encodedExtrinsic)) {
+		return nil, fmt.Errorf("%w: %d bytes for extrinsic %d of %d bytes",
+			errIndexedDataSizeTooLarge, operation.Size, operation.Extrinsic, len(encodedExtrinsic))
+	}
+
+	data = encodedExtrinsic[len(encodedExtrinsic)-int(operation.Size):]
+	hash, err := common.Blake2bHash(data)
+	if err != nil {
+		return nil, fmt.Errorf("hashing indexed data: %w", err)
+	}
+
+	if hash != operation.Hash {
+		return nil, fmt.Errorf("%w: expected %s but computed %s",
+			errIndexedDataHashMismatch, operation.Hash, hash)
+	}
+
+	data = append([]byte(nil), data...)
+	return data, nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIndexingBlock(t *testing.T, parentHash common.Hash, number uint,
+	extrinsics ...types.Extrinsic) *types.Block {
+	t.Helper()
+	return &types.Block{
+		Header: types.Header{
+			ParentHash: parentHash,
+			Number:     number,
+			StateRoot:  trie.EmptyHash,
+			Digest:     createPrimaryBABEDigest(t),
+		},
+		Body: append(types.Body{}, extrinsics...),
+	}
+}
+
+func newIndexOperation(t *testing.T, extrinsic uint32, data []byte) rtstorage.TransactionIndexOperation {
+	t.Helper()
+	hash, err := common.Blake2bHash(data)
+	require.NoError(t, err)
+	return rtstorage.TransactionIndexOperation{
+		Extrinsic: extrinsic,
+		Hash:      hash,
+		Size:      uint32(len(data)),
+	}
+}
+
+func Test_BlockState_StoreIndexedTransactions(t *testing.T) {
+	t.Parallel()
+
+	block := newIndexingBlock(t, testGenesisHeader.Hash(), 1,
+		types.Extrinsic{1, 2, 3, 4}, types.Extrinsic{5, 6})
+
+	testCases := map[string]struct {
+		operations []rtstorage.TransactionIndexOperation
+		errWrapped error
+		errMessage string
+	}{
+		"extrinsic index out of range": {
+			operations: []rtstorage.TransactionIndexOperation{{Extrinsic: 2}},
+			errWrapped: errExtrinsicIndexOutOfRange,
+			errMessage: "extrinsic index out of range: 2 for block with 2 extrinsics",
+		},
+		"size too large": {
+			operations: []rtstorage.TransactionIndexOperation{{Extrinsic: 1, Size: 4}},
+			errWrapped: errIndexedDataSizeTooLarge,
+			errMessage: "indexed data size is larger than the extrinsic: " +
+				"4 bytes for extrinsic 1 of 3 bytes",
+		},
+		"hash mismatch": {
+			operations: []rtstorage.TransactionIndexOperation{{Extrinsic: 1, Size: 1}},
+			errWrapped: errIndexedDataHashMismatch,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			bs := newTestBlockState(t, newTriesEmpty())
+
+			err := bs.StoreIndexedTransactions(block, testCase.operations)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
+
+func Test_BlockState_IndexedTransactions(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+	bs.transactionIndexRetention = 2
+
+	data := []byte{3, 4}
+	otherData := []byte{6}
+	block1 := newIndexingBlock(t, testGenesisHeader.Hash(), 1,
+		types.Extrinsic{1, 2, 3, 4}, types.Extrinsic{5, 6})
+	block2 := newIndexingBlock(t, block1.Header.Hash(), 2, types.Extrinsic{7})
+	block3 := newIndexingBlock(t, block2.Header.Hash(), 3)
+	block4 := newIndexingBlock(t, block3.Header.Hash(), 4)
+	for _, block := range []*types.Block{block1, block2, block3, block4} {
+		err := bs.AddBlock(block)
+		require.NoError(t, err)
+	}
+
+	dataOperation := newIndexOperation(t, 0, data)
+	otherDataOperation := newIndexOperation(t, 1, otherData)
+	err := bs.StoreIndexedTransactions(block1, []rtstorage.TransactionIndexOperation{
+		dataOperation, otherDataOperation,
+	})
+	require.NoError(t, err)
+
+	renewOperation := rtstorage.TransactionIndexOperation{Hash: dataOperation.Hash, Renew: true}
+	unknownRenewOperation := rtstorage.TransactionIndexOperation{Hash: common.Hash{1}, Renew: true}
+	err = bs.StoreIndexedTransactions(block2, []rtstorage.TransactionIndexOperation{
+		renewOperation, unknownRenewOperation,
+	})
+	require.NoError(t, err)
+
+	indexed, err := bs.GetIndexedTransaction(dataOperation.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, data, indexed)
+
+	indexedBody, err := bs.GetIndexedBody(block1.Header.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{data, otherData}, indexedBody)
+
+	indexedBody, err = bs.GetIndexedBody(block2.Header.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{data}, indexedBody)
+
+	indexedBody, err = bs.GetIndexedBody(block3.Header.Hash())
+	require.NoError(t, err)
+	assert.Nil(t, indexedBody)
+
+	// finalising block 3 prunes the indexed transactions of block 1
+	err = bs.SetFinalisedHash(block3.Header.Hash(), 1, 0)
+	require.NoError(t, err)
+
+	_, err = bs.GetIndexedTransaction(otherDataOperation.Hash)
+	assert.ErrorIs(t, err, ErrIndexedTransactionNotFound)
+	indexed, err = bs.GetIndexedTransaction(dataOperation.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, data, indexed)
+
+	// finalising block 4 prunes the indexed transactions of block 2
+	err = bs.SetFinalisedHash(block4.Header.Hash(), 2, 0)
+	require.NoError(t, err)
+
+	_, err = bs.GetIndexedTransaction(dataOperation.Hash)
+	assert.ErrorIs(t, err, ErrIndexedTransactionNotFound)
+	indexedBody, err = bs.GetIndexedBody(block2.Header.Hash())
+	require.NoError(t, err)
+	assert.Nil(t, indexedBody)
+}
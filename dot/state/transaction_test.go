@@ -125,3 +125,40 @@ func TestTransactionState_NotifierChannels(t *testing.T) {
 	require.Equal(t, expectedFutureCount, futureCount)
 	require.Equal(t, expectedReadyCount, readyCount)
 }
+
+func TestTransactionState_RemoveExtrinsicAndBan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockClient(ctrl)
+	telemetryMock.EXPECT().SendMessage(gomock.Any()).AnyTimes()
+
+	ts := NewTransactionState(telemetryMock)
+
+	inPool := &transaction.ValidTransaction{
+		Extrinsic: []byte("a"),
+		Validity:  &transaction.Validity{Priority: 1},
+	}
+	inQueue := &transaction.ValidTransaction{
+		Extrinsic: []byte("b"),
+		Validity:  &transaction.Validity{Priority: 1},
+	}
+
+	poolHash := ts.AddToPool(inPool)
+	queueHash, err := ts.Push(inQueue)
+	require.NoError(t, err)
+	unknownHash := common.Hash{1}
+
+	require.True(t, ts.RemoveExtrinsicAndBan(poolHash))
+	require.True(t, ts.RemoveExtrinsicAndBan(queueHash))
+	require.False(t, ts.RemoveExtrinsicAndBan(unknownHash))
+
+	require.Empty(t, ts.Pending())
+	require.True(t, ts.IsBanned(poolHash))
+	require.True(t, ts.IsBanned(queueHash))
+	require.True(t, ts.IsBanned(unknownHash))
+	require.False(t, ts.IsBanned(common.Hash{2}))
+
+	// expire the ban for the unknown hash
+	ts.banned[unknownHash] = time.Now().Add(-time.Second)
+	require.False(t, ts.IsBanned(unknownHash))
+	require.NotContains(t, ts.banned, unknownHash)
+}
@@ -5,10 +5,10 @@
 
 import (
 	"context"
-	"crypto/rand"
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -28,6 +28,9 @@
 const (
 	// maxWorkers is the maximum number of parallel sync workers
 	maxWorkers = 12
+
+	// stateSyncRetryDelay is the delay before retrying to download the state
+	stateSyncRetryDelay = 10 * time.Second
 )
 
 var _ ChainSync = &chainSync{}
@@ -104,6 +107,9 @@ type ChainSync interface {
 
 	// getHighestBlock returns the highest block or an error
 	getHighestBlock() (highestBlock uint, err error)
+
+	// called when the connection to a peer is closed
+	peerDisconnected(p peer.ID)
 }
 
 type chainSync struct {
@@ -125,6 +131,10 @@ type chainSync struct {
 	peerState   map[peer.ID]*peerState
 	ignorePeers map[peer.ID]struct{}
 
+	// tracks the statistics of the block requests made to our peers,
+	// used to select the peers to sync from
+	peerScores *peerScores
+
 	// current workers that are attempting to obtain blocks
 	workerState *workerState
 
@@ -156,6 +166,18 @@ type chainSync struct {
 
 	finalisedCh <-chan *types.FinalisationInfo
 
+	// warpSyncer is set if warp sync is enabled, to jump to
+	// the latest finalised block before syncing
+	warpSyncer *warpSyncer
+
+	// finalisedHeaderSyncer is set if state sync is enabled without
+	// warp sync, to jump to a recent finalised block before syncing
+	finalisedHeaderSyncer *finalisedHeaderSyncer
+
+	// stateSyncer is set if state sync is enabled, to download the
+	// state of the highest finalised block before syncing
+	stateSyncer *stateSyncer
+
 	minPeers         int
 	maxWorkerRetries uint16
 	slotDuration     time.Duration
@@ -167,12 +189,15 @@ type chainSync struct {
 }
 
 type chainSyncConfig struct {
-	bs                 BlockState
-	net                Network
-	readyBlocks        *blockQueue
-	pendingBlocks      DisjointBlockSet
-	minPeers, maxPeers int
-	slotDuration       time.Duration
+	bs                    BlockState
+	net                   Network
+	readyBlocks           *blockQueue
+	pendingBlocks         DisjointBlockSet
+	minPeers, maxPeers    int
+	slotDuration          time.Duration
+	warpSyncer            *warpSyncer
+	finalisedHeaderSyncer *finalisedHeaderSyncer
+	stateSyncer           *stateSyncer
 }
 
 func newChainSync(cfg *chainSyncConfig) *chainSync {
@@ -182,27 +207,31 @@ func newChainSync(cfg *chainSyncConfig) *chainSync {
 	logSyncTicker := time.NewTicker(logSyncPeriod)
 
 	return &chainSync{
-		ctx:              ctx,
-		cancel:           cancel,
-		blockState:       cfg.bs,
-		network:          cfg.net,
-		workQueue:        make(chan *peerState, 1024),
-		resultQueue:      make(chan *worker, 1024),
-		peerState:        make(map[peer.ID]*peerState),
-		ignorePeers:      make(map[peer.ID]struct{}),
-		workerState:      newWorkerState(),
-		readyBlocks:      cfg.readyBlocks,
-		pendingBlocks:    cfg.pendingBlocks,
-		state:            bootstrap,
-		handler:          newBootstrapSyncer(cfg.bs),
-		benchmarker:      newSyncBenchmarker(syncSamplesToKeep),
-		finalisedCh:      cfg.bs.GetFinalisedNotifierChannel(),
-		minPeers:         cfg.minPeers,
-		maxWorkerRetries: uint16(cfg.maxPeers),
-		slotDuration:     cfg.slotDuration,
-		logSyncTicker:    logSyncTicker,
-		logSyncTickerC:   logSyncTicker.C,
-		logSyncDone:      make(chan struct{}),
+		ctx:                   ctx,
+		cancel:                cancel,
+		blockState:            cfg.bs,
+		network:               cfg.net,
+		workQueue:             make(chan *peerState, 1024),
+		resultQueue:           make(chan *worker, 1024),
+		peerState:             make(map[peer.ID]*peerState),
+		ignorePeers:           make(map[peer.ID]struct{}),
+		peerScores:            newPeerScores(),
+		workerState:           newWorkerState(),
+		readyBlocks:           cfg.readyBlocks,
+		pendingBlocks:         cfg.pendingBlocks,
+		state:                 bootstrap,
+		handler:               newBootstrapSyncer(cfg.bs),
+		benchmarker:           newSyncBenchmarker(syncSamplesToKeep),
+		finalisedCh:           cfg.bs.GetFinalisedNotifierChannel(),
+		minPeers:              cfg.minPeers,
+		maxWorkerRetries:      uint16(cfg.maxPeers),
+		slotDuration:          cfg.slotDuration,
+		warpSyncer:            cfg.warpSyncer,
+		finalisedHeaderSyncer: cfg.finalisedHeaderSyncer,
+		stateSyncer:           cfg.stateSyncer,
+		logSyncTicker:         logSyncTicker,
+		logSyncTickerC:        logSyncTicker.C,
+		logSyncDone:           make(chan struct{}),
 	}
 }
 
@@ -218,6 +247,39 @@ func (cs *chainSync) start() {
 		time.Sleep(time.Millisecond * 100)
 	}
 
+	if cs.warpSyncer != nil {
+		err := cs.warpSyncer.sync(cs.peersByBestNumber())
+		if err != nil {
+			logger.Warnf("failed to warp sync, syncing from the highest finalised block: %s", err)
+		}
+	}
+
+	if cs.finalisedHeaderSyncer != nil {
+		err := cs.finalisedHeaderSyncer.sync(cs.peerStatesByBestNumber())
+		if err != nil {
+			logger.Warnf("failed to get a recent finalised header, "+
+				"downloading the state of the highest finalised block: %s", err)
+		}
+	}
+
+	if cs.stateSyncer != nil {
+		// blocks cannot be executed without the state of their parent,
+		// so the state download is retried until it succeeds.
+		for {
+			err := cs.stateSyncer.sync(cs.peersByBestNumber())
+			if err == nil {
+				break
+			}
+			logger.Warnf("failed to download state, retrying in %s: %s", stateSyncRetryDelay, err)
+
+			select {
+			case <-time.After(stateSyncRetryDelay):
+			case <-cs.ctx.Done():
+				return
+			}
+		}
+	}
+
 	isSyncedGauge.Set(float64(cs.state))
 
 	pendingBlockDoneCh := make(chan struct{})
@@ -228,6 +290,34 @@ func (cs *chainSync) start() {
 	go cs.logSyncSpeed()
 }
 
+// peersByBestNumber returns the peers we know the head of,
+// sorted by descending best block number.
+func (cs *chainSync) peersByBestNumber() []peer.ID {
+	peerStates := cs.peerStatesByBestNumber()
+	peers := make([]peer.ID, len(peerStates))
+	for i, ps := range peerStates {
+		peers[i] = ps.who
+	}
+	return peers
+}
+
+// peerStatesByBestNumber returns the heads of the peers we know,
+// sorted by descending best block number.
+func (cs *chainSync) peerStatesByBestNumber() []peerState {
+	cs.RLock()
+	defer cs.RUnlock()
+
+	peerStates := make([]peerState, 0, len(cs.peerState))
+	for _, ps := range cs.peerState {
+		peerStates = append(peerStates, *ps)
+	}
+
+	sort.Slice(peerStates, func(i, j int) bool {
+		return peerStates[i].number > peerStates[j].number
+	})
+	return peerStates
+}
+
 func (cs *chainSync) stop() {
 	if cs.pendingBlockDoneCh != nil {
 		close(cs.pendingBlockDoneCh)
@@ -341,6 +431,11 @@ func (cs *chainSync) setPeerHead(p peer.ID, hash common.Hash, number uint) error
 	return nil
 }
 
+// peerDisconnected removes the sync statistics of the disconnected peer.
+func (cs *chainSync) peerDisconnected(p peer.ID) {
+	cs.peerScores.remove(p)
+}
+
 func (cs *chainSync) logSyncSpeed() {
 	defer close(cs.logSyncDone)
 	defer cs.logSyncTicker.Stop()
@@ -664,42 +759,60 @@ func (cs *chainSync) dispatchWorker(w *worker) {
 		return
 	}
 
+	// keep requesting blocks from the last peer which answered successfully
+	var preferred peer.ID
 	for _, req := range reqs {
-		// TODO: if we find a good peer, do sync with them, right now it re-selects a peer each time (#1399)
-		if err := cs.doSync(req, w.peersTried); err != nil {
+		who, err := cs.doSync(req, w.peersTried, preferred)
+		if err != nil {
 			// failed to sync, set worker error and put into result queue
 			w.err = err
 			return
 		}
+		preferred = who
 	}
 }
 
-func (cs *chainSync) doSync(req *network.BlockRequestMessage, peersTried map[peer.ID]struct{}) *workerError {
+// doSync sends the block request to one of the peers likely to have the requested blocks,
+// preferring the given peer if it is one of them, and returns the peer which answered.
+func (cs *chainSync) doSync(req *network.BlockRequestMessage, peersTried map[peer.ID]struct{},
+	preferred peer.ID) (who peer.ID, workerErr *workerError) {
 	// determine which peers have the blocks we want to request
 	peers := cs.determineSyncPeers(req, peersTried)
 
 	if len(peers) == 0 {
-		return &workerError{
+		return "", &workerError{
 			err: errNoPeers,
 		}
 	}
 
+	who, err := cs.peerScores.selectPeer(peers, preferred)
+	if err != nil {
+		return "", &workerError{
+			err: fmt.Errorf("selecting peer: %w", err),
+		}
+	}
+
 	// send out request and potentially receive response, error if timeout
-	logger.Tracef("sending out block request: %s", req)
+	logger.Tracef("sending out block request to peer %s: %s", who, req)
 
-	// TODO: use scoring to determine what peer to try to sync from first (#1399)
-	idx, _ := rand.Int(rand.Reader, big.NewInt(int64(len(peers))))
-	who := peers[idx.Int64()]
-	resp, err := cs.network.DoBlockRequest(who, req)
+	start := time.Now()
+	resp, size, err := cs.network.DoBlockRequest(who, req)
+	latency := time.Since(start)
 	if err != nil {
-		return &workerError{
+		cs.peerScores.recordFailure(who)
+		return "", &workerError{
 			err: err,
 			who: who,
 		}
 	}
 
 	if resp == nil {
-		return &workerError{
+		cs.peerScores.recordInvalidResponse(who)
+		cs.network.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.BadMessageValue,
+			Reason: peerset.BadMessageReason,
+		}, who)
+		return "", &workerError{
 			err: errNilResponse,
 			who: who,
 		}
@@ -711,8 +824,29 @@ func (cs *chainSync) doSync(req *network.BlockRequestMessage, peersTried map[pee
 	}
 
 	// perform some pre-validation of response, error if failure
-	if err := cs.validateResponse(req, resp, who); err != nil {
-		return &workerError{
+	err = cs.validateResponse(req, resp, who)
+	switch {
+	case errors.Is(err, errEmptyBlockData),
+		errors.Is(err, errNilBlockData),
+		errors.Is(err, errNilBodyInResponse),
+		errors.Is(err, errResponseIsNotChain):
+		cs.peerScores.recordInvalidResponse(who)
+		cs.network.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.BadMessageValue,
+			Reason: peerset.BadMessageReason,
+		}, who)
+	case errors.Is(err, errNilHeaderInResponse),
+		errors.Is(err, errUnknownBlockForJustification):
+		// the peer is already reported by validateResponse
+		cs.peerScores.recordInvalidResponse(who)
+	case err != nil:
+		cs.peerScores.recordFailure(who)
+	default:
+		cs.peerScores.recordResponse(who, latency, size)
+	}
+
+	if err != nil {
+		return "", &workerError{
 			err: err,
 			who: who,
 		}
@@ -726,7 +860,7 @@ func (cs *chainSync) doSync(req *network.BlockRequestMessage, peersTried map[pee
 		cs.handleReadyBlock(bd)
 	}
 
-	return nil
+	return who, nil
 }
 
 func (cs *chainSync) handleReadyBlock(bd *types.BlockData) {
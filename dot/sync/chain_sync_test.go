@@ -404,6 +404,10 @@ func TestChainSync_sync_bootstrap_withWorkerError(t *testing.T) {
 		Direction:     0,
 		Max:           &max,
 	})
+	mockNetwork.EXPECT().ReportPeer(peerset.ReputationChange{
+		Value:  peerset.BadMessageValue,
+		Reason: peerset.BadMessageReason,
+	}, peer.ID("noot"))
 	cs.network = mockNetwork
 
 	go cs.sync()
@@ -902,7 +906,7 @@ func TestChainSync_doSync(t *testing.T) {
 	mockBlockState.EXPECT().HasHeader(common.Hash{}).Return(true, nil).Times(2)
 	cs.blockState = mockBlockState
 
-	workerErr := cs.doSync(req, make(map[peer.ID]struct{}))
+	_, workerErr := cs.doSync(req, make(map[peer.ID]struct{}), "")
 	require.NotNil(t, workerErr)
 	require.Equal(t, errNoPeers, workerErr.err)
 
@@ -920,9 +924,13 @@ func TestChainSync_doSync(t *testing.T) {
 		Direction:     0,
 		Max:           &max1,
 	})
+	mockNetwork.EXPECT().ReportPeer(peerset.ReputationChange{
+		Value:  peerset.BadMessageValue,
+		Reason: peerset.BadMessageReason,
+	}, peer.ID("noot"))
 	cs.network = mockNetwork
 
-	workerErr = cs.doSync(req, make(map[peer.ID]struct{}))
+	_, workerErr = cs.doSync(req, make(map[peer.ID]struct{}), "")
 	require.NotNil(t, workerErr)
 	require.Equal(t, errNilResponse, workerErr.err)
 
@@ -945,15 +953,22 @@ func TestChainSync_doSync(t *testing.T) {
 		EndBlockHash:  nil,
 		Direction:     0,
 		Max:           &max1,
-	}).Return(resp, nil)
+	}).Return(resp, 100, nil)
 	cs.network = mockNetwork
 
-	workerErr = cs.doSync(req, make(map[peer.ID]struct{}))
+	who, workerErr := cs.doSync(req, make(map[peer.ID]struct{}), "")
 	require.Nil(t, workerErr)
+	require.Equal(t, peer.ID("noot"), who)
 	bd := readyBlocks.pop(context.Background())
 	require.NotNil(t, bd)
 	require.Equal(t, resp.BlockData[0], bd)
 
+	// the nil response and the valid response are recorded
+	stats := cs.peerScores.stats[peer.ID("noot")]
+	require.Equal(t, uint(2), stats.requests)
+	require.Equal(t, uint(1), stats.successes)
+	require.Equal(t, uint(1), stats.invalidResponses)
+
 	parent := (&types.Header{
 		Number: 2,
 	}).Hash()
@@ -986,9 +1001,9 @@ func TestChainSync_doSync(t *testing.T) {
 		EndBlockHash:  nil,
 		Direction:     1,
 		Max:           &max1,
-	}).Return(resp, nil)
+	}).Return(resp, 100, nil)
 	cs.network = mockNetwork
-	workerErr = cs.doSync(req, make(map[peer.ID]struct{}))
+	_, workerErr = cs.doSync(req, make(map[peer.ID]struct{}), "noot")
 	require.Nil(t, workerErr)
 
 	bd = readyBlocks.pop(context.Background())
@@ -998,6 +1013,40 @@ func TestChainSync_doSync(t *testing.T) {
 	bd = readyBlocks.pop(context.Background())
 	require.NotNil(t, bd)
 	require.Equal(t, resp.BlockData[1], bd)
+
+	// a response failing validation with an unlisted error is recorded as a failure
+	req.Direction = network.Ascending
+	resp = &network.BlockResponseMessage{
+		BlockData: []*types.BlockData{
+			{
+				Hash: common.Hash{0x5},
+				Header: &types.Header{
+					ParentHash: common.Hash{0x4},
+					Number:     5,
+				},
+				Body: &types.Body{},
+			},
+		},
+	}
+	mockBlockState = NewMockBlockState(ctrl)
+	mockBlockState.EXPECT().HasHeader(common.Hash{0x4}).Return(false, nil)
+	cs.blockState = mockBlockState
+	mockNetwork = NewMockNetwork(ctrl)
+	mockNetwork.EXPECT().DoBlockRequest(peer.ID("noot"), &network.BlockRequestMessage{
+		RequestedData: 19,
+		StartingBlock: *startingBlock,
+		EndBlockHash:  nil,
+		Direction:     0,
+		Max:           &max1,
+	}).Return(resp, 100, nil)
+	cs.network = mockNetwork
+	_, workerErr = cs.doSync(req, make(map[peer.ID]struct{}), "noot")
+	require.NotNil(t, workerErr)
+	require.Equal(t, errUnknownParent, workerErr.err)
+
+	require.Equal(t, uint(4), stats.requests)
+	require.Equal(t, uint(2), stats.successes)
+	require.Equal(t, uint(1), stats.invalidResponses)
 }
 
 func TestHandleReadyBlock(t *testing.T) {
@@ -38,4 +38,22 @@
 	errFailedToGetParent            = errors.New("failed to get parent header")
 	errStartAndEndMismatch          = errors.New("request start and end hash are not on the same chain")
 	errFailedToGetDescendant        = errors.New("failed to find descendant block")
+
+	// warp sync errors
+	errWarpSyncBeginNotFinalised = errors.New("warp sync begin block is not finalised")
+	errWarpSyncBeginNotCanonical = errors.New("warp sync begin block is not on the canonical chain")
+	errEmptyWarpSyncProof        = errors.New("warp sync proof is empty and not finished")
+	errWarpSyncFragmentNotAfter  = errors.New("warp sync fragment is not after the previous block")
+	errWarpSyncFailed            = errors.New("failed to warp sync from any peer")
+
+	// finalised header sync errors
+	errHeaderNumberMismatch      = errors.New("header number mismatch")
+	errNoJustifiedHeader         = errors.New("no justified header")
+	errFinalisedHeaderSyncFailed = errors.New("failed to get a finalised header from any peer")
+
+	// state sync errors
+	errEmptyStateResponse     = errors.New("state response is empty and not complete")
+	errStateEntriesNotOrdered = errors.New("state entries are not in lexicographic order")
+	errStateRootMismatch      = errors.New("state root mismatch")
+	errStateSyncFailed        = errors.New("failed to download state from any peer")
 )
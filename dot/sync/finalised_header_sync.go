@@ -0,0 +1,131 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common/variadic"
+)
+
+// finalisedHeaderSyncer jumps to a recent finalised block of the chain by
+// requesting the latest headers of peers with their justifications, without
+// warp sync proofs. The justification is verified against the current
+// authority set, so it cannot jump past an authority set change.
+type finalisedHeaderSyncer struct {
+	blockState     BlockState
+	network        Network
+	finalityGadget FinalityGadget
+}
+
+func newFinalisedHeaderSyncer(bs BlockState, net Network, fg FinalityGadget) *finalisedHeaderSyncer {
+	return &finalisedHeaderSyncer{
+		blockState:     bs,
+		network:        net,
+		finalityGadget: fg,
+	}
+}
+
+// sync requests the latest headers of each of the given peers in turn, until
+// one of them sends a justified header following the highest finalised block.
+// This header is then set as the highest finalised block.
+func (f *finalisedHeaderSyncer) sync(peers []peerState) error {
+	for _, ps := range peers {
+		err := f.syncFromPeer(ps)
+		if err == nil {
+			return nil
+		}
+		logger.Debugf("failed to get finalised header from peer %s: %s", ps.who, err)
+	}
+
+	return errFinalisedHeaderSyncFailed
+}
+
+// syncFromPeer requests the headers of the peer in descending order from its
+// best block, until a header with a justification is found or the highest
+// finalised block is reached.
+func (f *finalisedHeaderSyncer) syncFromPeer(ps peerState) error {
+	finalised, err := f.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	start := ps.hash
+	number := ps.number
+	max := uint32(maxResponseSize)
+	for number > finalised.Number {
+		req := &network.BlockRequestMessage{
+			RequestedData: network.RequestedDataHeader + network.RequestedDataJustification,
+			StartingBlock: *variadic.MustNewUint32OrHash(start),
+			Direction:     network.Descending,
+			Max:           &max,
+		}
+
+		resp, _, err := f.network.DoBlockRequest(ps.who, req)
+		if err != nil {
+			return fmt.Errorf("requesting headers: %w", err)
+		}
+
+		if len(resp.BlockData) == 0 {
+			return errEmptyBlockData
+		}
+
+		for _, bd := range resp.BlockData {
+			if bd.Header == nil {
+				return errNilHeaderInResponse
+			}
+
+			if bd.Header.Number != number {
+				f.network.ReportPeer(peerset.ReputationChange{
+					Value:  peerset.BadMessageValue,
+					Reason: peerset.BadMessageReason,
+				}, ps.who)
+				return fmt.Errorf("%w: expected number %d but got number %d",
+					errHeaderNumberMismatch, number, bd.Header.Number)
+			}
+
+			if number <= finalised.Number {
+				break
+			}
+
+			if bd.Justification != nil {
+				return f.importHeader(ps, bd.Header, *bd.Justification)
+			}
+
+			start = bd.Header.ParentHash
+			number--
+		}
+	}
+
+	return fmt.Errorf("%w: after finalised block number %d", errNoJustifiedHeader, finalised.Number)
+}
+
+// importHeader verifies the justification of the header against the current
+// authority set and sets the header as the highest finalised block.
+func (f *finalisedHeaderSyncer) importHeader(ps peerState, header *types.Header, justification []byte) error {
+	round, setID, err := f.finalityGadget.VerifyWarpSyncFragment(header, justification)
+	if err != nil {
+		f.network.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.BadJustificationValue,
+			Reason: peerset.BadJustificationReason,
+		}, ps.who)
+		return fmt.Errorf("verifying justification for block number %d: %w", header.Number, err)
+	}
+
+	err = f.blockState.SetWarpSyncFinalisedHeader(header, round, setID)
+	if err != nil {
+		return fmt.Errorf("setting finalised header: %w", err)
+	}
+
+	err = f.blockState.SetJustification(header.Hash(), justification)
+	if err != nil {
+		return fmt.Errorf("setting justification: %w", err)
+	}
+
+	logger.Infof("synced to finalised block number %d", header.Number)
+	return nil
+}
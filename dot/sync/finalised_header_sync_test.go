@@ -0,0 +1,186 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common/variadic"
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_finalisedHeaderSyncer_syncFromPeer(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	const who = peer.ID("a")
+
+	headers := make([]*types.Header, 5)
+	headers[0] = newWarpTestHeader(0)
+	for number := 1; number < len(headers); number++ {
+		headers[number] = &types.Header{
+			ParentHash: headers[number-1].Hash(),
+			Number:     uint(number),
+			Digest:     types.NewDigest(),
+		}
+		headers[number].Hash()
+	}
+	justification := []byte{1, 2, 3}
+
+	max := uint32(maxResponseSize)
+	newRequest := func(number uint) *network.BlockRequestMessage {
+		return &network.BlockRequestMessage{
+			RequestedData: network.RequestedDataHeader + network.RequestedDataJustification,
+			StartingBlock: *variadic.MustNewUint32OrHash(headers[number].Hash()),
+			Direction:     network.Descending,
+			Max:           &max,
+		}
+	}
+	best := peerState{
+		who:    who,
+		hash:   headers[4].Hash(),
+		number: 4,
+	}
+
+	testCases := map[string]struct {
+		syncerBuilder func(ctrl *gomock.Controller) *finalisedHeaderSyncer
+		peerState     peerState
+		errWrapped    error
+		errMessage    string
+	}{
+		"peer not ahead": {
+			syncerBuilder: func(ctrl *gomock.Controller) *finalisedHeaderSyncer {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(headers[4], nil)
+				return newFinalisedHeaderSyncer(blockState, nil, nil)
+			},
+			peerState:  best,
+			errWrapped: errNoJustifiedHeader,
+			errMessage: "no justified header: after finalised block number 4",
+		},
+		"request error": {
+			syncerBuilder: func(ctrl *gomock.Controller) *finalisedHeaderSyncer {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(headers[0], nil)
+				net := NewMockNetwork(ctrl)
+				net.EXPECT().DoBlockRequest(who, newRequest(4)).Return(nil, 0, errTest)
+				return newFinalisedHeaderSyncer(blockState, net, nil)
+			},
+			peerState:  best,
+			errWrapped: errTest,
+			errMessage: "requesting headers: test error",
+		},
+		"header number mismatch": {
+			syncerBuilder: func(ctrl *gomock.Controller) *finalisedHeaderSyncer {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(headers[0], nil)
+				net := NewMockNetwork(ctrl)
+				net.EXPECT().DoBlockRequest(who, newRequest(4)).
+					Return(&network.BlockResponseMessage{
+						BlockData: []*types.BlockData{{Header: headers[3]}},
+					}, 0, nil)
+				net.EXPECT().ReportPeer(peerset.ReputationChange{
+					Value:  peerset.BadMessageValue,
+					Reason: peerset.BadMessageReason,
+				}, who)
+				return newFinalisedHeaderSyncer(blockState, net, nil)
+			},
+			peerState:  best,
+			errWrapped: errHeaderNumberMismatch,
+			errMessage: "header number mismatch: expected number 4 but got number 3",
+		},
+		"no justification after finalised block": {
+			syncerBuilder: func(ctrl *gomock.Controller) *finalisedHeaderSyncer {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(headers[2], nil)
+				net := NewMockNetwork(ctrl)
+				net.EXPECT().DoBlockRequest(who, newRequest(4)).
+					Return(&network.BlockResponseMessage{
+						BlockData: []*types.BlockData{
+							{Header: headers[4]},
+							{Header: headers[3]},
+						},
+					}, 0, nil)
+				return newFinalisedHeaderSyncer(blockState, net, nil)
+			},
+			peerState:  best,
+			errWrapped: errNoJustifiedHeader,
+			errMessage: "no justified header: after finalised block number 2",
+		},
+		"bad justification": {
+			syncerBuilder: func(ctrl *gomock.Controller) *finalisedHeaderSyncer {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(headers[0], nil)
+				net := NewMockNetwork(ctrl)
+				net.EXPECT().DoBlockRequest(who, newRequest(4)).
+					Return(&network.BlockResponseMessage{
+						BlockData: []*types.BlockData{
+							{Header: headers[4], Justification: &justification},
+						},
+					}, 0, nil)
+				finalityGadget := NewMockFinalityGadget(ctrl)
+				finalityGadget.EXPECT().VerifyWarpSyncFragment(headers[4], justification).
+					Return(uint64(0), uint64(0), errTest)
+				net.EXPECT().ReportPeer(peerset.ReputationChange{
+					Value:  peerset.BadJustificationValue,
+					Reason: peerset.BadJustificationReason,
+				}, who)
+				return newFinalisedHeaderSyncer(blockState, net, finalityGadget)
+			},
+			peerState:  best,
+			errWrapped: errTest,
+			errMessage: "verifying justification for block number 4: test error",
+		},
+		"justified header in second response": {
+			syncerBuilder: func(ctrl *gomock.Controller) *finalisedHeaderSyncer {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(headers[0], nil)
+				net := NewMockNetwork(ctrl)
+				net.EXPECT().DoBlockRequest(who, newRequest(4)).
+					Return(&network.BlockResponseMessage{
+						BlockData: []*types.BlockData{
+							{Header: headers[4]},
+							{Header: headers[3]},
+						},
+					}, 0, nil)
+				net.EXPECT().DoBlockRequest(who, newRequest(2)).
+					Return(&network.BlockResponseMessage{
+						BlockData: []*types.BlockData{
+							{Header: headers[2], Justification: &justification},
+						},
+					}, 0, nil)
+				finalityGadget := NewMockFinalityGadget(ctrl)
+				finalityGadget.EXPECT().VerifyWarpSyncFragment(headers[2], justification).
+					Return(uint64(3), uint64(1), nil)
+				blockState.EXPECT().SetWarpSyncFinalisedHeader(headers[2], uint64(3), uint64(1)).Return(nil)
+				blockState.EXPECT().SetJustification(headers[2].Hash(), justification).Return(nil)
+				return newFinalisedHeaderSyncer(blockState, net, finalityGadget)
+			},
+			peerState: best,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			syncer := testCase.syncerBuilder(ctrl)
+
+			err := syncer.syncFromPeer(testCase.peerState)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
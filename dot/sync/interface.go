@@ -10,6 +10,8 @@
 	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
@@ -27,11 +29,22 @@ type RuntimeInstance interface {
 	Validator() bool
 	Exec(function string, data []byte) ([]byte, error)
 	SetContextStorage(s runtime.Storage)
+	ContextStorage() runtime.Storage
 	GetCodeHash() common.Hash
 	Version() runtime.Version
 	Metadata() ([]byte, error)
 	BabeConfiguration() (*types.BabeConfiguration, error)
+	BabeCurrentEpoch() (*types.BabeEpoch, error)
+	BabeNextEpoch() (*types.BabeEpoch, error)
+	BabeGenerateKeyOwnershipProof(slot uint64, authorityID [sr25519.PublicKeyLength]byte) (
+		types.BabeOpaqueKeyOwnershipProof, error)
+	BabeSubmitReportEquivocationUnsignedExtrinsic(equivocationProof types.BabeEquivocationProof,
+		keyOwnershipProof types.BabeOpaqueKeyOwnershipProof) error
 	GrandpaAuthorities() ([]types.Authority, error)
+	GrandpaGenerateKeyOwnershipProof(authSetID uint64, authorityID ed25519.PublicKeyBytes) (
+		types.GrandpaOpaqueKeyOwnershipProof, error)
+	GrandpaSubmitReportEquivocationUnsignedExtrinsic(equivocationProof types.GrandpaEquivocationProof,
+		keyOwnershipProof types.GrandpaOpaqueKeyOwnershipProof) error
 	ValidateTransaction(e types.Extrinsic) (*transaction.Validity, error)
 	InitializeBlock(header *types.Header) error
 	InherentExtrinsics(data []byte) ([]byte, error)
@@ -39,11 +52,11 @@ type RuntimeInstance interface {
 	FinalizeBlock() (*types.Header, error)
 	ExecuteBlock(block *types.Block) ([]byte, error)
 	DecodeSessionKeys(enc []byte) ([]byte, error)
+	GenerateSessionKeys(seed *[]byte) ([]byte, error)
 	PaymentQueryInfo(ext []byte) (*types.TransactionPaymentQueryInfo, error)
+	OffchainWorker(header *types.Header) error
 	CheckInherents()
 	RandomSeed()
-	OffchainWorker()
-	GenerateSessionKeys()
 }
 
 // BlockState is the interface for the block state
@@ -63,6 +76,7 @@ type BlockState interface {
 	GetReceipt(common.Hash) ([]byte, error)
 	GetMessageQueue(common.Hash) ([]byte, error)
 	GetJustification(common.Hash) ([]byte, error)
+	GetIndexedBody(hash common.Hash) (indexedBody [][]byte, err error)
 	SetJustification(hash common.Hash, data []byte) error
 	SetFinalisedHash(hash common.Hash, round, setID uint64) error
 	AddBlockToBlockTree(block *types.Block) error
@@ -75,15 +89,23 @@ type BlockState interface {
 	GetHeaderByNumber(num uint) (*types.Header, error)
 	GetAllBlocksAtNumber(num uint) ([]common.Hash, error)
 	IsDescendantOf(parent, child common.Hash) (bool, error)
+	SetWarpSyncFinalisedHeader(header *types.Header, round, setID uint64) error
+	HandleRuntimeChanges(newState *rtstorage.TrieState, in runtime.Instance, bHash common.Hash) error
 }
 
 // StorageState is the interface for the storage state
 type StorageState interface {
 	TrieState(root *common.Hash) (*rtstorage.TrieState, error)
 	LoadCodeHash(*common.Hash) (common.Hash, error)
+	StoreTrie(*rtstorage.TrieState, *types.Header) error
 	sync.Locker
 }
 
+// EpochState is the interface for the epoch state
+type EpochState interface {
+	ImportBabeEpochs(current, next *types.BabeEpoch) error
+}
+
 // CodeSubstitutedState interface to handle storage of code substitute state
 type CodeSubstitutedState interface {
 	LoadCodeSubstitutedBlockHash() common.Hash
@@ -100,9 +122,17 @@ type BabeVerifier interface {
 	VerifyBlock(header *types.Header) error
 }
 
+// GrandpaState is the interface for the grandpa state
+type GrandpaState interface {
+	GetCurrentSetID() (uint64, error)
+	GetSetIDChange(setID uint64) (blockNumber uint, err error)
+	GetSetIDByBlockNumber(blockNumber uint) (uint64, error)
+}
+
 // FinalityGadget implements justification verification functionality
 type FinalityGadget interface {
 	VerifyBlockJustification(common.Hash, []byte) ([]byte, error)
+	VerifyWarpSyncFragment(header *types.Header, justification []byte) (round, setID uint64, err error)
 }
 
 // BlockImportHandler is the interface for the handler of newly imported blocks
@@ -113,9 +143,20 @@ type BlockImportHandler interface {
 // Network is the interface for the network
 type Network interface {
 	// DoBlockRequest sends a request to the given peer.
-	// If a response is received within a certain time period,
+	// If a response is received within a certain time period, it is returned
+	// with its size in bytes as read from the stream, otherwise an error is returned.
+	DoBlockRequest(to peer.ID, req *network.BlockRequestMessage) (
+		resp *network.BlockResponseMessage, size int, err error)
+
+	// DoWarpSyncRequest sends a warp sync request to the given peer.
+	// If a warp sync proof is received within a certain time period,
+	// it is returned, otherwise an error is returned.
+	DoWarpSyncRequest(to peer.ID, req *network.WarpSyncRequest) (*network.WarpSyncProof, error)
+
+	// DoStateRequest sends a state request to the given peer.
+	// If a state response is received within a certain time period,
 	// it is returned, otherwise an error is returned.
-	DoBlockRequest(to peer.ID, req *network.BlockRequestMessage) (*network.BlockResponseMessage, error)
+	DoStateRequest(to peer.ID, req *network.StateRequest) (*network.StateResponse, error)
 
 	// Peers returns a list of currently connected peers
 	Peers() []common.PeerInfo
@@ -421,5 +421,12 @@ func (s *Service) getBlockData(hash common.Hash, requestedData byte) (*types.Blo
 		}
 	}
 
+	if (requestedData&network.RequestedDataIndexedBody)>>5 == 1 {
+		retData, err := s.blockState.GetIndexedBody(hash)
+		if err == nil && retData != nil {
+			blockData.IndexedBody = &retData
+		}
+	}
+
 	return blockData, nil
 }
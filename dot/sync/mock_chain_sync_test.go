@@ -133,6 +133,18 @@ func (mr *MockChainSyncMockRecorder) getHighestBlock() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getHighestBlock", reflect.TypeOf((*MockChainSync)(nil).getHighestBlock))
 }
 
+// peerDisconnected mocks base method.
+func (m *MockChainSync) peerDisconnected(p peer.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "peerDisconnected", p)
+}
+
+// peerDisconnected indicates an expected call of peerDisconnected.
+func (mr *MockChainSyncMockRecorder) peerDisconnected(p interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "peerDisconnected", reflect.TypeOf((*MockChainSync)(nil).peerDisconnected), p)
+}
+
 // setBlockAnnounce mocks base method.
 func (m *MockChainSync) setBlockAnnounce(from peer.ID, header *types.Header) error {
 	m.ctrl.T.Helper()
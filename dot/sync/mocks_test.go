@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ChainSafe/gossamer/dot/sync (interfaces: BlockState,StorageState,CodeSubstitutedState,TransactionState,BabeVerifier,FinalityGadget,BlockImportHandler,Network,RuntimeInstance)
+// Source: github.com/ChainSafe/gossamer/dot/sync (interfaces: BlockState,StorageState,CodeSubstitutedState,TransactionState,BabeVerifier,FinalityGadget,GrandpaState,BlockImportHandler,Network,RuntimeInstance,EpochState)
 
 // Package sync is a generated GoMock package.
 package sync
@@ -11,6 +11,7 @@
 	peerset "github.com/ChainSafe/gossamer/dot/peerset"
 	types "github.com/ChainSafe/gossamer/dot/types"
 	common "github.com/ChainSafe/gossamer/lib/common"
+	ed25519 "github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	keystore "github.com/ChainSafe/gossamer/lib/keystore"
 	runtime "github.com/ChainSafe/gossamer/lib/runtime"
 	storage "github.com/ChainSafe/gossamer/lib/runtime/storage"
@@ -262,6 +263,21 @@ func (mr *MockBlockStateMockRecorder) GetHighestFinalisedHeader() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHighestFinalisedHeader", reflect.TypeOf((*MockBlockState)(nil).GetHighestFinalisedHeader))
 }
 
+// GetIndexedBody mocks base method.
+func (m *MockBlockState) GetIndexedBody(arg0 common.Hash) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIndexedBody", arg0)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIndexedBody indicates an expected call of GetIndexedBody.
+func (mr *MockBlockStateMockRecorder) GetIndexedBody(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIndexedBody", reflect.TypeOf((*MockBlockState)(nil).GetIndexedBody), arg0)
+}
+
 // GetJustification mocks base method.
 func (m *MockBlockState) GetJustification(arg0 common.Hash) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -322,6 +338,20 @@ func (mr *MockBlockStateMockRecorder) GetRuntime(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuntime", reflect.TypeOf((*MockBlockState)(nil).GetRuntime), arg0)
 }
 
+// HandleRuntimeChanges mocks base method.
+func (m *MockBlockState) HandleRuntimeChanges(arg0 *storage.TrieState, arg1 runtime.Instance, arg2 common.Hash) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleRuntimeChanges", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleRuntimeChanges indicates an expected call of HandleRuntimeChanges.
+func (mr *MockBlockStateMockRecorder) HandleRuntimeChanges(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleRuntimeChanges", reflect.TypeOf((*MockBlockState)(nil).HandleRuntimeChanges), arg0, arg1, arg2)
+}
+
 // HasBlockBody mocks base method.
 func (m *MockBlockState) HasBlockBody(arg0 common.Hash) (bool, error) {
 	m.ctrl.T.Helper()
@@ -409,6 +439,20 @@ func (mr *MockBlockStateMockRecorder) SetJustification(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetJustification", reflect.TypeOf((*MockBlockState)(nil).SetJustification), arg0, arg1)
 }
 
+// SetWarpSyncFinalisedHeader mocks base method.
+func (m *MockBlockState) SetWarpSyncFinalisedHeader(arg0 *types.Header, arg1, arg2 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWarpSyncFinalisedHeader", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWarpSyncFinalisedHeader indicates an expected call of SetWarpSyncFinalisedHeader.
+func (mr *MockBlockStateMockRecorder) SetWarpSyncFinalisedHeader(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWarpSyncFinalisedHeader", reflect.TypeOf((*MockBlockState)(nil).SetWarpSyncFinalisedHeader), arg0, arg1, arg2)
+}
+
 // StoreRuntime mocks base method.
 func (m *MockBlockState) StoreRuntime(arg0 common.Hash, arg1 runtime.Instance) {
 	m.ctrl.T.Helper()
@@ -486,6 +530,20 @@ func (mr *MockStorageStateMockRecorder) Lock() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockStorageState)(nil).Lock))
 }
 
+// StoreTrie mocks base method.
+func (m *MockStorageState) StoreTrie(arg0 *storage.TrieState, arg1 *types.Header) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreTrie", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreTrie indicates an expected call of StoreTrie.
+func (mr *MockStorageStateMockRecorder) StoreTrie(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreTrie", reflect.TypeOf((*MockStorageState)(nil).StoreTrie), arg0, arg1)
+}
+
 // TrieState mocks base method.
 func (m *MockStorageState) TrieState(arg0 *common.Hash) (*storage.TrieState, error) {
 	m.ctrl.T.Helper()
@@ -674,6 +732,90 @@ func (mr *MockFinalityGadgetMockRecorder) VerifyBlockJustification(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyBlockJustification", reflect.TypeOf((*MockFinalityGadget)(nil).VerifyBlockJustification), arg0, arg1)
 }
 
+// VerifyWarpSyncFragment mocks base method.
+func (m *MockFinalityGadget) VerifyWarpSyncFragment(arg0 *types.Header, arg1 []byte) (uint64, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyWarpSyncFragment", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// VerifyWarpSyncFragment indicates an expected call of VerifyWarpSyncFragment.
+func (mr *MockFinalityGadgetMockRecorder) VerifyWarpSyncFragment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyWarpSyncFragment", reflect.TypeOf((*MockFinalityGadget)(nil).VerifyWarpSyncFragment), arg0, arg1)
+}
+
+// MockGrandpaState is a mock of GrandpaState interface.
+type MockGrandpaState struct {
+	ctrl     *gomock.Controller
+	recorder *MockGrandpaStateMockRecorder
+}
+
+// MockGrandpaStateMockRecorder is the mock recorder for MockGrandpaState.
+type MockGrandpaStateMockRecorder struct {
+	mock *MockGrandpaState
+}
+
+// NewMockGrandpaState creates a new mock instance.
+func NewMockGrandpaState(ctrl *gomock.Controller) *MockGrandpaState {
+	mock := &MockGrandpaState{ctrl: ctrl}
+	mock.recorder = &MockGrandpaStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGrandpaState) EXPECT() *MockGrandpaStateMockRecorder {
+	return m.recorder
+}
+
+// GetCurrentSetID mocks base method.
+func (m *MockGrandpaState) GetCurrentSetID() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentSetID")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentSetID indicates an expected call of GetCurrentSetID.
+func (mr *MockGrandpaStateMockRecorder) GetCurrentSetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentSetID", reflect.TypeOf((*MockGrandpaState)(nil).GetCurrentSetID))
+}
+
+// GetSetIDByBlockNumber mocks base method.
+func (m *MockGrandpaState) GetSetIDByBlockNumber(arg0 uint) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSetIDByBlockNumber", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSetIDByBlockNumber indicates an expected call of GetSetIDByBlockNumber.
+func (mr *MockGrandpaStateMockRecorder) GetSetIDByBlockNumber(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSetIDByBlockNumber", reflect.TypeOf((*MockGrandpaState)(nil).GetSetIDByBlockNumber), arg0)
+}
+
+// GetSetIDChange mocks base method.
+func (m *MockGrandpaState) GetSetIDChange(arg0 uint64) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSetIDChange", arg0)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSetIDChange indicates an expected call of GetSetIDChange.
+func (mr *MockGrandpaStateMockRecorder) GetSetIDChange(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSetIDChange", reflect.TypeOf((*MockGrandpaState)(nil).GetSetIDChange), arg0)
+}
+
 // MockBlockImportHandler is a mock of BlockImportHandler interface.
 type MockBlockImportHandler struct {
 	ctrl     *gomock.Controller
@@ -735,12 +877,13 @@ func (m *MockNetwork) EXPECT() *MockNetworkMockRecorder {
 }
 
 // DoBlockRequest mocks base method.
-func (m *MockNetwork) DoBlockRequest(arg0 peer.ID, arg1 *network.BlockRequestMessage) (*network.BlockResponseMessage, error) {
+func (m *MockNetwork) DoBlockRequest(arg0 peer.ID, arg1 *network.BlockRequestMessage) (*network.BlockResponseMessage, int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DoBlockRequest", arg0, arg1)
 	ret0, _ := ret[0].(*network.BlockResponseMessage)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // DoBlockRequest indicates an expected call of DoBlockRequest.
@@ -749,6 +892,36 @@ func (mr *MockNetworkMockRecorder) DoBlockRequest(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoBlockRequest", reflect.TypeOf((*MockNetwork)(nil).DoBlockRequest), arg0, arg1)
 }
 
+// DoStateRequest mocks base method.
+func (m *MockNetwork) DoStateRequest(arg0 peer.ID, arg1 *network.StateRequest) (*network.StateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DoStateRequest", arg0, arg1)
+	ret0, _ := ret[0].(*network.StateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DoStateRequest indicates an expected call of DoStateRequest.
+func (mr *MockNetworkMockRecorder) DoStateRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoStateRequest", reflect.TypeOf((*MockNetwork)(nil).DoStateRequest), arg0, arg1)
+}
+
+// DoWarpSyncRequest mocks base method.
+func (m *MockNetwork) DoWarpSyncRequest(arg0 peer.ID, arg1 *network.WarpSyncRequest) (*network.WarpSyncProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DoWarpSyncRequest", arg0, arg1)
+	ret0, _ := ret[0].(*network.WarpSyncProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DoWarpSyncRequest indicates an expected call of DoWarpSyncRequest.
+func (mr *MockNetworkMockRecorder) DoWarpSyncRequest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoWarpSyncRequest", reflect.TypeOf((*MockNetwork)(nil).DoWarpSyncRequest), arg0, arg1)
+}
+
 // Peers mocks base method.
 func (m *MockNetwork) Peers() []common.PeerInfo {
 	m.ctrl.T.Helper()
@@ -828,6 +1001,65 @@ func (mr *MockRuntimeInstanceMockRecorder) BabeConfiguration() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BabeConfiguration", reflect.TypeOf((*MockRuntimeInstance)(nil).BabeConfiguration))
 }
 
+// BabeCurrentEpoch mocks base method.
+func (m *MockRuntimeInstance) BabeCurrentEpoch() (*types.BabeEpoch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BabeCurrentEpoch")
+	ret0, _ := ret[0].(*types.BabeEpoch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BabeCurrentEpoch indicates an expected call of BabeCurrentEpoch.
+func (mr *MockRuntimeInstanceMockRecorder) BabeCurrentEpoch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BabeCurrentEpoch", reflect.TypeOf((*MockRuntimeInstance)(nil).BabeCurrentEpoch))
+}
+
+// BabeGenerateKeyOwnershipProof mocks base method.
+func (m *MockRuntimeInstance) BabeGenerateKeyOwnershipProof(arg0 uint64, arg1 [32]byte) (types.BabeOpaqueKeyOwnershipProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BabeGenerateKeyOwnershipProof", arg0, arg1)
+	ret0, _ := ret[0].(types.BabeOpaqueKeyOwnershipProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BabeGenerateKeyOwnershipProof indicates an expected call of BabeGenerateKeyOwnershipProof.
+func (mr *MockRuntimeInstanceMockRecorder) BabeGenerateKeyOwnershipProof(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BabeGenerateKeyOwnershipProof", reflect.TypeOf((*MockRuntimeInstance)(nil).BabeGenerateKeyOwnershipProof), arg0, arg1)
+}
+
+// BabeNextEpoch mocks base method.
+func (m *MockRuntimeInstance) BabeNextEpoch() (*types.BabeEpoch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BabeNextEpoch")
+	ret0, _ := ret[0].(*types.BabeEpoch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BabeNextEpoch indicates an expected call of BabeNextEpoch.
+func (mr *MockRuntimeInstanceMockRecorder) BabeNextEpoch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BabeNextEpoch", reflect.TypeOf((*MockRuntimeInstance)(nil).BabeNextEpoch))
+}
+
+// BabeSubmitReportEquivocationUnsignedExtrinsic mocks base method.
+func (m *MockRuntimeInstance) BabeSubmitReportEquivocationUnsignedExtrinsic(arg0 types.BabeEquivocationProof, arg1 types.BabeOpaqueKeyOwnershipProof) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BabeSubmitReportEquivocationUnsignedExtrinsic", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BabeSubmitReportEquivocationUnsignedExtrinsic indicates an expected call of BabeSubmitReportEquivocationUnsignedExtrinsic.
+func (mr *MockRuntimeInstanceMockRecorder) BabeSubmitReportEquivocationUnsignedExtrinsic(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BabeSubmitReportEquivocationUnsignedExtrinsic", reflect.TypeOf((*MockRuntimeInstance)(nil).BabeSubmitReportEquivocationUnsignedExtrinsic), arg0, arg1)
+}
+
 // CheckInherents mocks base method.
 func (m *MockRuntimeInstance) CheckInherents() {
 	m.ctrl.T.Helper()
@@ -840,6 +1072,20 @@ func (mr *MockRuntimeInstanceMockRecorder) CheckInherents() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckInherents", reflect.TypeOf((*MockRuntimeInstance)(nil).CheckInherents))
 }
 
+// ContextStorage mocks base method.
+func (m *MockRuntimeInstance) ContextStorage() runtime.Storage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContextStorage")
+	ret0, _ := ret[0].(runtime.Storage)
+	return ret0
+}
+
+// ContextStorage indicates an expected call of ContextStorage.
+func (mr *MockRuntimeInstanceMockRecorder) ContextStorage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContextStorage", reflect.TypeOf((*MockRuntimeInstance)(nil).ContextStorage))
+}
+
 // DecodeSessionKeys mocks base method.
 func (m *MockRuntimeInstance) DecodeSessionKeys(arg0 []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -901,15 +1147,18 @@ func (mr *MockRuntimeInstanceMockRecorder) FinalizeBlock() *gomock.Call {
 }
 
 // GenerateSessionKeys mocks base method.
-func (m *MockRuntimeInstance) GenerateSessionKeys() {
+func (m *MockRuntimeInstance) GenerateSessionKeys(arg0 *[]byte) ([]byte, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "GenerateSessionKeys")
+	ret := m.ctrl.Call(m, "GenerateSessionKeys", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GenerateSessionKeys indicates an expected call of GenerateSessionKeys.
-func (mr *MockRuntimeInstanceMockRecorder) GenerateSessionKeys() *gomock.Call {
+func (mr *MockRuntimeInstanceMockRecorder) GenerateSessionKeys(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSessionKeys", reflect.TypeOf((*MockRuntimeInstance)(nil).GenerateSessionKeys))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSessionKeys", reflect.TypeOf((*MockRuntimeInstance)(nil).GenerateSessionKeys), arg0)
 }
 
 // GetCodeHash mocks base method.
@@ -941,6 +1190,35 @@ func (mr *MockRuntimeInstanceMockRecorder) GrandpaAuthorities() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrandpaAuthorities", reflect.TypeOf((*MockRuntimeInstance)(nil).GrandpaAuthorities))
 }
 
+// GrandpaGenerateKeyOwnershipProof mocks base method.
+func (m *MockRuntimeInstance) GrandpaGenerateKeyOwnershipProof(arg0 uint64, arg1 ed25519.PublicKeyBytes) (types.GrandpaOpaqueKeyOwnershipProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrandpaGenerateKeyOwnershipProof", arg0, arg1)
+	ret0, _ := ret[0].(types.GrandpaOpaqueKeyOwnershipProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrandpaGenerateKeyOwnershipProof indicates an expected call of GrandpaGenerateKeyOwnershipProof.
+func (mr *MockRuntimeInstanceMockRecorder) GrandpaGenerateKeyOwnershipProof(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrandpaGenerateKeyOwnershipProof", reflect.TypeOf((*MockRuntimeInstance)(nil).GrandpaGenerateKeyOwnershipProof), arg0, arg1)
+}
+
+// GrandpaSubmitReportEquivocationUnsignedExtrinsic mocks base method.
+func (m *MockRuntimeInstance) GrandpaSubmitReportEquivocationUnsignedExtrinsic(arg0 types.GrandpaEquivocationProof, arg1 types.GrandpaOpaqueKeyOwnershipProof) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrandpaSubmitReportEquivocationUnsignedExtrinsic", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrandpaSubmitReportEquivocationUnsignedExtrinsic indicates an expected call of GrandpaSubmitReportEquivocationUnsignedExtrinsic.
+func (mr *MockRuntimeInstanceMockRecorder) GrandpaSubmitReportEquivocationUnsignedExtrinsic(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrandpaSubmitReportEquivocationUnsignedExtrinsic", reflect.TypeOf((*MockRuntimeInstance)(nil).GrandpaSubmitReportEquivocationUnsignedExtrinsic), arg0, arg1)
+}
+
 // InherentExtrinsics mocks base method.
 func (m *MockRuntimeInstance) InherentExtrinsics(arg0 []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -1028,15 +1306,17 @@ func (mr *MockRuntimeInstanceMockRecorder) NodeStorage() *gomock.Call {
 }
 
 // OffchainWorker mocks base method.
-func (m *MockRuntimeInstance) OffchainWorker() {
+func (m *MockRuntimeInstance) OffchainWorker(arg0 *types.Header) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "OffchainWorker")
+	ret := m.ctrl.Call(m, "OffchainWorker", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // OffchainWorker indicates an expected call of OffchainWorker.
-func (mr *MockRuntimeInstanceMockRecorder) OffchainWorker() *gomock.Call {
+func (mr *MockRuntimeInstanceMockRecorder) OffchainWorker(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OffchainWorker", reflect.TypeOf((*MockRuntimeInstance)(nil).OffchainWorker))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OffchainWorker", reflect.TypeOf((*MockRuntimeInstance)(nil).OffchainWorker), arg0)
 }
 
 // PaymentQueryInfo mocks base method.
@@ -1146,3 +1426,40 @@ func (mr *MockRuntimeInstanceMockRecorder) Version() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockRuntimeInstance)(nil).Version))
 }
+
+// MockEpochState is a mock of EpochState interface.
+type MockEpochState struct {
+	ctrl     *gomock.Controller
+	recorder *MockEpochStateMockRecorder
+}
+
+// MockEpochStateMockRecorder is the mock recorder for MockEpochState.
+type MockEpochStateMockRecorder struct {
+	mock *MockEpochState
+}
+
+// NewMockEpochState creates a new mock instance.
+func NewMockEpochState(ctrl *gomock.Controller) *MockEpochState {
+	mock := &MockEpochState{ctrl: ctrl}
+	mock.recorder = &MockEpochStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEpochState) EXPECT() *MockEpochStateMockRecorder {
+	return m.recorder
+}
+
+// ImportBabeEpochs mocks base method.
+func (m *MockEpochState) ImportBabeEpochs(arg0, arg1 *types.BabeEpoch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBabeEpochs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportBabeEpochs indicates an expected call of ImportBabeEpochs.
+func (mr *MockEpochStateMockRecorder) ImportBabeEpochs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBabeEpochs", reflect.TypeOf((*MockEpochState)(nil).ImportBabeEpochs), arg0, arg1)
+}
@@ -0,0 +1,224 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// movingAverageWeight is the weight of the latest sample
+	// in the latency and download rate moving averages.
+	movingAverageWeight = 0.2
+
+	// scoreResolution is the number of selection weight units per score unit,
+	// used to select a peer at random with a probability proportional to its score.
+	scoreResolution = 1_000_000
+)
+
+var (
+	peerScoreGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_syncer",
+		Name:      "peer_score",
+		Help:      "score of the peer used to select the peers to request blocks from",
+	}, []string{"peer"})
+	peerLatencyGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_syncer",
+		Name:      "peer_latency_seconds",
+		Help:      "average latency of the block responses of the peer",
+	}, []string{"peer"})
+	peerSuccessRateGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_syncer",
+		Name:      "peer_success_rate",
+		Help:      "ratio of the block requests to the peer answered with a valid response",
+	}, []string{"peer"})
+	peerBytesPerSecondGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_syncer",
+		Name:      "peer_bytes_per_second",
+		Help:      "average download rate of the block responses of the peer",
+	}, []string{"peer"})
+	peerInvalidResponsesGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_syncer",
+		Name:      "peer_invalid_responses_total",
+		Help:      "total number of invalid block responses received from the peer",
+	}, []string{"peer"})
+)
+
+// peerSyncStats contains the statistics of the block requests made to a peer.
+type peerSyncStats struct {
+	requests         uint
+	successes        uint
+	invalidResponses uint
+	// latency is the moving average of the block response latency.
+	latency time.Duration
+	// bytesPerSecond is the moving average of the block response download rate.
+	bytesPerSecond float64
+}
+
+// successRate returns the ratio of requests answered with a valid response.
+// It is smoothed so peers with no requests yet have a success rate of 0.5.
+func (s *peerSyncStats) successRate() float64 {
+	return float64(s.successes+1) / float64(s.requests+2)
+}
+
+// score returns the score of the peer, which is higher for peers answering
+// reliably with a high download rate, and lower for peers sending invalid responses.
+func (s *peerSyncStats) score() float64 {
+	kilobytesPerSecond := s.bytesPerSecond / 1024
+	return s.successRate() * (1 + math.Log2(1+kilobytesPerSecond)) / float64(1+s.invalidResponses)
+}
+
+// peerScores tracks the sync statistics of our peers,
+// and uses them to select the peers to sync from.
+type peerScores struct {
+	sync.Mutex
+	stats map[peer.ID]*peerSyncStats
+}
+
+func newPeerScores() *peerScores {
+	return &peerScores{
+		stats: make(map[peer.ID]*peerSyncStats),
+	}
+}
+
+// getStats returns the statistics of the given peer, creating them if needed.
+// It must be called with the lock held.
+func (p *peerScores) getStats(who peer.ID) *peerSyncStats {
+	stats, has := p.stats[who]
+	if !has {
+		stats = &peerSyncStats{}
+		p.stats[who] = stats
+	}
+	return stats
+}
+
+// recordResponse records a valid block response of the given size in bytes,
+// received from the given peer after the given latency.
+func (p *peerScores) recordResponse(who peer.ID, latency time.Duration, size int) {
+	p.Lock()
+	defer p.Unlock()
+
+	stats := p.getStats(who)
+	stats.requests++
+	stats.successes++
+
+	bytesPerSecond := float64(size)
+	if latency > 0 {
+		bytesPerSecond = float64(size) / latency.Seconds()
+	}
+
+	if stats.successes == 1 {
+		stats.latency = latency
+		stats.bytesPerSecond = bytesPerSecond
+	} else {
+		stats.latency = time.Duration(movingAverageWeight*float64(latency) +
+			(1-movingAverageWeight)*float64(stats.latency))
+		stats.bytesPerSecond = movingAverageWeight*bytesPerSecond +
+			(1-movingAverageWeight)*stats.bytesPerSecond
+	}
+
+	p.updateMetrics(who, stats)
+}
+
+// recordFailure records a block request to the given peer which failed without response.
+func (p *peerScores) recordFailure(who peer.ID) {
+	p.Lock()
+	defer p.Unlock()
+
+	stats := p.getStats(who)
+	stats.requests++
+	p.updateMetrics(who, stats)
+}
+
+// recordInvalidResponse records an invalid block response received from the given peer.
+func (p *peerScores) recordInvalidResponse(who peer.ID) {
+	p.Lock()
+	defer p.Unlock()
+
+	stats := p.getStats(who)
+	stats.requests++
+	stats.invalidResponses++
+	p.updateMetrics(who, stats)
+}
+
+func (*peerScores) updateMetrics(who peer.ID, stats *peerSyncStats) {
+	label := who.String()
+	peerScoreGauge.WithLabelValues(label).Set(stats.score())
+	peerLatencyGauge.WithLabelValues(label).Set(stats.latency.Seconds())
+	peerSuccessRateGauge.WithLabelValues(label).Set(stats.successRate())
+	peerBytesPerSecondGauge.WithLabelValues(label).Set(stats.bytesPerSecond)
+	peerInvalidResponsesGauge.WithLabelValues(label).Set(float64(stats.invalidResponses))
+}
+
+// remove removes the statistics of the given peer and its metrics.
+func (p *peerScores) remove(who peer.ID) {
+	p.Lock()
+	defer p.Unlock()
+
+	delete(p.stats, who)
+
+	label := who.String()
+	peerScoreGauge.DeleteLabelValues(label)
+	peerLatencyGauge.DeleteLabelValues(label)
+	peerSuccessRateGauge.DeleteLabelValues(label)
+	peerBytesPerSecondGauge.DeleteLabelValues(label)
+	peerInvalidResponsesGauge.DeleteLabelValues(label)
+}
+
+// score returns the score of the given peer.
+func (p *peerScores) score(who peer.ID) float64 {
+	p.Lock()
+	defer p.Unlock()
+
+	stats, has := p.stats[who]
+	if !has {
+		stats = &peerSyncStats{}
+	}
+	return stats.score()
+}
+
+// selectPeer returns the preferred peer if it is one of the given peers, so we
+// keep syncing from a peer which answered our previous request. Otherwise, it
+// returns one of the given peers at random with a probability proportional to its score.
+func (p *peerScores) selectPeer(peers []peer.ID, preferred peer.ID) (peer.ID, error) {
+	if preferred != "" {
+		for _, who := range peers {
+			if who == preferred {
+				return preferred, nil
+			}
+		}
+	}
+
+	weights := make([]int64, len(peers))
+	var total int64
+	for i, who := range peers {
+		// every peer keeps a chance to be selected, so its score can recover.
+		weights[i] = int64(p.score(who)*scoreResolution) + 1
+		total += weights[i]
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(total))
+	if err != nil {
+		return "", fmt.Errorf("generating random number: %w", err)
+	}
+
+	target := n.Int64()
+	for i, weight := range weights {
+		if target < weight {
+			return peers[i], nil
+		}
+		target -= weight
+	}
+
+	return peers[len(peers)-1], nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_peerSyncStats_score(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		stats peerSyncStats
+		score float64
+	}{
+		"no request": {
+			score: 0.5,
+		},
+		"successes without download rate": {
+			stats: peerSyncStats{requests: 2, successes: 2},
+			score: 0.75,
+		},
+		"successes with download rate": {
+			stats: peerSyncStats{requests: 2, successes: 2, bytesPerSecond: 1024},
+			score: 1.5,
+		},
+		"invalid responses": {
+			stats: peerSyncStats{requests: 2, invalidResponses: 2},
+			score: 0.25 / 3,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			score := testCase.stats.score()
+
+			assert.InDelta(t, testCase.score, score, 1e-9)
+		})
+	}
+}
+
+func Test_peerScores_record(t *testing.T) {
+	t.Parallel()
+
+	const who = peer.ID("peer")
+	scores := newPeerScores()
+
+	scores.recordResponse(who, time.Second, 2048)
+	assert.Equal(t, &peerSyncStats{
+		requests:       1,
+		successes:      1,
+		latency:        time.Second,
+		bytesPerSecond: 2048,
+	}, scores.stats[who])
+
+	scores.recordResponse(who, 2*time.Second, 1024)
+	stats := scores.stats[who]
+	assert.Equal(t, uint(2), stats.requests)
+	assert.Equal(t, uint(2), stats.successes)
+	assert.Equal(t, 1200*time.Millisecond, stats.latency)
+	assert.InDelta(t, 0.2*512+0.8*2048, stats.bytesPerSecond, 1e-9)
+
+	scores.recordFailure(who)
+	scores.recordInvalidResponse(who)
+	assert.Equal(t, uint(4), stats.requests)
+	assert.Equal(t, uint(2), stats.successes)
+	assert.Equal(t, uint(1), stats.invalidResponses)
+}
+
+func Test_peerScores_remove(t *testing.T) {
+	t.Parallel()
+
+	const who = peer.ID("removed peer")
+	scores := newPeerScores()
+
+	scores.recordResponse(who, time.Second, 2048)
+	require.Contains(t, scores.stats, who)
+	deleted := peerScoreGauge.DeleteLabelValues(who.String())
+	require.True(t, deleted)
+
+	scores.recordFailure(who)
+	scores.remove(who)
+
+	assert.NotContains(t, scores.stats, who)
+	deleted = peerScoreGauge.DeleteLabelValues(who.String())
+	assert.False(t, deleted)
+}
+
+func Test_peerScores_selectPeer(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		peers     []peer.ID
+		preferred peer.ID
+		expected  peer.ID
+	}{
+		"single peer": {
+			peers:    []peer.ID{"a"},
+			expected: "a",
+		},
+		"preferred peer": {
+			peers:     []peer.ID{"a", "b", "c"},
+			preferred: "b",
+			expected:  "b",
+		},
+		"preferred peer not available": {
+			peers:     []peer.ID{"a"},
+			preferred: "b",
+			expected:  "a",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			scores := newPeerScores()
+
+			who, err := scores.selectPeer(testCase.peers, testCase.preferred)
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, who)
+		})
+	}
+}
+
+func Test_peerScores_selectPeer_weightedByScore(t *testing.T) {
+	t.Parallel()
+
+	scores := newPeerScores()
+	scores.stats["good"] = &peerSyncStats{requests: 100, successes: 100, bytesPerSecond: 1 << 20}
+	scores.stats["bad"] = &peerSyncStats{requests: 100, invalidResponses: 100}
+
+	selected := make(map[peer.ID]int)
+	for i := 0; i < 100; i++ {
+		who, err := scores.selectPeer([]peer.ID{"good", "bad"}, "")
+		require.NoError(t, err)
+		selected[who]++
+	}
+
+	assert.Greater(t, selected["good"], selected["bad"])
+}
@@ -0,0 +1,258 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/lib/trie/proof"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// stateSyncer downloads the state of the highest finalised block from
+// peers, instead of executing every block from genesis to build it.
+type stateSyncer struct {
+	blockState   BlockState
+	storageState StorageState
+	epochState   EpochState
+	network      Network
+}
+
+func newStateSyncer(bs BlockState, ss StorageState, es EpochState, net Network) *stateSyncer {
+	return &stateSyncer{
+		blockState:   bs,
+		storageState: ss,
+		epochState:   es,
+		network:      net,
+	}
+}
+
+// sync downloads the state trie and the child tries of the highest finalised
+// block from the given peers, verifies it against the state root of the block
+// and stores it. It does nothing if the state is already stored.
+func (s *stateSyncer) sync(peers []peer.ID) error {
+	header, err := s.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	_, err = s.storageState.TrieState(&header.StateRoot)
+	if err == nil {
+		logger.Debugf("state of finalised block number %d is already stored", header.Number)
+		return nil
+	}
+
+	logger.Infof("downloading state of finalised block number %d with state root %s...",
+		header.Number, header.StateRoot)
+
+	hash := header.Hash()
+	stateTrie, err := s.downloadTrie(peers, hash, header.StateRoot, nil)
+	if err != nil {
+		return fmt.Errorf("downloading state trie: %w", err)
+	}
+
+	for _, childStorageKey := range stateTrie.GetKeysWithPrefix(trie.ChildStorageKeyPrefix) {
+		childRoot := common.BytesToHash(stateTrie.Get(childStorageKey))
+		childTrie, err := s.downloadTrie(peers, hash, childRoot, childStorageKey)
+		if err != nil {
+			return fmt.Errorf("downloading child trie at key 0x%x: %w", childStorageKey, err)
+		}
+
+		err = stateTrie.PutChild(childStorageKey[len(trie.ChildStorageKeyPrefix):], childTrie)
+		if err != nil {
+			return fmt.Errorf("putting child trie at key 0x%x: %w", childStorageKey, err)
+		}
+	}
+
+	return s.importState(header, stateTrie)
+}
+
+// downloadTrie downloads the entries of the trie with the given root at the block
+// with the given hash, trying each peer in turn if a peer fails. The download
+// continues from the last entry received when a peer fails, but starts over with
+// the next peer if the entries of a peer do not build the trie with the given root.
+// The child storage key is nil to download the state trie.
+func (s *stateSyncer) downloadTrie(peers []peer.ID, hash, root common.Hash,
+	childStorageKey []byte) (*trie.Trie, error) {
+	t := trie.NewEmptyTrie()
+	var start []byte
+	for _, who := range peers {
+		for {
+			req := &network.StateRequest{
+				Block: hash,
+				Start: stateRequestStart(childStorageKey, start),
+			}
+
+			resp, err := s.network.DoStateRequest(who, req)
+			if err != nil {
+				logger.Debugf("failed to request state from peer %s: %s", who, err)
+				break
+			}
+
+			err = verifyStateResponse(resp, root, start)
+			if err != nil {
+				logger.Debugf("invalid state response from peer %s: %s", who, err)
+				s.network.ReportPeer(peerset.ReputationChange{
+					Value:  peerset.BadMessageValue,
+					Reason: peerset.BadMessageReason,
+				}, who)
+				break
+			}
+
+			for _, entry := range resp.Entries {
+				t.Put(entry.Key, entry.Value)
+			}
+
+			if !resp.Complete {
+				start = resp.Entries[len(resp.Entries)-1].Key
+				continue
+			}
+
+			err = verifyTrieRoot(t, root)
+			if err == nil {
+				return t, nil
+			}
+
+			logger.Debugf("incomplete state from peer %s: %s", who, err)
+			s.network.ReportPeer(peerset.ReputationChange{
+				Value:  peerset.BadMessageValue,
+				Reason: peerset.BadMessageReason,
+			}, who)
+			t = trie.NewEmptyTrie()
+			start = nil
+			break
+		}
+	}
+
+	return nil, errStateSyncFailed
+}
+
+// stateRequestStart returns the start path of a state request to continue
+// the trie at the child storage key given after the start key given.
+func stateRequestStart(childStorageKey, start []byte) [][]byte {
+	if childStorageKey != nil {
+		return [][]byte{childStorageKey, start}
+	}
+
+	if len(start) == 0 {
+		return nil
+	}
+
+	return [][]byte{start}
+}
+
+// verifyStateResponse verifies the entries of the response follow the start key
+// given in lexicographic order, and are proven by the response proof to belong
+// to the trie with the given root.
+func verifyStateResponse(resp *network.StateResponse, root common.Hash, start []byte) error {
+	if len(resp.Entries) == 0 {
+		if resp.Complete {
+			return nil
+		}
+		return errEmptyStateResponse
+	}
+
+	previous := start
+	keys := make([][]byte, len(resp.Entries))
+	values := make([][]byte, len(resp.Entries))
+	for i, entry := range resp.Entries {
+		if previous != nil && bytes.Compare(entry.Key, previous) <= 0 {
+			return fmt.Errorf("%w: key 0x%x is not after key 0x%x",
+				errStateEntriesNotOrdered, entry.Key, previous)
+		}
+		previous = entry.Key
+		keys[i] = entry.Key
+		values[i] = entry.Value
+	}
+
+	err := proof.VerifyEntries(resp.Proof, root.ToBytes(), keys, values)
+	if err != nil {
+		return fmt.Errorf("verifying state proof: %w", err)
+	}
+
+	return nil
+}
+
+func verifyTrieRoot(t *trie.Trie, root common.Hash) error {
+	hash, err := t.Hash()
+	if err != nil {
+		return fmt.Errorf("computing trie root: %w", err)
+	}
+
+	if hash != root {
+		return fmt.Errorf("%w: expected %s but got %s", errStateRootMismatch, root, hash)
+	}
+
+	return nil
+}
+
+// importState verifies the downloaded state against the state root of the
+// given header, stores it, updates the runtime of the block to the runtime
+// code found in the state and imports the BABE epochs from this runtime.
+func (s *stateSyncer) importState(header *types.Header, stateTrie *trie.Trie) error {
+	err := verifyTrieRoot(stateTrie, header.StateRoot)
+	if err != nil {
+		return err
+	}
+
+	trieState := rtstorage.NewTrieState(stateTrie)
+	err = s.storageState.StoreTrie(trieState, header)
+	if err != nil {
+		return fmt.Errorf("storing state trie: %w", err)
+	}
+
+	hash := header.Hash()
+	instance, err := s.blockState.GetRuntime(&hash)
+	if err != nil {
+		return fmt.Errorf("getting runtime: %w", err)
+	}
+
+	err = s.blockState.HandleRuntimeChanges(trieState, instance, hash)
+	if err != nil {
+		return fmt.Errorf("handling runtime changes: %w", err)
+	}
+
+	instance, err = s.blockState.GetRuntime(&hash)
+	if err != nil {
+		return fmt.Errorf("getting updated runtime: %w", err)
+	}
+
+	err = s.importBabeEpochs(instance, trieState)
+	if err != nil {
+		return fmt.Errorf("importing BABE epochs: %w", err)
+	}
+
+	logger.Infof("downloaded state of finalised block number %d with state root %s",
+		header.Number, header.StateRoot)
+	return nil
+}
+
+// importBabeEpochs imports the current and next BABE epochs from the runtime
+// given at the downloaded state, since the blocks announcing them were skipped.
+func (s *stateSyncer) importBabeEpochs(instance runtime.Instance, trieState *rtstorage.TrieState) error {
+	s.storageState.Lock()
+	defer s.storageState.Unlock()
+
+	instance.SetContextStorage(trieState)
+
+	current, err := instance.BabeCurrentEpoch()
+	if err != nil {
+		return fmt.Errorf("getting current epoch: %w", err)
+	}
+
+	next, err := instance.BabeNextEpoch()
+	if err != nil {
+		return fmt.Errorf("getting next epoch: %w", err)
+	}
+
+	return s.epochState.ImportBabeEpochs(current, next)
+}
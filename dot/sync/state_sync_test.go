@@ -0,0 +1,279 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/lib/trie/proof"
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestStateResponse returns the state response containing the entries of the
+// trie given at the keys given, with their proof generated from the database given.
+func newTestStateResponse(t *testing.T, database chaindb.Database, tr *trie.Trie,
+	complete bool, keys ...[]byte) *network.StateResponse {
+	t.Helper()
+
+	resp := &network.StateResponse{
+		Complete: complete,
+	}
+	for _, key := range keys {
+		resp.Entries = append(resp.Entries, network.StateEntry{
+			Key:   key,
+			Value: tr.Get(key),
+		})
+	}
+
+	encodedProofNodes, err := proof.Generate(tr.MustHash().ToBytes(), keys, database)
+	require.NoError(t, err)
+	resp.Proof = encodedProofNodes
+	return resp
+}
+
+func Test_stateSyncer_sync(t *testing.T) {
+	t.Parallel()
+
+	const (
+		peerA = peer.ID("a")
+		peerB = peer.ID("b")
+	)
+
+	keyToChild := []byte("child")
+	childStorageKey := append(append([]byte{}, trie.ChildStorageKeyPrefix...), keyToChild...)
+
+	child := trie.NewEmptyTrie()
+	child.Put([]byte{1}, []byte{1})
+
+	stateTrie := trie.NewEmptyTrie()
+	stateTrie.Put([]byte{1}, []byte{10})
+	stateTrie.Put([]byte{2}, []byte{20})
+	err := stateTrie.PutChild(keyToChild, child)
+	require.NoError(t, err)
+
+	database, err := chaindb.NewBadgerDB(&chaindb.Config{
+		InMemory: true,
+	})
+	require.NoError(t, err)
+	err = stateTrie.Store(database)
+	require.NoError(t, err)
+
+	header := &types.Header{
+		Number:    10,
+		StateRoot: stateTrie.MustHash(),
+		Digest:    types.NewDigest(),
+	}
+	hash := header.Hash()
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		stateSyncerBuilder func(ctrl *gomock.Controller) *stateSyncer
+		peers              []peer.ID
+		errWrapped         error
+		errMessage         string
+	}{
+		"state already stored": {
+			stateSyncerBuilder: func(ctrl *gomock.Controller) *stateSyncer {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(header, nil)
+				storageState := NewMockStorageState(ctrl)
+				storageState.EXPECT().TrieState(&header.StateRoot).
+					Return(rtstorage.NewTrieState(stateTrie), nil)
+				return newStateSyncer(blockState, storageState, nil, nil)
+			},
+		},
+		"state downloaded": {
+			stateSyncerBuilder: func(ctrl *gomock.Controller) *stateSyncer {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(header, nil)
+				storageState := NewMockStorageState(ctrl)
+				storageState.EXPECT().TrieState(&header.StateRoot).
+					Return(nil, chaindb.ErrKeyNotFound)
+				net := NewMockNetwork(ctrl)
+
+				// peer A fails and peer B sends a bad proof for the first request
+				net.EXPECT().DoStateRequest(peerA, &network.StateRequest{Block: hash}).
+					Return(nil, errTest)
+				badResponse := newTestStateResponse(t, database, stateTrie, false, []byte{1})
+				badResponse.Entries[0].Value = []byte{99}
+				net.EXPECT().DoStateRequest(peerB, &network.StateRequest{Block: hash}).
+					Return(badResponse, nil)
+				net.EXPECT().ReportPeer(peerset.ReputationChange{
+					Value:  peerset.BadMessageValue,
+					Reason: peerset.BadMessageReason,
+				}, peerB)
+
+				// peer C sends the state trie in two responses
+				const peerC = peer.ID("c")
+				net.EXPECT().DoStateRequest(peerC, &network.StateRequest{Block: hash}).
+					Return(newTestStateResponse(t, database, stateTrie, false, []byte{1}), nil)
+				net.EXPECT().DoStateRequest(peerC, &network.StateRequest{
+					Block: hash,
+					Start: [][]byte{{1}},
+				}).Return(newTestStateResponse(t, database, stateTrie, true,
+					[]byte{2}, childStorageKey), nil)
+
+				// the child trie download starts again from the first peer
+				childRequest := &network.StateRequest{
+					Block: hash,
+					Start: [][]byte{childStorageKey, nil},
+				}
+				net.EXPECT().DoStateRequest(peerA, childRequest).Return(nil, errTest)
+				net.EXPECT().DoStateRequest(peerB, childRequest).
+					Return(newTestStateResponse(t, database, child, true, []byte{1}), nil)
+
+				storageState.EXPECT().StoreTrie(gomock.Any(), header).
+					DoAndReturn(func(trieState *rtstorage.TrieState, _ *types.Header) error {
+						assert.Equal(t, header.StateRoot, trieState.MustRoot())
+						return nil
+					})
+				instance := NewMockRuntimeInstance(ctrl)
+				blockState.EXPECT().GetRuntime(&hash).Return(instance, nil).Times(2)
+				blockState.EXPECT().HandleRuntimeChanges(gomock.Any(), instance, hash).Return(nil)
+
+				// the BABE epochs are imported from the runtime at the downloaded state
+				storageState.EXPECT().Lock()
+				storageState.EXPECT().Unlock()
+				instance.EXPECT().SetContextStorage(gomock.Any())
+				currentEpoch := &types.BabeEpoch{EpochIndex: 2, StartSlot: 1200, Duration: 100}
+				nextEpoch := &types.BabeEpoch{EpochIndex: 3, StartSlot: 1300, Duration: 100}
+				instance.EXPECT().BabeCurrentEpoch().Return(currentEpoch, nil)
+				instance.EXPECT().BabeNextEpoch().Return(nextEpoch, nil)
+				epochState := NewMockEpochState(ctrl)
+				epochState.EXPECT().ImportBabeEpochs(currentEpoch, nextEpoch).Return(nil)
+
+				return newStateSyncer(blockState, storageState, epochState, net)
+			},
+			peers: []peer.ID{peerA, peerB, "c"},
+		},
+		"incomplete state": {
+			stateSyncerBuilder: func(ctrl *gomock.Controller) *stateSyncer {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(header, nil)
+				storageState := NewMockStorageState(ctrl)
+				storageState.EXPECT().TrieState(&header.StateRoot).
+					Return(nil, chaindb.ErrKeyNotFound)
+				net := NewMockNetwork(ctrl)
+				net.EXPECT().DoStateRequest(peerA, &network.StateRequest{Block: hash}).
+					Return(newTestStateResponse(t, database, stateTrie, false, []byte{1}), nil)
+				net.EXPECT().DoStateRequest(peerA, &network.StateRequest{
+					Block: hash,
+					Start: [][]byte{{1}},
+				}).Return(newTestStateResponse(t, database, stateTrie, true, []byte{2}), nil)
+				net.EXPECT().ReportPeer(peerset.ReputationChange{
+					Value:  peerset.BadMessageValue,
+					Reason: peerset.BadMessageReason,
+				}, peerA)
+				// the download starts over with the next peer
+				net.EXPECT().DoStateRequest(peerB, &network.StateRequest{Block: hash}).
+					Return(nil, errTest)
+				return newStateSyncer(blockState, storageState, nil, net)
+			},
+			peers:      []peer.ID{peerA, peerB},
+			errWrapped: errStateSyncFailed,
+			errMessage: "downloading state trie: failed to download state from any peer",
+		},
+		"no peers": {
+			stateSyncerBuilder: func(ctrl *gomock.Controller) *stateSyncer {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(header, nil)
+				storageState := NewMockStorageState(ctrl)
+				storageState.EXPECT().TrieState(&header.StateRoot).
+					Return(nil, chaindb.ErrKeyNotFound)
+				return newStateSyncer(blockState, storageState, nil, nil)
+			},
+			errWrapped: errStateSyncFailed,
+			errMessage: "downloading state trie: failed to download state from any peer",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			stateSyncer := testCase.stateSyncerBuilder(ctrl)
+
+			err := stateSyncer.sync(testCase.peers)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
+
+func Test_verifyStateResponse(t *testing.T) {
+	t.Parallel()
+
+	root := common.Hash{1}
+
+	testCases := map[string]struct {
+		resp       *network.StateResponse
+		start      []byte
+		errWrapped error
+		errMessage string
+	}{
+		"empty complete response": {
+			resp: &network.StateResponse{Complete: true},
+		},
+		"empty incomplete response": {
+			resp:       &network.StateResponse{},
+			errWrapped: errEmptyStateResponse,
+			errMessage: "state response is empty and not complete",
+		},
+		"entry not after start": {
+			resp: &network.StateResponse{
+				Entries: []network.StateEntry{{Key: []byte{1}}},
+			},
+			start:      []byte{1},
+			errWrapped: errStateEntriesNotOrdered,
+			errMessage: "state entries are not in lexicographic order: key 0x01 is not after key 0x01",
+		},
+		"entries not ordered": {
+			resp: &network.StateResponse{
+				Entries: []network.StateEntry{{Key: []byte{2}}, {Key: []byte{1}}},
+			},
+			errWrapped: errStateEntriesNotOrdered,
+			errMessage: "state entries are not in lexicographic order: key 0x01 is not after key 0x02",
+		},
+		"missing proof": {
+			resp: &network.StateResponse{
+				Entries: []network.StateEntry{{Key: []byte{1}}},
+			},
+			errWrapped: proof.ErrEmptyProof,
+			errMessage: "verifying state proof: building trie from proof encoded nodes: " +
+				"proof slice empty: for Merkle root hash " +
+				"0x0100000000000000000000000000000000000000000000000000000000000000",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := verifyStateResponse(testCase.resp, root, testCase.start)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
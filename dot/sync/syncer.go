@@ -19,6 +19,7 @@
 // Service deals with chain syncing by sending block request messages and watching for responses.
 type Service struct {
 	blockState     BlockState
+	grandpaState   GrandpaState
 	chainSync      ChainSync
 	chainProcessor ChainProcessor
 	network        Network
@@ -30,6 +31,8 @@ type Config struct {
 	Network            Network
 	BlockState         BlockState
 	StorageState       StorageState
+	EpochState         EpochState
+	GrandpaState       GrandpaState
 	FinalityGadget     FinalityGadget
 	TransactionState   TransactionState
 	BlockImportHandler BlockImportHandler
@@ -37,6 +40,15 @@ type Config struct {
 	MinPeers, MaxPeers int
 	SlotDuration       time.Duration
 	Telemetry          telemetry.Client
+	// WarpSync enables jumping to the latest finalised block using
+	// warp sync proofs before syncing the following blocks. It implies
+	// StateSync, since the state of the block jumped to is not known.
+	WarpSync bool
+	// StateSync enables downloading the state of a recent finalised block
+	// from peers before syncing the following blocks. Without WarpSync, the
+	// recent finalised block is found by requesting the latest headers of
+	// peers with their justifications.
+	StateSync bool
 }
 
 // NewService returns a new *sync.Service
@@ -56,6 +68,18 @@ func NewService(cfg *Config) (*Service, error) {
 		slotDuration:  cfg.SlotDuration,
 	}
 
+	if cfg.WarpSync {
+		csCfg.warpSyncer = newWarpSyncer(cfg.BlockState, cfg.Network, cfg.FinalityGadget)
+	} else if cfg.StateSync {
+		csCfg.finalisedHeaderSyncer = newFinalisedHeaderSyncer(cfg.BlockState, cfg.Network, cfg.FinalityGadget)
+	}
+
+	if cfg.WarpSync || cfg.StateSync {
+		// blocks following the block warp synced to cannot be
+		// imported without its state, so it is always downloaded.
+		csCfg.stateSyncer = newStateSyncer(cfg.BlockState, cfg.StorageState, cfg.EpochState, cfg.Network)
+	}
+
 	chainSync := newChainSync(csCfg)
 	chainProcessor := newChainProcessor(readyBlocks, pendingBlocks,
 		cfg.BlockState, cfg.StorageState, cfg.TransactionState,
@@ -63,6 +87,7 @@ func NewService(cfg *Config) (*Service, error) {
 
 	return &Service{
 		blockState:     cfg.BlockState,
+		grandpaState:   cfg.GrandpaState,
 		chainSync:      chainSync,
 		chainProcessor: chainProcessor,
 		network:        cfg.Network,
@@ -89,6 +114,12 @@ func (s *Service) HandleBlockAnnounceHandshake(from peer.ID, msg *network.BlockA
 	return s.chainSync.setPeerHead(from, msg.BestBlockHash, uint(msg.BestBlockNumber))
 }
 
+// HandlePeerDisconnected notifies the `chainSync` module that
+// the connection to the given peer was closed.
+func (s *Service) HandlePeerDisconnected(who peer.ID) {
+	s.chainSync.peerDisconnected(who)
+}
+
 // HandleBlockAnnounce notifies the `chainSync` module that we have received a block announcement from the given peer.
 func (s *Service) HandleBlockAnnounce(from peer.ID, msg *network.BlockAnnounceMessage) error {
 	logger.Debug("received BlockAnnounceMessage")
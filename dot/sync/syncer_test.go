@@ -190,6 +190,19 @@ func Test_Service_HandleBlockAnnounceHandshake(t *testing.T) {
 	}
 }
 
+func TestService_HandlePeerDisconnected(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	chainSync := NewMockChainSync(ctrl)
+	chainSync.EXPECT().peerDisconnected(peer.ID("abc"))
+	service := Service{
+		chainSync: chainSync,
+	}
+
+	service.HandlePeerDisconnected(peer.ID("abc"))
+}
+
 func TestService_IsSynced(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,258 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// CreateWarpSyncProof creates the warp sync proof starting from the finalised block
+// with the given hash. The proof contains the header of each block enacting an authority
+// set change after the begin block, together with its justification, and the latest
+// finalised block with its justification. It is limited to network.MaxWarpSyncProofSize
+// bytes, in which case it is not finished and must be continued by another request.
+func (s *Service) CreateWarpSyncProof(begin common.Hash) (*network.WarpSyncProof, error) {
+	beginHeader, err := s.blockState.GetHeader(begin)
+	if err != nil {
+		return nil, fmt.Errorf("getting begin header: %w", err)
+	}
+
+	finalised, err := s.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	if beginHeader.Number > finalised.Number {
+		return nil, fmt.Errorf("%w: begin block number %d is greater than finalised block number %d",
+			errWarpSyncBeginNotFinalised, beginHeader.Number, finalised.Number)
+	}
+
+	canonicalHash, err := s.blockState.GetHashByNumber(beginHeader.Number)
+	if err != nil {
+		return nil, fmt.Errorf("getting canonical hash for block number %d: %w", beginHeader.Number, err)
+	}
+
+	if !canonicalHash.Equal(begin) {
+		return nil, fmt.Errorf("%w: %s", errWarpSyncBeginNotCanonical, begin)
+	}
+
+	beginSetID, err := s.grandpaState.GetSetIDByBlockNumber(beginHeader.Number)
+	if err != nil {
+		return nil, fmt.Errorf("getting set ID for block number %d: %w", beginHeader.Number, err)
+	}
+
+	currentSetID, err := s.grandpaState.GetCurrentSetID()
+	if err != nil {
+		return nil, fmt.Errorf("getting current set ID: %w", err)
+	}
+
+	proof := &network.WarpSyncProof{}
+	proofSize := 0
+	lastNumber := beginHeader.Number
+
+	for setID := beginSetID + 1; setID <= currentSetID; setID++ {
+		number, err := s.grandpaState.GetSetIDChange(setID)
+		if err != nil {
+			return nil, fmt.Errorf("getting block number of set ID %d change: %w", setID, err)
+		}
+
+		if number <= beginHeader.Number || number > finalised.Number {
+			continue
+		}
+
+		fragment, err := s.createWarpSyncFragment(number)
+		if errors.Is(err, chaindb.ErrKeyNotFound) {
+			// the justification of the authority set change block is not known,
+			// so the authority set changes after it cannot be proven.
+			logger.Debugf("no justification for block number %d enacting set ID %d", number, setID)
+			return proof, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		fragmentSize, err := encodedLength(fragment)
+		if err != nil {
+			return nil, err
+		}
+
+		if proofSize+fragmentSize > network.MaxWarpSyncProofSize {
+			return proof, nil
+		}
+
+		proof.Fragments = append(proof.Fragments, *fragment)
+		proofSize += fragmentSize
+		lastNumber = number
+	}
+
+	if finalised.Number > lastNumber {
+		fragment, err := s.createWarpSyncFragment(finalised.Number)
+		if err != nil && !errors.Is(err, chaindb.ErrKeyNotFound) {
+			return nil, err
+		}
+
+		if err == nil {
+			fragmentSize, err := encodedLength(fragment)
+			if err != nil {
+				return nil, err
+			}
+
+			if proofSize+fragmentSize > network.MaxWarpSyncProofSize {
+				return proof, nil
+			}
+
+			proof.Fragments = append(proof.Fragments, *fragment)
+		}
+	}
+
+	proof.IsFinished = true
+	return proof, nil
+}
+
+func (s *Service) createWarpSyncFragment(number uint) (*network.WarpSyncFragment, error) {
+	header, err := s.blockState.GetHeaderByNumber(number)
+	if err != nil {
+		return nil, fmt.Errorf("getting header for block number %d: %w", number, err)
+	}
+
+	encodedJustification, err := s.blockState.GetJustification(header.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("getting justification for block number %d: %w", number, err)
+	}
+
+	justification, err := types.DecodeGrandpaJustification(encodedJustification)
+	if err != nil {
+		return nil, fmt.Errorf("decoding justification for block number %d: %w", number, err)
+	}
+
+	return &network.WarpSyncFragment{
+		Header:        *header,
+		Justification: *justification,
+	}, nil
+}
+
+func encodedLength(fragment *network.WarpSyncFragment) (length int, err error) {
+	encoded, err := scale.Marshal(*fragment)
+	if err != nil {
+		return 0, fmt.Errorf("encoding warp sync fragment: %w", err)
+	}
+	return len(encoded), nil
+}
+
+// warpSyncer jumps to the latest finalised block of the chain by
+// requesting and verifying warp sync proofs from peers.
+type warpSyncer struct {
+	blockState     BlockState
+	network        Network
+	finalityGadget FinalityGadget
+}
+
+func newWarpSyncer(bs BlockState, net Network, fg FinalityGadget) *warpSyncer {
+	return &warpSyncer{
+		blockState:     bs,
+		network:        net,
+		finalityGadget: fg,
+	}
+}
+
+// sync warp syncs from each of the given peers in turn, until one of them
+// proves its latest finalised block. Each fragment verified is set as
+// the highest finalised block, so progress made with a peer is kept.
+func (w *warpSyncer) sync(peers []peer.ID) error {
+	for _, who := range peers {
+		err := w.syncFromPeer(who)
+		if err == nil {
+			return nil
+		}
+		logger.Debugf("failed to warp sync from peer %s: %s", who, err)
+	}
+
+	return errWarpSyncFailed
+}
+
+func (w *warpSyncer) syncFromPeer(who peer.ID) error {
+	for {
+		finalised, err := w.blockState.GetHighestFinalisedHeader()
+		if err != nil {
+			return fmt.Errorf("getting highest finalised header: %w", err)
+		}
+
+		req := &network.WarpSyncRequest{
+			Begin: finalised.Hash(),
+		}
+
+		proof, err := w.network.DoWarpSyncRequest(who, req)
+		if err != nil {
+			return fmt.Errorf("requesting warp sync proof: %w", err)
+		}
+
+		if len(proof.Fragments) == 0 && !proof.IsFinished {
+			return errEmptyWarpSyncProof
+		}
+
+		lastNumber := finalised.Number
+		for i := range proof.Fragments {
+			fragment := &proof.Fragments[i]
+			if fragment.Header.Number <= lastNumber {
+				w.network.ReportPeer(peerset.ReputationChange{
+					Value:  peerset.BadMessageValue,
+					Reason: peerset.BadMessageReason,
+				}, who)
+				return fmt.Errorf("%w: fragment number %d and previous block number %d",
+					errWarpSyncFragmentNotAfter, fragment.Header.Number, lastNumber)
+			}
+
+			err = w.importFragment(fragment)
+			if err != nil {
+				w.network.ReportPeer(peerset.ReputationChange{
+					Value:  peerset.BadJustificationValue,
+					Reason: peerset.BadJustificationReason,
+				}, who)
+				return err
+			}
+
+			lastNumber = fragment.Header.Number
+		}
+
+		if proof.IsFinished {
+			logger.Infof("warp synced to finalised block number %d", lastNumber)
+			return nil
+		}
+	}
+}
+
+// importFragment verifies the justification of the fragment and sets
+// its header as the highest finalised block.
+func (w *warpSyncer) importFragment(fragment *network.WarpSyncFragment) error {
+	header := &fragment.Header
+	justification, err := scale.Marshal(fragment.Justification)
+	if err != nil {
+		return fmt.Errorf("encoding justification: %w", err)
+	}
+
+	round, setID, err := w.finalityGadget.VerifyWarpSyncFragment(header, justification)
+	if err != nil {
+		return fmt.Errorf("verifying warp sync fragment for block number %d: %w", header.Number, err)
+	}
+
+	err = w.blockState.SetWarpSyncFinalisedHeader(header, round, setID)
+	if err != nil {
+		return fmt.Errorf("setting finalised header: %w", err)
+	}
+
+	err = w.blockState.SetJustification(header.Hash(), justification)
+	if err != nil {
+		return fmt.Errorf("setting justification: %w", err)
+	}
+
+	return nil
+}
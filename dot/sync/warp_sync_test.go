@@ -0,0 +1,314 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newWarpTestHeader(number uint) *types.Header {
+	header := &types.Header{
+		ParentHash: common.Hash{byte(number)},
+		Number:     number,
+		Digest:     types.NewDigest(),
+	}
+	// cache the hash so copies of the header compare equal
+	header.Hash()
+	return header
+}
+
+// newWarpTestJustification returns a justification for the header given,
+// finalised in the round given, and its SCALE encoding.
+func newWarpTestJustification(t *testing.T, header *types.Header, round uint64) (
+	justification types.GrandpaJustification, encoded []byte) {
+	t.Helper()
+
+	justification = types.GrandpaJustification{
+		Round: round,
+		Commit: types.GrandpaCommit{
+			Hash:   header.Hash(),
+			Number: uint32(header.Number),
+		},
+	}
+
+	encoded, err := scale.Marshal(justification)
+	require.NoError(t, err)
+	return justification, encoded
+}
+
+func Test_Service_CreateWarpSyncProof(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	begin := newWarpTestHeader(1)
+	change := newWarpTestHeader(5)
+	finalised := newWarpTestHeader(8)
+	changeJustification, encodedChangeJustification := newWarpTestJustification(t, change, 1)
+	finalisedJustification, encodedFinalisedJustification := newWarpTestJustification(t, finalised, 2)
+
+	testCases := map[string]struct {
+		serviceBuilder func(ctrl *gomock.Controller) *Service
+		proof          *network.WarpSyncProof
+		errWrapped     error
+		errMessage     string
+	}{
+		"begin header error": {
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeader(begin.Hash()).Return(nil, errTest)
+				return &Service{blockState: blockState}
+			},
+			errWrapped: errTest,
+			errMessage: "getting begin header: test error",
+		},
+		"begin not finalised": {
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeader(begin.Hash()).Return(begin, nil)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(newWarpTestHeader(0), nil)
+				return &Service{blockState: blockState}
+			},
+			errWrapped: errWarpSyncBeginNotFinalised,
+			errMessage: "warp sync begin block is not finalised: " +
+				"begin block number 1 is greater than finalised block number 0",
+		},
+		"begin not canonical": {
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeader(begin.Hash()).Return(begin, nil)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(finalised, nil)
+				blockState.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{9}, nil)
+				return &Service{blockState: blockState}
+			},
+			errWrapped: errWarpSyncBeginNotCanonical,
+			errMessage: "warp sync begin block is not on the canonical chain: " + begin.Hash().String(),
+		},
+		"missing authority set change justification": {
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeader(begin.Hash()).Return(begin, nil)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(finalised, nil)
+				blockState.EXPECT().GetHashByNumber(uint(1)).Return(begin.Hash(), nil)
+				blockState.EXPECT().GetHeaderByNumber(uint(5)).Return(change, nil)
+				blockState.EXPECT().GetJustification(change.Hash()).Return(nil, chaindb.ErrKeyNotFound)
+				grandpaState := NewMockGrandpaState(ctrl)
+				grandpaState.EXPECT().GetSetIDByBlockNumber(uint(1)).Return(uint64(0), nil)
+				grandpaState.EXPECT().GetCurrentSetID().Return(uint64(1), nil)
+				grandpaState.EXPECT().GetSetIDChange(uint64(1)).Return(uint(5), nil)
+				return &Service{blockState: blockState, grandpaState: grandpaState}
+			},
+			proof: &network.WarpSyncProof{},
+		},
+		"finished proof": {
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeader(begin.Hash()).Return(begin, nil)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(finalised, nil)
+				blockState.EXPECT().GetHashByNumber(uint(1)).Return(begin.Hash(), nil)
+				blockState.EXPECT().GetHeaderByNumber(uint(5)).Return(change, nil)
+				blockState.EXPECT().GetJustification(change.Hash()).Return(encodedChangeJustification, nil)
+				blockState.EXPECT().GetHeaderByNumber(uint(8)).Return(finalised, nil)
+				blockState.EXPECT().GetJustification(finalised.Hash()).Return(encodedFinalisedJustification, nil)
+				grandpaState := NewMockGrandpaState(ctrl)
+				grandpaState.EXPECT().GetSetIDByBlockNumber(uint(1)).Return(uint64(0), nil)
+				grandpaState.EXPECT().GetCurrentSetID().Return(uint64(2), nil)
+				grandpaState.EXPECT().GetSetIDChange(uint64(1)).Return(uint(5), nil)
+				// set ID 2 is enacted after the finalised block
+				grandpaState.EXPECT().GetSetIDChange(uint64(2)).Return(uint(10), nil)
+				return &Service{blockState: blockState, grandpaState: grandpaState}
+			},
+			proof: &network.WarpSyncProof{
+				Fragments: []network.WarpSyncFragment{
+					{Header: *change, Justification: changeJustification},
+					{Header: *finalised, Justification: finalisedJustification},
+				},
+				IsFinished: true,
+			},
+		},
+		"finalised block without justification": {
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetHeader(begin.Hash()).Return(begin, nil)
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(finalised, nil)
+				blockState.EXPECT().GetHashByNumber(uint(1)).Return(begin.Hash(), nil)
+				blockState.EXPECT().GetHeaderByNumber(uint(8)).Return(finalised, nil)
+				blockState.EXPECT().GetJustification(finalised.Hash()).Return(nil, chaindb.ErrKeyNotFound)
+				grandpaState := NewMockGrandpaState(ctrl)
+				grandpaState.EXPECT().GetSetIDByBlockNumber(uint(1)).Return(uint64(0), nil)
+				grandpaState.EXPECT().GetCurrentSetID().Return(uint64(0), nil)
+				return &Service{blockState: blockState, grandpaState: grandpaState}
+			},
+			proof: &network.WarpSyncProof{
+				IsFinished: true,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			service := testCase.serviceBuilder(ctrl)
+
+			proof, err := service.CreateWarpSyncProof(begin.Hash())
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.proof, proof)
+		})
+	}
+}
+
+func Test_warpSyncer_sync(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	const (
+		peerA = peer.ID("a")
+		peerB = peer.ID("b")
+	)
+	genesis := newWarpTestHeader(0)
+	change := newWarpTestHeader(5)
+	finalised := newWarpTestHeader(8)
+	changeJustification, encodedChangeJustification := newWarpTestJustification(t, change, 3)
+	finalisedJustification, encodedFinalisedJustification := newWarpTestJustification(t, finalised, 1)
+
+	testCases := map[string]struct {
+		warpSyncerBuilder func(ctrl *gomock.Controller) *warpSyncer
+		peers             []peer.ID
+		errWrapped        error
+		errMessage        string
+	}{
+		"no peers": {
+			warpSyncerBuilder: func(ctrl *gomock.Controller) *warpSyncer {
+				return newWarpSyncer(nil, nil, nil)
+			},
+			errWrapped: errWarpSyncFailed,
+			errMessage: "failed to warp sync from any peer",
+		},
+		"request error then success": {
+			warpSyncerBuilder: func(ctrl *gomock.Controller) *warpSyncer {
+				blockState := NewMockBlockState(ctrl)
+				net := NewMockNetwork(ctrl)
+				finalityGadget := NewMockFinalityGadget(ctrl)
+
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(genesis, nil)
+				net.EXPECT().DoWarpSyncRequest(peerA, &network.WarpSyncRequest{Begin: genesis.Hash()}).
+					Return(nil, errTest)
+
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(genesis, nil)
+				net.EXPECT().DoWarpSyncRequest(peerB, &network.WarpSyncRequest{Begin: genesis.Hash()}).
+					Return(&network.WarpSyncProof{
+						Fragments: []network.WarpSyncFragment{
+							{Header: *change, Justification: changeJustification},
+						},
+					}, nil)
+				finalityGadget.EXPECT().VerifyWarpSyncFragment(change, encodedChangeJustification).
+					Return(uint64(3), uint64(1), nil)
+				blockState.EXPECT().SetWarpSyncFinalisedHeader(change, uint64(3), uint64(1)).Return(nil)
+				blockState.EXPECT().SetJustification(change.Hash(), encodedChangeJustification).Return(nil)
+
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(change, nil)
+				net.EXPECT().DoWarpSyncRequest(peerB, &network.WarpSyncRequest{Begin: change.Hash()}).
+					Return(&network.WarpSyncProof{
+						Fragments: []network.WarpSyncFragment{
+							{Header: *finalised, Justification: finalisedJustification},
+						},
+						IsFinished: true,
+					}, nil)
+				finalityGadget.EXPECT().VerifyWarpSyncFragment(finalised, encodedFinalisedJustification).
+					Return(uint64(1), uint64(1), nil)
+				blockState.EXPECT().SetWarpSyncFinalisedHeader(finalised, uint64(1), uint64(1)).Return(nil)
+				blockState.EXPECT().SetJustification(finalised.Hash(), encodedFinalisedJustification).Return(nil)
+
+				return newWarpSyncer(blockState, net, finalityGadget)
+			},
+			peers: []peer.ID{peerA, peerB},
+		},
+		"fragment not after finalised block": {
+			warpSyncerBuilder: func(ctrl *gomock.Controller) *warpSyncer {
+				blockState := NewMockBlockState(ctrl)
+				net := NewMockNetwork(ctrl)
+
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(change, nil)
+				net.EXPECT().DoWarpSyncRequest(peerA, &network.WarpSyncRequest{Begin: change.Hash()}).
+					Return(&network.WarpSyncProof{
+						Fragments: []network.WarpSyncFragment{
+							{Header: *genesis},
+						},
+						IsFinished: true,
+					}, nil)
+				net.EXPECT().ReportPeer(peerset.ReputationChange{
+					Value:  peerset.BadMessageValue,
+					Reason: peerset.BadMessageReason,
+				}, peerA)
+
+				return newWarpSyncer(blockState, net, nil)
+			},
+			peers:      []peer.ID{peerA},
+			errWrapped: errWarpSyncFailed,
+			errMessage: "failed to warp sync from any peer",
+		},
+		"bad justification": {
+			warpSyncerBuilder: func(ctrl *gomock.Controller) *warpSyncer {
+				blockState := NewMockBlockState(ctrl)
+				net := NewMockNetwork(ctrl)
+				finalityGadget := NewMockFinalityGadget(ctrl)
+
+				blockState.EXPECT().GetHighestFinalisedHeader().Return(genesis, nil)
+				net.EXPECT().DoWarpSyncRequest(peerA, &network.WarpSyncRequest{Begin: genesis.Hash()}).
+					Return(&network.WarpSyncProof{
+						Fragments: []network.WarpSyncFragment{
+							{Header: *change, Justification: changeJustification},
+						},
+						IsFinished: true,
+					}, nil)
+				finalityGadget.EXPECT().VerifyWarpSyncFragment(change, encodedChangeJustification).
+					Return(uint64(0), uint64(0), errTest)
+				net.EXPECT().ReportPeer(peerset.ReputationChange{
+					Value:  peerset.BadJustificationValue,
+					Reason: peerset.BadJustificationReason,
+				}, peerA)
+
+				return newWarpSyncer(blockState, net, finalityGadget)
+			},
+			peers:      []peer.ID{peerA},
+			errWrapped: errWarpSyncFailed,
+			errMessage: "failed to warp sync from any peer",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			warpSyncer := testCase.warpSyncerBuilder(ctrl)
+
+			err := warpSyncer.sync(testCase.peers)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@
 import (
 	"errors"
 	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 )
 
 var ErrNoFirstPreDigest = errors.New("first digest item is not pre-digest")
@@ -106,6 +108,26 @@ type ConfigData struct {
 	SecondarySlots byte
 }
 
+// BabeEpoch is a BABE epoch as returned by the runtime calls
+// BabeApi_current_epoch and BabeApi_next_epoch
+type BabeEpoch struct {
+	EpochIndex  uint64
+	StartSlot   uint64
+	Duration    uint64 // duration of the epoch in slots
+	Authorities []AuthorityRaw
+	Randomness  [RandomnessLength]byte
+	Config      ConfigData
+}
+
+// ToEpochData returns the authorities and randomness of the BabeEpoch as EpochData
+func (e *BabeEpoch) ToEpochData() (*EpochData, error) {
+	raw := &EpochDataRaw{
+		Authorities: e.Authorities,
+		Randomness:  e.Randomness,
+	}
+	return raw.ToEpochData()
+}
+
 // GetSlotFromHeader returns the BABE slot from the given header
 func GetSlotFromHeader(header *Header) (uint64, error) {
 	if len(header.Digest.Types) == 0 {
@@ -170,3 +192,16 @@ func IsPrimary(header *Header) (bool, error) {
 		return false, nil
 	}
 }
+
+// BabeEquivocationProof is a proof that a BABE authority produced two
+// different headers for the same slot.
+type BabeEquivocationProof struct {
+	Offender     [sr25519.PublicKeyLength]byte
+	Slot         uint64
+	FirstHeader  Header
+	SecondHeader Header
+}
+
+// BabeOpaqueKeyOwnershipProof is an opaque proof, generated by the runtime,
+// that a BABE authority key belongs to a validator at a given session.
+type BabeOpaqueKeyOwnershipProof []byte
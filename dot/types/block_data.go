@@ -19,6 +19,7 @@ type BlockData struct {
 	Receipt       *[]byte
 	MessageQueue  *[]byte
 	Justification *[]byte
+	IndexedBody   *[][]byte `scale:"-"`
 }
 
 // NewEmptyBlockData Creates an empty blockData struct
@@ -54,5 +55,9 @@ func (bd *BlockData) String() string {
 		str = str + fmt.Sprintf("Justification=0x%x ", bd.Justification)
 	}
 
+	if bd.IndexedBody != nil {
+		str = str + fmt.Sprintf("IndexedBody=0x%x ", *bd.IndexedBody)
+	}
+
 	return str
 }
@@ -4,7 +4,9 @@
 package types
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
@@ -183,6 +185,94 @@ func (s *GrandpaSignedVote) String() string {
 	)
 }
 
+// GrandpaCommit holds the signed precommits for the block with the given hash and number
+type GrandpaCommit struct {
+	Hash       common.Hash
+	Number     uint32
+	Precommits []GrandpaSignedVote
+}
+
+// GrandpaJustification is a GRANDPA justification finalising the block of its commit.
+// VotesAncestries holds the headers proving the precommit targets descend from
+// the committed block.
+type GrandpaJustification struct {
+	Round           uint64
+	Commit          GrandpaCommit
+	VotesAncestries []Header
+}
+
+// UnmarshalSCALE decodes the SCALE encoding read from the reader into the GrandpaJustification.
+func (j *GrandpaJustification) UnmarshalSCALE(reader io.Reader) (err error) {
+	decoder := scale.NewDecoder(reader)
+	decoded := GrandpaJustification{}
+
+	err = decoder.Decode(&decoded.Round)
+	if err != nil {
+		return fmt.Errorf("decoding round: %w", err)
+	}
+
+	err = decoder.Decode(&decoded.Commit)
+	if err != nil {
+		return fmt.Errorf("decoding commit: %w", err)
+	}
+
+	decoded.VotesAncestries, err = decodeVotesAncestries(decoder)
+	if err != nil {
+		return err
+	}
+
+	*j = decoded
+	return nil
+}
+
+func decodeVotesAncestries(decoder *scale.Decoder) (headers []Header, err error) {
+	var headersCount uint
+	err = decoder.Decode(&headersCount)
+	if err != nil {
+		return nil, fmt.Errorf("decoding votes ancestries count: %w", err)
+	}
+
+	for i := uint(0); i < headersCount; i++ {
+		header := NewEmptyHeader()
+		err = decoder.Decode(header)
+		if err != nil {
+			return nil, fmt.Errorf("decoding votes ancestry %d: %w", i, err)
+		}
+		headers = append(headers, *header)
+	}
+
+	return headers, nil
+}
+
+// DecodeGrandpaJustification decodes the SCALE encoded justification given. The votes
+// ancestries are optional, since they are absent from justifications created by this node.
+func DecodeGrandpaJustification(encoded []byte) (justification *GrandpaJustification, err error) {
+	reader := bytes.NewReader(encoded)
+	decoder := scale.NewDecoder(reader)
+
+	justification = new(GrandpaJustification)
+	err = decoder.Decode(&justification.Round)
+	if err != nil {
+		return nil, fmt.Errorf("decoding round: %w", err)
+	}
+
+	err = decoder.Decode(&justification.Commit)
+	if err != nil {
+		return nil, fmt.Errorf("decoding commit: %w", err)
+	}
+
+	if reader.Len() == 0 {
+		return justification, nil
+	}
+
+	justification.VotesAncestries, err = decodeVotesAncestries(decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	return justification, nil
+}
+
 // GrandpaVote represents a vote for a block with the given hash and number
 type GrandpaVote struct {
 	Hash   common.Hash
@@ -193,3 +283,41 @@ type GrandpaVote struct {
 func (v *GrandpaVote) String() string {
 	return fmt.Sprintf("hash=%s number=%d", v.Hash, v.Number)
 }
+
+// GrandpaEquivocation is a proof that an authority voted for two different
+// blocks in the same round and stage.
+type GrandpaEquivocation struct {
+	RoundNumber     uint64
+	ID              ed25519.PublicKeyBytes
+	FirstVote       GrandpaVote
+	FirstSignature  [64]byte
+	SecondVote      GrandpaVote
+	SecondSignature [64]byte
+}
+
+// PreVoteEquivocation is an equivocation in the pre-vote stage
+type PreVoteEquivocation GrandpaEquivocation
+
+// Index returns VDT index
+func (PreVoteEquivocation) Index() uint { return 0 }
+
+// PreCommitEquivocation is an equivocation in the pre-commit stage
+type PreCommitEquivocation GrandpaEquivocation
+
+// Index returns VDT index
+func (PreCommitEquivocation) Index() uint { return 1 }
+
+// NewGrandpaEquivocation returns a new VaryingDataType to represent a GRANDPA equivocation
+func NewGrandpaEquivocation() scale.VaryingDataType {
+	return scale.MustNewVaryingDataType(PreVoteEquivocation{}, PreCommitEquivocation{})
+}
+
+// GrandpaEquivocationProof is the proof of an equivocation submitted to the runtime
+type GrandpaEquivocationProof struct {
+	SetID        uint64
+	Equivocation scale.VaryingDataType
+}
+
+// GrandpaOpaqueKeyOwnershipProof is an opaque proof, generated by the runtime,
+// that an authority key belongs to a validator at a given session.
+type GrandpaOpaqueKeyOwnershipProof []byte
@@ -82,3 +82,92 @@ func TestGrandpaAuthoritiesRawToAuthorities(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, a, authoritys[1])
 }
+
+func TestEncodeGrandpaEquivocationProof(t *testing.T) {
+	equivocation := NewGrandpaEquivocation()
+	err := equivocation.Set(PreCommitEquivocation{
+		RoundNumber: 2,
+		ID:          [32]byte{1},
+		FirstVote: GrandpaVote{
+			Hash:   common.Hash{0xa},
+			Number: 3,
+		},
+		FirstSignature: [64]byte{2},
+		SecondVote: GrandpaVote{
+			Hash:   common.Hash{0xb},
+			Number: 3,
+		},
+		SecondSignature: [64]byte{3},
+	})
+	require.NoError(t, err)
+
+	proof := GrandpaEquivocationProof{
+		SetID:        1,
+		Equivocation: equivocation,
+	}
+
+	exp := common.MustHexToBytes("0x0100000000000000" + // set id
+		"01" + // pre-commit equivocation
+		"0200000000000000" + // round number
+		"0100000000000000000000000000000000000000000000000000000000000000" + // authority id
+		"0a00000000000000000000000000000000000000000000000000000000000000" + "03000000" + // first vote
+		"02000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000" + //nolint:lll
+		"0b00000000000000000000000000000000000000000000000000000000000000" + "03000000" + // second vote
+		"03000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000") //nolint:lll
+
+	enc, err := scale.Marshal(proof)
+	require.NoError(t, err)
+	require.Equal(t, exp, enc)
+
+	dec := GrandpaEquivocationProof{
+		Equivocation: NewGrandpaEquivocation(),
+	}
+	err = scale.Unmarshal(enc, &dec)
+	require.NoError(t, err)
+	require.Equal(t, proof, dec)
+}
+
+func TestDecodeGrandpaJustification(t *testing.T) {
+	t.Parallel()
+
+	justification := GrandpaJustification{
+		Round: 2,
+		Commit: GrandpaCommit{
+			Hash:   common.Hash{1},
+			Number: 3,
+			Precommits: []GrandpaSignedVote{{
+				Vote:        GrandpaVote{Hash: common.Hash{4}, Number: 4},
+				Signature:   [64]byte{5},
+				AuthorityID: [32]byte{6},
+			}},
+		},
+		VotesAncestries: []Header{{
+			ParentHash: common.Hash{1},
+			Number:     4,
+			Digest:     NewDigest(),
+		}},
+	}
+
+	encoded, err := scale.Marshal(justification)
+	require.NoError(t, err)
+
+	decoded, err := DecodeGrandpaJustification(encoded)
+	require.NoError(t, err)
+	require.Equal(t, justification, *decoded)
+
+	// justification without votes ancestries
+	justification.VotesAncestries = nil
+	encoded, err = scale.Marshal(struct {
+		Round  uint64
+		Commit GrandpaCommit
+	}{Round: justification.Round, Commit: justification.Commit})
+	require.NoError(t, err)
+
+	decoded, err = DecodeGrandpaJustification(encoded)
+	require.NoError(t, err)
+	require.Equal(t, justification, *decoded)
+
+	_, err = DecodeGrandpaJustification(encoded[:10])
+	require.EqualError(t, err, "decoding commit: EOF, field: "+
+		"0x0000000000000000000000000000000000000000000000000000000000000000")
+}
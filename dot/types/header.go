@@ -10,6 +10,8 @@
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
+//go:generate go run github.com/ChainSafe/gossamer/cmd/scalegen -type=Header,Block,BlockData -output=scale_generated.go
+
 // Header is a state block header
 type Header struct {
 	ParentHash     common.Hash                `json:"parentHash"`
@@ -0,0 +1,361 @@
+// Code generated by scalegen. DO NOT EDIT.
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// MarshalSCALE returns the SCALE encoding of the Header.
+func (bh Header) MarshalSCALE() ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+	err := bh.encodeSCALE(buffer)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// encodeSCALE writes the SCALE encoding of the Header to the writer.
+func (bh Header) encodeSCALE(writer io.Writer) (err error) {
+	_, err = writer.Write(bh.ParentHash[:])
+	if err != nil {
+		return fmt.Errorf("encoding ParentHash: %w", err)
+	}
+	err = scale.EncodeCompactUint(writer, bh.Number)
+	if err != nil {
+		return fmt.Errorf("encoding Number: %w", err)
+	}
+	_, err = writer.Write(bh.StateRoot[:])
+	if err != nil {
+		return fmt.Errorf("encoding StateRoot: %w", err)
+	}
+	_, err = writer.Write(bh.ExtrinsicsRoot[:])
+	if err != nil {
+		return fmt.Errorf("encoding ExtrinsicsRoot: %w", err)
+	}
+	err = scale.NewEncoder(writer).Encode(bh.Digest)
+	if err != nil {
+		return fmt.Errorf("encoding Digest: %w", err)
+	}
+	return nil
+}
+
+// UnmarshalSCALE decodes the SCALE encoding read from the reader into the Header.
+func (bh *Header) UnmarshalSCALE(reader io.Reader) (err error) {
+	decoded := Header{}
+	decoded.Digest = bh.Digest
+	_, err = io.ReadFull(reader, decoded.ParentHash[:])
+	if err != nil {
+		return fmt.Errorf("decoding ParentHash: %w", err)
+	}
+	var v0 uint
+	v0, err = scale.DecodeCompactUint(reader)
+	if err != nil {
+		return fmt.Errorf("decoding Number: %w", err)
+	}
+	decoded.Number = v0
+	_, err = io.ReadFull(reader, decoded.StateRoot[:])
+	if err != nil {
+		return fmt.Errorf("decoding StateRoot: %w", err)
+	}
+	_, err = io.ReadFull(reader, decoded.ExtrinsicsRoot[:])
+	if err != nil {
+		return fmt.Errorf("decoding ExtrinsicsRoot: %w", err)
+	}
+	err = scale.NewDecoder(reader).Decode(&decoded.Digest)
+	if err != nil {
+		return fmt.Errorf("decoding Digest: %w", err)
+	}
+	*bh = decoded
+	return nil
+}
+
+// MarshalSCALE returns the SCALE encoding of the Block.
+func (b Block) MarshalSCALE() ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+	err := b.encodeSCALE(buffer)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// encodeSCALE writes the SCALE encoding of the Block to the writer.
+func (b Block) encodeSCALE(writer io.Writer) (err error) {
+	err = b.Header.encodeSCALE(writer)
+	if err != nil {
+		return fmt.Errorf("encoding Header: %w", err)
+	}
+	err = scale.EncodeCompactUint(writer, uint(len(b.Body)))
+	if err != nil {
+		return fmt.Errorf("encoding Body: %w", err)
+	}
+	for _, v0 := range b.Body {
+		err = scale.EncodeBytes(writer, []byte(v0))
+		if err != nil {
+			return fmt.Errorf("encoding Body: %w", err)
+		}
+	}
+	return nil
+}
+
+// UnmarshalSCALE decodes the SCALE encoding read from the reader into the Block.
+func (b *Block) UnmarshalSCALE(reader io.Reader) (err error) {
+	decoded := Block{}
+	decoded.Header = b.Header
+	err = decoded.Header.UnmarshalSCALE(reader)
+	if err != nil {
+		return fmt.Errorf("decoding Header: %w", err)
+	}
+	var length1 uint
+	length1, err = scale.DecodeLength(reader)
+	if err != nil {
+		return fmt.Errorf("decoding Body: %w", err)
+	}
+	for i2 := uint(0); i2 < length1; i2++ {
+		var v3 Extrinsic
+		var v4 []byte
+		v4, err = scale.DecodeBytes(reader)
+		if err != nil {
+			return fmt.Errorf("decoding Body: %w", err)
+		}
+		v3 = Extrinsic(v4)
+		decoded.Body = append(decoded.Body, v3)
+	}
+	*b = decoded
+	return nil
+}
+
+// MarshalSCALE returns the SCALE encoding of the BlockData.
+func (bd BlockData) MarshalSCALE() ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+	err := bd.encodeSCALE(buffer)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// encodeSCALE writes the SCALE encoding of the BlockData to the writer.
+func (bd BlockData) encodeSCALE(writer io.Writer) (err error) {
+	_, err = writer.Write(bd.Hash[:])
+	if err != nil {
+		return fmt.Errorf("encoding Hash: %w", err)
+	}
+	if bd.Header == nil {
+		_, err = writer.Write([]byte{0})
+		if err != nil {
+			return fmt.Errorf("encoding Header: %w", err)
+		}
+	} else {
+		_, err = writer.Write([]byte{1})
+		if err != nil {
+			return fmt.Errorf("encoding Header: %w", err)
+		}
+		err = bd.Header.encodeSCALE(writer)
+		if err != nil {
+			return fmt.Errorf("encoding Header: %w", err)
+		}
+	}
+	if bd.Body == nil {
+		_, err = writer.Write([]byte{0})
+		if err != nil {
+			return fmt.Errorf("encoding Body: %w", err)
+		}
+	} else {
+		_, err = writer.Write([]byte{1})
+		if err != nil {
+			return fmt.Errorf("encoding Body: %w", err)
+		}
+		err = scale.EncodeCompactUint(writer, uint(len(*bd.Body)))
+		if err != nil {
+			return fmt.Errorf("encoding Body: %w", err)
+		}
+		for _, v0 := range *bd.Body {
+			err = scale.EncodeBytes(writer, []byte(v0))
+			if err != nil {
+				return fmt.Errorf("encoding Body: %w", err)
+			}
+		}
+	}
+	if bd.Receipt == nil {
+		_, err = writer.Write([]byte{0})
+		if err != nil {
+			return fmt.Errorf("encoding Receipt: %w", err)
+		}
+	} else {
+		_, err = writer.Write([]byte{1})
+		if err != nil {
+			return fmt.Errorf("encoding Receipt: %w", err)
+		}
+		err = scale.EncodeBytes(writer, *bd.Receipt)
+		if err != nil {
+			return fmt.Errorf("encoding Receipt: %w", err)
+		}
+	}
+	if bd.MessageQueue == nil {
+		_, err = writer.Write([]byte{0})
+		if err != nil {
+			return fmt.Errorf("encoding MessageQueue: %w", err)
+		}
+	} else {
+		_, err = writer.Write([]byte{1})
+		if err != nil {
+			return fmt.Errorf("encoding MessageQueue: %w", err)
+		}
+		err = scale.EncodeBytes(writer, *bd.MessageQueue)
+		if err != nil {
+			return fmt.Errorf("encoding MessageQueue: %w", err)
+		}
+	}
+	if bd.Justification == nil {
+		_, err = writer.Write([]byte{0})
+		if err != nil {
+			return fmt.Errorf("encoding Justification: %w", err)
+		}
+	} else {
+		_, err = writer.Write([]byte{1})
+		if err != nil {
+			return fmt.Errorf("encoding Justification: %w", err)
+		}
+		err = scale.EncodeBytes(writer, *bd.Justification)
+		if err != nil {
+			return fmt.Errorf("encoding Justification: %w", err)
+		}
+	}
+	return nil
+}
+
+// UnmarshalSCALE decodes the SCALE encoding read from the reader into the BlockData.
+func (bd *BlockData) UnmarshalSCALE(reader io.Reader) (err error) {
+	decoded := BlockData{}
+	decoded.Header = bd.Header
+	decoded.Body = bd.Body
+	decoded.Receipt = bd.Receipt
+	decoded.MessageQueue = bd.MessageQueue
+	decoded.Justification = bd.Justification
+	_, err = io.ReadFull(reader, decoded.Hash[:])
+	if err != nil {
+		return fmt.Errorf("decoding Hash: %w", err)
+	}
+	var option1 uint8
+	option1, err = scale.DecodeUint8(reader)
+	if err != nil {
+		return fmt.Errorf("decoding Header: %w", err)
+	}
+	switch option1 {
+	case 0:
+		decoded.Header = nil
+	case 1:
+		if decoded.Header == nil {
+			decoded.Header = new(Header)
+		}
+		err = decoded.Header.UnmarshalSCALE(reader)
+		if err != nil {
+			return fmt.Errorf("decoding Header: %w", err)
+		}
+	default:
+		return fmt.Errorf("decoding Header: unsupported Option value: %d", option1)
+	}
+	var option2 uint8
+	option2, err = scale.DecodeUint8(reader)
+	if err != nil {
+		return fmt.Errorf("decoding Body: %w", err)
+	}
+	switch option2 {
+	case 0:
+		decoded.Body = nil
+	case 1:
+		if decoded.Body == nil {
+			decoded.Body = new(Body)
+		}
+		var length3 uint
+		length3, err = scale.DecodeLength(reader)
+		if err != nil {
+			return fmt.Errorf("decoding Body: %w", err)
+		}
+		*decoded.Body = nil
+		for i4 := uint(0); i4 < length3; i4++ {
+			var v5 Extrinsic
+			var v6 []byte
+			v6, err = scale.DecodeBytes(reader)
+			if err != nil {
+				return fmt.Errorf("decoding Body: %w", err)
+			}
+			v5 = Extrinsic(v6)
+			*decoded.Body = append(*decoded.Body, v5)
+		}
+	default:
+		return fmt.Errorf("decoding Body: unsupported Option value: %d", option2)
+	}
+	var option7 uint8
+	option7, err = scale.DecodeUint8(reader)
+	if err != nil {
+		return fmt.Errorf("decoding Receipt: %w", err)
+	}
+	switch option7 {
+	case 0:
+		decoded.Receipt = nil
+	case 1:
+		if decoded.Receipt == nil {
+			decoded.Receipt = new([]byte)
+		}
+		var v8 []byte
+		v8, err = scale.DecodeBytes(reader)
+		if err != nil {
+			return fmt.Errorf("decoding Receipt: %w", err)
+		}
+		*decoded.Receipt = v8
+	default:
+		return fmt.Errorf("decoding Receipt: unsupported Option value: %d", option7)
+	}
+	var option9 uint8
+	option9, err = scale.DecodeUint8(reader)
+	if err != nil {
+		return fmt.Errorf("decoding MessageQueue: %w", err)
+	}
+	switch option9 {
+	case 0:
+		decoded.MessageQueue = nil
+	case 1:
+		if decoded.MessageQueue == nil {
+			decoded.MessageQueue = new([]byte)
+		}
+		var v10 []byte
+		v10, err = scale.DecodeBytes(reader)
+		if err != nil {
+			return fmt.Errorf("decoding MessageQueue: %w", err)
+		}
+		*decoded.MessageQueue = v10
+	default:
+		return fmt.Errorf("decoding MessageQueue: unsupported Option value: %d", option9)
+	}
+	var option11 uint8
+	option11, err = scale.DecodeUint8(reader)
+	if err != nil {
+		return fmt.Errorf("decoding Justification: %w", err)
+	}
+	switch option11 {
+	case 0:
+		decoded.Justification = nil
+	case 1:
+		if decoded.Justification == nil {
+			decoded.Justification = new([]byte)
+		}
+		var v12 []byte
+		v12, err = scale.DecodeBytes(reader)
+		if err != nil {
+			return fmt.Errorf("decoding Justification: %w", err)
+		}
+		*decoded.Justification = v12
+	default:
+		return fmt.Errorf("decoding Justification: unsupported Option value: %d", option11)
+	}
+	*bd = decoded
+	return nil
+}
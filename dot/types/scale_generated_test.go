@@ -0,0 +1,192 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package types
+
+import (
+	"io"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The types below have no method, so they are encoded and decoded
+// using the reflection based encoder and decoder of the scale package.
+type (
+	reflectiveHeader    Header
+	reflectiveBlock     Block
+	reflectiveBlockData BlockData
+)
+
+func newTestGeneratedHeader(t *testing.T) *Header {
+	t.Helper()
+
+	digest := NewDigest()
+	err := digest.Add(
+		PreRuntimeDigest{
+			ConsensusEngineID: BabeEngineID,
+			Data:              []byte{1, 2, 3},
+		},
+		SealDigest{
+			ConsensusEngineID: BabeEngineID,
+			Data:              []byte{4, 5, 6, 7},
+		},
+	)
+	require.NoError(t, err)
+
+	return &Header{
+		ParentHash:     common.Hash{1},
+		Number:         1 << 20,
+		StateRoot:      common.Hash{2},
+		ExtrinsicsRoot: common.Hash{3},
+		Digest:         digest,
+	}
+}
+
+func Test_Header_generatedSCALE(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]Header{
+		"empty":       *NewEmptyHeader(),
+		"with digest": *newTestGeneratedHeader(t),
+	}
+
+	for name, header := range testCases {
+		header := header
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			encoding, err := header.MarshalSCALE()
+			require.NoError(t, err)
+
+			reflectiveEncoding, err := scale.Marshal(reflectiveHeader(header))
+			require.NoError(t, err)
+			assert.Equal(t, reflectiveEncoding, encoding)
+
+			decoded := NewEmptyHeader()
+			err = scale.Unmarshal(encoding, decoded)
+			require.NoError(t, err)
+
+			reflectiveDecoded := reflectiveHeader(*NewEmptyHeader())
+			err = scale.Unmarshal(encoding, &reflectiveDecoded)
+			require.NoError(t, err)
+
+			assert.Equal(t, header, *decoded)
+			assert.Equal(t, Header(reflectiveDecoded), *decoded)
+		})
+	}
+}
+
+func Test_Header_UnmarshalSCALE_resetsHash(t *testing.T) {
+	t.Parallel()
+
+	header := newTestGeneratedHeader(t)
+	encoding, err := header.MarshalSCALE()
+	require.NoError(t, err)
+
+	decoded := NewEmptyHeader()
+	_ = decoded.Hash()
+
+	err = scale.Unmarshal(encoding, decoded)
+	require.NoError(t, err)
+	assert.Equal(t, header.Hash(), decoded.Hash())
+}
+
+func Test_Block_generatedSCALE(t *testing.T) {
+	t.Parallel()
+
+	block := Block{
+		Header: *newTestGeneratedHeader(t),
+		Body:   Body{{1, 2}, {3}},
+	}
+
+	encoding, err := block.MarshalSCALE()
+	require.NoError(t, err)
+
+	reflectiveEncoding, err := scale.Marshal(reflectiveBlock(block))
+	require.NoError(t, err)
+	assert.Equal(t, reflectiveEncoding, encoding)
+
+	// the Encode method encodes the header and body separately.
+	blockEncoding, err := block.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, blockEncoding, encoding)
+
+	decoded := NewBlock(*NewEmptyHeader(), nil)
+	err = scale.Unmarshal(encoding, &decoded)
+	require.NoError(t, err)
+
+	reflectiveDecoded := reflectiveBlock(NewBlock(*NewEmptyHeader(), nil))
+	err = scale.Unmarshal(encoding, &reflectiveDecoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, block, decoded)
+	assert.Equal(t, Block(reflectiveDecoded), decoded)
+}
+
+func Test_BlockData_generatedSCALE(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]BlockData{
+		"empty": {
+			Hash: common.Hash{1},
+		},
+		"all fields": {
+			Hash:          common.Hash{1},
+			Header:        newTestGeneratedHeader(t),
+			Body:          NewBody([]Extrinsic{{1, 2}, {3}}),
+			Receipt:       &[]byte{4},
+			MessageQueue:  &[]byte{5, 6},
+			Justification: &[]byte{7},
+		},
+	}
+
+	for name, blockData := range testCases {
+		blockData := blockData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			encoding, err := blockData.MarshalSCALE()
+			require.NoError(t, err)
+
+			reflectiveEncoding, err := scale.Marshal(reflectiveBlockData(blockData))
+			require.NoError(t, err)
+			assert.Equal(t, reflectiveEncoding, encoding)
+
+			decoded := BlockData{Header: NewEmptyHeader()}
+			err = scale.Unmarshal(encoding, &decoded)
+			require.NoError(t, err)
+
+			reflectiveDecoded := reflectiveBlockData{Header: NewEmptyHeader()}
+			err = scale.Unmarshal(encoding, &reflectiveDecoded)
+			require.NoError(t, err)
+
+			assert.Equal(t, blockData, decoded)
+			// the reflective decoder keeps the existing pointer
+			// of the header when decoding a None option.
+			if blockData.Header == nil {
+				reflectiveDecoded.Header = nil
+			}
+			assert.Equal(t, BlockData(reflectiveDecoded), decoded)
+		})
+	}
+}
+
+func Test_BlockData_UnmarshalSCALE_errors(t *testing.T) {
+	t.Parallel()
+
+	encoding := append(make([]byte, 32), 2)
+
+	var blockData BlockData
+	err := scale.Unmarshal(encoding, &blockData)
+	assert.EqualError(t, err, "unmarshaling types.BlockData: "+
+		"decoding Header: unsupported Option value: 2")
+
+	err = scale.Unmarshal(encoding[:16], &blockData)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.EqualError(t, err, "unmarshaling types.BlockData: "+
+		"decoding Hash: unexpected EOF")
+}
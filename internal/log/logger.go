@@ -51,3 +51,11 @@ func (l *Logger) New(options ...Option) *Logger {
 
 	return newLogger
 }
+
+// Level returns the current level of the logger.
+func (l *Logger) Level() Level {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return *l.settings.level
+}
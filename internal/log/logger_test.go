@@ -144,3 +144,17 @@ func Test_Logger_New(t *testing.T) {
 		})
 	}
 }
+
+func Test_Logger_Level(t *testing.T) {
+	t.Parallel()
+
+	logger := New(SetLevel(Debug))
+	assert.Equal(t, Debug, logger.Level())
+
+	child := logger.New(SetLevel(Trace))
+	assert.Equal(t, Trace, child.Level())
+
+	logger.Patch(SetLevel(Error))
+	assert.Equal(t, Error, logger.Level())
+	assert.Equal(t, Error, child.Level())
+}
@@ -19,14 +19,14 @@ type encodingAsyncResult struct {
 	err    error
 }
 
-func runEncodeChild(child *Node, index int,
+func runEncodeChild(child *Node, index, maxInlineValue int,
 	results chan<- encodingAsyncResult, rateLimit <-chan struct{}) {
 	buffer := pools.EncodingBuffers.Get().(*bytes.Buffer)
 	buffer.Reset()
 	// buffer is put back in the pool after processing its
 	// data in the select block below.
 
-	err := encodeChild(child, buffer)
+	err := encodeChild(child, maxInlineValue, buffer)
 
 	results <- encodingAsyncResult{
 		index:  index,
@@ -49,14 +49,14 @@ func runEncodeChild(child *Node, index int,
 // goroutines IF they are less than the parallelLimit number of goroutines already
 // running. This is designed to limit the total number of goroutines in order to
 // avoid using too much memory on the stack.
-func encodeChildrenOpportunisticParallel(children []*Node, buffer io.Writer) (err error) {
+func encodeChildrenOpportunisticParallel(children []*Node, maxInlineValue int, buffer io.Writer) (err error) {
 	// Buffered channels since children might be encoded in this
 	// goroutine or another one.
 	resultsCh := make(chan encodingAsyncResult, ChildrenCapacity)
 
 	for i, child := range children {
 		if child == nil || child.Kind() == Leaf {
-			runEncodeChild(child, i, resultsCh, nil)
+			runEncodeChild(child, i, maxInlineValue, resultsCh, nil)
 			continue
 		}
 
@@ -65,11 +65,11 @@ func encodeChildrenOpportunisticParallel(children []*Node, buffer io.Writer) (er
 		case parallelEncodingRateLimit <- struct{}{}:
 			// We have a goroutine available to encode
 			// the branch in parallel.
-			go runEncodeChild(child, i, resultsCh, parallelEncodingRateLimit)
+			go runEncodeChild(child, i, maxInlineValue, resultsCh, parallelEncodingRateLimit)
 		default:
 			// we reached the maximum parallel goroutines
 			// so encode this branch in this goroutine
-			runEncodeChild(child, i, resultsCh, nil)
+			runEncodeChild(child, i, maxInlineValue, resultsCh, nil)
 		}
 	}
 
@@ -114,9 +114,9 @@ func encodeChildrenOpportunisticParallel(children []*Node, buffer io.Writer) (er
 	return err
 }
 
-func encodeChildrenSequentially(children []*Node, buffer io.Writer) (err error) {
+func encodeChildrenSequentially(children []*Node, maxInlineValue int, buffer io.Writer) (err error) {
 	for i, child := range children {
-		err = encodeChild(child, buffer)
+		err = encodeChild(child, maxInlineValue, buffer)
 		if err != nil {
 			return fmt.Errorf("cannot encode child at index %d: %w", i, err)
 		}
@@ -124,12 +124,12 @@ func encodeChildrenSequentially(children []*Node, buffer io.Writer) (err error)
 	return nil
 }
 
-func encodeChild(child *Node, buffer io.Writer) (err error) {
+func encodeChild(child *Node, maxInlineValue int, buffer io.Writer) (err error) {
 	if child == nil {
 		return nil
 	}
 
-	scaleEncodedChildHash, err := scaleEncodeHash(child)
+	scaleEncodedChildHash, err := scaleEncodeHash(child, maxInlineValue)
 	if err != nil {
 		return fmt.Errorf("failed to hash and scale encode child: %w", err)
 	}
@@ -145,8 +145,8 @@ func encodeChild(child *Node, buffer io.Writer) (err error) {
 // scaleEncodeHash hashes the node (blake2b sum on encoded value)
 // and then SCALE encodes it. This is used to encode children
 // nodes of branches.
-func scaleEncodeHash(node *Node) (encoding []byte, err error) {
-	_, merkleValue, err := node.EncodeAndHash()
+func scaleEncodeHash(node *Node, maxInlineValue int) (encoding []byte, err error) {
+	_, merkleValue, err := node.EncodeAndHash(maxInlineValue)
 	if err != nil {
 		return nil, fmt.Errorf("encoding and hashing %s: %w", node.Kind(), err)
 	}
@@ -23,7 +23,7 @@ func Benchmark_encodeChildrenOpportunisticParallel(b *testing.B) {
 
 	b.Run("", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
-			_ = encodeChildrenOpportunisticParallel(children, io.Discard)
+			_ = encodeChildrenOpportunisticParallel(children, NoMaxInlineValueSize, io.Discard)
 		}
 	})
 }
@@ -156,7 +156,7 @@ func Test_encodeChildrenOpportunisticParallel(t *testing.T) {
 				previousCall = call
 			}
 
-			err := encodeChildrenOpportunisticParallel(testCase.children, buffer)
+			err := encodeChildrenOpportunisticParallel(testCase.children, NoMaxInlineValueSize, buffer)
 
 			if testCase.wrappedErr != nil {
 				assert.ErrorIs(t, err, testCase.wrappedErr)
@@ -179,7 +179,7 @@ func Test_encodeChildrenOpportunisticParallel(t *testing.T) {
 
 		buffer := bytes.NewBuffer(nil)
 
-		err := encodeChildrenOpportunisticParallel(children, buffer)
+		err := encodeChildrenOpportunisticParallel(children, NoMaxInlineValueSize, buffer)
 
 		require.NoError(t, err)
 		expectedBytes := []byte{
@@ -281,7 +281,7 @@ func Test_encodeChildrenSequentially(t *testing.T) {
 				previousCall = call
 			}
 
-			err := encodeChildrenSequentially(testCase.children, buffer)
+			err := encodeChildrenSequentially(testCase.children, NoMaxInlineValueSize, buffer)
 
 			if testCase.wrappedErr != nil {
 				assert.ErrorIs(t, err, testCase.wrappedErr)
@@ -366,7 +366,7 @@ func Test_encodeChild(t *testing.T) {
 					Return(testCase.write.n, testCase.write.err)
 			}
 
-			err := encodeChild(testCase.child, buffer)
+			err := encodeChild(testCase.child, NoMaxInlineValueSize, buffer)
 
 			if testCase.wrappedErr != nil {
 				assert.ErrorIs(t, err, testCase.wrappedErr)
@@ -404,7 +404,7 @@ func Test_scaleEncodeHash(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			encoding, err := scaleEncodeHash(testCase.node)
+			encoding, err := scaleEncodeHash(testCase.node, NoMaxInlineValueSize)
 
 			if testCase.wrappedErr != nil {
 				assert.ErrorIs(t, err, testCase.wrappedErr)
@@ -91,6 +91,7 @@ func (n *Node) Copy(settings CopySettings) *Node {
 	if settings.CopyValue && n.SubValue != nil {
 		cpy.SubValue = make([]byte, len(n.SubValue))
 		copy(cpy.SubValue, n.SubValue)
+		cpy.IsHashedValue = n.IsHashedValue
 	}
 
 	if settings.CopyCached {
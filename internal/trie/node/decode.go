@@ -24,6 +24,9 @@
 	// TODO remove once the following issue is done:
 	// https://github.com/ChainSafe/gossamer/issues/2631 .
 	ErrDecodeChildHash = errors.New("cannot decode child hash")
+	// ErrDecodeHashedValue is returned when the hash of a node value
+	// cannot be read, for the state trie version 1 node variants.
+	ErrDecodeHashedValue = errors.New("cannot decode hashed value")
 )
 
 // Decode decodes a node from a reader.
@@ -39,13 +42,13 @@ func Decode(reader io.Reader) (n *Node, err error) {
 	}
 
 	switch variant {
-	case leafVariant.bits:
-		n, err = decodeLeaf(reader, partialKeyLength)
+	case leafVariant.bits, leafWithHashedValueVariant.bits:
+		n, err = decodeLeaf(reader, variant, partialKeyLength)
 		if err != nil {
 			return nil, fmt.Errorf("cannot decode leaf: %w", err)
 		}
 		return n, nil
-	case branchVariant.bits, branchWithValueVariant.bits:
+	case branchVariant.bits, branchWithValueVariant.bits, branchWithHashedValueVariant.bits:
 		n, err = decodeBranch(reader, variant, partialKeyLength)
 		if err != nil {
 			return nil, fmt.Errorf("cannot decode branch: %w", err)
@@ -83,11 +86,18 @@ func decodeBranch(reader io.Reader, variant byte, partialKeyLength uint16) (
 
 	sd := scale.NewDecoder(reader)
 
-	if variant == branchWithValueVariant.bits {
+	switch variant {
+	case branchWithValueVariant.bits:
 		err := sd.Decode(&node.SubValue)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %s", ErrDecodeValue, err)
 		}
+	case branchWithHashedValueVariant.bits:
+		node.SubValue, err = decodeHashedValue(reader)
+		if err != nil {
+			return nil, err
+		}
+		node.IsHashedValue = true
 	}
 
 	for i := 0; i < ChildrenCapacity; i++ {
@@ -125,7 +135,7 @@ func decodeBranch(reader io.Reader, variant byte, partialKeyLength uint16) (
 }
 
 // decodeLeaf reads from a reader and decodes to a leaf node.
-func decodeLeaf(reader io.Reader, partialKeyLength uint16) (node *Node, err error) {
+func decodeLeaf(reader io.Reader, variant byte, partialKeyLength uint16) (node *Node, err error) {
 	node = &Node{
 		Dirty: true,
 	}
@@ -135,6 +145,15 @@ func decodeLeaf(reader io.Reader, partialKeyLength uint16) (node *Node, err erro
 		return nil, fmt.Errorf("cannot decode key: %w", err)
 	}
 
+	if variant == leafWithHashedValueVariant.bits {
+		node.SubValue, err = decodeHashedValue(reader)
+		if err != nil {
+			return nil, err
+		}
+		node.IsHashedValue = true
+		return node, nil
+	}
+
 	sd := scale.NewDecoder(reader)
 	var value []byte
 	err = sd.Decode(&value)
@@ -148,3 +167,30 @@ func decodeLeaf(reader io.Reader, partialKeyLength uint16) (node *Node, err erro
 
 	return node, nil
 }
+
+// decodeHashedValue reads the 32 bytes Blake2b hash of a node value.
+func decodeHashedValue(reader io.Reader) (hashedValue []byte, err error) {
+	const hashLength = 32
+	hashedValue = make([]byte, hashLength)
+	_, err = io.ReadFull(reader, hashedValue)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDecodeHashedValue, err)
+	}
+	return hashedValue, nil
+}
+
+// HasHashedValue returns true if the given node encoding contains the
+// Blake2b hash of the node value instead of the value itself.
+func HasHashedValue(encoding []byte) bool {
+	if len(encoding) == 0 {
+		return false
+	}
+
+	variant, _, _, err := decodeHeaderByte(encoding[0])
+	if err != nil {
+		return false
+	}
+
+	return variant == leafWithHashedValueVariant.bits ||
+		variant == branchWithHashedValueVariant.bits
+}
@@ -38,6 +38,10 @@ func concatByteSlices(slices [][]byte) (concatenated []byte) {
 func Test_Decode(t *testing.T) {
 	t.Parallel()
 
+	hashedValue := []byte{
+		1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16,
+		17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32}
+
 	testCases := map[string]struct {
 		reader     io.Reader
 		n          *Node
@@ -79,6 +83,30 @@ func Test_Decode(t *testing.T) {
 				Dirty:    true,
 			},
 		},
+		"leaf with hashed value success": {
+			reader: bytes.NewReader(
+				concatByteSlices([][]byte{
+					{leafWithHashedValueVariant.bits | 1}, // key length 1
+					{9},                                   // key data
+					hashedValue,
+				}),
+			),
+			n: &Node{
+				Key:           []byte{9},
+				SubValue:      hashedValue,
+				IsHashedValue: true,
+				Dirty:         true,
+			},
+		},
+		"leaf with hashed value decoding error": {
+			reader: bytes.NewReader([]byte{
+				leafWithHashedValueVariant.bits | 1, // key length 1
+				9,                                   // key data
+				1, 2, 3,                             // truncated hashed value
+			}),
+			errWrapped: ErrDecodeHashedValue,
+			errMessage: "cannot decode leaf: cannot decode hashed value: unexpected EOF",
+		},
 		"branch decoding error": {
 			reader: bytes.NewReader([]byte{
 				branchVariant.bits | 1, // key length 1
@@ -102,6 +130,23 @@ func Test_Decode(t *testing.T) {
 				Dirty:    true,
 			},
 		},
+		"branch with hashed value success": {
+			reader: bytes.NewReader(
+				concatByteSlices([][]byte{
+					{branchWithHashedValueVariant.bits | 1}, // key length 1
+					{9},                                     // key data
+					{0, 0},                                  // no children bitmap
+					hashedValue,
+				}),
+			),
+			n: &Node{
+				Key:           []byte{9},
+				SubValue:      hashedValue,
+				IsHashedValue: true,
+				Children:      make([]*Node, ChildrenCapacity),
+				Dirty:         true,
+			},
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -369,7 +414,7 @@ func Test_decodeLeaf(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			leaf, err := decodeLeaf(testCase.reader,
+			leaf, err := decodeLeaf(testCase.reader, leafVariant.bits,
 				testCase.partialKeyLength)
 
 			assert.ErrorIs(t, err, testCase.errWrapped)
@@ -380,3 +425,42 @@ func Test_decodeLeaf(t *testing.T) {
 		})
 	}
 }
+
+func Test_HasHashedValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		encoding       []byte
+		hasHashedValue bool
+	}{
+		"empty encoding": {},
+		"unknown variant": {
+			encoding: []byte{0},
+		},
+		"leaf": {
+			encoding: []byte{leafVariant.bits | 1},
+		},
+		"branch with value": {
+			encoding: []byte{branchWithValueVariant.bits | 1},
+		},
+		"leaf with hashed value": {
+			encoding:       []byte{leafWithHashedValueVariant.bits | 1},
+			hasHashedValue: true,
+		},
+		"branch with hashed value": {
+			encoding:       []byte{branchWithHashedValueVariant.bits | 1},
+			hasHashedValue: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			hasHashedValue := HasHashedValue(testCase.encoding)
+
+			assert.Equal(t, testCase.hasHashedValue, hasHashedValue)
+		})
+	}
+}
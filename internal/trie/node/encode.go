@@ -5,17 +5,25 @@
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/ChainSafe/gossamer/internal/trie/codec"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
+// NoMaxInlineValueSize is the maximum inline value size to use
+// for the state trie version 0, where values are never hashed.
+const NoMaxInlineValueSize = math.MaxInt
+
 // Encode encodes the node to the buffer given.
 // The encoding format is documented in the README.md
 // of this package, and specified in the Polkadot spec at
 // https://spec.polkadot.network/#sect-state-storage
-func (n *Node) Encode(buffer Buffer) (err error) {
+// Values larger than maxInlineValue bytes are encoded as their
+// Blake2b hash, as done for the state trie version 1.
+// Note a node which is not dirty is encoded using its cached encoding.
+func (n *Node) Encode(buffer Buffer, maxInlineValue int) (err error) {
 	if !n.Dirty && n.Encoding != nil {
 		_, err = buffer.Write(n.Encoding)
 		if err != nil {
@@ -24,7 +32,8 @@ func (n *Node) Encode(buffer Buffer) (err error) {
 		return nil
 	}
 
-	err = encodeHeader(n, buffer)
+	isHashedValue := n.IsHashedValue || len(n.SubValue) > maxInlineValue
+	err = encodeHeader(n, isHashedValue, buffer)
 	if err != nil {
 		return fmt.Errorf("cannot encode header: %w", err)
 	}
@@ -45,7 +54,12 @@ func (n *Node) Encode(buffer Buffer) (err error) {
 
 	// check value is not nil for branch nodes, even though
 	// leaf nodes always have a non-nil value.
-	if n.SubValue != nil {
+	if isHashedValue {
+		err = encodeHashedValue(n, buffer)
+		if err != nil {
+			return fmt.Errorf("cannot encode hashed value: %w", err)
+		}
+	} else if n.SubValue != nil {
 		encodedValue, err := scale.Marshal(n.SubValue) // TODO scale encoder to write to buffer
 		if err != nil {
 			return fmt.Errorf("cannot scale encode value: %w", err)
@@ -58,7 +72,7 @@ func (n *Node) Encode(buffer Buffer) (err error) {
 	}
 
 	if n.Kind() == Branch {
-		err = encodeChildrenOpportunisticParallel(n.Children, buffer)
+		err = encodeChildrenOpportunisticParallel(n.Children, maxInlineValue, buffer)
 		if err != nil {
 			return fmt.Errorf("cannot encode children of branch: %w", err)
 		}
@@ -74,3 +88,23 @@ func (n *Node) Encode(buffer Buffer) (err error) {
 
 	return nil
 }
+
+// encodeHashedValue writes the Blake2b hash of the node value to the
+// buffer, or the value itself if it is already the hash of the value.
+// Note the hash is not SCALE encoded since it has a fixed length.
+func encodeHashedValue(n *Node, buffer Buffer) (err error) {
+	hashedValue := n.SubValue
+	if !n.IsHashedValue {
+		hash, err := common.Blake2bHash(n.SubValue)
+		if err != nil {
+			return fmt.Errorf("hashing value: %w", err)
+		}
+		hashedValue = hash.ToBytes()
+	}
+
+	_, err = buffer.Write(hashedValue)
+	if err != nil {
+		return fmt.Errorf("writing hashed value to buffer: %w", err)
+	}
+	return nil
+}
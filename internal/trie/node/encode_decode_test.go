@@ -7,6 +7,8 @@
 	"bytes"
 	"testing"
 
+	"github.com/ChainSafe/gossamer/lib/common"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -126,7 +128,7 @@ func Test_Branch_Encode_Decode(t *testing.T) {
 
 			buffer := bytes.NewBuffer(nil)
 
-			err := testCase.branchToEncode.Encode(buffer)
+			err := testCase.branchToEncode.Encode(buffer, NoMaxInlineValueSize)
 			require.NoError(t, err)
 
 			variant, partialKeyLength, err := decodeHeader(buffer)
@@ -139,3 +141,96 @@ func Test_Branch_Encode_Decode(t *testing.T) {
 		})
 	}
 }
+
+func Test_Node_Encode_Decode_HashedValue(t *testing.T) {
+	t.Parallel()
+
+	const maxInlineValue = 32
+	longValue := bytes.Repeat([]byte{1}, maxInlineValue+1)
+	hashedValue := common.MustBlake2bHash(longValue).ToBytes()
+
+	testCases := map[string]struct {
+		nodeToEncode *Node
+		nodeDecoded  *Node
+	}{
+		"leaf with inlined value": {
+			nodeToEncode: &Node{
+				Key:      []byte{5},
+				SubValue: []byte{1, 2},
+			},
+			nodeDecoded: &Node{
+				Key:      []byte{5},
+				SubValue: []byte{1, 2},
+				Dirty:    true,
+			},
+		},
+		"leaf with hashed value": {
+			nodeToEncode: &Node{
+				Key:      []byte{5},
+				SubValue: longValue,
+			},
+			nodeDecoded: &Node{
+				Key:           []byte{5},
+				SubValue:      hashedValue,
+				IsHashedValue: true,
+				Dirty:         true,
+			},
+		},
+		"leaf with value already hashed": {
+			nodeToEncode: &Node{
+				Key:           []byte{5},
+				SubValue:      hashedValue,
+				IsHashedValue: true,
+			},
+			nodeDecoded: &Node{
+				Key:           []byte{5},
+				SubValue:      hashedValue,
+				IsHashedValue: true,
+				Dirty:         true,
+			},
+		},
+		"branch with hashed value": {
+			nodeToEncode: &Node{
+				Key:      []byte{5},
+				SubValue: longValue,
+				Children: padRightChildren([]*Node{
+					{
+						Key:      []byte{9},
+						SubValue: []byte{10},
+					},
+				}),
+			},
+			nodeDecoded: &Node{
+				Key:           []byte{5},
+				SubValue:      hashedValue,
+				IsHashedValue: true,
+				Descendants:   1,
+				Children: padRightChildren([]*Node{
+					{
+						Key:      []byte{9},
+						SubValue: []byte{10},
+						Dirty:    true,
+					},
+				}),
+				Dirty: true,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			buffer := bytes.NewBuffer(nil)
+
+			err := testCase.nodeToEncode.Encode(buffer, maxInlineValue)
+			require.NoError(t, err)
+
+			decoded, err := Decode(buffer)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.nodeDecoded, decoded)
+		})
+	}
+}
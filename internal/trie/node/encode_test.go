@@ -324,7 +324,7 @@ func Test_Node_Encode(t *testing.T) {
 				buffer.EXPECT().Bytes().Return(testCase.expectedEncoding)
 			}
 
-			err := testCase.node.Encode(buffer)
+			err := testCase.node.Encode(buffer, NoMaxInlineValueSize)
 
 			if testCase.wrappedErr != nil {
 				assert.ErrorIs(t, err, testCase.wrappedErr)
@@ -55,12 +55,12 @@ func hashEncoding(encoding []byte, writer io.Writer) (err error) {
 }
 
 // CalculateMerkleValue returns the Merkle value of the non-root node.
-func (n *Node) CalculateMerkleValue() (merkleValue []byte, err error) {
+func (n *Node) CalculateMerkleValue(maxInlineValue int) (merkleValue []byte, err error) {
 	if !n.Dirty && n.MerkleValue != nil {
 		return n.MerkleValue, nil
 	}
 
-	_, merkleValue, err = n.EncodeAndHash()
+	_, merkleValue, err = n.EncodeAndHash(maxInlineValue)
 	if err != nil {
 		return nil, fmt.Errorf("encoding and hashing node: %w", err)
 	}
@@ -69,13 +69,13 @@ func (n *Node) CalculateMerkleValue() (merkleValue []byte, err error) {
 }
 
 // CalculateRootMerkleValue returns the Merkle value of the root node.
-func (n *Node) CalculateRootMerkleValue() (merkleValue []byte, err error) {
+func (n *Node) CalculateRootMerkleValue(maxInlineValue int) (merkleValue []byte, err error) {
 	const rootMerkleValueLength = 32
 	if !n.Dirty && len(n.MerkleValue) == rootMerkleValueLength {
 		return n.MerkleValue, nil
 	}
 
-	_, merkleValue, err = n.EncodeAndHashRoot()
+	_, merkleValue, err = n.EncodeAndHashRoot(maxInlineValue)
 	if err != nil {
 		return nil, fmt.Errorf("encoding and hashing root node: %w", err)
 	}
@@ -89,12 +89,12 @@ func (n *Node) CalculateRootMerkleValue() (merkleValue []byte, err error) {
 // TODO change this function to write to an encoding writer
 // and a merkle value writer, such that buffer sync pools can be used
 // by the caller.
-func (n *Node) EncodeAndHash() (encoding, merkleValue []byte, err error) {
+func (n *Node) EncodeAndHash(maxInlineValue int) (encoding, merkleValue []byte, err error) {
 	if !n.Dirty && n.Encoding != nil && n.MerkleValue != nil {
 		return n.Encoding, n.MerkleValue, nil
 	}
 
-	encoding, err = n.encodeIfNeeded()
+	encoding, err = n.encodeIfNeeded(maxInlineValue)
 	if err != nil {
 		return nil, nil, fmt.Errorf("encoding node: %w", err)
 	}
@@ -117,13 +117,13 @@ func (n *Node) EncodeAndHash() (encoding, merkleValue []byte, err error) {
 // TODO change this function to write to an encoding writer
 // and a merkle value writer, such that buffer sync pools can be used
 // by the caller.
-func (n *Node) EncodeAndHashRoot() (encoding, merkleValue []byte, err error) {
+func (n *Node) EncodeAndHashRoot(maxInlineValue int) (encoding, merkleValue []byte, err error) {
 	const rootMerkleValueLength = 32
 	if !n.Dirty && n.Encoding != nil && len(n.MerkleValue) == rootMerkleValueLength {
 		return n.Encoding, n.MerkleValue, nil
 	}
 
-	encoding, err = n.encodeIfNeeded()
+	encoding, err = n.encodeIfNeeded(maxInlineValue)
 	if err != nil {
 		return nil, nil, fmt.Errorf("encoding node: %w", err)
 	}
@@ -140,7 +140,7 @@ func (n *Node) EncodeAndHashRoot() (encoding, merkleValue []byte, err error) {
 	return encoding, merkleValue, nil
 }
 
-func (n *Node) encodeIfNeeded() (encoding []byte, err error) {
+func (n *Node) encodeIfNeeded(maxInlineValue int) (encoding []byte, err error) {
 	if !n.Dirty && n.Encoding != nil {
 		return n.Encoding, nil // no need to copy
 	}
@@ -149,7 +149,7 @@ func (n *Node) encodeIfNeeded() (encoding []byte, err error) {
 	buffer.Reset()
 	defer pools.EncodingBuffers.Put(buffer)
 
-	err = n.Encode(buffer)
+	err = n.Encode(buffer, maxInlineValue)
 	if err != nil {
 		return nil, fmt.Errorf("encoding: %w", err)
 	}
@@ -196,7 +196,7 @@ func Test_Node_CalculateMerkleValue(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			merkleValue, err := testCase.node.CalculateMerkleValue()
+			merkleValue, err := testCase.node.CalculateMerkleValue(NoMaxInlineValueSize)
 
 			assert.ErrorIs(t, err, testCase.errWrapped)
 			if testCase.errWrapped != nil {
@@ -256,7 +256,7 @@ func Test_Node_CalculateRootMerkleValue(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			merkleValue, err := testCase.node.CalculateRootMerkleValue()
+			merkleValue, err := testCase.node.CalculateRootMerkleValue(NoMaxInlineValueSize)
 
 			assert.ErrorIs(t, err, testCase.errWrapped)
 			if testCase.errWrapped != nil {
@@ -416,7 +416,7 @@ func Test_Node_EncodeAndHash(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			encoding, hash, err := testCase.node.EncodeAndHash()
+			encoding, hash, err := testCase.node.EncodeAndHash(NoMaxInlineValueSize)
 
 			assert.ErrorIs(t, err, testCase.errWrapped)
 			if testCase.errWrapped != nil {
@@ -495,7 +495,7 @@ func Test_Node_EncodeAndHashRoot(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			encoding, hash, err := testCase.node.EncodeAndHashRoot()
+			encoding, hash, err := testCase.node.EncodeAndHashRoot(NoMaxInlineValueSize)
 
 			assert.ErrorIs(t, err, testCase.errWrapped)
 			if testCase.errWrapped != nil {
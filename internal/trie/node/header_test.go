@@ -217,7 +217,7 @@ func Test_encodeHeader(t *testing.T) {
 				previousCall = call
 			}
 
-			err := encodeHeader(testCase.node, writer)
+			err := encodeHeader(testCase.node, false, writer)
 
 			assert.ErrorIs(t, err, testCase.errWrapped)
 			if testCase.errWrapped != nil {
@@ -235,7 +235,7 @@ func Test_encodeHeader(t *testing.T) {
 		}
 
 		assert.PanicsWithValue(t, "partial key length is too big: 65536", func() {
-			_ = encodeHeader(node, io.Discard)
+			_ = encodeHeader(node, false, io.Discard)
 		})
 	})
 }
@@ -270,7 +270,7 @@ func Test_encodeHeader_At_Maximum(t *testing.T) {
 		Key: make([]byte, keyLength),
 	}
 
-	err := encodeHeader(node, buffer)
+	err := encodeHeader(node, false, buffer)
 
 	require.NoError(t, err)
 	assert.Equal(t, expectedBytes, buffer.Bytes())
@@ -295,10 +295,10 @@ func Test_decodeHeader(t *testing.T) {
 		},
 		"header byte decoding error": {
 			reads: []readCall{
-				{buffArgCap: 1, read: []byte{0b0011_1110}},
+				{buffArgCap: 1, read: []byte{0b0000_1110}},
 			},
 			errWrapped: ErrVariantUnknown,
-			errMessage: "decoding header byte: node variant is unknown: for header byte 00111110",
+			errMessage: "decoding header byte: node variant is unknown: for header byte 00001110",
 		},
 		"partial key length contained in first byte": {
 			reads: []readCall{
@@ -398,6 +398,18 @@ func Test_decodeHeaderByte(t *testing.T) {
 			partialKeyLengthHeader:     0b0010_1001,
 			partialKeyLengthHeaderMask: 0b0011_1111,
 		},
+		"leaf with hashed value header": {
+			header:                     0b0010_1001,
+			variantBits:                0b0010_0000,
+			partialKeyLengthHeader:     0b0000_1001,
+			partialKeyLengthHeaderMask: 0b0001_1111,
+		},
+		"branch with hashed value header": {
+			header:                     0b0001_1001,
+			variantBits:                0b0001_0000,
+			partialKeyLengthHeader:     0b0000_1001,
+			partialKeyLengthHeaderMask: 0b0000_1111,
+		},
 		"unknown variant header": {
 			header:     0b0000_0000,
 			errWrapped: ErrVariantUnknown,
@@ -18,6 +18,11 @@ type Node struct {
 	// Key is the partial key bytes in nibbles (0 to f in hexadecimal)
 	Key      []byte
 	SubValue []byte
+	// IsHashedValue is true when SubValue is the Blake2b hash of the
+	// node value, as found in the state trie version 1 encoding of a
+	// node with a value larger than 32 bytes. It is only set for
+	// decoded nodes whose value was not loaded.
+	IsHashedValue bool
 	// Generation is incremented on every trie Snapshot() call.
 	// Each node also contain a certain Generation number,
 	// which is updated to match the trie Generation once they are
@@ -61,6 +66,9 @@ func (n Node) StringNode() (stringNode *gotree.Node) {
 	stringNode.Appendf("Dirty: %t", n.Dirty)
 	stringNode.Appendf("Key: " + bytesToString(n.Key))
 	stringNode.Appendf("Value: " + bytesToString(n.SubValue))
+	if n.IsHashedValue {
+		stringNode.Appendf("Value is hashed")
+	}
 	if n.Descendants > 0 { // must be a branch
 		stringNode.Appendf("Descendants: %d", n.Descendants)
 	}
@@ -23,4 +23,12 @@ type variant struct {
 		bits: 0b1100_0000,
 		mask: 0b1100_0000,
 	}
+	leafWithHashedValueVariant = variant{ // leaf containing hashes 001
+		bits: 0b0010_0000,
+		mask: 0b1110_0000,
+	}
+	branchWithHashedValueVariant = variant{ // branch containing hashes 0001
+		bits: 0b0001_0000,
+		mask: 0b1111_0000,
+	}
 )
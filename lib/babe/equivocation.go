@@ -0,0 +1,233 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// maxSlotCapacity is the number of slots before the current slot
+	// for which the imported headers are kept to detect equivocations.
+	maxSlotCapacity = 1000
+	// pruningBound is the number of slots after the first stored slot
+	// at which the headers of the slots out of capacity are pruned.
+	pruningBound = 2 * maxSlotCapacity
+	// maxPendingEquivocationReports is the maximum number of equivocations
+	// waiting to be reported to the runtime.
+	maxPendingEquivocationReports = 16
+)
+
+var equivocationsCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "gossamer_babe",
+	Name:      "equivocations_total",
+	Help:      "total number of BABE equivocations detected",
+})
+
+// slotHeader is a header imported in a slot, together with the key of its author.
+type slotHeader struct {
+	header *types.Header
+	signer [sr25519.PublicKeyLength]byte
+}
+
+// slotHeaderStore keeps the headers imported for each of the latest
+// maxSlotCapacity slots, so that an authority producing two different
+// headers for the same slot can be detected.
+type slotHeaderStore struct {
+	sync.Mutex
+	headers   map[uint64][]slotHeader // map of slot -> headers imported in the slot
+	firstSlot uint64                  // lowest slot for which headers may be stored
+	hasFirst  bool
+}
+
+func newSlotHeaderStore() *slotHeaderStore {
+	return &slotHeaderStore{
+		headers: make(map[uint64][]slotHeader),
+	}
+}
+
+// checkEquivocation stores the given header, claimed in the given slot by the given signer,
+// and returns an equivocation proof if the signer already produced a different header
+// in the same slot. Headers for slots older than maxSlotCapacity slots before the
+// current slot are ignored.
+func (s *slotHeaderStore) checkEquivocation(slotNow, slot uint64, header *types.Header,
+	signer [sr25519.PublicKeyLength]byte) (*types.BabeEquivocationProof, error) {
+	if slotNow > slot && slotNow-slot > maxSlotCapacity {
+		return nil, nil //nolint:nilnil
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	hash := header.Hash()
+	for _, stored := range s.headers[slot] {
+		if stored.header.Hash().Equal(hash) {
+			return nil, nil //nolint:nilnil
+		}
+
+		if stored.signer == signer {
+			return &types.BabeEquivocationProof{
+				Offender:     signer,
+				Slot:         slot,
+				FirstHeader:  *stored.header,
+				SecondHeader: *header,
+			}, nil
+		}
+	}
+
+	headerCopy, err := header.DeepCopy()
+	if err != nil {
+		return nil, fmt.Errorf("copying header: %w", err)
+	}
+
+	s.headers[slot] = append(s.headers[slot], slotHeader{
+		header: headerCopy,
+		signer: signer,
+	})
+
+	if !s.hasFirst || slot < s.firstSlot {
+		s.firstSlot = slot
+		s.hasFirst = true
+	}
+
+	s.prune(slotNow)
+	return nil, nil //nolint:nilnil
+}
+
+// prune removes the headers of the slots more than maxSlotCapacity slots
+// before the current slot, once the stored slots exceed the pruning bound.
+func (s *slotHeaderStore) prune(slotNow uint64) {
+	if slotNow < s.firstSlot || slotNow-s.firstSlot < pruningBound {
+		return
+	}
+
+	newFirstSlot := slotNow - maxSlotCapacity
+	for slot := range s.headers {
+		if slot < newFirstSlot {
+			delete(s.headers, slot)
+		}
+	}
+
+	s.firstSlot = newFirstSlot
+}
+
+// checkEquivocation records the given verified header in the slot header store and,
+// if its author already produced a different header for the same slot, queues
+// the equivocation to be reported to the runtime.
+func (v *VerificationManager) checkEquivocation(header *types.Header, info *verifierInfo) error {
+	slot, err := types.GetSlotFromHeader(header)
+	if err != nil {
+		return fmt.Errorf("getting slot from header: %w", err)
+	}
+
+	authorityIndex, err := getAuthorityIndex(header)
+	if err != nil {
+		return fmt.Errorf("getting authority index: %w", err)
+	}
+
+	if uint64(authorityIndex) >= uint64(len(info.authorities)) {
+		return ErrInvalidBlockProducerIndex
+	}
+
+	signer, err := sr25519.NewPublicKey(info.authorities[authorityIndex].Key.Encode())
+	if err != nil {
+		return fmt.Errorf("decoding authority key: %w", err)
+	}
+
+	slotDuration, err := v.epochState.GetSlotDuration()
+	if err != nil {
+		return fmt.Errorf("getting slot duration: %w", err)
+	}
+
+	proof, err := v.slotHeaders.checkEquivocation(getCurrentSlot(slotDuration), slot, header, signer.AsBytes())
+	if err != nil {
+		return fmt.Errorf("checking equivocation: %w", err)
+	}
+
+	if proof == nil {
+		return nil
+	}
+
+	equivocationsCounter.Inc()
+	logger.Warnf("detected equivocation by authority 0x%x in slot %d: blocks %s and %s",
+		proof.Offender, slot, proof.FirstHeader.Hash(), proof.SecondHeader.Hash())
+
+	v.queueEquivocationReport(proof)
+	return nil
+}
+
+// queueEquivocationReport queues the given equivocation proof to be reported to the
+// runtime by reportEquivocations, so block verification is not blocked by the runtime
+// calls. The proof is dropped if too many equivocations are waiting to be reported.
+func (v *VerificationManager) queueEquivocationReport(proof *types.BabeEquivocationProof) {
+	select {
+	case v.equivocations <- proof:
+	default:
+		logger.Warnf("dropping report of equivocation by authority 0x%x in slot %d: too many pending reports",
+			proof.Offender, proof.Slot)
+	}
+}
+
+// reportEquivocations reports the queued equivocations to the runtime
+// until the verification manager is stopped.
+func (v *VerificationManager) reportEquivocations() {
+	for {
+		select {
+		case <-v.ctx.Done():
+			return
+		case proof := <-v.equivocations:
+			err := v.reportEquivocation(proof)
+			if err != nil {
+				logger.Errorf("failed to report equivocation by authority 0x%x in slot %d: %s",
+					proof.Offender, proof.Slot, err)
+			}
+		}
+	}
+}
+
+// reportEquivocation submits the given equivocation proof to the runtime, together
+// with the proof that the offender key belongs to a validator, both using the
+// runtime and the state of the best block.
+func (v *VerificationManager) reportEquivocation(proof *types.BabeEquivocationProof) error {
+	bestBlockHeader, err := v.blockState.BestBlockHeader()
+	if err != nil {
+		return fmt.Errorf("getting best block header: %w", err)
+	}
+
+	v.storageState.Lock()
+	defer v.storageState.Unlock()
+
+	trieState, err := v.storageState.TrieState(&bestBlockHeader.StateRoot)
+	if err != nil {
+		return fmt.Errorf("getting trie state for best block state root %s: %w",
+			bestBlockHeader.StateRoot, err)
+	}
+
+	bestBlockHash := bestBlockHeader.Hash()
+	instance, err := v.blockState.GetRuntime(&bestBlockHash)
+	if err != nil {
+		return fmt.Errorf("getting runtime for best block %s: %w", bestBlockHash, err)
+	}
+
+	instance.SetContextStorage(trieState)
+
+	keyOwnershipProof, err := instance.BabeGenerateKeyOwnershipProof(proof.Slot, proof.Offender)
+	if err != nil {
+		return fmt.Errorf("generating key ownership proof: %w", err)
+	}
+
+	err = instance.BabeSubmitReportEquivocationUnsignedExtrinsic(*proof, keyOwnershipProof)
+	if err != nil {
+		return fmt.Errorf("submitting equivocation report: %w", err)
+	}
+
+	logger.Infof("submitted report of equivocation by authority 0x%x in slot %d", proof.Offender, proof.Slot)
+	return nil
+}
@@ -0,0 +1,315 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	mocksruntime "github.com/ChainSafe/gossamer/lib/runtime/mocks"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_slotHeaderStore_checkEquivocation(t *testing.T) {
+	t.Parallel()
+
+	firstHeader := types.NewEmptyHeader()
+	firstHeader.Number = 1
+	secondHeader := types.NewEmptyHeader()
+	secondHeader.Number = 2
+	// cache the header hashes so they compare equal to the stored headers
+	firstHeader.Hash()
+	secondHeader.Hash()
+
+	signer := [32]byte{1}
+	otherSigner := [32]byte{2}
+
+	testCases := map[string]struct {
+		store          *slotHeaderStore
+		slotNow        uint64
+		slot           uint64
+		header         *types.Header
+		signer         [32]byte
+		proof          *types.BabeEquivocationProof
+		expectedStored map[uint64][]slotHeader
+	}{
+		"slot_too_old": {
+			store:          newSlotHeaderStore(),
+			slotNow:        maxSlotCapacity + 2,
+			slot:           1,
+			header:         firstHeader,
+			signer:         signer,
+			expectedStored: map[uint64][]slotHeader{},
+		},
+		"first_header_in_slot": {
+			store:   newSlotHeaderStore(),
+			slotNow: 10,
+			slot:    10,
+			header:  firstHeader,
+			signer:  signer,
+			expectedStored: map[uint64][]slotHeader{
+				10: {{header: firstHeader, signer: signer}},
+			},
+		},
+		"same_header_imported_again": {
+			store: &slotHeaderStore{
+				headers: map[uint64][]slotHeader{
+					10: {{header: firstHeader, signer: signer}},
+				},
+			},
+			slotNow: 10,
+			slot:    10,
+			header:  firstHeader,
+			signer:  signer,
+			expectedStored: map[uint64][]slotHeader{
+				10: {{header: firstHeader, signer: signer}},
+			},
+		},
+		"header_from_other_signer": {
+			store: &slotHeaderStore{
+				headers: map[uint64][]slotHeader{
+					10: {{header: firstHeader, signer: otherSigner}},
+				},
+			},
+			slotNow: 10,
+			slot:    10,
+			header:  secondHeader,
+			signer:  signer,
+			expectedStored: map[uint64][]slotHeader{
+				10: {
+					{header: firstHeader, signer: otherSigner},
+					{header: secondHeader, signer: signer},
+				},
+			},
+		},
+		"equivocation": {
+			store: &slotHeaderStore{
+				headers: map[uint64][]slotHeader{
+					10: {{header: firstHeader, signer: signer}},
+				},
+			},
+			slotNow: 10,
+			slot:    10,
+			header:  secondHeader,
+			signer:  signer,
+			proof: &types.BabeEquivocationProof{
+				Offender:     signer,
+				Slot:         10,
+				FirstHeader:  *firstHeader,
+				SecondHeader: *secondHeader,
+			},
+			expectedStored: map[uint64][]slotHeader{
+				10: {{header: firstHeader, signer: signer}},
+			},
+		},
+		"prune_old_slots": {
+			store: &slotHeaderStore{
+				headers: map[uint64][]slotHeader{
+					1:                 {{header: firstHeader, signer: signer}},
+					pruningBound - 10: {{header: firstHeader, signer: otherSigner}},
+				},
+				firstSlot: 1,
+				hasFirst:  true,
+			},
+			slotNow: pruningBound + 1,
+			slot:    pruningBound + 1,
+			header:  secondHeader,
+			signer:  signer,
+			expectedStored: map[uint64][]slotHeader{
+				pruningBound - 10: {{header: firstHeader, signer: otherSigner}},
+				pruningBound + 1:  {{header: secondHeader, signer: signer}},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			proof, err := testCase.store.checkEquivocation(testCase.slotNow,
+				testCase.slot, testCase.header, testCase.signer)
+
+			require.NoError(t, err)
+			if proof != nil {
+				proof.FirstHeader.Hash()
+				proof.SecondHeader.Hash()
+			}
+			assert.Equal(t, testCase.proof, proof)
+			for _, stored := range testCase.store.headers {
+				for _, slotHeader := range stored {
+					slotHeader.header.Hash()
+				}
+			}
+			assert.Equal(t, testCase.expectedStored, testCase.store.headers)
+		})
+	}
+}
+
+func Test_VerificationManager_queueEquivocationReport(t *testing.T) {
+	t.Parallel()
+
+	verificationManager := &VerificationManager{
+		equivocations: make(chan *types.BabeEquivocationProof, 1),
+	}
+
+	proof := &types.BabeEquivocationProof{Slot: 1}
+	verificationManager.queueEquivocationReport(proof)
+	// the queue is full so the second proof is dropped
+	verificationManager.queueEquivocationReport(&types.BabeEquivocationProof{Slot: 2})
+
+	require.Len(t, verificationManager.equivocations, 1)
+	assert.Equal(t, proof, <-verificationManager.equivocations)
+}
+
+func Test_VerificationManager_reportEquivocation(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	bestBlockHeader := &types.Header{
+		Number:    2,
+		StateRoot: common.Hash{2},
+		Digest:    types.NewDigest(),
+	}
+	bestBlockHash := bestBlockHeader.Hash()
+	trieState := rtstorage.NewTrieState(nil)
+
+	proof := &types.BabeEquivocationProof{
+		Offender:     [32]byte{1},
+		Slot:         10,
+		FirstHeader:  *types.NewEmptyHeader(),
+		SecondHeader: *types.NewEmptyHeader(),
+	}
+	keyOwnershipProof := types.BabeOpaqueKeyOwnershipProof{3}
+
+	newStorageState := func(ctrl *gomock.Controller) StorageState {
+		storageState := NewMockStorageState(ctrl)
+		storageState.EXPECT().Lock()
+		storageState.EXPECT().TrieState(&bestBlockHeader.StateRoot).Return(trieState, nil)
+		storageState.EXPECT().Unlock()
+		return storageState
+	}
+
+	testCases := map[string]struct {
+		blockStateBuilder   func(ctrl *gomock.Controller) BlockState
+		storageStateBuilder func(ctrl *gomock.Controller) StorageState
+		errWrapped          error
+		errMessage          string
+	}{
+		"best_block_header_error": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().BestBlockHeader().Return(nil, errTest)
+				return blockState
+			},
+			storageStateBuilder: func(ctrl *gomock.Controller) StorageState { return nil },
+			errWrapped:          errTest,
+			errMessage:          "getting best block header: test error",
+		},
+		"trie_state_error": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().BestBlockHeader().Return(bestBlockHeader, nil)
+				return blockState
+			},
+			storageStateBuilder: func(ctrl *gomock.Controller) StorageState {
+				storageState := NewMockStorageState(ctrl)
+				storageState.EXPECT().Lock()
+				storageState.EXPECT().TrieState(&bestBlockHeader.StateRoot).Return(nil, errTest)
+				storageState.EXPECT().Unlock()
+				return storageState
+			},
+			errWrapped: errTest,
+			errMessage: "getting trie state for best block state root " +
+				"0x0200000000000000000000000000000000000000000000000000000000000000: test error",
+		},
+		"runtime_error": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().BestBlockHeader().Return(bestBlockHeader, nil)
+				blockState.EXPECT().GetRuntime(&bestBlockHash).Return(nil, errTest)
+				return blockState
+			},
+			storageStateBuilder: newStorageState,
+			errWrapped:          errTest,
+			errMessage:          "getting runtime for best block " + bestBlockHash.String() + ": test error",
+		},
+		"key_ownership_proof_error": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				instance := mocksruntime.NewInstance(t)
+				instance.On("SetContextStorage", trieState)
+				instance.On("BabeGenerateKeyOwnershipProof", proof.Slot, proof.Offender).
+					Return(nil, errTest)
+
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().BestBlockHeader().Return(bestBlockHeader, nil)
+				blockState.EXPECT().GetRuntime(&bestBlockHash).Return(instance, nil)
+				return blockState
+			},
+			storageStateBuilder: newStorageState,
+			errWrapped:          errTest,
+			errMessage:          "generating key ownership proof: test error",
+		},
+		"submit_error": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				instance := mocksruntime.NewInstance(t)
+				instance.On("SetContextStorage", trieState)
+				instance.On("BabeGenerateKeyOwnershipProof", proof.Slot, proof.Offender).
+					Return(keyOwnershipProof, nil)
+				instance.On("BabeSubmitReportEquivocationUnsignedExtrinsic", *proof, keyOwnershipProof).
+					Return(errTest)
+
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().BestBlockHeader().Return(bestBlockHeader, nil)
+				blockState.EXPECT().GetRuntime(&bestBlockHash).Return(instance, nil)
+				return blockState
+			},
+			storageStateBuilder: newStorageState,
+			errWrapped:          errTest,
+			errMessage:          "submitting equivocation report: test error",
+		},
+		"reported": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				instance := mocksruntime.NewInstance(t)
+				instance.On("SetContextStorage", trieState)
+				instance.On("BabeGenerateKeyOwnershipProof", proof.Slot, proof.Offender).
+					Return(keyOwnershipProof, nil)
+				instance.On("BabeSubmitReportEquivocationUnsignedExtrinsic", *proof, keyOwnershipProof).
+					Return(nil)
+
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().BestBlockHeader().Return(bestBlockHeader, nil)
+				blockState.EXPECT().GetRuntime(&bestBlockHash).Return(instance, nil)
+				return blockState
+			},
+			storageStateBuilder: newStorageState,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			verificationManager := &VerificationManager{
+				blockState:   testCase.blockStateBuilder(ctrl),
+				storageState: testCase.storageStateBuilder(ctrl),
+			}
+
+			err := verificationManager.reportEquivocation(proof)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@
 package babe
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -33,26 +35,52 @@ type onDisabledInfo struct {
 // VerificationManager deals with verification that a BABE block producer was authorized to produce a given block.
 // It trakcs the BABE epoch data that is needed for verification.
 type VerificationManager struct {
-	lock       sync.RWMutex
-	blockState BlockState
-	epochState EpochState
-	epochInfo  map[uint64]*verifierInfo // map of epoch number -> info needed for verification
+	ctx          context.Context
+	cancel       context.CancelFunc
+	lock         sync.RWMutex
+	blockState   BlockState
+	storageState StorageState
+	epochState   EpochState
+	epochInfo    map[uint64]*verifierInfo // map of epoch number -> info needed for verification
 	// there may be different OnDisabled digests on different
 	// branches of the chain, so we need to keep track of all of them.
 	// map of epoch number -> block producer index -> block number and hash
 	onDisabled map[uint64]map[uint32][]*onDisabledInfo
+	// slotHeaders keeps the headers of the latest slots to detect equivocations.
+	slotHeaders *slotHeaderStore
+	// equivocations are the detected equivocations waiting to be reported to the runtime.
+	equivocations chan *types.BabeEquivocationProof
 }
 
 // NewVerificationManager returns a new NewVerificationManager
-func NewVerificationManager(blockState BlockState, epochState EpochState) *VerificationManager {
+func NewVerificationManager(blockState BlockState, storageState StorageState,
+	epochState EpochState) *VerificationManager {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &VerificationManager{
-		epochState: epochState,
-		blockState: blockState,
-		epochInfo:  make(map[uint64]*verifierInfo),
-		onDisabled: make(map[uint64]map[uint32][]*onDisabledInfo),
+		ctx:           ctx,
+		cancel:        cancel,
+		epochState:    epochState,
+		blockState:    blockState,
+		storageState:  storageState,
+		epochInfo:     make(map[uint64]*verifierInfo),
+		onDisabled:    make(map[uint64]map[uint32][]*onDisabledInfo),
+		slotHeaders:   newSlotHeaderStore(),
+		equivocations: make(chan *types.BabeEquivocationProof, maxPendingEquivocationReports),
 	}
 }
 
+// Start starts reporting the detected equivocations to the runtime.
+func (v *VerificationManager) Start() error {
+	go v.reportEquivocations()
+	return nil
+}
+
+// Stop stops reporting the detected equivocations.
+func (v *VerificationManager) Stop() error {
+	v.cancel()
+	return nil
+}
+
 // SetOnDisabled sets the BABE authority with the given index as disabled for the rest of the epoch
 func (v *VerificationManager) SetOnDisabled(index uint32, header *types.Header) error {
 	epoch, err := v.epochState.GetEpochForBlock(header)
@@ -182,7 +210,18 @@ func (v *VerificationManager) VerifyBlock(header *types.Header) error {
 
 	verifier := newVerifier(v.blockState, epoch, info)
 
-	return verifier.verifyAuthorshipRight(header)
+	err = verifier.verifyAuthorshipRight(header)
+	if err != nil && !errors.Is(err, ErrProducerEquivocated) {
+		return err
+	}
+
+	// the seal of the header is verified, so it can be used as an equivocation proof.
+	equivocationErr := v.checkEquivocation(header, info)
+	if equivocationErr != nil {
+		logger.Errorf("failed to check equivocation for block %s: %s", header.Hash(), equivocationErr)
+	}
+
+	return err
 }
 
 func (v *VerificationManager) getVerifierInfo(epoch uint64, header *types.Header) (*verifierInfo, error) {
@@ -57,7 +57,7 @@ func newTestVerificationManager(t *testing.T, genCfg *types.BabeConfiguration) *
 
 	logger.Patch(log.SetLevel(defaultTestLogLvl))
 
-	return NewVerificationManager(dbSrv.Block, dbSrv.Epoch)
+	return NewVerificationManager(dbSrv.Block, dbSrv.Storage, dbSrv.Epoch)
 }
 
 // TODO: add test against latest dev runtime
@@ -533,7 +533,7 @@ func TestVerifyForkBlocksWithRespectiveEpochData(t *testing.T) {
 
 	digestHandler.Start()
 
-	verificationManager := NewVerificationManager(stateService.Block, epochState)
+	verificationManager := NewVerificationManager(stateService.Block, stateService.Storage, epochState)
 
 	/*
 	* lets issue different blocks starting from genesis (a fork)
@@ -954,14 +954,14 @@ func TestVerificationManager_VerifyBlock(t *testing.T) {
 		secondarySlots: true,
 	}
 
-	vm0 := NewVerificationManager(mockBlockStateCheckFinErr, mockEpochStateEmpty)
-	vm1 := NewVerificationManager(mockBlockStateNotFinal, mockEpochStateEmpty)
-	vm2 := NewVerificationManager(mockBlockStateNotFinal2, mockEpochStateSetSlotErr)
-	vm3 := NewVerificationManager(mockBlockStateNotFinal2, mockEpochStateGetEpochErr)
-	vm4 := NewVerificationManager(mockBlockStateEmpty, mockEpochStateSkipVerifyErr)
-	vm5 := NewVerificationManager(mockBlockStateEmpty, mockEpochStateSkipVerifyTrue)
-	vm6 := NewVerificationManager(mockBlockStateEmpty, mockEpochStateGetVerifierInfoErr)
-	vm8 := NewVerificationManager(mockBlockStateEmpty, mockEpochStateVerifyAuthorshipErr)
+	vm0 := NewVerificationManager(mockBlockStateCheckFinErr, nil, mockEpochStateEmpty)
+	vm1 := NewVerificationManager(mockBlockStateNotFinal, nil, mockEpochStateEmpty)
+	vm2 := NewVerificationManager(mockBlockStateNotFinal2, nil, mockEpochStateSetSlotErr)
+	vm3 := NewVerificationManager(mockBlockStateNotFinal2, nil, mockEpochStateGetEpochErr)
+	vm4 := NewVerificationManager(mockBlockStateEmpty, nil, mockEpochStateSkipVerifyErr)
+	vm5 := NewVerificationManager(mockBlockStateEmpty, nil, mockEpochStateSkipVerifyTrue)
+	vm6 := NewVerificationManager(mockBlockStateEmpty, nil, mockEpochStateGetVerifierInfoErr)
+	vm8 := NewVerificationManager(mockBlockStateEmpty, nil, mockEpochStateVerifyAuthorshipErr)
 	vm8.epochInfo[1] = info
 
 	tests := []struct {
@@ -1088,27 +1088,27 @@ func TestVerificationManager_SetOnDisabled(t *testing.T) {
 		},
 	}
 
-	vm0 := NewVerificationManager(mockBlockStateEmpty, mockEpochStateGetEpochErr)
-	vm1 := NewVerificationManager(mockBlockStateEmpty, mockEpochStateGetEpochDataErr)
+	vm0 := NewVerificationManager(mockBlockStateEmpty, nil, mockEpochStateGetEpochErr)
+	vm1 := NewVerificationManager(mockBlockStateEmpty, nil, mockEpochStateGetEpochDataErr)
 	vm1.epochInfo[1] = info
 
-	vm2 := NewVerificationManager(mockBlockStateEmpty, mockEpochStateIndexLenErr)
+	vm2 := NewVerificationManager(mockBlockStateEmpty, nil, mockEpochStateIndexLenErr)
 	vm2.epochInfo[2] = info
 
-	vm3 := NewVerificationManager(mockBlockStateEmpty, mockEpochStateSetDisabledProd)
+	vm3 := NewVerificationManager(mockBlockStateEmpty, nil, mockEpochStateSetDisabledProd)
 	vm3.epochInfo[2] = info
 
-	vm4 := NewVerificationManager(mockBlockStateIsDescendantErr, mockEpochStateOk)
+	vm4 := NewVerificationManager(mockBlockStateIsDescendantErr, nil, mockEpochStateOk)
 	vm4.epochInfo[2] = info
 	vm4.onDisabled[2] = map[uint32][]*onDisabledInfo{}
 	vm4.onDisabled[2][0] = disabledInfo
 
-	vm5 := NewVerificationManager(mockBlockStateAuthorityDisabled, mockEpochStateOk2)
+	vm5 := NewVerificationManager(mockBlockStateAuthorityDisabled, nil, mockEpochStateOk2)
 	vm5.epochInfo[2] = info
 	vm5.onDisabled[2] = map[uint32][]*onDisabledInfo{}
 	vm5.onDisabled[2][0] = disabledInfo
 
-	vm6 := NewVerificationManager(mockBlockStateOk, mockEpochStateOk3)
+	vm6 := NewVerificationManager(mockBlockStateOk, nil, mockEpochStateOk3)
 	vm6.epochInfo[2] = info
 	vm6.onDisabled[2] = map[uint32][]*onDisabledInfo{}
 	vm6.onDisabled[2][0] = disabledInfo
@@ -9,6 +9,7 @@
 
 	types "github.com/ChainSafe/gossamer/dot/types"
 	common "github.com/ChainSafe/gossamer/lib/common"
+	ed25519 "github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	keystore "github.com/ChainSafe/gossamer/lib/keystore"
 	runtime "github.com/ChainSafe/gossamer/lib/runtime"
 	transaction "github.com/ChainSafe/gossamer/lib/transaction"
@@ -68,6 +69,65 @@ func (mr *MockInstanceMockRecorder) BabeConfiguration() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BabeConfiguration", reflect.TypeOf((*MockInstance)(nil).BabeConfiguration))
 }
 
+// BabeCurrentEpoch mocks base method.
+func (m *MockInstance) BabeCurrentEpoch() (*types.BabeEpoch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BabeCurrentEpoch")
+	ret0, _ := ret[0].(*types.BabeEpoch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BabeCurrentEpoch indicates an expected call of BabeCurrentEpoch.
+func (mr *MockInstanceMockRecorder) BabeCurrentEpoch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BabeCurrentEpoch", reflect.TypeOf((*MockInstance)(nil).BabeCurrentEpoch))
+}
+
+// BabeGenerateKeyOwnershipProof mocks base method.
+func (m *MockInstance) BabeGenerateKeyOwnershipProof(arg0 uint64, arg1 [32]byte) (types.BabeOpaqueKeyOwnershipProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BabeGenerateKeyOwnershipProof", arg0, arg1)
+	ret0, _ := ret[0].(types.BabeOpaqueKeyOwnershipProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BabeGenerateKeyOwnershipProof indicates an expected call of BabeGenerateKeyOwnershipProof.
+func (mr *MockInstanceMockRecorder) BabeGenerateKeyOwnershipProof(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BabeGenerateKeyOwnershipProof", reflect.TypeOf((*MockInstance)(nil).BabeGenerateKeyOwnershipProof), arg0, arg1)
+}
+
+// BabeNextEpoch mocks base method.
+func (m *MockInstance) BabeNextEpoch() (*types.BabeEpoch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BabeNextEpoch")
+	ret0, _ := ret[0].(*types.BabeEpoch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BabeNextEpoch indicates an expected call of BabeNextEpoch.
+func (mr *MockInstanceMockRecorder) BabeNextEpoch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BabeNextEpoch", reflect.TypeOf((*MockInstance)(nil).BabeNextEpoch))
+}
+
+// BabeSubmitReportEquivocationUnsignedExtrinsic mocks base method.
+func (m *MockInstance) BabeSubmitReportEquivocationUnsignedExtrinsic(arg0 types.BabeEquivocationProof, arg1 types.BabeOpaqueKeyOwnershipProof) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BabeSubmitReportEquivocationUnsignedExtrinsic", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BabeSubmitReportEquivocationUnsignedExtrinsic indicates an expected call of BabeSubmitReportEquivocationUnsignedExtrinsic.
+func (mr *MockInstanceMockRecorder) BabeSubmitReportEquivocationUnsignedExtrinsic(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BabeSubmitReportEquivocationUnsignedExtrinsic", reflect.TypeOf((*MockInstance)(nil).BabeSubmitReportEquivocationUnsignedExtrinsic), arg0, arg1)
+}
+
 // CheckInherents mocks base method.
 func (m *MockInstance) CheckInherents() {
 	m.ctrl.T.Helper()
@@ -80,6 +140,20 @@ func (mr *MockInstanceMockRecorder) CheckInherents() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckInherents", reflect.TypeOf((*MockInstance)(nil).CheckInherents))
 }
 
+// ContextStorage mocks base method.
+func (m *MockInstance) ContextStorage() runtime.Storage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContextStorage")
+	ret0, _ := ret[0].(runtime.Storage)
+	return ret0
+}
+
+// ContextStorage indicates an expected call of ContextStorage.
+func (mr *MockInstanceMockRecorder) ContextStorage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContextStorage", reflect.TypeOf((*MockInstance)(nil).ContextStorage))
+}
+
 // DecodeSessionKeys mocks base method.
 func (m *MockInstance) DecodeSessionKeys(arg0 []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -141,15 +215,18 @@ func (mr *MockInstanceMockRecorder) FinalizeBlock() *gomock.Call {
 }
 
 // GenerateSessionKeys mocks base method.
-func (m *MockInstance) GenerateSessionKeys() {
+func (m *MockInstance) GenerateSessionKeys(arg0 *[]byte) ([]byte, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "GenerateSessionKeys")
+	ret := m.ctrl.Call(m, "GenerateSessionKeys", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GenerateSessionKeys indicates an expected call of GenerateSessionKeys.
-func (mr *MockInstanceMockRecorder) GenerateSessionKeys() *gomock.Call {
+func (mr *MockInstanceMockRecorder) GenerateSessionKeys(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSessionKeys", reflect.TypeOf((*MockInstance)(nil).GenerateSessionKeys))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSessionKeys", reflect.TypeOf((*MockInstance)(nil).GenerateSessionKeys), arg0)
 }
 
 // GetCodeHash mocks base method.
@@ -181,6 +258,35 @@ func (mr *MockInstanceMockRecorder) GrandpaAuthorities() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrandpaAuthorities", reflect.TypeOf((*MockInstance)(nil).GrandpaAuthorities))
 }
 
+// GrandpaGenerateKeyOwnershipProof mocks base method.
+func (m *MockInstance) GrandpaGenerateKeyOwnershipProof(arg0 uint64, arg1 ed25519.PublicKeyBytes) (types.GrandpaOpaqueKeyOwnershipProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrandpaGenerateKeyOwnershipProof", arg0, arg1)
+	ret0, _ := ret[0].(types.GrandpaOpaqueKeyOwnershipProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrandpaGenerateKeyOwnershipProof indicates an expected call of GrandpaGenerateKeyOwnershipProof.
+func (mr *MockInstanceMockRecorder) GrandpaGenerateKeyOwnershipProof(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrandpaGenerateKeyOwnershipProof", reflect.TypeOf((*MockInstance)(nil).GrandpaGenerateKeyOwnershipProof), arg0, arg1)
+}
+
+// GrandpaSubmitReportEquivocationUnsignedExtrinsic mocks base method.
+func (m *MockInstance) GrandpaSubmitReportEquivocationUnsignedExtrinsic(arg0 types.GrandpaEquivocationProof, arg1 types.GrandpaOpaqueKeyOwnershipProof) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrandpaSubmitReportEquivocationUnsignedExtrinsic", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrandpaSubmitReportEquivocationUnsignedExtrinsic indicates an expected call of GrandpaSubmitReportEquivocationUnsignedExtrinsic.
+func (mr *MockInstanceMockRecorder) GrandpaSubmitReportEquivocationUnsignedExtrinsic(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrandpaSubmitReportEquivocationUnsignedExtrinsic", reflect.TypeOf((*MockInstance)(nil).GrandpaSubmitReportEquivocationUnsignedExtrinsic), arg0, arg1)
+}
+
 // InherentExtrinsics mocks base method.
 func (m *MockInstance) InherentExtrinsics(arg0 []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -268,15 +374,17 @@ func (mr *MockInstanceMockRecorder) NodeStorage() *gomock.Call {
 }
 
 // OffchainWorker mocks base method.
-func (m *MockInstance) OffchainWorker() {
+func (m *MockInstance) OffchainWorker(arg0 *types.Header) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "OffchainWorker")
+	ret := m.ctrl.Call(m, "OffchainWorker", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // OffchainWorker indicates an expected call of OffchainWorker.
-func (mr *MockInstanceMockRecorder) OffchainWorker() *gomock.Call {
+func (mr *MockInstanceMockRecorder) OffchainWorker(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OffchainWorker", reflect.TypeOf((*MockInstance)(nil).OffchainWorker))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OffchainWorker", reflect.TypeOf((*MockInstance)(nil).OffchainWorker), arg0)
 }
 
 // PaymentQueryInfo mocks base method.
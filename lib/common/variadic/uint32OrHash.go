@@ -153,3 +153,13 @@ func (x *Uint32OrHash) Decode(r io.Reader) error {
 	}
 	return nil
 }
+
+// MarshalSCALE returns the SCALE encoding of the Uint32OrHash.
+func (x *Uint32OrHash) MarshalSCALE() ([]byte, error) {
+	return x.Encode()
+}
+
+// UnmarshalSCALE decodes the SCALE encoding read from the reader into the Uint32OrHash.
+func (x *Uint32OrHash) UnmarshalSCALE(reader io.Reader) error {
+	return x.Decode(reader)
+}
@@ -6,7 +6,6 @@
 import (
 	"errors"
 	"sync"
-	"time"
 
 	"github.com/ChainSafe/gossamer/internal/log"
 )
@@ -31,8 +30,8 @@ type SignatureVerifier struct {
 	invalid bool // Set to true if any signature verification fails.
 	logger  log.LeveledLogger
 	closeCh chan struct{}
+	addedCh chan struct{} // Signals the background verification a signature was added.
 	sync.RWMutex
-	sync.Once
 	sync.WaitGroup
 }
 
@@ -48,6 +47,7 @@ func NewSignatureVerifier(logger log.LeveledLogger) *SignatureVerifier {
 		logger:  logger,
 		RWMutex: sync.RWMutex{},
 		closeCh: make(chan struct{}),
+		addedCh: make(chan struct{}, 1),
 	}
 }
 
@@ -56,6 +56,7 @@ func (sv *SignatureVerifier) Start() {
 	// Update the init state.
 	sv.Lock()
 	sv.init = true
+	closeCh := sv.closeCh
 	sv.Unlock()
 
 	sv.WaitGroup.Add(1)
@@ -63,25 +64,34 @@ func (sv *SignatureVerifier) Start() {
 	go func() {
 		defer sv.Done()
 		for {
+			sv.verifyBatch()
 			select {
-			case <-sv.closeCh:
+			case <-closeCh:
 				return
-			default:
-				signature := sv.Remove()
-				if signature == nil {
-					continue
-				}
-				err := signature.VerifyFunc(signature.PubKey, signature.Sign, signature.Msg)
-				if err != nil {
-					sv.logger.Errorf("[ext_crypto_start_batch_verify_version_1]: %s", err)
-					sv.Invalid()
-					return
-				}
+			case <-sv.addedCh:
 			}
 		}
 	}()
 }
 
+// verifyBatch verifies the signatures of the batch until it is empty
+// or until a signature verification fails.
+func (sv *SignatureVerifier) verifyBatch() {
+	for {
+		signature := sv.Remove()
+		if signature == nil {
+			return
+		}
+
+		err := signature.VerifyFunc(signature.PubKey, signature.Sign, signature.Msg)
+		if err != nil {
+			sv.logger.Errorf("[ext_crypto_start_batch_verify_version_1]: %s", err)
+			sv.Invalid()
+			return
+		}
+	}
+}
+
 // IsStarted ...
 func (sv *SignatureVerifier) IsStarted() bool {
 	sv.RLock()
@@ -96,11 +106,12 @@ func (sv *SignatureVerifier) IsInvalid() bool {
 	return sv.invalid
 }
 
-// Invalid ...
+// Invalid marks the batch as invalid and drops its remaining signatures.
 func (sv *SignatureVerifier) Invalid() {
-	sv.RLock()
-	defer sv.RUnlock()
+	sv.Lock()
+	defer sv.Unlock()
 	sv.invalid = true
+	sv.batch = make([]*SignatureInfo, 0)
 }
 
 // Add ...
@@ -110,8 +121,13 @@ func (sv *SignatureVerifier) Add(s *SignatureInfo) {
 	}
 
 	sv.Lock()
-	defer sv.Unlock()
 	sv.batch = append(sv.batch, s)
+	sv.Unlock()
+
+	select {
+	case sv.addedCh <- struct{}{}:
+	default:
+	}
 }
 
 // Remove returns the first signature from the batch. Returns nil if batch is empty.
@@ -138,18 +154,17 @@ func (sv *SignatureVerifier) Reset() {
 
 // Finish waits till batch is finished. Returns true if all the signatures are valid, Otherwise returns false.
 func (sv *SignatureVerifier) Finish() bool {
-	for {
-		time.Sleep(100 * time.Millisecond)
-		sv.Lock()
-		if sv.invalid || len(sv.batch) == 0 {
-			close(sv.closeCh)
-			sv.Unlock()
-			break
-		}
-		sv.RUnlock()
+	sv.Lock()
+	if sv.init {
+		close(sv.closeCh)
 	}
-	// Wait till start function to finish and then reset it.
+	sv.Unlock()
+
+	// Wait till the background verification stops, and
+	// verify the signatures it did not verify yet.
 	sv.Wait()
+	sv.verifyBatch()
+
 	isInvalid := sv.IsInvalid()
 	sv.Reset()
 	return !isInvalid
@@ -124,3 +124,38 @@ func TestVerifySignature(t *testing.T) {
 	}
 
 }
+
+func TestSignatureVerifier_AddAfterStart(t *testing.T) {
+	t.Parallel()
+
+	message := []byte("a225e8c75da7da319af6335e7642d473")
+
+	keypair, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	signature, err := keypair.Sign(message)
+	require.NoError(t, err)
+
+	signVerify := crypto.NewSignatureVerifier(log.New(log.SetWriter(io.Discard)))
+
+	signVerify.Start()
+	require.True(t, signVerify.IsStarted())
+	signVerify.Add(&crypto.SignatureInfo{
+		PubKey:     keypair.Public().Encode(),
+		Sign:       signature,
+		Msg:        message,
+		VerifyFunc: sr25519.VerifySignature,
+	})
+	require.True(t, signVerify.Finish())
+	require.False(t, signVerify.IsStarted())
+
+	// The verifier can be reused once finished.
+	signVerify.Start()
+	signVerify.Add(&crypto.SignatureInfo{
+		PubKey:     keypair.Public().Encode(),
+		Sign:       signature,
+		Msg:        []byte("other message"),
+		VerifyFunc: sr25519.VerifySignature,
+	})
+	require.False(t, signVerify.Finish())
+	require.False(t, signVerify.IsInvalid())
+}
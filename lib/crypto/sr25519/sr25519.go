@@ -67,6 +67,25 @@ func VerifySignature(publicKey, signature, message []byte) error {
 	return nil
 }
 
+// VerifyDeprecatedSignature verifies a signature given a public key and a message,
+// also accepting signatures produced with the deprecated schnorrkel 0.1.1 format.
+func VerifyDeprecatedSignature(publicKey, signature, message []byte) error {
+	pubKey, err := NewPublicKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("sr25519: %w", err)
+	}
+
+	ok, err := pubKey.VerifyDeprecated(message, signature)
+	if err != nil {
+		return fmt.Errorf("sr25519: %w", err)
+	} else if !ok {
+		return fmt.Errorf("sr25519: %w: for message 0x%x, signature 0x%x and public key 0x%x",
+			crypto.ErrSignatureVerificationFailed, message, signature, publicKey)
+	}
+
+	return nil
+}
+
 // NewKeypair returns a sr25519 Keypair given a schnorrkel secret key
 func NewKeypair(priv *sr25519.SecretKey) (*Keypair, error) {
 	pub, err := priv.Public()
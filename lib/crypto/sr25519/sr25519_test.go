@@ -182,3 +182,25 @@ func TestVerifySignature(t *testing.T) {
 	}
 
 }
+
+func TestVerifyDeprecatedSignature(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := GenerateKeypair()
+	require.NoError(t, err)
+
+	publicKey := keypair.public.Encode()
+	message := []byte("Hello world!")
+
+	signature, err := keypair.Sign(message)
+	require.NoError(t, err)
+
+	err = VerifyDeprecatedSignature(publicKey, signature, message)
+	require.NoError(t, err)
+
+	otherMessage := []byte("a225e8c75da7da319af6335e7642d473")
+	err = VerifyDeprecatedSignature(publicKey, signature, otherMessage)
+	errMessage := fmt.Sprintf("sr25519: %s: for message 0x%x, signature 0x%x and public key 0x%x",
+		crypto.ErrSignatureVerificationFailed, otherMessage, signature, publicKey)
+	require.EqualError(t, err, errMessage)
+}
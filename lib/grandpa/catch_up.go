@@ -0,0 +1,103 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// catchUpRequestInterval is the minimum duration between two catch up
+// requests sent to the same peer.
+const catchUpRequestInterval = catchUpResponseTimeout
+
+// pendingCatchUp is a catch up request we sent and are waiting a response for.
+type pendingCatchUp struct {
+	peer   peer.ID
+	round  uint64
+	setID  uint64
+	sentAt time.Time
+}
+
+// catchUpTracker keeps track of the peers which announced themselves as
+// authorities and of the catch up requests we sent to them.
+type catchUpTracker struct {
+	sync.Mutex
+	authorities  map[peer.ID]struct{}
+	lastRequests map[peer.ID]time.Time
+	pending      *pendingCatchUp
+}
+
+func newCatchUpTracker() *catchUpTracker {
+	return &catchUpTracker{
+		authorities:  make(map[peer.ID]struct{}),
+		lastRequests: make(map[peer.ID]time.Time),
+	}
+}
+
+// addAuthority records the peer as an authority.
+func (c *catchUpTracker) addAuthority(id peer.ID) {
+	c.Lock()
+	defer c.Unlock()
+	c.authorities[id] = struct{}{}
+}
+
+// startRequest records a catch up request to the peer for the given round and set id.
+// It returns false if the peer is not a known authority, if a request is already
+// pending or if the peer was sent a request less than catchUpRequestInterval ago.
+func (c *catchUpTracker) startRequest(id peer.ID, round, setID uint64, now time.Time) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	if _, ok := c.authorities[id]; !ok {
+		return false
+	}
+
+	if c.pending != nil && now.Sub(c.pending.sentAt) < catchUpResponseTimeout {
+		return false
+	}
+
+	for p, sentAt := range c.lastRequests {
+		if now.Sub(sentAt) >= catchUpRequestInterval {
+			delete(c.lastRequests, p)
+		}
+	}
+
+	if _, ok := c.lastRequests[id]; ok {
+		return false
+	}
+
+	c.lastRequests[id] = now
+	c.pending = &pendingCatchUp{
+		peer:   id,
+		round:  round,
+		setID:  setID,
+		sentAt: now,
+	}
+	return true
+}
+
+// cancelRequest forgets the pending catch up request, if any.
+func (c *catchUpTracker) cancelRequest() {
+	c.Lock()
+	defer c.Unlock()
+	c.pending = nil
+}
+
+// completeRequest returns true and clears the pending catch up request if
+// the response from the peer for the given round and set id answers it.
+func (c *catchUpTracker) completeRequest(id peer.ID, round, setID uint64) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	if c.pending == nil || c.pending.peer != id ||
+		c.pending.round != round || c.pending.setID != setID {
+		return false
+	}
+
+	c.pending = nil
+	return true
+}
@@ -67,7 +67,7 @@ func errRoundMismatch(got, want uint64) error {
 	ErrInvalidCatchUpRound = errors.New("catch up request is for future round")
 
 	// ErrInvalidCatchUpResponseRound is returned when a catch-up response is received with an invalid round
-	ErrInvalidCatchUpResponseRound = errors.New("catch up response is not for previous round")
+	ErrInvalidCatchUpResponseRound = errors.New("catch up response is for a past round")
 
 	// ErrGHOSTlessCatchUp is returned when a catch up response
 	// does not contain a valid grandpa-GHOST (ie. finalised block)
@@ -76,7 +76,7 @@ func errRoundMismatch(got, want uint64) error {
 	// ErrCatchUpResponseNotCompletable is returned when the round represented by the catch up response is not completable
 	ErrCatchUpResponseNotCompletable = errors.New("catch up response is not completable")
 
-	// ErrServicePaused is returned if the service is paused and waiting for catch up messages
+	// ErrServicePaused is returned if the service is paused and waiting for a catch up response
 	ErrServicePaused = errors.New("service is paused")
 
 	// ErrPrecommitSignatureMismatch is returned when the number of precommits
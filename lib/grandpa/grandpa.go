@@ -27,6 +27,7 @@
 
 const (
 	defaultGrandpaInterval = time.Second
+	catchUpResponseTimeout = 45 * time.Second
 )
 
 var (
@@ -46,14 +47,17 @@ type Service struct {
 	ctx            context.Context
 	cancel         context.CancelFunc
 	blockState     BlockState
+	storageState   StorageState
 	grandpaState   GrandpaState
 	keypair        *ed25519.Keypair // TODO: change to grandpa keystore (#1870)
 	mapLock        sync.Mutex
 	chanLock       sync.Mutex
 	roundLock      sync.Mutex
-	authority      bool          // run the service as an authority (ie participate in voting)
-	paused         atomic.Value  // the service will be paused if it is waiting for catch up responses
-	resumed        chan struct{} // this channel will be closed when the service resumes
+	authority      bool            // run the service as an authority (ie participate in voting)
+	paused         atomic.Value    // the service is paused while a catch up response is applied
+	resumed        chan struct{}   // this channel is signalled when the service resumes
+	catchUp        *catchUpTracker // tracker of authority peers and catch up requests sent to them
+	peerViews      *peerViews      // views announced by peers in their neighbour messages
 	messageHandler *MessageHandler
 	network        Network
 	interval       time.Duration
@@ -74,8 +78,9 @@ type Service struct {
 	bestFinalCandidate map[uint64]*Vote // map of round number -> best final candidate
 
 	// channels for communication with other services
-	in          chan *networkVoteMessage // only used to receive *VoteMessage
-	finalisedCh chan *types.FinalisationInfo
+	in            chan *networkVoteMessage // only used to receive *VoteMessage
+	finalisedCh   chan *types.FinalisationInfo
+	equivocations chan *equivocationReport // equivocations to report to the runtime
 
 	telemetry telemetry.Client
 }
@@ -84,6 +89,7 @@ type Service struct {
 type Config struct {
 	LogLvl       log.Level
 	BlockState   BlockState
+	StorageState StorageState
 	GrandpaState GrandpaState
 	Network      Network
 	Voters       []Voter
@@ -134,6 +140,7 @@ func NewService(cfg *Config) (*Service, error) {
 		cancel:             cancel,
 		state:              NewState(cfg.Voters, setID, round),
 		blockState:         cfg.BlockState,
+		storageState:       cfg.StorageState,
 		grandpaState:       cfg.GrandpaState,
 		keypair:            cfg.Keypair,
 		authority:          cfg.Authority,
@@ -145,7 +152,10 @@ func NewService(cfg *Config) (*Service, error) {
 		bestFinalCandidate: make(map[uint64]*Vote),
 		head:               head,
 		in:                 make(chan *networkVoteMessage, 1024),
-		resumed:            make(chan struct{}),
+		equivocations:      make(chan *equivocationReport, maxPendingEquivocationReports),
+		resumed:            make(chan struct{}, 1),
+		catchUp:            newCatchUpTracker(),
+		peerViews:          newPeerViews(),
 		network:            cfg.Network,
 		finalisedCh:        finalisedCh,
 		interval:           cfg.Interval,
@@ -164,6 +174,8 @@ func NewService(cfg *Config) (*Service, error) {
 
 // Start begins the GRANDPA finality service
 func (s *Service) Start() error {
+	go s.reportEquivocations()
+
 	// if we're not an authority, we don't need to worry about the voting process.
 	// the grandpa service is only used to verify incoming block justifications
 	if !s.authority {
@@ -346,7 +358,12 @@ func (s *Service) initiateRound() error {
 // initiate initates the grandpa service to begin voting in sequential rounds
 func (s *Service) initiate() error {
 	for {
-		err := s.initiateRound()
+		err := s.waitWhileVotingPaused()
+		if err != nil {
+			return err
+		}
+
+		err = s.initiateRound()
 		if err != nil {
 			logger.Warnf("failed to initiate round for round %d: %s", s.state.round, err)
 			return err
@@ -355,9 +372,11 @@ func (s *Service) initiate() error {
 		err = s.playGrandpaRound()
 		if errors.Is(err, ErrServicePaused) {
 			logger.Info("service paused")
-			// wait for service to un-pause
-			<-s.resumed
-			err = s.initiate()
+			err = s.waitForCatchUpResponse()
+			if err != nil {
+				return err
+			}
+			continue
 		}
 
 		if err != nil {
@@ -371,6 +390,75 @@ func (s *Service) initiate() error {
 	}
 }
 
+// waitForCatchUpResponse blocks until the catch up response being applied
+// resumes the service, or until catchUpResponseTimeout elapses, in which
+// case the service is resumed without catching up.
+func (s *Service) waitForCatchUpResponse() error {
+	timer := time.NewTimer(catchUpResponseTimeout)
+	defer timer.Stop()
+
+	for s.paused.Load().(bool) {
+		select {
+		case <-s.resumed:
+		case <-timer.C:
+			logger.Debug("timed out waiting for catch up response")
+			s.paused.Store(false)
+		case <-s.ctx.Done():
+			return errors.New("context cancelled")
+		}
+	}
+
+	logger.Info("service resumed")
+	return nil
+}
+
+// resume unpauses the service and signals it to the voting loop.
+func (s *Service) resume() {
+	s.paused.Store(false)
+	select {
+	case s.resumed <- struct{}{}:
+	default:
+	}
+}
+
+// waitWhileVotingPaused blocks until voting is no longer paused by a
+// GRANDPA Pause consensus digest. Voting resumes once the block enacting
+// the matching Resume digest is imported on our best chain.
+func (s *Service) waitWhileVotingPaused() error {
+	paused, err := s.grandpaState.IsVotingPaused()
+	if err != nil {
+		return fmt.Errorf("checking if voting is paused: %w", err)
+	}
+
+	if !paused {
+		return nil
+	}
+
+	logger.Info("voting paused")
+
+	ch := s.blockState.GetImportedBlockNotifierChannel()
+	defer s.blockState.FreeImportedBlockNotifierChannel(ch)
+
+	for {
+		select {
+		case <-ch:
+		case <-time.After(s.interval):
+		case <-s.ctx.Done():
+			return errors.New("context cancelled")
+		}
+
+		paused, err = s.grandpaState.IsVotingPaused()
+		if err != nil {
+			return fmt.Errorf("checking if voting is paused: %w", err)
+		}
+
+		if !paused {
+			logger.Info("voting resumed")
+			return nil
+		}
+	}
+}
+
 func (s *Service) waitForFirstBlock() {
 	ch := s.blockState.GetImportedBlockNotifierChannel()
 	defer s.blockState.FreeImportedBlockNotifierChannel(ch)
@@ -769,34 +857,50 @@ func (s *Service) finalise() error {
 		return err
 	}
 
-	pcj, err := scale.Marshal(*newJustification(s.state.round, bfc.Hash, bfc.Number, pcs))
+	head, err := s.finaliseBlock(s.state.round, s.state.setID, bfc, pvs, pcs)
 	if err != nil {
 		return err
 	}
 
-	if err = s.blockState.SetJustification(bfc.Hash, pcj); err != nil {
-		return err
+	s.head = head
+	return nil
+}
+
+// finaliseBlock stores the justification and the votes of the given round
+// and finalises the voted block, returning its header.
+func (s *Service) finaliseBlock(round, setID uint64, vote *Vote, pvs, pcs []SignedVote) (*types.Header, error) {
+	pcj, err := scale.Marshal(*newJustification(round, vote.Hash, vote.Number, pcs))
+	if err != nil {
+		return nil, err
 	}
 
-	if err = s.grandpaState.SetPrevotes(s.state.round, s.state.setID, pvs); err != nil {
-		return err
+	if err = s.blockState.SetJustification(vote.Hash, pcj); err != nil {
+		return nil, err
 	}
 
-	if err = s.grandpaState.SetPrecommits(s.state.round, s.state.setID, pcs); err != nil {
-		return err
+	if err = s.grandpaState.SetPrevotes(round, setID, pvs); err != nil {
+		return nil, err
+	}
+
+	if err = s.grandpaState.SetPrecommits(round, setID, pcs); err != nil {
+		return nil, err
 	}
 
-	s.head, err = s.blockState.GetHeader(bfc.Hash)
+	header, err := s.blockState.GetHeader(vote.Hash)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// set finalised head for round in db
-	if err = s.blockState.SetFinalisedHash(bfc.Hash, s.state.round, s.state.setID); err != nil {
-		return err
+	if err = s.blockState.SetFinalisedHash(vote.Hash, round, setID); err != nil {
+		return nil, err
+	}
+
+	if err = s.grandpaState.SetLatestRound(round); err != nil {
+		return nil, err
 	}
 
-	return s.grandpaState.SetLatestRound(s.state.round)
+	return header, nil
 }
 
 // createJustification collects the signed precommits received for this round and turns them into
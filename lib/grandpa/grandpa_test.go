@@ -4,6 +4,8 @@
 package grandpa
 
 import (
+	"context"
+	"errors"
 	"sort"
 	"sync"
 	"testing"
@@ -1253,3 +1255,96 @@ func addBlocksAndReturnTheLastOne(
 
 	return bfcBlock
 }
+
+func TestService_waitWhileVotingPaused(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not paused", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		grandpaState := NewMockGrandpaState(ctrl)
+		grandpaState.EXPECT().IsVotingPaused().Return(false, nil)
+
+		s := &Service{
+			ctx:          context.Background(),
+			grandpaState: grandpaState,
+		}
+
+		err := s.waitWhileVotingPaused()
+		require.NoError(t, err)
+	})
+
+	t.Run("checking pause error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		errTest := errors.New("test error")
+
+		grandpaState := NewMockGrandpaState(ctrl)
+		grandpaState.EXPECT().IsVotingPaused().Return(false, errTest)
+
+		s := &Service{
+			ctx:          context.Background(),
+			grandpaState: grandpaState,
+		}
+
+		err := s.waitWhileVotingPaused()
+		require.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("resumed on block import", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		importedBlocks := make(chan *types.Block, 1)
+		importedBlocks <- &types.Block{}
+
+		blockState := NewMockBlockState(ctrl)
+		blockState.EXPECT().GetImportedBlockNotifierChannel().Return(importedBlocks)
+		blockState.EXPECT().FreeImportedBlockNotifierChannel(importedBlocks)
+
+		grandpaState := NewMockGrandpaState(ctrl)
+		gomock.InOrder(
+			grandpaState.EXPECT().IsVotingPaused().Return(true, nil),
+			grandpaState.EXPECT().IsVotingPaused().Return(false, nil),
+		)
+
+		s := &Service{
+			ctx:          context.Background(),
+			blockState:   blockState,
+			grandpaState: grandpaState,
+			interval:     time.Hour,
+		}
+
+		err := s.waitWhileVotingPaused()
+		require.NoError(t, err)
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+
+		importedBlocks := make(chan *types.Block)
+
+		blockState := NewMockBlockState(ctrl)
+		blockState.EXPECT().GetImportedBlockNotifierChannel().Return(importedBlocks)
+		blockState.EXPECT().FreeImportedBlockNotifierChannel(importedBlocks)
+
+		grandpaState := NewMockGrandpaState(ctrl)
+		grandpaState.EXPECT().IsVotingPaused().Return(true, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		s := &Service{
+			ctx:          ctx,
+			blockState:   blockState,
+			grandpaState: grandpaState,
+			interval:     time.Hour,
+		}
+
+		err := s.waitWhileVotingPaused()
+		require.EqualError(t, err, "context cancelled")
+	})
+}
@@ -34,6 +34,8 @@ type FullVote struct {
 	SetID uint64
 }
 
+//go:generate go run github.com/ChainSafe/gossamer/cmd/scalegen -type=SignedMessage,VoteMessage -output=scale_generated.go
+
 // SignedMessage represents a block hash and number signed by an authority
 type SignedMessage struct {
 	Stage       Subround // 0 for pre-vote, 1 for pre-commit, 2 for primary proposal
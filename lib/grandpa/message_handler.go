@@ -8,9 +8,11 @@
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/ChainSafe/chaindb"
 	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
@@ -25,6 +27,10 @@
 	ErrNeighbourVersionNotSupported = errors.New("neighbour version not supported")
 )
 
+// catchUpThreshold is the number of rounds a peer in the same set
+// must be ahead of us by, at least, before we send it a catch up request.
+const catchUpThreshold = 2
+
 // MessageHandler handles GRANDPA consensus messages
 type MessageHandler struct {
 	grandpa    *Service
@@ -57,14 +63,17 @@ func (h *MessageHandler) handleMessage(from peer.ID, m GrandpaMessage) (network.
 
 		return nil, nil
 	case *CommitMessage:
-		return nil, h.handleCommitMessage(msg)
+		err := h.handleCommitMessage(msg)
+		h.reportInvalidCommit(from, err)
+		return nil, err
 	case *NeighbourPacketV1:
 		// we can afford to not retry handling neighbour message, if it errors.
-		return nil, h.handleNeighbourMessage(msg)
+		return nil, h.handleNeighbourMessage(from, msg)
 	case *CatchUpRequest:
 		return h.handleCatchUpRequest(msg)
 	case *CatchUpResponse:
-		err := h.handleCatchUpResponse(msg)
+		err := h.handleCatchUpResponse(from, msg)
+		h.reportInvalidCatchUp(from, err)
 		if errors.Is(err, blocktree.ErrNodeNotFound) {
 			// TODO: we are adding these messages to reprocess them again, but we
 			// haven't added code to reprocess them. Do that.
@@ -78,7 +87,49 @@ func (h *MessageHandler) handleMessage(from peer.ID, m GrandpaMessage) (network.
 	}
 }
 
-func (h *MessageHandler) handleNeighbourMessage(msg *NeighbourPacketV1) error {
+// reportInvalidCommit reports the peer which sent a commit message
+// if handling it failed with an error caused by the message itself.
+func (h *MessageHandler) reportInvalidCommit(from peer.ID, err error) {
+	switch {
+	case errors.Is(err, ErrPrecommitSignatureMismatch):
+		h.grandpa.network.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.MalformedCommitValue,
+			Reason: peerset.MalformedCommitReason,
+		}, from)
+	case errors.Is(err, errVoteBlockMismatch),
+		errors.Is(err, ErrMinVotesNotMet):
+		h.grandpa.network.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.InvalidCommitValue,
+			Reason: peerset.InvalidCommitReason,
+		}, from)
+	}
+}
+
+// reportInvalidCatchUp reports the peer which sent a catch up response
+// if handling it failed with an error caused by the message itself.
+func (h *MessageHandler) reportInvalidCatchUp(from peer.ID, err error) {
+	switch {
+	case errors.Is(err, ErrSetIDMismatch),
+		errors.Is(err, ErrInvalidCatchUpResponseRound),
+		errors.Is(err, ErrMinVotesNotMet),
+		errors.Is(err, ErrGHOSTlessCatchUp),
+		errors.Is(err, ErrCatchUpResponseNotCompletable),
+		errors.Is(err, errVoteBlockMismatch):
+		h.grandpa.network.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.InvalidCatchUpValue,
+			Reason: peerset.InvalidCatchUpReason,
+		}, from)
+	}
+}
+
+func (h *MessageHandler) handleNeighbourMessage(from peer.ID, msg *NeighbourPacketV1) error {
+	h.grandpa.peerViews.update(from, msg.Round, msg.SetID)
+
+	err := h.requestCatchUp(from, msg)
+	if err != nil {
+		return fmt.Errorf("requesting catch up: %w", err)
+	}
+
 	currFinalized, err := h.blockState.GetFinalisedHeader(0, 0)
 	if err != nil {
 		return err
@@ -106,6 +157,45 @@ func (h *MessageHandler) handleNeighbourMessage(msg *NeighbourPacketV1) error {
 	return nil
 }
 
+// requestCatchUp sends a catch up request for the last round completed by the peer
+// if the peer is an authority more than catchUpThreshold rounds ahead of us in the same set.
+// Only one request is in flight at a time, and each peer is sent at most one request
+// every catchUpRequestInterval. Voting goes on until a valid response is received.
+func (h *MessageHandler) requestCatchUp(from peer.ID, msg *NeighbourPacketV1) error {
+	if !h.grandpa.authority {
+		return nil
+	}
+
+	h.grandpa.roundLock.Lock()
+	round, setID := h.grandpa.state.round, h.grandpa.state.setID
+	h.grandpa.roundLock.Unlock()
+
+	if msg.SetID != setID || msg.Round <= round+catchUpThreshold {
+		return nil
+	}
+
+	req := newCatchUpRequest(msg.Round-1, msg.SetID)
+	if !h.grandpa.catchUp.startRequest(from, req.Round, req.SetID, time.Now()) {
+		return nil
+	}
+
+	cm, err := req.ToConsensusMessage()
+	if err != nil {
+		h.grandpa.catchUp.cancelRequest()
+		return err
+	}
+
+	err = h.grandpa.network.SendMessage(from, cm)
+	if err != nil {
+		h.grandpa.catchUp.cancelRequest()
+		return fmt.Errorf("sending catch up request to peer %s: %w", from, err)
+	}
+
+	logger.Debugf("sent catch up request for round %d and set id %d to peer %s, our round is %d",
+		req.Round, req.SetID, from, round)
+	return nil
+}
+
 func (h *MessageHandler) handleCommitMessage(msg *CommitMessage) error {
 	logger.Debugf("received commit message, msg: %+v", msg)
 
@@ -159,7 +249,6 @@ func (h *MessageHandler) handleCommitMessage(msg *CommitMessage) error {
 		return err
 	}
 
-	// TODO: re-add catch-up logic (#1531)
 	return nil
 }
 
@@ -190,7 +279,7 @@ func (h *MessageHandler) handleCatchUpRequest(msg *CatchUpRequest) (*ConsensusMe
 	return resp.ToConsensusMessage()
 }
 
-func (h *MessageHandler) handleCatchUpResponse(msg *CatchUpResponse) error {
+func (h *MessageHandler) handleCatchUpResponse(from peer.ID, msg *CatchUpResponse) error {
 	if !h.grandpa.authority {
 		return nil
 	}
@@ -199,6 +288,12 @@ func (h *MessageHandler) handleCatchUpResponse(msg *CatchUpResponse) error {
 		"received catch up response with hash %s for round %d and set id %d",
 		msg.Hash, msg.Round, msg.SetID)
 
+	// ignore catch up responses we did not request
+	if !h.grandpa.catchUp.completeRequest(from, msg.Round, msg.SetID) {
+		logger.Debugf("ignoring unrequested catch up response from peer %s", from)
+		return nil
+	}
+
 	err := verifyBlockHashAgainstBlockNumber(h.blockState, msg.Hash, uint(msg.Number))
 	if err != nil {
 		if errors.Is(err, chaindb.ErrKeyNotFound) {
@@ -209,22 +304,15 @@ func (h *MessageHandler) handleCatchUpResponse(msg *CatchUpResponse) error {
 		return err
 	}
 
-	// TODO: re-add catch-up logic (#1531)
-	if true {
-		return nil
-	}
-
-	// if we aren't currently expecting a catch up response, return
-	if !h.grandpa.paused.Load().(bool) {
-		logger.Debug("not currently paused, ignoring catch up response")
-		return nil
-	}
+	h.grandpa.roundLock.Lock()
+	round, setID := h.grandpa.state.round, h.grandpa.state.setID
+	h.grandpa.roundLock.Unlock()
 
-	if msg.SetID != h.grandpa.state.setID {
+	if msg.SetID != setID {
 		return ErrSetIDMismatch
 	}
 
-	if msg.Round != h.grandpa.state.round-1 {
+	if msg.Round < round {
 		return ErrInvalidCatchUpResponseRound
 	}
 
@@ -245,30 +333,50 @@ func (h *MessageHandler) handleCatchUpResponse(msg *CatchUpResponse) error {
 		return err
 	}
 
-	// set prevotes and precommits in db
-	if err = h.grandpa.grandpaState.SetPrevotes(msg.Round, msg.SetID, msg.PreVoteJustification); err != nil {
-		return err
-	}
+	// the response is valid, pause voting while it is applied
+	h.grandpa.paused.Store(true)
+	defer h.grandpa.resume()
 
-	if err = h.grandpa.grandpaState.SetPrecommits(msg.Round, msg.SetID, msg.PreCommitJustification); err != nil {
-		return err
-	}
-
-	// update state and signal to grandpa we are ready to initiate
-	head, err := h.grandpa.blockState.GetHeader(msg.Hash)
+	head, err := h.applyCatchUpResponse(msg)
 	if err != nil {
-		return err
+		return fmt.Errorf("applying catch up response: %w", err)
 	}
 
+	h.grandpa.roundLock.Lock()
 	h.grandpa.head = head
 	h.grandpa.state.round = msg.Round
-	close(h.grandpa.resumed)
-	h.grandpa.resumed = make(chan struct{})
-	h.grandpa.paused.Store(false)
-	logger.Debugf("caught up to round; unpaused service and grandpa state round is %d", h.grandpa.state.round)
+	roundGauge.Set(float64(msg.Round))
+	h.grandpa.roundLock.Unlock()
+
+	logger.Debugf("caught up to round; unpaused service and grandpa state round is %d", msg.Round)
 	return nil
 }
 
+// applyCatchUpResponse finalises the block of a verified catch up response
+// and returns the header of our latest finalised block.
+func (h *MessageHandler) applyCatchUpResponse(msg *CatchUpResponse) (*types.Header, error) {
+	finalised, err := h.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	// the block may already be finalised, for example by a justification received while syncing
+	if finalised.Number >= uint(msg.Number) {
+		err = h.grandpa.grandpaState.SetLatestRound(msg.Round)
+		if err != nil {
+			return nil, fmt.Errorf("setting latest round: %w", err)
+		}
+		return finalised, nil
+	}
+
+	vote := &Vote{
+		Hash:   msg.Hash,
+		Number: msg.Number,
+	}
+	return h.grandpa.finaliseBlock(msg.Round, msg.SetID, vote,
+		msg.PreVoteJustification, msg.PreCommitJustification)
+}
+
 // verifyCatchUpResponseCompletability verifies that the pre-commit block is a descendant of, or is, the pre-voted block
 func (h *MessageHandler) verifyCatchUpResponseCompletability(prevote, precommit common.Hash) error {
 	if prevote == precommit {
@@ -591,99 +699,288 @@ func (s *Service) VerifyBlockJustification(hash common.Hash, justification []byt
 		return nil, fmt.Errorf("cannot get authorities for set ID: %w", err)
 	}
 
-	// threshold is two-thirds the number of authorities,
-	// uses the current set of authorities to define the threshold
-	threshold := (2 * len(auths) / 3)
+	logger.Debugf(
+		"verifying justification: set id %d, round %d, hash %s, number %d, sig count %d",
+		setID, fj.Round, fj.Commit.Hash, fj.Commit.Number, len(fj.Commit.Precommits))
+
+	isPrecommitDescendant := func(vote Vote) (bool, error) {
+		// check if vote was for descendant of committed block
+		return s.blockState.IsDescendantOf(hash, vote.Hash)
+	}
 
-	if len(fj.Commit.Precommits) < threshold {
-		return nil, ErrMinVotesNotMet
+	err = verifyJustificationPrecommits(&fj, setID, auths, isPrecommitDescendant)
+	if err != nil {
+		return nil, err
+	}
+
+	err = verifyBlockHashAgainstBlockNumber(s.blockState, fj.Commit.Hash, uint(fj.Commit.Number))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, preCommit := range fj.Commit.Precommits {
+		err := verifyBlockHashAgainstBlockNumber(s.blockState, preCommit.Vote.Hash, uint(preCommit.Vote.Number))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.blockState.SetFinalisedHash(hash, fj.Round, setID)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debugf(
+		"set finalised block with hash %s, round %d and set id %d",
+		hash, fj.Round, setID)
+	return scale.Marshal(fj)
+}
+
+// VerifyWarpSyncFragment verifies the justification of a block header received in a warp
+// sync proof against the current authority set, the same way VerifyBlockJustification does.
+// Since the blocks preceding and following the header are not known, the precommits must
+// target the header block or one of its descendants proven by the ancestry headers sent
+// along the justification. If the header schedules an authority set change, the change
+// is applied so the next fragment of the proof is verified with the new authority set.
+// It returns the round and the set ID of the justification.
+func (s *Service) VerifyWarpSyncFragment(header *types.Header, justification []byte) (
+	round, setID uint64, err error) {
+	fj, ancestries, err := decodeWarpSyncJustification(justification)
+	if err != nil {
+		return 0, 0, fmt.Errorf("decoding justification: %w", err)
+	}
+
+	hash := header.Hash()
+	if !hash.Equal(fj.Commit.Hash) {
+		return 0, 0, fmt.Errorf("%w: justification %s and block hash %s",
+			ErrJustificationMismatch, fj.Commit.Hash.Short(), hash.Short())
+	}
+
+	if header.Number != uint(fj.Commit.Number) {
+		return 0, 0, fmt.Errorf("%w: expected number %d from header but got number %d",
+			ErrBlockHashMismatch, header.Number, fj.Commit.Number)
+	}
+
+	setID, err = s.grandpaState.GetCurrentSetID()
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot get current set ID: %w", err)
+	}
+
+	auths, err := s.grandpaState.GetAuthorities(setID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot get authorities for set ID: %w", err)
+	}
+
+	isDescendant := func(vote Vote) (bool, error) {
+		return isDescendantInAncestries(ancestries, hash, header.Number, vote), nil
+	}
+
+	err = verifyJustificationPrecommits(fj, setID, auths, isDescendant)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = s.applyWarpSyncAuthoritySetChange(header)
+	if err != nil {
+		return 0, 0, fmt.Errorf("applying authority set change: %w", err)
+	}
+
+	logger.Debugf(
+		"verified warp sync fragment: set id %d, round %d, hash %s, number %d",
+		setID, fj.Round, hash, header.Number)
+	return fj.Round, setID, nil
+}
+
+// decodeWarpSyncJustification decodes the justification and the headers following
+// it, which prove the precommit targets descend from the committed block. The headers
+// are absent from justifications whose precommits all target the committed block.
+func decodeWarpSyncJustification(in []byte) (fj *Justification,
+	ancestries map[common.Hash]*types.Header, err error) {
+	reader := bytes.NewReader(in)
+	decoder := scale.NewDecoder(reader)
+
+	fj = new(Justification)
+	err = decoder.Decode(fj)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ancestries = make(map[common.Hash]*types.Header)
+	if reader.Len() == 0 {
+		return fj, ancestries, nil
+	}
+
+	var headersCount uint
+	err = decoder.Decode(&headersCount)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding votes ancestries count: %w", err)
+	}
+
+	for i := uint(0); i < headersCount; i++ {
+		header := types.NewEmptyHeader()
+		err = decoder.Decode(header)
+		if err != nil {
+			return nil, nil, fmt.Errorf("decoding votes ancestry %d: %w", i, err)
+		}
+		ancestries[header.Hash()] = header
+	}
+
+	return fj, ancestries, nil
+}
+
+// isDescendantInAncestries returns true if the vote is for the block with the given
+// hash and number, or for a descendant of it whose chain down to this block is
+// made of the ancestry headers given.
+func isDescendantInAncestries(ancestries map[common.Hash]*types.Header,
+	hash common.Hash, number uint, vote Vote) bool {
+	current, currentNumber := vote.Hash, uint(vote.Number)
+	for currentNumber > number {
+		header, ok := ancestries[current]
+		if !ok || header.Number != currentNumber {
+			return false
+		}
+		current, currentNumber = header.ParentHash, currentNumber-1
+	}
+
+	return currentNumber == number && current.Equal(hash)
+}
+
+// verifyJustificationPrecommits verifies each precommit of the justification is for
+// the committed block or one of its descendants, according to isDescendant, and is
+// signed by an authority of the given set. It then checks strictly more than two
+// thirds of the authorities precommitted, counting each authority at most once.
+func verifyJustificationPrecommits(fj *Justification, setID uint64, auths []types.GrandpaVoter,
+	isDescendant func(vote Vote) (bool, error)) error {
+	// threshold is two-thirds the number of authorities, rounded down,
+	// so strictly more precommits than the threshold are required
+	threshold := 2 * len(auths) / 3
+
+	if len(fj.Commit.Precommits) <= threshold {
+		return ErrMinVotesNotMet
 	}
 
 	authPubKeys := make([]AuthData, len(fj.Commit.Precommits))
 	for i, pcj := range fj.Commit.Precommits {
-		authPubKeys[i] = AuthData{AuthorityID: pcj.AuthorityID}
+		authPubKeys[i] = AuthData{AuthorityID: pcj.AuthorityID, Signature: pcj.Signature}
 	}
 
 	equivocatoryVoters := getEquivocatoryVoters(authPubKeys)
+	voters := make(map[ed25519.PublicKeyBytes]struct{}, len(fj.Commit.Precommits))
 
-	var count int
+	for _, just := range fj.Commit.Precommits {
+		ok, err := isDescendant(just.Vote)
+		if err != nil {
+			return err
+		}
 
-	logger.Debugf(
-		"verifying justification: set id %d, round %d, hash %s, number %d, sig count %d",
-		setID, fj.Round, fj.Commit.Hash, fj.Commit.Number, len(fj.Commit.Precommits))
+		if !ok {
+			return ErrPrecommitBlockMismatch
+		}
 
-	for _, just := range fj.Commit.Precommits {
-		// check if vote was for descendant of committed block
-		isDescendant, err := s.blockState.IsDescendantOf(hash, just.Vote.Hash)
+		err = verifyPrecommitJustification(just, fj.Round, setID, auths)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		if !isDescendant {
-			return nil, ErrPrecommitBlockMismatch
+		if _, ok := equivocatoryVoters[just.AuthorityID]; ok {
+			continue
 		}
 
-		pk, err := ed25519.NewPublicKey(just.AuthorityID[:])
+		// the same precommit sent twice only counts once
+		voters[just.AuthorityID] = struct{}{}
+	}
+
+	if len(voters)+len(equivocatoryVoters) <= threshold {
+		return ErrMinVotesNotMet
+	}
+
+	return nil
+}
+
+// applyWarpSyncAuthoritySetChange applies the authority set change scheduled
+// in the digest of the given header, if any, at the header block number.
+func (s *Service) applyWarpSyncAuthoritySetChange(header *types.Header) error {
+	for _, digestItem := range header.Digest.Types {
+		digestValue, err := digestItem.Value()
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("getting digest item value: %w", err)
 		}
 
-		if !isInAuthSet(pk, auths) {
-			return nil, ErrAuthorityNotInSet
+		consensusDigest, ok := digestValue.(types.ConsensusDigest)
+		if !ok || consensusDigest.ConsensusEngineID != types.GrandpaEngineID {
+			continue
 		}
 
-		// verify signature for each precommit
-		msg, err := scale.Marshal(FullVote{
-			Stage: precommit,
-			Vote:  just.Vote,
-			Round: fj.Round,
-			SetID: setID,
-		})
+		grandpaDigest := types.NewGrandpaConsensusDigest()
+		err = scale.Unmarshal(consensusDigest.Data, &grandpaDigest)
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("decoding GRANDPA consensus digest: %w", err)
 		}
 
-		ok, err := pk.Verify(msg, just.Signature[:])
+		grandpaDigestValue, err := grandpaDigest.Value()
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("getting GRANDPA consensus digest value: %w", err)
 		}
 
+		scheduledChange, ok := grandpaDigestValue.(types.GrandpaScheduledChange)
 		if !ok {
-			return nil, ErrInvalidSignature
+			continue
 		}
 
-		if _, ok := equivocatoryVoters[just.AuthorityID]; ok {
-			continue
+		voters, err := types.NewGrandpaVotersFromAuthoritiesRaw(scheduledChange.Auths)
+		if err != nil {
+			return fmt.Errorf("converting authorities: %w", err)
 		}
 
-		count++
-	}
+		err = s.grandpaState.SetNextChange(voters, header.Number)
+		if err != nil {
+			return fmt.Errorf("setting next change: %w", err)
+		}
+
+		_, err = s.grandpaState.IncrementSetID()
+		if err != nil {
+			return fmt.Errorf("incrementing set ID: %w", err)
+		}
 
-	if count+len(equivocatoryVoters) < threshold {
-		return nil, ErrMinVotesNotMet
+		return nil
 	}
 
-	err = verifyBlockHashAgainstBlockNumber(s.blockState, fj.Commit.Hash, uint(fj.Commit.Number))
+	return nil
+}
+
+// verifyPrecommitJustification verifies the given precommit is signed by
+// an authority of the given set, for the given round and set ID.
+func verifyPrecommitJustification(just SignedVote, round, setID uint64, auths []types.GrandpaVoter) error {
+	pk, err := ed25519.NewPublicKey(just.AuthorityID[:])
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	for _, preCommit := range fj.Commit.Precommits {
-		err := verifyBlockHashAgainstBlockNumber(s.blockState, preCommit.Vote.Hash, uint(preCommit.Vote.Number))
-		if err != nil {
-			return nil, err
-		}
+	if !isInAuthSet(pk, auths) {
+		return ErrAuthorityNotInSet
 	}
 
-	err = s.blockState.SetFinalisedHash(hash, fj.Round, setID)
+	// verify signature for each precommit
+	msg, err := scale.Marshal(FullVote{
+		Stage: precommit,
+		Vote:  just.Vote,
+		Round: round,
+		SetID: setID,
+	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	logger.Debugf(
-		"set finalised block with hash %s, round %d and set id %d",
-		hash, fj.Round, setID)
-	return scale.Marshal(fj)
+	ok, err := pk.Verify(msg, just.Signature[:])
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return ErrInvalidSignature
+	}
+
+	return nil
 }
 
 func verifyBlockHashAgainstBlockNumber(bs BlockState, hash common.Hash, number uint) error {
@@ -9,14 +9,17 @@
 	"testing"
 	"time"
 
+	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/grandpa/mocks"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -1253,11 +1256,16 @@ func signFakeFullVote(
 func TestService_VerifyBlockJustification(t *testing.T) {
 	t.Parallel()
 
-	precommits := buildTestJustification(t, 2, 1, 0, kr, precommit)
+	precommits := buildTestJustification(t, 3, 1, 0, kr, precommit)
 	justification := newJustification(1, testHash, 1, precommits)
 	justificationBytes, err := scale.Marshal(*justification)
 	require.NoError(t, err)
 
+	// two thirds of the three authorities is not a supermajority
+	twoThirdsJustification := newJustification(1, testHash, 1, precommits[:2])
+	twoThirdsJustificationBytes, err := scale.Marshal(*twoThirdsJustification)
+	require.NoError(t, err)
+
 	type fields struct {
 		blockStateBuilder   func(ctrl *gomock.Controller) BlockState
 		grandpaStateBuilder func(ctrl *gomock.Controller) GrandpaState
@@ -1289,6 +1297,32 @@ type args struct {
 			wantErr: errors.New("EOF, field: 0x0000000000000000000000000000000000000000000000000000000000000000, " +
 				"field: {Hash:0x0000000000000000000000000000000000000000000000000000000000000000 Number:0 Precommits:[]}"),
 		},
+		"two thirds of votes": {
+			fields: fields{
+				blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+					mockBlockState := NewMockBlockState(ctrl)
+					mockBlockState.EXPECT().HasFinalisedBlock(uint64(1), uint64(0)).Return(false, nil)
+					mockBlockState.EXPECT().GetHighestFinalisedHeader().Return(testHeader, nil)
+					mockBlockState.EXPECT().IsDescendantOf(testHash, testHash).Return(true, nil)
+					return mockBlockState
+				},
+				grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState {
+					mockGrandpaState := NewMockGrandpaState(ctrl)
+					mockGrandpaState.EXPECT().GetSetIDByBlockNumber(uint(1)).Return(uint64(0), nil)
+					mockGrandpaState.EXPECT().GetAuthorities(uint64(0)).Return([]types.GrandpaVoter{
+						{Key: *kr.Alice().Public().(*ed25519.PublicKey), ID: 1},
+						{Key: *kr.Bob().Public().(*ed25519.PublicKey), ID: 2},
+						{Key: *kr.Charlie().Public().(*ed25519.PublicKey), ID: 3},
+					}, nil)
+					return mockGrandpaState
+				},
+			},
+			args: args{
+				hash:          testHash,
+				justification: twoThirdsJustificationBytes,
+			},
+			wantErr: ErrMinVotesNotMet,
+		},
 		"valid justification": {
 			fields: fields{
 				blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
@@ -1296,8 +1330,8 @@ type args struct {
 					mockBlockState.EXPECT().HasFinalisedBlock(uint64(1), uint64(0)).Return(false, nil)
 					mockBlockState.EXPECT().GetHighestFinalisedHeader().Return(testHeader, nil)
 					mockBlockState.EXPECT().IsDescendantOf(testHash, testHash).
-						Return(true, nil).Times(3)
-					mockBlockState.EXPECT().GetHeader(testHash).Return(testHeader, nil).Times(3)
+						Return(true, nil).Times(4)
+					mockBlockState.EXPECT().GetHeader(testHash).Return(testHeader, nil).Times(4)
 					mockBlockState.EXPECT().SetFinalisedHash(testHash, uint64(1),
 						uint64(0)).Return(nil)
 					return mockBlockState
@@ -1326,8 +1360,8 @@ type args struct {
 					mockBlockState.EXPECT().HasFinalisedBlock(uint64(1), uint64(0)).Return(false, nil)
 					mockBlockState.EXPECT().GetHighestFinalisedHeader().Return(testHeader, nil)
 					mockBlockState.EXPECT().IsDescendantOf(testHash, testHash).
-						Return(true, nil).Times(3)
-					mockBlockState.EXPECT().GetHeader(testHash).Return(testHeader, nil).Times(3)
+						Return(true, nil).Times(4)
+					mockBlockState.EXPECT().GetHeader(testHash).Return(testHeader, nil).Times(4)
 					mockBlockState.EXPECT().SetFinalisedHash(testHash, uint64(1),
 						uint64(0)).Return(nil)
 					return mockBlockState
@@ -1369,3 +1403,503 @@ type args struct {
 		})
 	}
 }
+
+func TestService_VerifyWarpSyncFragment(t *testing.T) {
+	t.Parallel()
+
+	precommits := buildTestJustification(t, 3, 1, 0, kr, precommit)
+	justification := newJustification(1, testHash, 1, precommits)
+	justificationBytes, err := scale.Marshal(*justification)
+	require.NoError(t, err)
+
+	duplicatePrecommits := []SignedVote{precommits[0], precommits[1], precommits[1]}
+	duplicateJustification := newJustification(1, testHash, 1, duplicatePrecommits)
+	duplicateJustificationBytes, err := scale.Marshal(*duplicateJustification)
+	require.NoError(t, err)
+
+	// the last precommit is for a child of the committed block
+	childHeader := &types.Header{
+		ParentHash: testHash,
+		Number:     2,
+		Digest:     types.NewDigest(),
+	}
+	childVote := *NewVoteFromHeader(childHeader)
+	descendantPrecommits := []SignedVote{precommits[0], precommits[1], {
+		Vote:        childVote,
+		Signature:   signFakeFullVote(t, kr.Charlie().(*ed25519.Keypair), precommit, childVote, 1, 0),
+		AuthorityID: kr.Charlie().Public().(*ed25519.PublicKey).AsBytes(),
+	}}
+	descendantJustification := newJustification(1, testHash, 1, descendantPrecommits)
+	descendantJustificationBytes, err := scale.Marshal(*descendantJustification)
+	require.NoError(t, err)
+	ancestriesBytes, err := scale.Marshal([]types.Header{*childHeader})
+	require.NoError(t, err)
+	descendantJustificationWithAncestriesBytes := append(descendantJustificationBytes, ancestriesBytes...)
+
+	voters := []types.GrandpaVoter{
+		{Key: *kr.Alice().Public().(*ed25519.PublicKey), ID: 1},
+		{Key: *kr.Bob().Public().(*ed25519.PublicKey), ID: 2},
+		{Key: *kr.Charlie().Public().(*ed25519.PublicKey), ID: 3},
+	}
+
+	tests := map[string]struct {
+		grandpaStateBuilder func(ctrl *gomock.Controller) GrandpaState
+		header              *types.Header
+		justification       []byte
+		round               uint64
+		setID               uint64
+		errWrapped          error
+	}{
+		"hash mismatch": {
+			grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState {
+				return nil
+			},
+			header:        testGenesisHeader,
+			justification: justificationBytes,
+			errWrapped:    ErrJustificationMismatch,
+		},
+		"not enough votes": {
+			grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState {
+				mockGrandpaState := NewMockGrandpaState(ctrl)
+				mockGrandpaState.EXPECT().GetCurrentSetID().Return(uint64(0), nil)
+				mockGrandpaState.EXPECT().GetAuthorities(uint64(0)).Return(append(voters,
+					types.GrandpaVoter{Key: *kr.Dave().Public().(*ed25519.PublicKey), ID: 4},
+					types.GrandpaVoter{Key: *kr.Eve().Public().(*ed25519.PublicKey), ID: 5},
+				), nil)
+				return mockGrandpaState
+			},
+			header:        testHeader,
+			justification: justificationBytes,
+			errWrapped:    ErrMinVotesNotMet,
+		},
+		"duplicate precommits": {
+			grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState {
+				mockGrandpaState := NewMockGrandpaState(ctrl)
+				mockGrandpaState.EXPECT().GetCurrentSetID().Return(uint64(0), nil)
+				mockGrandpaState.EXPECT().GetAuthorities(uint64(0)).Return(voters, nil)
+				return mockGrandpaState
+			},
+			header:        testHeader,
+			justification: duplicateJustificationBytes,
+			errWrapped:    ErrMinVotesNotMet,
+		},
+		"precommit descendant without ancestry": {
+			grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState {
+				mockGrandpaState := NewMockGrandpaState(ctrl)
+				mockGrandpaState.EXPECT().GetCurrentSetID().Return(uint64(0), nil)
+				mockGrandpaState.EXPECT().GetAuthorities(uint64(0)).Return(voters, nil)
+				return mockGrandpaState
+			},
+			header:        testHeader,
+			justification: descendantJustificationBytes,
+			errWrapped:    ErrPrecommitBlockMismatch,
+		},
+		"precommit descendant with ancestry": {
+			grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState {
+				mockGrandpaState := NewMockGrandpaState(ctrl)
+				mockGrandpaState.EXPECT().GetCurrentSetID().Return(uint64(0), nil)
+				mockGrandpaState.EXPECT().GetAuthorities(uint64(0)).Return(voters, nil)
+				return mockGrandpaState
+			},
+			header:        testHeader,
+			justification: descendantJustificationWithAncestriesBytes,
+			round:         1,
+		},
+		"valid fragment": {
+			grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState {
+				mockGrandpaState := NewMockGrandpaState(ctrl)
+				mockGrandpaState.EXPECT().GetCurrentSetID().Return(uint64(0), nil)
+				mockGrandpaState.EXPECT().GetAuthorities(uint64(0)).Return(voters, nil)
+				return mockGrandpaState
+			},
+			header:        testHeader,
+			justification: justificationBytes,
+			round:         1,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			s := &Service{
+				grandpaState: tt.grandpaStateBuilder(ctrl),
+			}
+
+			round, setID, err := s.VerifyWarpSyncFragment(tt.header, tt.justification)
+
+			assert.ErrorIs(t, err, tt.errWrapped)
+			assert.Equal(t, tt.round, round)
+			assert.Equal(t, tt.setID, setID)
+		})
+	}
+}
+
+func Test_MessageHandler_requestCatchUp(t *testing.T) {
+	t.Parallel()
+
+	const from = peer.ID("peer")
+	expectedRequest, err := newCatchUpRequest(4, 0).ToConsensusMessage()
+	require.NoError(t, err)
+	errTest := errors.New("test error")
+
+	tests := map[string]struct {
+		networkBuilder  func(t *testing.T) Network
+		authority       bool
+		peerIsAuthority bool
+		pending         *pendingCatchUp
+		lastRequest     time.Duration // time elapsed since the last request to the peer, if any
+		msg             *NeighbourPacketV1
+		errWrapped      error
+		errMessage      string
+		expectedPending *pendingCatchUp
+	}{
+		"not authority": {
+			networkBuilder:  func(t *testing.T) Network { return nil },
+			peerIsAuthority: true,
+			msg:             &NeighbourPacketV1{Round: 5},
+		},
+		"different set id": {
+			networkBuilder:  func(t *testing.T) Network { return nil },
+			authority:       true,
+			peerIsAuthority: true,
+			msg:             &NeighbourPacketV1{Round: 5, SetID: 1},
+		},
+		"below catch up threshold": {
+			networkBuilder:  func(t *testing.T) Network { return nil },
+			authority:       true,
+			peerIsAuthority: true,
+			msg:             &NeighbourPacketV1{Round: 3},
+		},
+		"peer is not an authority": {
+			networkBuilder: func(t *testing.T) Network { return nil },
+			authority:      true,
+			msg:            &NeighbourPacketV1{Round: 5},
+		},
+		"catch up already requested": {
+			networkBuilder:  func(t *testing.T) Network { return nil },
+			authority:       true,
+			peerIsAuthority: true,
+			pending:         &pendingCatchUp{peer: "other", round: 4},
+			msg:             &NeighbourPacketV1{Round: 5},
+			expectedPending: &pendingCatchUp{peer: "other", round: 4},
+		},
+		"peer recently requested": {
+			networkBuilder:  func(t *testing.T) Network { return nil },
+			authority:       true,
+			peerIsAuthority: true,
+			lastRequest:     time.Second,
+			msg:             &NeighbourPacketV1{Round: 5},
+		},
+		"send message error": {
+			networkBuilder: func(t *testing.T) Network {
+				network := mocks.NewNetwork(t)
+				network.On("SendMessage", from, expectedRequest).Return(errTest)
+				return network
+			},
+			authority:       true,
+			peerIsAuthority: true,
+			msg:             &NeighbourPacketV1{Round: 5},
+			errWrapped:      errTest,
+			errMessage:      "sending catch up request to peer 3sdfvR: test error",
+		},
+		"catch up requested": {
+			networkBuilder: func(t *testing.T) Network {
+				network := mocks.NewNetwork(t)
+				network.On("SendMessage", from, expectedRequest).Return(nil)
+				return network
+			},
+			authority:       true,
+			peerIsAuthority: true,
+			lastRequest:     catchUpRequestInterval,
+			msg:             &NeighbourPacketV1{Round: 5},
+			expectedPending: &pendingCatchUp{peer: from, round: 4},
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			catchUp := newCatchUpTracker()
+			if tt.peerIsAuthority {
+				catchUp.addAuthority(from)
+			}
+			if tt.pending != nil {
+				tt.pending.sentAt = time.Now()
+				catchUp.pending = tt.pending
+			}
+			if tt.lastRequest != 0 {
+				catchUp.lastRequests[from] = time.Now().Add(-tt.lastRequest)
+			}
+
+			s := &Service{
+				authority: tt.authority,
+				network:   tt.networkBuilder(t),
+				state:     NewState(nil, 0, 1),
+				catchUp:   catchUp,
+			}
+			s.paused.Store(false)
+			h := &MessageHandler{grandpa: s}
+
+			err := h.requestCatchUp(from, tt.msg)
+
+			assert.ErrorIs(t, err, tt.errWrapped)
+			if tt.errWrapped != nil {
+				assert.EqualError(t, err, tt.errMessage)
+			}
+			assert.False(t, s.paused.Load().(bool))
+			if tt.expectedPending != nil {
+				require.NotNil(t, catchUp.pending)
+				tt.expectedPending.sentAt = catchUp.pending.sentAt
+			}
+			assert.Equal(t, tt.expectedPending, catchUp.pending)
+		})
+	}
+}
+
+func Test_MessageHandler_handleCatchUpResponse(t *testing.T) {
+	t.Parallel()
+
+	const from = peer.ID("peer")
+	const round, setID = uint64(1), uint64(0)
+	voters := []types.GrandpaVoter{
+		{Key: *kr.Alice().Public().(*ed25519.PublicKey), ID: 1},
+		{Key: *kr.Bob().Public().(*ed25519.PublicKey), ID: 2},
+		{Key: *kr.Charlie().Public().(*ed25519.PublicKey), ID: 3},
+	}
+	prevotes := buildTestJustification(t, len(voters), round, setID, kr, prevote)
+	precommits := buildTestJustification(t, len(voters), round, setID, kr, precommit)
+	justification, err := scale.Marshal(*newJustification(round, testHash, uint32(testHeader.Number), precommits))
+	require.NoError(t, err)
+	finalisedHeader := &types.Header{Number: testHeader.Number + 1}
+
+	newResponse := func(round, setID uint64) *CatchUpResponse {
+		return &CatchUpResponse{
+			SetID:                  setID,
+			Round:                  round,
+			PreVoteJustification:   prevotes,
+			PreCommitJustification: precommits,
+			Hash:                   testHash,
+			Number:                 uint32(testHeader.Number),
+		}
+	}
+
+	tests := map[string]struct {
+		blockStateBuilder   func(ctrl *gomock.Controller) BlockState
+		grandpaStateBuilder func(ctrl *gomock.Controller) GrandpaState
+		pending             *pendingCatchUp
+		stateRound          uint64
+		msg                 *CatchUpResponse
+		errWrapped          error
+		expectedRound       uint64
+		expectedHead        *types.Header
+	}{
+		"not requested": {
+			blockStateBuilder:   func(ctrl *gomock.Controller) BlockState { return nil },
+			grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState { return nil },
+			stateRound:          1,
+			msg:                 newResponse(round, setID),
+			expectedRound:       1,
+		},
+		"requested from another peer": {
+			blockStateBuilder:   func(ctrl *gomock.Controller) BlockState { return nil },
+			grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState { return nil },
+			pending:             &pendingCatchUp{peer: "other", round: round, setID: setID},
+			stateRound:          1,
+			msg:                 newResponse(round, setID),
+			expectedRound:       1,
+		},
+		"set id mismatch": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().GetHeader(testHash).Return(testHeader, nil)
+				return mockBlockState
+			},
+			grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState { return nil },
+			pending:             &pendingCatchUp{peer: from, round: round, setID: setID + 1},
+			stateRound:          1,
+			msg:                 newResponse(round, setID+1),
+			errWrapped:          ErrSetIDMismatch,
+			expectedRound:       1,
+		},
+		"past round": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().GetHeader(testHash).Return(testHeader, nil)
+				return mockBlockState
+			},
+			grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState { return nil },
+			pending:             &pendingCatchUp{peer: from, round: round, setID: setID},
+			stateRound:          2,
+			msg:                 newResponse(round, setID),
+			errWrapped:          ErrInvalidCatchUpResponseRound,
+			expectedRound:       2,
+		},
+		"already finalised": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().GetHeader(testHash).Return(testHeader, nil).AnyTimes()
+				mockBlockState.EXPECT().GetHighestFinalisedHeader().Return(testGenesisHeader, nil)
+				mockBlockState.EXPECT().IsDescendantOf(testGenesisHeader.Hash(), testHash).Return(true, nil)
+				mockBlockState.EXPECT().GetHighestFinalisedHeader().Return(finalisedHeader, nil)
+				return mockBlockState
+			},
+			grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState {
+				mockGrandpaState := NewMockGrandpaState(ctrl)
+				mockGrandpaState.EXPECT().SetLatestRound(round).Return(nil)
+				return mockGrandpaState
+			},
+			pending:       &pendingCatchUp{peer: from, round: round, setID: setID},
+			stateRound:    1,
+			msg:           newResponse(round, setID),
+			expectedRound: round,
+			expectedHead:  finalisedHeader,
+		},
+		"caught up": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().GetHeader(testHash).Return(testHeader, nil).AnyTimes()
+				mockBlockState.EXPECT().GetHighestFinalisedHeader().Return(testGenesisHeader, nil).Times(2)
+				mockBlockState.EXPECT().IsDescendantOf(testGenesisHeader.Hash(), testHash).Return(true, nil)
+				mockBlockState.EXPECT().SetJustification(testHash, justification).Return(nil)
+				mockBlockState.EXPECT().SetFinalisedHash(testHash, round, setID).Return(nil)
+				return mockBlockState
+			},
+			grandpaStateBuilder: func(ctrl *gomock.Controller) GrandpaState {
+				mockGrandpaState := NewMockGrandpaState(ctrl)
+				mockGrandpaState.EXPECT().SetPrevotes(round, setID, prevotes).Return(nil)
+				mockGrandpaState.EXPECT().SetPrecommits(round, setID, precommits).Return(nil)
+				mockGrandpaState.EXPECT().SetLatestRound(round).Return(nil)
+				return mockGrandpaState
+			},
+			pending:       &pendingCatchUp{peer: from, round: round, setID: setID},
+			stateRound:    1,
+			msg:           newResponse(round, setID),
+			expectedRound: round,
+			expectedHead:  testHeader,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			catchUp := newCatchUpTracker()
+			catchUp.pending = tt.pending
+
+			blockState := tt.blockStateBuilder(ctrl)
+			s := &Service{
+				authority:    true,
+				blockState:   blockState,
+				grandpaState: tt.grandpaStateBuilder(ctrl),
+				state:        NewState(voters, 0, tt.stateRound),
+				resumed:      make(chan struct{}, 1),
+				catchUp:      catchUp,
+			}
+			s.paused.Store(false)
+			h := &MessageHandler{
+				grandpa:    s,
+				blockState: blockState,
+			}
+
+			err := h.handleCatchUpResponse(from, tt.msg)
+
+			assert.ErrorIs(t, err, tt.errWrapped)
+			assert.False(t, s.paused.Load().(bool))
+			assert.Equal(t, tt.expectedRound, s.state.round)
+			assert.Equal(t, tt.expectedHead, s.head)
+			if tt.expectedHead != nil {
+				assert.Len(t, s.resumed, 1)
+				assert.Nil(t, catchUp.pending)
+			}
+		})
+	}
+}
+
+func Test_MessageHandler_reportInvalidCommit(t *testing.T) {
+	t.Parallel()
+
+	const from = peer.ID("peer")
+
+	tests := map[string]struct {
+		err    error
+		change *peerset.ReputationChange
+	}{
+		"no error": {},
+		"error not caused by message": {
+			err: errors.New("test error"),
+		},
+		"malformed commit": {
+			err: ErrPrecommitSignatureMismatch,
+			change: &peerset.ReputationChange{
+				Value:  peerset.MalformedCommitValue,
+				Reason: peerset.MalformedCommitReason,
+			},
+		},
+		"minimum votes not met": {
+			err: fmt.Errorf("wrapped: %w", ErrMinVotesNotMet),
+			change: &peerset.ReputationChange{
+				Value:  peerset.InvalidCommitValue,
+				Reason: peerset.InvalidCommitReason,
+			},
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			network := mocks.NewNetwork(t)
+			if tt.change != nil {
+				network.On("ReportPeer", *tt.change, from)
+			}
+			h := &MessageHandler{grandpa: &Service{network: network}}
+
+			h.reportInvalidCommit(from, tt.err)
+		})
+	}
+}
+
+func Test_MessageHandler_reportInvalidCatchUp(t *testing.T) {
+	t.Parallel()
+
+	const from = peer.ID("peer")
+	invalidCatchUp := &peerset.ReputationChange{
+		Value:  peerset.InvalidCatchUpValue,
+		Reason: peerset.InvalidCatchUpReason,
+	}
+
+	tests := map[string]struct {
+		err    error
+		change *peerset.ReputationChange
+	}{
+		"no error": {},
+		"error not caused by message": {
+			err: errors.New("test error"),
+		},
+		"set id mismatch": {
+			err:    ErrSetIDMismatch,
+			change: invalidCatchUp,
+		},
+		"not completable": {
+			err:    ErrCatchUpResponseNotCompletable,
+			change: invalidCatchUp,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			network := mocks.NewNetwork(t)
+			if tt.change != nil {
+				network.On("ReportPeer", *tt.change, from)
+			}
+			h := &MessageHandler{grandpa: &Service{network: network}}
+
+			h.reportInvalidCatchUp(from, tt.err)
+		})
+	}
+}
@@ -8,6 +8,8 @@
 
 	peer "github.com/libp2p/go-libp2p-core/peer"
 
+	peerset "github.com/ChainSafe/gossamer/dot/peerset"
+
 	protocol "github.com/libp2p/go-libp2p-core/protocol"
 )
 
@@ -35,6 +37,11 @@ func (_m *Network) RegisterNotificationsProtocol(sub protocol.ID, messageID byte
 	return r0
 }
 
+// ReportPeer provides a mock function with given fields: change, p
+func (_m *Network) ReportPeer(change peerset.ReputationChange, p peer.ID) {
+	_m.Called(change, p)
+}
+
 // SendMessage provides a mock function with given fields: to, msg
 func (_m *Network) SendMessage(to peer.ID, msg network.NotificationsMessage) error {
 	ret := _m.Called(to, msg)
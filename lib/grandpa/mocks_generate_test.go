@@ -3,5 +3,5 @@
 
 package grandpa
 
-//go:generate mockgen -destination=mocks_test.go -package $GOPACKAGE . BlockState,GrandpaState
+//go:generate mockgen -destination=mocks_test.go -package $GOPACKAGE . BlockState,GrandpaState,StorageState
 //go:generate mockery --name Network --structname Network --case underscore --keeptree
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ChainSafe/gossamer/lib/grandpa (interfaces: BlockState,GrandpaState)
+// Source: github.com/ChainSafe/gossamer/lib/grandpa (interfaces: BlockState,GrandpaState,StorageState)
 
 // Package grandpa is a generated GoMock package.
 package grandpa
@@ -9,6 +9,8 @@
 
 	types "github.com/ChainSafe/gossamer/dot/types"
 	common "github.com/ChainSafe/gossamer/lib/common"
+	runtime "github.com/ChainSafe/gossamer/lib/runtime"
+	storage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -265,6 +267,21 @@ func (mr *MockBlockStateMockRecorder) GetJustification(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJustification", reflect.TypeOf((*MockBlockState)(nil).GetJustification), arg0)
 }
 
+// GetRuntime mocks base method.
+func (m *MockBlockState) GetRuntime(arg0 *common.Hash) (runtime.Instance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRuntime", arg0)
+	ret0, _ := ret[0].(runtime.Instance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRuntime indicates an expected call of GetRuntime.
+func (mr *MockBlockStateMockRecorder) GetRuntime(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuntime", reflect.TypeOf((*MockBlockState)(nil).GetRuntime), arg0)
+}
+
 // HasFinalisedBlock mocks base method.
 func (m *MockBlockState) HasFinalisedBlock(arg0, arg1 uint64) (bool, error) {
 	m.ctrl.T.Helper()
@@ -495,6 +512,36 @@ func (mr *MockGrandpaStateMockRecorder) GetSetIDByBlockNumber(arg0 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSetIDByBlockNumber", reflect.TypeOf((*MockGrandpaState)(nil).GetSetIDByBlockNumber), arg0)
 }
 
+// IncrementSetID mocks base method.
+func (m *MockGrandpaState) IncrementSetID() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementSetID")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementSetID indicates an expected call of IncrementSetID.
+func (mr *MockGrandpaStateMockRecorder) IncrementSetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementSetID", reflect.TypeOf((*MockGrandpaState)(nil).IncrementSetID))
+}
+
+// IsVotingPaused mocks base method.
+func (m *MockGrandpaState) IsVotingPaused() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsVotingPaused")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsVotingPaused indicates an expected call of IsVotingPaused.
+func (mr *MockGrandpaStateMockRecorder) IsVotingPaused() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsVotingPaused", reflect.TypeOf((*MockGrandpaState)(nil).IsVotingPaused))
+}
+
 // NextGrandpaAuthorityChange mocks base method.
 func (m *MockGrandpaState) NextGrandpaAuthorityChange(arg0 common.Hash, arg1 uint) (uint, error) {
 	m.ctrl.T.Helper()
@@ -524,6 +571,20 @@ func (mr *MockGrandpaStateMockRecorder) SetLatestRound(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLatestRound", reflect.TypeOf((*MockGrandpaState)(nil).SetLatestRound), arg0)
 }
 
+// SetNextChange mocks base method.
+func (m *MockGrandpaState) SetNextChange(arg0 []types.GrandpaVoter, arg1 uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNextChange", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetNextChange indicates an expected call of SetNextChange.
+func (mr *MockGrandpaStateMockRecorder) SetNextChange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNextChange", reflect.TypeOf((*MockGrandpaState)(nil).SetNextChange), arg0, arg1)
+}
+
 // SetPrecommits mocks base method.
 func (m *MockGrandpaState) SetPrecommits(arg0, arg1 uint64, arg2 []types.GrandpaSignedVote) error {
 	m.ctrl.T.Helper()
@@ -551,3 +612,65 @@ func (mr *MockGrandpaStateMockRecorder) SetPrevotes(arg0, arg1, arg2 interface{}
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrevotes", reflect.TypeOf((*MockGrandpaState)(nil).SetPrevotes), arg0, arg1, arg2)
 }
+
+// MockStorageState is a mock of StorageState interface.
+type MockStorageState struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageStateMockRecorder
+}
+
+// MockStorageStateMockRecorder is the mock recorder for MockStorageState.
+type MockStorageStateMockRecorder struct {
+	mock *MockStorageState
+}
+
+// NewMockStorageState creates a new mock instance.
+func NewMockStorageState(ctrl *gomock.Controller) *MockStorageState {
+	mock := &MockStorageState{ctrl: ctrl}
+	mock.recorder = &MockStorageStateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorageState) EXPECT() *MockStorageStateMockRecorder {
+	return m.recorder
+}
+
+// Lock mocks base method.
+func (m *MockStorageState) Lock() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Lock")
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockStorageStateMockRecorder) Lock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockStorageState)(nil).Lock))
+}
+
+// TrieState mocks base method.
+func (m *MockStorageState) TrieState(arg0 *common.Hash) (*storage.TrieState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrieState", arg0)
+	ret0, _ := ret[0].(*storage.TrieState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TrieState indicates an expected call of TrieState.
+func (mr *MockStorageStateMockRecorder) TrieState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrieState", reflect.TypeOf((*MockStorageState)(nil).TrieState), arg0)
+}
+
+// Unlock mocks base method.
+func (m *MockStorageState) Unlock() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unlock")
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockStorageStateMockRecorder) Unlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockStorageState)(nil).Unlock))
+}
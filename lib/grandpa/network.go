@@ -102,7 +102,17 @@ func (*Service) decodeHandshake(in []byte) (Handshake, error) {
 	return hs, err
 }
 
-func (*Service) validateHandshake(_ peer.ID, _ Handshake) error {
+func (s *Service) validateHandshake(from peer.ID, hs Handshake) error {
+	grandpaHandshake, ok := hs.(*GrandpaHandshake)
+	if !ok {
+		return nil
+	}
+
+	// only authorities are sent catch up requests
+	if grandpaHandshake.Roles == common.AuthorityRole {
+		s.catchUp.addAuthority(from)
+	}
+
 	return nil
 }
 
@@ -138,9 +148,20 @@ func (s *Service) handleNetworkMessage(from peer.ID, msg NotificationsMessage) (
 
 	switch r := resp.(type) {
 	case *ConsensusMessage:
-		if r != nil {
-			s.network.GossipMessage(resp)
+		if r == nil {
+			break
 		}
+
+		// catch up responses are only sent to the requesting peer
+		if _, ok := m.(*CatchUpRequest); ok {
+			err = s.network.SendMessage(from, r)
+			if err != nil {
+				return false, fmt.Errorf("sending catch up response to peer %s: %w", from, err)
+			}
+			break
+		}
+
+		s.network.GossipMessage(resp)
 	case nil:
 	default:
 		logger.Warnf(
@@ -149,7 +170,7 @@ func (s *Service) handleNetworkMessage(from peer.ID, msg NotificationsMessage) (
 	}
 
 	switch m.(type) {
-	case *NeighbourPacketV1, *CatchUpResponse:
+	case *NeighbourPacketV1, *CatchUpRequest, *CatchUpResponse:
 		return false, nil
 	}
 
@@ -8,9 +8,11 @@
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/golang/mock/gomock"
 
 	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -140,3 +142,36 @@ func TestSendNeighbourMessage(t *testing.T) {
 		require.Equal(t, expected, nm)
 	}
 }
+
+func Test_Service_validateHandshake(t *testing.T) {
+	t.Parallel()
+
+	const from = peer.ID("peer")
+
+	tests := map[string]struct {
+		handshake         Handshake
+		expectedAuthority bool
+	}{
+		"full node": {
+			handshake: &GrandpaHandshake{Roles: common.FullNodeRole},
+		},
+		"authority": {
+			handshake:         &GrandpaHandshake{Roles: common.AuthorityRole},
+			expectedAuthority: true,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &Service{catchUp: newCatchUpTracker()}
+
+			err := s.validateHandshake(from, tt.handshake)
+
+			require.NoError(t, err)
+			_, isAuthority := s.catchUp.authorities[from]
+			assert.Equal(t, tt.expectedAuthority, isAuthority)
+		})
+	}
+}
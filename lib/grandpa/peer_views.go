@@ -0,0 +1,62 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"sync"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// peerView is the round and set id last announced by a peer in a neighbour message.
+type peerView struct {
+	round uint64
+	setID uint64
+}
+
+// peerViews keeps track of the views announced by peers in their neighbour messages.
+type peerViews struct {
+	sync.Mutex
+	views map[peer.ID]peerView
+}
+
+func newPeerViews() *peerViews {
+	return &peerViews{
+		views: make(map[peer.ID]peerView),
+	}
+}
+
+// update records the view announced by the peer. As in Substrate, a view
+// can only move forward so a view going back is ignored.
+func (p *peerViews) update(id peer.ID, round, setID uint64) {
+	p.Lock()
+	defer p.Unlock()
+
+	view, has := p.views[id]
+	if has && (setID < view.setID || (setID == view.setID && round < view.round)) {
+		return
+	}
+
+	p.views[id] = peerView{round: round, setID: setID}
+}
+
+// isExpired returns true if a message for the given round and set id is already
+// expired in the view announced by the peer, that is if it is from a previous set
+// or more than one round behind the peer's round. It returns false if the peer has
+// not announced its view yet, since the message may just be late gossip.
+func (p *peerViews) isExpired(id peer.ID, round, setID uint64) bool {
+	p.Lock()
+	defer p.Unlock()
+
+	view, has := p.views[id]
+	if !has {
+		return false
+	}
+
+	if setID != view.setID {
+		return setID < view.setID
+	}
+
+	return round+1 < view.round
+}
@@ -0,0 +1,73 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_peerViews_isExpired(t *testing.T) {
+	t.Parallel()
+
+	const id = peer.ID("peer")
+
+	testCases := map[string]struct {
+		views   []peerView
+		round   uint64
+		setID   uint64
+		expired bool
+	}{
+		"unknown_view": {
+			round: 1,
+			setID: 1,
+		},
+		"previous_set": {
+			views:   []peerView{{round: 1, setID: 2}},
+			round:   10,
+			setID:   1,
+			expired: true,
+		},
+		"next_set": {
+			views: []peerView{{round: 1, setID: 2}},
+			round: 1,
+			setID: 3,
+		},
+		"previous_round": {
+			views: []peerView{{round: 5, setID: 1}},
+			round: 4,
+			setID: 1,
+		},
+		"two_rounds_behind": {
+			views:   []peerView{{round: 5, setID: 1}},
+			round:   3,
+			setID:   1,
+			expired: true,
+		},
+		"view_going_back_ignored": {
+			views:   []peerView{{round: 5, setID: 1}, {round: 1, setID: 1}},
+			round:   3,
+			setID:   1,
+			expired: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			views := newPeerViews()
+			for _, view := range testCase.views {
+				views.update(id, view.round, view.setID)
+			}
+
+			expired := views.isExpired(id, testCase.round, testCase.setID)
+
+			assert.Equal(t, testCase.expired, expired)
+		})
+	}
+}
@@ -11,6 +11,7 @@
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
@@ -64,6 +65,8 @@ func (n *testNetwork) SendMessage(_ peer.ID, _ NotificationsMessage) error {
 	return nil
 }
 
+func (*testNetwork) ReportPeer(_ peerset.ReputationChange, _ peer.ID) {}
+
 func (n *testNetwork) SendJustificationRequest(to peer.ID, num uint32) {
 	n.justificationRequest = &testJustificationRequest{
 		to:  to,
@@ -0,0 +1,128 @@
+// Code generated by scalegen. DO NOT EDIT.
+
+package grandpa
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// MarshalSCALE returns the SCALE encoding of the SignedMessage.
+func (m SignedMessage) MarshalSCALE() ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+	err := m.encodeSCALE(buffer)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// encodeSCALE writes the SCALE encoding of the SignedMessage to the writer.
+func (m SignedMessage) encodeSCALE(writer io.Writer) (err error) {
+	err = scale.EncodeUint8(writer, uint8(m.Stage))
+	if err != nil {
+		return fmt.Errorf("encoding Stage: %w", err)
+	}
+	_, err = writer.Write(m.BlockHash[:])
+	if err != nil {
+		return fmt.Errorf("encoding BlockHash: %w", err)
+	}
+	err = scale.EncodeUint32(writer, m.Number)
+	if err != nil {
+		return fmt.Errorf("encoding Number: %w", err)
+	}
+	_, err = writer.Write(m.Signature[:])
+	if err != nil {
+		return fmt.Errorf("encoding Signature: %w", err)
+	}
+	_, err = writer.Write(m.AuthorityID[:])
+	if err != nil {
+		return fmt.Errorf("encoding AuthorityID: %w", err)
+	}
+	return nil
+}
+
+// UnmarshalSCALE decodes the SCALE encoding read from the reader into the SignedMessage.
+func (m *SignedMessage) UnmarshalSCALE(reader io.Reader) (err error) {
+	decoded := SignedMessage{}
+	var v0 uint8
+	v0, err = scale.DecodeUint8(reader)
+	if err != nil {
+		return fmt.Errorf("decoding Stage: %w", err)
+	}
+	decoded.Stage = Subround(v0)
+	_, err = io.ReadFull(reader, decoded.BlockHash[:])
+	if err != nil {
+		return fmt.Errorf("decoding BlockHash: %w", err)
+	}
+	var v1 uint32
+	v1, err = scale.DecodeUint32(reader)
+	if err != nil {
+		return fmt.Errorf("decoding Number: %w", err)
+	}
+	decoded.Number = v1
+	_, err = io.ReadFull(reader, decoded.Signature[:])
+	if err != nil {
+		return fmt.Errorf("decoding Signature: %w", err)
+	}
+	_, err = io.ReadFull(reader, decoded.AuthorityID[:])
+	if err != nil {
+		return fmt.Errorf("decoding AuthorityID: %w", err)
+	}
+	*m = decoded
+	return nil
+}
+
+// MarshalSCALE returns the SCALE encoding of the VoteMessage.
+func (v VoteMessage) MarshalSCALE() ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+	err := v.encodeSCALE(buffer)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// encodeSCALE writes the SCALE encoding of the VoteMessage to the writer.
+func (v VoteMessage) encodeSCALE(writer io.Writer) (err error) {
+	err = scale.EncodeUint64(writer, v.Round)
+	if err != nil {
+		return fmt.Errorf("encoding Round: %w", err)
+	}
+	err = scale.EncodeUint64(writer, v.SetID)
+	if err != nil {
+		return fmt.Errorf("encoding SetID: %w", err)
+	}
+	err = v.Message.encodeSCALE(writer)
+	if err != nil {
+		return fmt.Errorf("encoding Message: %w", err)
+	}
+	return nil
+}
+
+// UnmarshalSCALE decodes the SCALE encoding read from the reader into the VoteMessage.
+func (v *VoteMessage) UnmarshalSCALE(reader io.Reader) (err error) {
+	decoded := VoteMessage{}
+	decoded.Message = v.Message
+	var v0 uint64
+	v0, err = scale.DecodeUint64(reader)
+	if err != nil {
+		return fmt.Errorf("decoding Round: %w", err)
+	}
+	decoded.Round = v0
+	var v1 uint64
+	v1, err = scale.DecodeUint64(reader)
+	if err != nil {
+		return fmt.Errorf("decoding SetID: %w", err)
+	}
+	decoded.SetID = v1
+	err = decoded.Message.UnmarshalSCALE(reader)
+	if err != nil {
+		return fmt.Errorf("decoding Message: %w", err)
+	}
+	*v = decoded
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The types below have no method, so they are encoded and decoded
+// using the reflection based encoder and decoder of the scale package.
+type (
+	reflectiveSignedMessage SignedMessage
+	reflectiveVoteMessage   VoteMessage
+)
+
+func Test_VoteMessage_generatedSCALE(t *testing.T) {
+	t.Parallel()
+
+	message := VoteMessage{
+		Round: 77,
+		SetID: 3,
+		Message: SignedMessage{
+			Stage:       precommit,
+			BlockHash:   common.Hash{0xa, 0xb},
+			Number:      999,
+			Signature:   testSignature,
+			AuthorityID: testAuthorityID,
+		},
+	}
+
+	encoding, err := message.MarshalSCALE()
+	require.NoError(t, err)
+
+	reflectiveEncoding, err := scale.Marshal(reflectiveVoteMessage(message))
+	require.NoError(t, err)
+	assert.Equal(t, reflectiveEncoding, encoding)
+
+	signedEncoding, err := message.Message.MarshalSCALE()
+	require.NoError(t, err)
+	reflectiveSignedEncoding, err := scale.Marshal(reflectiveSignedMessage(message.Message))
+	require.NoError(t, err)
+	assert.Equal(t, reflectiveSignedEncoding, signedEncoding)
+
+	var decoded VoteMessage
+	err = scale.Unmarshal(encoding, &decoded)
+	require.NoError(t, err)
+
+	var reflectiveDecoded reflectiveVoteMessage
+	err = scale.Unmarshal(encoding, &reflectiveDecoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, message, decoded)
+	assert.Equal(t, VoteMessage(reflectiveDecoded), decoded)
+
+	// vote messages are encoded as varying data type values
+	// in GRANDPA network messages.
+	networkMessage := newGrandpaMessage()
+	err = networkMessage.Set(message)
+	require.NoError(t, err)
+
+	networkEncoding, err := scale.Marshal(networkMessage)
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte{0}, encoding...), networkEncoding)
+
+	decodedNetworkMessage := newGrandpaMessage()
+	err = scale.Unmarshal(networkEncoding, &decodedNetworkMessage)
+	require.NoError(t, err)
+	decodedValue, err := decodedNetworkMessage.Value()
+	require.NoError(t, err)
+	assert.Equal(t, message, decodedValue)
+}
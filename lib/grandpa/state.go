@@ -4,12 +4,17 @@
 package grandpa
 
 import (
+	"sync"
+
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/protocol"
 
 	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 )
 
 // BlockState is the interface required by GRANDPA into the block state
@@ -38,6 +43,13 @@ type BlockState interface {
 	GetHashByNumber(num uint) (common.Hash, error)
 	BestBlockNumber() (blockNumber uint, err error)
 	GetHighestRoundAndSetID() (uint64, uint64, error)
+	GetRuntime(blockHash *common.Hash) (instance runtime.Instance, err error)
+}
+
+// StorageState is the interface required by GRANDPA into the storage state
+type StorageState interface {
+	TrieState(root *common.Hash) (*rtstorage.TrieState, error)
+	sync.Locker
 }
 
 // GrandpaState is the interface required by grandpa into the grandpa state
@@ -52,12 +64,16 @@ type GrandpaState interface {
 	GetPrevotes(round, setID uint64) ([]SignedVote, error)
 	GetPrecommits(round, setID uint64) ([]SignedVote, error)
 	NextGrandpaAuthorityChange(bestBlockHash common.Hash, bestBlockNumber uint) (blockHeight uint, err error)
+	SetNextChange(authorities []types.GrandpaVoter, number uint) error
+	IncrementSetID() (newSetID uint64, err error)
+	IsVotingPaused() (paused bool, err error)
 }
 
 // Network is the interface required by GRANDPA for the network
 type Network interface {
 	GossipMessage(msg network.NotificationsMessage)
 	SendMessage(to peer.ID, msg NotificationsMessage) error
+	ReportPeer(change peerset.ReputationChange, p peer.ID)
 	RegisterNotificationsProtocol(sub protocol.ID,
 		messageID byte,
 		handshakeGetter network.HandshakeGetter,
@@ -9,7 +9,9 @@
 	"errors"
 	"fmt"
 
+	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
+	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/pkg/scale"
@@ -126,19 +128,30 @@ func (s *Service) validateVoteMessage(from peer.ID, m *VoteMessage) (*Vote, erro
 	// check for message signature
 	pk, err := ed25519.NewPublicKey(m.Message.AuthorityID[:])
 	if err != nil {
-		// TODO Affect peer reputation
-		// https://github.com/ChainSafe/gossamer/issues/2505
+		s.network.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.BadMessageValue,
+			Reason: peerset.BadMessageReason,
+		}, from)
 		return nil, err
 	}
 
 	err = validateMessageSignature(pk, m)
 	if err != nil {
-		// TODO Affect peer reputation
-		// https://github.com/ChainSafe/gossamer/issues/2505
+		s.network.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.BadSignatureValue,
+			Reason: peerset.BadSignatureReason,
+		}, from)
 		return nil, err
 	}
 
-	if m.SetID != s.state.setID {
+	if m.SetID < s.state.setID {
+		s.reportExpiredMessage(from, m)
+		return nil, ErrSetIDMismatch
+	} else if m.SetID > s.state.setID {
+		s.network.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.FutureMessageValue,
+			Reason: peerset.FutureMessageReason,
+		}, from)
 		return nil, ErrSetIDMismatch
 	}
 
@@ -152,10 +165,16 @@ func (s *Service) validateVoteMessage(from peer.ID, m *VoteMessage) (*Vote, erro
 	const maxRoundsAhead = 1
 	maxRoundAccepted := s.state.round + maxRoundsAhead
 
-	if m.Round < minRoundAccepted || m.Round > maxRoundAccepted {
+	if m.Round < minRoundAccepted {
+		// Discard message
+		s.reportExpiredMessage(from, m)
+		return nil, nil //nolint:nilnil
+	} else if m.Round > maxRoundAccepted {
 		// Discard message
-		// TODO: affect peer reputation, this is shameful impolite behaviour
-		// https://github.com/ChainSafe/gossamer/issues/2505
+		s.network.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.FutureMessageValue,
+			Reason: peerset.FutureMessageReason,
+		}, from)
 		return nil, nil //nolint:nilnil
 	}
 
@@ -195,6 +214,12 @@ func (s *Service) validateVoteMessage(from peer.ID, m *VoteMessage) (*Vote, erro
 	// check for equivocation ie. multiple votes within one subround
 	voter, err := s.state.pubkeyToVoter(pk)
 	if err != nil {
+		if errors.Is(err, ErrVoterNotFound) {
+			s.network.ReportPeer(peerset.ReputationChange{
+				Value:  peerset.UnknownVoterValue,
+				Reason: peerset.UnknownVoterReason,
+			}, from)
+		}
 		return nil, err
 	}
 
@@ -223,8 +248,19 @@ func (s *Service) validateVoteMessage(from peer.ID, m *VoteMessage) (*Vote, erro
 		AuthorityID: pk.AsBytes(),
 	}
 
-	equivocated := s.checkForEquivocation(voter, just, m.Message.Stage)
+	equivocated, existingVote := s.checkForEquivocation(voter, just, m.Message.Stage)
 	if equivocated {
+		// the peer may just be relaying the vote, the equivocating
+		// authority is reported to the runtime instead.
+		if existingVote != nil {
+			s.queueEquivocationReport(&equivocationReport{
+				round:        m.Round,
+				setID:        m.SetID,
+				stage:        m.Message.Stage,
+				existingVote: existingVote,
+				currentVote:  just,
+			})
+		}
 		return nil, ErrEquivocation
 	}
 
@@ -238,10 +274,27 @@ func (s *Service) validateVoteMessage(from peer.ID, m *VoteMessage) (*Vote, erro
 	return vote, nil
 }
 
+// reportExpiredMessage reports the peer for a vote message from a past round or set,
+// following Substrate's neighbour view rules: late gossip is discarded silently and the
+// peer is only reported if the message is already expired in the view it announced to us.
+func (s *Service) reportExpiredMessage(from peer.ID, m *VoteMessage) {
+	if !s.peerViews.isExpired(from, m.Round, m.SetID) {
+		return
+	}
+
+	s.network.ReportPeer(peerset.ReputationChange{
+		Value:  peerset.PastRejectionValue,
+		Reason: peerset.PastRejectionReason,
+	}, from)
+}
+
 // checkForEquivocation checks if the vote is an equivocatory vote.
 // it returns true if so, false otherwise.
 // additionally, if the vote is equivocatory, it updates the service's votes and equivocations.
-func (s *Service) checkForEquivocation(voter *Voter, vote *SignedVote, stage Subround) bool {
+// The existing vote of the voter is returned when the vote is the first equivocation of the
+// voter, so the equivocation can be reported.
+func (s *Service) checkForEquivocation(voter *Voter, vote *SignedVote, stage Subround) (
+	equivocated bool, existingVote *SignedVote) {
 	v := voter.Key.AsBytes()
 
 	// save justification, since equivocatory vote may still be used in justification
@@ -261,22 +314,135 @@ func (s *Service) checkForEquivocation(voter *Voter, vote *SignedVote, stage Sub
 	if has {
 		// if the voter has already equivocated, every vote in that round is an equivocatory vote
 		eq[v] = append(eq[v], vote)
-		return true
+		return true, nil
 	}
 
-	existingVote, has := s.loadVote(v, stage)
+	existingVote, has = s.loadVote(v, stage)
 	if !has {
-		return false
+		return false, nil
 	}
 
 	if has && existingVote.Vote.Hash != vote.Vote.Hash {
 		// the voter has already voted, all their votes are now equivocatory
 		eq[v] = []*SignedVote{existingVote, vote}
 		s.deleteVote(v, stage)
-		return true
+		return true, existingVote
+	}
+
+	return false, nil
+}
+
+// maxPendingEquivocationReports is the maximum number of equivocations
+// waiting to be reported to the runtime.
+const maxPendingEquivocationReports = 16
+
+// equivocationReport is an equivocation to report to the runtime.
+type equivocationReport struct {
+	round        uint64
+	setID        uint64
+	stage        Subround
+	existingVote *SignedVote
+	currentVote  *SignedVote
+}
+
+// queueEquivocationReport queues the equivocation given to be reported to the runtime
+// by reportEquivocations, so votes are not blocked by the runtime calls.
+// The equivocation is dropped if too many equivocations are waiting to be reported.
+func (s *Service) queueEquivocationReport(report *equivocationReport) {
+	select {
+	case s.equivocations <- report:
+	default:
+		logger.Warnf("dropping report of %s equivocation of voter %s in round %d: too many pending reports",
+			report.stage, report.existingVote.AuthorityID, report.round)
+	}
+}
+
+// reportEquivocations reports the queued equivocations to the runtime
+// until the service is stopped.
+func (s *Service) reportEquivocations() {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case report := <-s.equivocations:
+			err := s.reportEquivocation(report)
+			if err != nil {
+				logger.Errorf("failed to report %s equivocation of voter %s in round %d: %s",
+					report.stage, report.existingVote.AuthorityID, report.round, err)
+			}
+		}
+	}
+}
+
+// reportEquivocation submits an unsigned extrinsic to the runtime reporting the
+// equivocation given, using the runtime and state of the best block.
+func (s *Service) reportEquivocation(report *equivocationReport) error {
+	authorityID := report.existingVote.AuthorityID
+
+	equivocation := types.GrandpaEquivocation{
+		RoundNumber:     report.round,
+		ID:              authorityID,
+		FirstVote:       report.existingVote.Vote,
+		FirstSignature:  report.existingVote.Signature,
+		SecondVote:      report.currentVote.Vote,
+		SecondSignature: report.currentVote.Signature,
 	}
 
-	return false
+	equivocationVote := types.NewGrandpaEquivocation()
+	var err error
+	switch report.stage {
+	case prevote, primaryProposal:
+		err = equivocationVote.Set(types.PreVoteEquivocation(equivocation))
+	case precommit:
+		err = equivocationVote.Set(types.PreCommitEquivocation(equivocation))
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedSubround, report.stage)
+	}
+	if err != nil {
+		return fmt.Errorf("setting equivocation: %w", err)
+	}
+
+	bestBlockHeader, err := s.blockState.BestBlockHeader()
+	if err != nil {
+		return fmt.Errorf("getting best block header: %w", err)
+	}
+
+	s.storageState.Lock()
+	defer s.storageState.Unlock()
+
+	trieState, err := s.storageState.TrieState(&bestBlockHeader.StateRoot)
+	if err != nil {
+		return fmt.Errorf("getting trie state: %w", err)
+	}
+
+	bestBlockHash := bestBlockHeader.Hash()
+	instance, err := s.blockState.GetRuntime(&bestBlockHash)
+	if err != nil {
+		return fmt.Errorf("getting runtime: %w", err)
+	}
+
+	previousStorage := instance.ContextStorage()
+	instance.SetContextStorage(trieState)
+	defer instance.SetContextStorage(previousStorage)
+
+	keyOwnershipProof, err := instance.GrandpaGenerateKeyOwnershipProof(report.setID, authorityID)
+	if err != nil {
+		return fmt.Errorf("generating key ownership proof: %w", err)
+	}
+
+	equivocationProof := types.GrandpaEquivocationProof{
+		SetID:        report.setID,
+		Equivocation: equivocationVote,
+	}
+
+	err = instance.GrandpaSubmitReportEquivocationUnsignedExtrinsic(equivocationProof, keyOwnershipProof)
+	if err != nil {
+		return fmt.Errorf("submitting equivocation report: %w", err)
+	}
+
+	logger.Infof("reported %s equivocation of voter %s in round %d and set id %d",
+		report.stage, authorityID, report.round, report.setID)
+	return nil
 }
 
 // validateVote checks if the block that is being voted for exists, and that it is a descendant of a
@@ -4,13 +4,23 @@
 package grandpa
 
 import (
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/grandpa/mocks"
 	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	mocksruntime "github.com/ChainSafe/gossamer/lib/runtime/mocks"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/golang/mock/gomock"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -36,10 +46,11 @@ func TestCheckForEquivocation_NoEquivocation(t *testing.T) {
 	require.NoError(t, err)
 
 	for _, v := range voters {
-		equivocated := gs.checkForEquivocation(&v, &SignedVote{
+		equivocated, existingVote := gs.checkForEquivocation(&v, &SignedVote{
 			Vote: *vote,
 		}, prevote)
 		require.False(t, equivocated)
+		require.Nil(t, existingVote)
 	}
 }
 
@@ -73,10 +84,11 @@ func TestCheckForEquivocation_WithEquivocation(t *testing.T) {
 	vote2, err := NewVoteFromHash(leaves[1], st.Block)
 	require.NoError(t, err)
 
-	equivocated := gs.checkForEquivocation(&voter, &SignedVote{
+	equivocated, existingVote := gs.checkForEquivocation(&voter, &SignedVote{
 		Vote: *vote2,
 	}, prevote)
 	require.True(t, equivocated)
+	require.Equal(t, &SignedVote{Vote: *vote1}, existingVote)
 
 	require.Equal(t, 0, gs.lenVotes(prevote))
 	require.Equal(t, 1, len(gs.pvEquivocations))
@@ -113,20 +125,23 @@ func TestCheckForEquivocation_WithExistingEquivocation(t *testing.T) {
 	vote2, err := NewVoteFromHash(leaves[0], gs.blockState)
 	require.NoError(t, err)
 
-	equivocated := gs.checkForEquivocation(&voter, &SignedVote{
+	equivocated, existingVote := gs.checkForEquivocation(&voter, &SignedVote{
 		Vote: *vote2,
 	}, prevote)
 	require.True(t, equivocated)
+	require.NotNil(t, existingVote)
 
 	require.Equal(t, 0, gs.lenVotes(prevote))
 	require.Equal(t, 1, len(gs.pvEquivocations))
 
 	vote3 := vote1
 
-	equivocated = gs.checkForEquivocation(&voter, &SignedVote{
+	// the equivocation of the voter is only reported once
+	equivocated, existingVote = gs.checkForEquivocation(&voter, &SignedVote{
 		Vote: *vote3,
 	}, prevote)
 	require.True(t, equivocated)
+	require.Nil(t, existingVote)
 
 	require.Equal(t, 0, gs.lenVotes(prevote))
 	require.Equal(t, 1, len(gs.pvEquivocations))
@@ -341,3 +356,369 @@ func TestValidateMessage_IsNotDescendant(t *testing.T) {
 	_, err = gs.validateVoteMessage("", msg)
 	require.Equal(t, errVoteBlockMismatch, err, gs.prevotes)
 }
+
+func TestService_reportEquivocation(t *testing.T) {
+	t.Parallel()
+
+	authorityID := kr.Alice().Public().(*ed25519.PublicKey).AsBytes()
+	firstVote := &SignedVote{
+		Vote:        types.GrandpaVote{Hash: common.Hash{1}, Number: 1},
+		Signature:   [64]byte{1},
+		AuthorityID: authorityID,
+	}
+	secondVote := &SignedVote{
+		Vote:        types.GrandpaVote{Hash: common.Hash{2}, Number: 1},
+		Signature:   [64]byte{2},
+		AuthorityID: authorityID,
+	}
+
+	bestBlockHeader := &types.Header{
+		Number:    3,
+		StateRoot: common.Hash{3},
+		Digest:    types.NewDigest(),
+	}
+	bestBlockHash := bestBlockHeader.Hash()
+	trieState := rtstorage.NewTrieState(nil)
+	previousStorage := rtstorage.NewTrieState(nil)
+	previousStorage.Set([]byte("key"), []byte("value"))
+	keyOwnershipProof := types.GrandpaOpaqueKeyOwnershipProof{4}
+	errTest := errors.New("test error")
+
+	newEquivocationProof := func(t *testing.T, stage Subround) types.GrandpaEquivocationProof {
+		t.Helper()
+
+		equivocation := types.GrandpaEquivocation{
+			RoundNumber:     2,
+			ID:              authorityID,
+			FirstVote:       firstVote.Vote,
+			FirstSignature:  firstVote.Signature,
+			SecondVote:      secondVote.Vote,
+			SecondSignature: secondVote.Signature,
+		}
+
+		equivocationVote := types.NewGrandpaEquivocation()
+		var err error
+		if stage == precommit {
+			err = equivocationVote.Set(types.PreCommitEquivocation(equivocation))
+		} else {
+			err = equivocationVote.Set(types.PreVoteEquivocation(equivocation))
+		}
+		require.NoError(t, err)
+
+		return types.GrandpaEquivocationProof{
+			SetID:        1,
+			Equivocation: equivocationVote,
+		}
+	}
+
+	newBlockState := func(ctrl *gomock.Controller, instance runtime.Instance) BlockState {
+		blockState := NewMockBlockState(ctrl)
+		blockState.EXPECT().BestBlockHeader().Return(bestBlockHeader, nil)
+		blockState.EXPECT().GetRuntime(&bestBlockHash).Return(instance, nil)
+		return blockState
+	}
+
+	newStorageState := func(ctrl *gomock.Controller) StorageState {
+		storageState := NewMockStorageState(ctrl)
+		storageState.EXPECT().Lock()
+		storageState.EXPECT().Unlock()
+		storageState.EXPECT().TrieState(&bestBlockHeader.StateRoot).Return(trieState, nil)
+		return storageState
+	}
+
+	tests := map[string]struct {
+		blockStateBuilder   func(t *testing.T, ctrl *gomock.Controller, stage Subround) BlockState
+		storageStateBuilder func(ctrl *gomock.Controller) StorageState
+		stage               Subround
+		errWrapped          error
+		errMessage          string
+	}{
+		"unsupported subround": {
+			blockStateBuilder: func(t *testing.T, ctrl *gomock.Controller, stage Subround) BlockState {
+				return nil
+			},
+			storageStateBuilder: func(ctrl *gomock.Controller) StorageState { return nil },
+			stage:               Subround(3),
+			errWrapped:          ErrUnsupportedSubround,
+			errMessage:          "unsupported subround: unknown",
+		},
+		"best block header error": {
+			blockStateBuilder: func(t *testing.T, ctrl *gomock.Controller, stage Subround) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().BestBlockHeader().Return(nil, errTest)
+				return blockState
+			},
+			storageStateBuilder: func(ctrl *gomock.Controller) StorageState { return nil },
+			stage:               prevote,
+			errWrapped:          errTest,
+			errMessage:          "getting best block header: test error",
+		},
+		"trie state error": {
+			blockStateBuilder: func(t *testing.T, ctrl *gomock.Controller, stage Subround) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().BestBlockHeader().Return(bestBlockHeader, nil)
+				return blockState
+			},
+			storageStateBuilder: func(ctrl *gomock.Controller) StorageState {
+				storageState := NewMockStorageState(ctrl)
+				storageState.EXPECT().Lock()
+				storageState.EXPECT().Unlock()
+				storageState.EXPECT().TrieState(&bestBlockHeader.StateRoot).Return(nil, errTest)
+				return storageState
+			},
+			stage:      prevote,
+			errWrapped: errTest,
+			errMessage: "getting trie state: test error",
+		},
+		"get runtime error": {
+			blockStateBuilder: func(t *testing.T, ctrl *gomock.Controller, stage Subround) BlockState {
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().BestBlockHeader().Return(bestBlockHeader, nil)
+				blockState.EXPECT().GetRuntime(&bestBlockHash).Return(nil, errTest)
+				return blockState
+			},
+			storageStateBuilder: newStorageState,
+			stage:               prevote,
+			errWrapped:          errTest,
+			errMessage:          "getting runtime: test error",
+		},
+		"generate key ownership proof error": {
+			blockStateBuilder: func(t *testing.T, ctrl *gomock.Controller, stage Subround) BlockState {
+				instance := mocksruntime.NewInstance(t)
+				instance.On("ContextStorage").Return(previousStorage)
+				instance.On("SetContextStorage", trieState)
+				instance.On("SetContextStorage", previousStorage)
+				instance.On("GrandpaGenerateKeyOwnershipProof", uint64(1), authorityID).
+					Return(nil, errTest)
+				return newBlockState(ctrl, instance)
+			},
+			storageStateBuilder: newStorageState,
+			stage:               prevote,
+			errWrapped:          errTest,
+			errMessage:          "generating key ownership proof: test error",
+		},
+		"submit report error": {
+			blockStateBuilder: func(t *testing.T, ctrl *gomock.Controller, stage Subround) BlockState {
+				instance := mocksruntime.NewInstance(t)
+				instance.On("ContextStorage").Return(previousStorage)
+				instance.On("SetContextStorage", trieState)
+				instance.On("SetContextStorage", previousStorage)
+				instance.On("GrandpaGenerateKeyOwnershipProof", uint64(1), authorityID).
+					Return(keyOwnershipProof, nil)
+				instance.On("GrandpaSubmitReportEquivocationUnsignedExtrinsic",
+					newEquivocationProof(t, stage), keyOwnershipProof).Return(errTest)
+				return newBlockState(ctrl, instance)
+			},
+			storageStateBuilder: newStorageState,
+			stage:               precommit,
+			errWrapped:          errTest,
+			errMessage:          "submitting equivocation report: test error",
+		},
+		"prevote equivocation reported": {
+			blockStateBuilder: func(t *testing.T, ctrl *gomock.Controller, stage Subround) BlockState {
+				instance := mocksruntime.NewInstance(t)
+				instance.On("ContextStorage").Return(previousStorage)
+				instance.On("SetContextStorage", trieState)
+				instance.On("SetContextStorage", previousStorage)
+				instance.On("GrandpaGenerateKeyOwnershipProof", uint64(1), authorityID).
+					Return(keyOwnershipProof, nil)
+				instance.On("GrandpaSubmitReportEquivocationUnsignedExtrinsic",
+					newEquivocationProof(t, stage), keyOwnershipProof).Return(nil)
+				return newBlockState(ctrl, instance)
+			},
+			storageStateBuilder: newStorageState,
+			stage:               prevote,
+		},
+		"precommit equivocation reported": {
+			blockStateBuilder: func(t *testing.T, ctrl *gomock.Controller, stage Subround) BlockState {
+				instance := mocksruntime.NewInstance(t)
+				instance.On("ContextStorage").Return(previousStorage)
+				instance.On("SetContextStorage", trieState)
+				instance.On("SetContextStorage", previousStorage)
+				instance.On("GrandpaGenerateKeyOwnershipProof", uint64(1), authorityID).
+					Return(keyOwnershipProof, nil)
+				instance.On("GrandpaSubmitReportEquivocationUnsignedExtrinsic",
+					newEquivocationProof(t, stage), keyOwnershipProof).Return(nil)
+				return newBlockState(ctrl, instance)
+			},
+			storageStateBuilder: newStorageState,
+			stage:               precommit,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			s := &Service{
+				blockState:   tt.blockStateBuilder(t, ctrl, tt.stage),
+				storageState: tt.storageStateBuilder(ctrl),
+			}
+
+			err := s.reportEquivocation(&equivocationReport{
+				round:        2,
+				setID:        1,
+				stage:        tt.stage,
+				existingVote: firstVote,
+				currentVote:  secondVote,
+			})
+
+			assert.ErrorIs(t, err, tt.errWrapped)
+			if tt.errWrapped != nil {
+				assert.EqualError(t, err, tt.errMessage)
+			}
+		})
+	}
+}
+
+func TestService_queueEquivocationReport(t *testing.T) {
+	t.Parallel()
+
+	s := &Service{
+		equivocations: make(chan *equivocationReport, 1),
+	}
+
+	report := &equivocationReport{
+		round:        1,
+		existingVote: &SignedVote{},
+	}
+	s.queueEquivocationReport(report)
+	// the queue is full so the second report is dropped
+	s.queueEquivocationReport(&equivocationReport{
+		round:        2,
+		existingVote: &SignedVote{},
+	})
+
+	require.Len(t, s.equivocations, 1)
+	assert.Equal(t, report, <-s.equivocations)
+}
+
+func TestService_validateVoteMessage_reportPeer(t *testing.T) {
+	t.Parallel()
+
+	const from = peer.ID("peer")
+
+	kp, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	signer := &Service{
+		keypair: kp,
+		state:   NewState(nil, 1, 5),
+	}
+	_, voteMessage, err := signer.createSignedVoteAndVoteMessage(NewVote(common.Hash{1}, 1), prevote)
+	require.NoError(t, err)
+
+	badSignatureMessage := *voteMessage
+	badSignatureMessage.Message.Signature[0]++
+
+	testCases := map[string]struct {
+		state      *State
+		peerView   *peerView
+		msg        *VoteMessage
+		change     *peerset.ReputationChange
+		errWrapped error
+		errMessage string
+	}{
+		"bad_signature": {
+			state: NewState(nil, 1, 5),
+			msg:   &badSignatureMessage,
+			change: &peerset.ReputationChange{
+				Value:  peerset.BadSignatureValue,
+				Reason: peerset.BadSignatureReason,
+			},
+			errWrapped: ErrInvalidSignature,
+			errMessage: "signature is not valid",
+		},
+		"past_set_id_late_gossip": {
+			state:      NewState(nil, 2, 5),
+			msg:        voteMessage,
+			errWrapped: ErrSetIDMismatch,
+			errMessage: "set IDs do not match",
+		},
+		"past_set_id": {
+			state:    NewState(nil, 2, 5),
+			peerView: &peerView{round: 1, setID: 2},
+			msg:      voteMessage,
+			change: &peerset.ReputationChange{
+				Value:  peerset.PastRejectionValue,
+				Reason: peerset.PastRejectionReason,
+			},
+			errWrapped: ErrSetIDMismatch,
+			errMessage: "set IDs do not match",
+		},
+		"future_set_id": {
+			state: NewState(nil, 0, 5),
+			msg:   voteMessage,
+			change: &peerset.ReputationChange{
+				Value:  peerset.FutureMessageValue,
+				Reason: peerset.FutureMessageReason,
+			},
+			errWrapped: ErrSetIDMismatch,
+			errMessage: "set IDs do not match",
+		},
+		"past_round_late_gossip": {
+			state:    NewState(nil, 1, 7),
+			peerView: &peerView{round: 6, setID: 1},
+			msg:      voteMessage,
+		},
+		"past_round": {
+			state:    NewState(nil, 1, 7),
+			peerView: &peerView{round: 7, setID: 1},
+			msg:      voteMessage,
+			change: &peerset.ReputationChange{
+				Value:  peerset.PastRejectionValue,
+				Reason: peerset.PastRejectionReason,
+			},
+		},
+		"future_round": {
+			state: NewState(nil, 1, 3),
+			msg:   voteMessage,
+			change: &peerset.ReputationChange{
+				Value:  peerset.FutureMessageValue,
+				Reason: peerset.FutureMessageReason,
+			},
+		},
+		"unknown_voter": {
+			state: NewState(nil, 1, 5),
+			msg:   voteMessage,
+			change: &peerset.ReputationChange{
+				Value:  peerset.UnknownVoterValue,
+				Reason: peerset.UnknownVoterReason,
+			},
+			errWrapped: ErrVoterNotFound,
+			errMessage: "voter is not in voter set",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			network := mocks.NewNetwork(t)
+			if testCase.change != nil {
+				network.On("ReportPeer", *testCase.change, from)
+			}
+
+			views := newPeerViews()
+			if testCase.peerView != nil {
+				views.update(from, testCase.peerView.round, testCase.peerView.setID)
+			}
+
+			s := &Service{
+				state:     testCase.state,
+				peerViews: views,
+				network:   network,
+			}
+
+			vote, err := s.validateVoteMessage(from, testCase.msg)
+
+			assert.Nil(t, vote)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
@@ -59,8 +59,22 @@
 	TaggedTransactionQueueValidateTransaction = "TaggedTransactionQueue_validate_transaction"
 	// GrandpaAuthorities is the runtime API call GrandpaApi_grandpa_authorities
 	GrandpaAuthorities = "GrandpaApi_grandpa_authorities"
+	// GrandpaGenerateKeyOwnershipProof is the runtime API call GrandpaApi_generate_key_ownership_proof
+	GrandpaGenerateKeyOwnershipProof = "GrandpaApi_generate_key_ownership_proof"
+	// GrandpaSubmitReportEquivocation is the runtime API call
+	// GrandpaApi_submit_report_equivocation_unsigned_extrinsic
+	GrandpaSubmitReportEquivocation = "GrandpaApi_submit_report_equivocation_unsigned_extrinsic"
 	// BabeAPIConfiguration is the runtime API call BabeApi_configuration
 	BabeAPIConfiguration = "BabeApi_configuration"
+	// BabeAPICurrentEpoch is the runtime API call BabeApi_current_epoch
+	BabeAPICurrentEpoch = "BabeApi_current_epoch"
+	// BabeAPINextEpoch is the runtime API call BabeApi_next_epoch
+	BabeAPINextEpoch = "BabeApi_next_epoch"
+	// BabeAPIGenerateKeyOwnershipProof is the runtime API call BabeApi_generate_key_ownership_proof
+	BabeAPIGenerateKeyOwnershipProof = "BabeApi_generate_key_ownership_proof"
+	// BabeAPISubmitReportEquivocation is the runtime API call
+	// BabeApi_submit_report_equivocation_unsigned_extrinsic
+	BabeAPISubmitReportEquivocation = "BabeApi_submit_report_equivocation_unsigned_extrinsic"
 	// BlockBuilderInherentExtrinsics is the runtime API call BlockBuilder_inherent_extrinsics
 	BlockBuilderInherentExtrinsics = "BlockBuilder_inherent_extrinsics"
 	// BlockBuilderApplyExtrinsic is the runtime API call BlockBuilder_apply_extrinsic
@@ -69,6 +83,10 @@
 	BlockBuilderFinalizeBlock = "BlockBuilder_finalize_block"
 	// DecodeSessionKeys is the runtime API call SessionKeys_decode_session_keys
 	DecodeSessionKeys = "SessionKeys_decode_session_keys"
+	// GenerateSessionKeys is the runtime API call SessionKeys_generate_session_keys
+	GenerateSessionKeys = "SessionKeys_generate_session_keys"
 	// TransactionPaymentAPIQueryInfo returns information of a given extrinsic
 	TransactionPaymentAPIQueryInfo = "TransactionPaymentApi_query_info"
+	// OffchainWorkerAPIOffchainWorker is the runtime API call OffchainWorkerApi_offchain_worker
+	OffchainWorkerAPIOffchainWorker = "OffchainWorkerApi_offchain_worker"
 )
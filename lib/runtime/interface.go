@@ -6,6 +6,8 @@
 import (
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/lib/trie"
@@ -23,6 +25,7 @@ type Instance interface {
 	Validator() bool
 	Exec(function string, data []byte) ([]byte, error)
 	SetContextStorage(s Storage) // used to set the TrieState before a runtime call
+	ContextStorage() Storage     // returns the storage set with SetContextStorage
 
 	GetCodeHash() common.Hash
 	// Version returns the version from the runtime.
@@ -30,7 +33,17 @@ type Instance interface {
 	Version() (version Version)
 	Metadata() ([]byte, error)
 	BabeConfiguration() (*types.BabeConfiguration, error)
+	BabeCurrentEpoch() (*types.BabeEpoch, error)
+	BabeNextEpoch() (*types.BabeEpoch, error)
+	BabeGenerateKeyOwnershipProof(slot uint64, authorityID [sr25519.PublicKeyLength]byte) (
+		types.BabeOpaqueKeyOwnershipProof, error)
+	BabeSubmitReportEquivocationUnsignedExtrinsic(equivocationProof types.BabeEquivocationProof,
+		keyOwnershipProof types.BabeOpaqueKeyOwnershipProof) error
 	GrandpaAuthorities() ([]types.Authority, error)
+	GrandpaGenerateKeyOwnershipProof(authSetID uint64, authorityID ed25519.PublicKeyBytes) (
+		types.GrandpaOpaqueKeyOwnershipProof, error)
+	GrandpaSubmitReportEquivocationUnsignedExtrinsic(equivocationProof types.GrandpaEquivocationProof,
+		keyOwnershipProof types.GrandpaOpaqueKeyOwnershipProof) error
 	ValidateTransaction(e types.Extrinsic) (*transaction.Validity, error)
 	InitializeBlock(header *types.Header) error
 	InherentExtrinsics(data []byte) ([]byte, error)
@@ -38,14 +51,14 @@ type Instance interface {
 	FinalizeBlock() (*types.Header, error)
 	ExecuteBlock(block *types.Block) ([]byte, error)
 	DecodeSessionKeys(enc []byte) ([]byte, error)
+	GenerateSessionKeys(seed *[]byte) ([]byte, error)
 	PaymentQueryInfo(ext []byte) (*types.TransactionPaymentQueryInfo, error)
+	OffchainWorker(header *types.Header) error
 
 	CheckInherents() // TODO: use this in block verification process (#1873)
 
 	// parameters and return values for these are undefined in the spec
 	RandomSeed()
-	OffchainWorker()
-	GenerateSessionKeys()
 }
 
 // Storage interface
@@ -53,6 +66,7 @@ type Storage interface {
 	Set(key []byte, value []byte)
 	Get(key []byte) []byte
 	Root() (common.Hash, error)
+	SetVersion(version trie.Version)
 	SetChild(keyToChild []byte, child *trie.Trie) error
 	SetChildStorage(keyToChild, key, value []byte) error
 	GetChildStorage(keyToChild, key []byte) ([]byte, error)
@@ -70,6 +84,8 @@ type Storage interface {
 	CommitStorageTransaction()
 	RollbackStorageTransaction()
 	LoadCode() []byte
+	IndexTransaction(extrinsic, size uint32, hash common.Hash)
+	RenewTransactionIndex(extrinsic uint32, hash common.Hash)
 }
 
 // BasicNetwork interface for functions used by runtime network state function
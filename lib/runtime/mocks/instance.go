@@ -4,6 +4,8 @@
 
 import (
 	common "github.com/ChainSafe/gossamer/lib/common"
+	ed25519 "github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+
 	keystore "github.com/ChainSafe/gossamer/lib/keystore"
 
 	mock "github.com/stretchr/testify/mock"
@@ -66,11 +68,110 @@ func (_m *Instance) BabeConfiguration() (*types.BabeConfiguration, error) {
 	return r0, r1
 }
 
+// BabeCurrentEpoch provides a mock function with given fields:
+func (_m *Instance) BabeCurrentEpoch() (*types.BabeEpoch, error) {
+	ret := _m.Called()
+
+	var r0 *types.BabeEpoch
+	if rf, ok := ret.Get(0).(func() *types.BabeEpoch); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.BabeEpoch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BabeGenerateKeyOwnershipProof provides a mock function with given fields: slot, authorityID
+func (_m *Instance) BabeGenerateKeyOwnershipProof(slot uint64, authorityID [32]byte) (types.BabeOpaqueKeyOwnershipProof, error) {
+	ret := _m.Called(slot, authorityID)
+
+	var r0 types.BabeOpaqueKeyOwnershipProof
+	if rf, ok := ret.Get(0).(func(uint64, [32]byte) types.BabeOpaqueKeyOwnershipProof); ok {
+		r0 = rf(slot, authorityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.BabeOpaqueKeyOwnershipProof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64, [32]byte) error); ok {
+		r1 = rf(slot, authorityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BabeNextEpoch provides a mock function with given fields:
+func (_m *Instance) BabeNextEpoch() (*types.BabeEpoch, error) {
+	ret := _m.Called()
+
+	var r0 *types.BabeEpoch
+	if rf, ok := ret.Get(0).(func() *types.BabeEpoch); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.BabeEpoch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BabeSubmitReportEquivocationUnsignedExtrinsic provides a mock function with given fields: equivocationProof, keyOwnershipProof
+func (_m *Instance) BabeSubmitReportEquivocationUnsignedExtrinsic(equivocationProof types.BabeEquivocationProof, keyOwnershipProof types.BabeOpaqueKeyOwnershipProof) error {
+	ret := _m.Called(equivocationProof, keyOwnershipProof)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(types.BabeEquivocationProof, types.BabeOpaqueKeyOwnershipProof) error); ok {
+		r0 = rf(equivocationProof, keyOwnershipProof)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CheckInherents provides a mock function with given fields:
 func (_m *Instance) CheckInherents() {
 	_m.Called()
 }
 
+// ContextStorage provides a mock function with given fields:
+func (_m *Instance) ContextStorage() runtime.Storage {
+	ret := _m.Called()
+
+	var r0 runtime.Storage
+	if rf, ok := ret.Get(0).(func() runtime.Storage); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(runtime.Storage)
+		}
+	}
+
+	return r0
+}
+
 // DecodeSessionKeys provides a mock function with given fields: enc
 func (_m *Instance) DecodeSessionKeys(enc []byte) ([]byte, error) {
 	ret := _m.Called(enc)
@@ -163,9 +264,27 @@ func (_m *Instance) FinalizeBlock() (*types.Header, error) {
 	return r0, r1
 }
 
-// GenerateSessionKeys provides a mock function with given fields:
-func (_m *Instance) GenerateSessionKeys() {
-	_m.Called()
+// GenerateSessionKeys provides a mock function with given fields: seed
+func (_m *Instance) GenerateSessionKeys(seed *[]byte) ([]byte, error) {
+	ret := _m.Called(seed)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(*[]byte) []byte); ok {
+		r0 = rf(seed)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*[]byte) error); ok {
+		r1 = rf(seed)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // GetCodeHash provides a mock function with given fields:
@@ -207,6 +326,43 @@ func (_m *Instance) GrandpaAuthorities() ([]types.Authority, error) {
 	return r0, r1
 }
 
+// GrandpaGenerateKeyOwnershipProof provides a mock function with given fields: authSetID, authorityID
+func (_m *Instance) GrandpaGenerateKeyOwnershipProof(authSetID uint64, authorityID ed25519.PublicKeyBytes) (types.GrandpaOpaqueKeyOwnershipProof, error) {
+	ret := _m.Called(authSetID, authorityID)
+
+	var r0 types.GrandpaOpaqueKeyOwnershipProof
+	if rf, ok := ret.Get(0).(func(uint64, ed25519.PublicKeyBytes) types.GrandpaOpaqueKeyOwnershipProof); ok {
+		r0 = rf(authSetID, authorityID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.GrandpaOpaqueKeyOwnershipProof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64, ed25519.PublicKeyBytes) error); ok {
+		r1 = rf(authSetID, authorityID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrandpaSubmitReportEquivocationUnsignedExtrinsic provides a mock function with given fields: equivocationProof, keyOwnershipProof
+func (_m *Instance) GrandpaSubmitReportEquivocationUnsignedExtrinsic(equivocationProof types.GrandpaEquivocationProof, keyOwnershipProof types.GrandpaOpaqueKeyOwnershipProof) error {
+	ret := _m.Called(equivocationProof, keyOwnershipProof)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(types.GrandpaEquivocationProof, types.GrandpaOpaqueKeyOwnershipProof) error); ok {
+		r0 = rf(equivocationProof, keyOwnershipProof)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InherentExtrinsics provides a mock function with given fields: data
 func (_m *Instance) InherentExtrinsics(data []byte) ([]byte, error) {
 	ret := _m.Called(data)
@@ -313,9 +469,18 @@ func (_m *Instance) NodeStorage() runtime.NodeStorage {
 	return r0
 }
 
-// OffchainWorker provides a mock function with given fields:
-func (_m *Instance) OffchainWorker() {
-	_m.Called()
+// OffchainWorker provides a mock function with given fields: header
+func (_m *Instance) OffchainWorker(header *types.Header) error {
+	ret := _m.Called(header)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*types.Header) error); ok {
+		r0 = rf(header)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
 // PaymentQueryInfo provides a mock function with given fields: ext
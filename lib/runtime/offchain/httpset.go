@@ -4,12 +4,18 @@
 package offchain
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
 type contextKey string
@@ -27,8 +33,109 @@
 	errRequestIDNotAvailable = errors.New("request id not available")
 	errRequestInvalid        = errors.New("request is invalid")
 	errInvalidHeaderKey      = errors.New("invalid header key")
+	errRequestNotFound       = errors.New("request not found")
+	errRequestAlreadySent    = errors.New("request already sent")
+	errDeadlineReached       = errors.New("deadline reached")
+)
+
+// HTTPError is the error of an offchain HTTP request as exposed to the runtime.
+// Its values are SCALE encoded as single bytes and must match the runtime ones,
+// which start at 1.
+type HTTPError byte
+
+const (
+	// HTTPErrorDeadlineReached is returned when the deadline was reached before completion
+	HTTPErrorDeadlineReached HTTPError = iota + 1
+	// HTTPErrorIO is returned when an I/O error occurred while sending the request
+	// or receiving the response
+	HTTPErrorIO
+	// HTTPErrorInvalid is returned when the request id is invalid, or the
+	// request is not in a state allowing the operation
+	HTTPErrorInvalid
 )
 
+// NewHTTPError returns the HTTPError corresponding to the error given
+func NewHTTPError(err error) HTTPError {
+	switch {
+	case errors.Is(err, errDeadlineReached):
+		return HTTPErrorDeadlineReached
+	case errors.Is(err, errRequestNotFound),
+		errors.Is(err, errRequestInvalid),
+		errors.Is(err, errRequestAlreadySent):
+		return HTTPErrorInvalid
+	default:
+		return HTTPErrorIO
+	}
+}
+
+// DeadlineReachedStatus is the status of a request for which the deadline
+// was reached before receiving the response
+type DeadlineReachedStatus struct{}
+
+// Index returns the VDT index
+func (DeadlineReachedStatus) Index() uint { return 0 }
+
+// IOErrorStatus is the status of a request which failed
+type IOErrorStatus struct{}
+
+// Index returns the VDT index
+func (IOErrorStatus) Index() uint { return 1 }
+
+// InvalidStatus is the status of an unknown request
+type InvalidStatus struct{}
+
+// Index returns the VDT index
+func (InvalidStatus) Index() uint { return 2 }
+
+// FinishedStatus is the status of a request for which the response
+// was received, holding the HTTP response status code
+type FinishedStatus uint16
+
+// Index returns the VDT index
+func (FinishedStatus) Index() uint { return 3 }
+
+// HTTPRequestStatus is the status of a request after waiting for its response
+type HTTPRequestStatus scale.VaryingDataType
+
+// Set will set a VaryingDataTypeValue using the underlying VaryingDataType
+func (s *HTTPRequestStatus) Set(val scale.VaryingDataTypeValue) (err error) {
+	vdt := scale.VaryingDataType(*s)
+	err = vdt.Set(val)
+	if err != nil {
+		return err
+	}
+	*s = HTTPRequestStatus(vdt)
+	return nil
+}
+
+// Value will return the value from the underlying VaryingDataType
+func (s *HTTPRequestStatus) Value() (val scale.VaryingDataTypeValue, err error) {
+	vdt := scale.VaryingDataType(*s)
+	return vdt.Value()
+}
+
+// NewHTTPRequestStatus returns a HTTPRequestStatus set with the given value
+func NewHTTPRequestStatus(value scale.VaryingDataTypeValue) HTTPRequestStatus {
+	vdt, err := scale.NewVaryingDataType(DeadlineReachedStatus{}, IOErrorStatus{},
+		InvalidStatus{}, FinishedStatus(0))
+	if err != nil {
+		panic(err)
+	}
+
+	err = vdt.Set(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return HTTPRequestStatus(vdt)
+}
+
+// HTTPHeader is a HTTP header name and value pair
+type HTTPHeader struct {
+	Name  []byte
+	Value []byte
+}
+
 // requestIDBuffer created to control the amount of available non-duplicated ids
 type requestIDBuffer chan int16
 
@@ -60,10 +167,27 @@ func (b requestIDBuffer) put(i int16) error {
 	}
 }
 
+type readResult struct {
+	data []byte
+	err  error
+}
+
 // Request holds the request object and update the invalid and waiting status whenever
 // the request starts or is waiting to be read
 type Request struct {
 	Request *http.Request
+
+	body bytes.Buffer
+	sent bool
+
+	// done is closed once the response or the error is set
+	done     chan struct{}
+	response *http.Response
+	err      error
+
+	pendingRead chan readResult
+	unread      []byte
+	readErr     error
 }
 
 // AddHeader adds a new HTTP header into request property, only if request is valid
@@ -73,6 +197,10 @@ func (r *Request) AddHeader(name, value string) error {
 		return errRequestInvalid
 	}
 
+	if r.sent {
+		return fmt.Errorf("%w: cannot add header", errRequestAlreadySent)
+	}
+
 	name = strings.TrimSpace(name)
 	if len(name) == 0 {
 		return fmt.Errorf("%w: empty header key", errInvalidHeaderKey)
@@ -82,20 +210,97 @@ func (r *Request) AddHeader(name, value string) error {
 	return nil
 }
 
+// responseReceived returns true if the request got its response or failed.
+func (r *Request) responseReceived() bool {
+	if !r.sent {
+		return false
+	}
+
+	select {
+	case <-r.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// readBody reads the response body into buf. The body read continues in the
+// background if the timeout is reached or if the interrupt channel is closed, and its
+// data is returned on the next call.
+func (r *Request) readBody(buf []byte, timeout <-chan time.Time, interrupt <-chan struct{}) (n int, err error) {
+	for len(r.unread) == 0 && r.readErr == nil {
+		if r.pendingRead == nil {
+			r.pendingRead = make(chan readResult, 1)
+			go func(pending chan<- readResult, size int) {
+				data := make([]byte, size)
+				n, err := r.response.Body.Read(data)
+				pending <- readResult{data: data[:n], err: err}
+			}(r.pendingRead, len(buf))
+		}
+
+		select {
+		case result := <-r.pendingRead:
+			r.pendingRead = nil
+			r.unread, r.readErr = result.data, result.err
+		case <-timeout:
+			return 0, errDeadlineReached
+		case <-interrupt:
+			return 0, errDeadlineReached
+		}
+	}
+
+	n = copy(buf, r.unread)
+	r.unread = r.unread[n:]
+	if n > 0 {
+		return n, nil
+	}
+
+	return 0, r.readErr
+}
+
 // HTTPSet holds a pool of concurrent http request calls
 type HTTPSet struct {
 	*sync.Mutex
-	reqs   map[int16]*Request
-	idBuff requestIDBuffer
+	reqs      map[int16]*Request
+	idBuff    requestIDBuffer
+	client    *http.Client
+	interrupt <-chan struct{}
+	// ctx is the parent context of the requests,
+	// canceled when the set is closed.
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // NewHTTPSet creates a offchain http set that can be used
-// by runtime as HTTP clients, the max concurrent requests is 1000
-func NewHTTPSet() *HTTPSet {
+// by runtime as HTTP clients, the max concurrent requests is 1000.
+// Waiting for responses stops as if the deadline was reached once
+// the interrupt channel is closed. A nil channel never interrupts.
+func NewHTTPSet(interrupt <-chan struct{}) *HTTPSet {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &HTTPSet{
-		new(sync.Mutex),
-		make(map[int16]*Request),
-		newIntBuffer(maxConcurrentRequests),
+		Mutex:     new(sync.Mutex),
+		reqs:      make(map[int16]*Request),
+		idBuff:    newIntBuffer(maxConcurrentRequests),
+		client:    new(http.Client),
+		interrupt: interrupt,
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// Close cancels the requests in progress and closes the responses
+// received and not fully read. The set must not be used afterwards.
+func (p *HTTPSet) Close() {
+	p.Lock()
+	defer p.Unlock()
+
+	p.cancel()
+
+	for id, req := range p.reqs {
+		if req.responseReceived() && req.response != nil {
+			_ = req.response.Body.Close()
+		}
+		delete(p.reqs, id)
 	}
 }
 
@@ -114,17 +319,15 @@ func (p *HTTPSet) StartRequest(method, uri string) (int16, error) {
 		return 0, errRequestIDNotAvailable
 	}
 
-	req, err := http.NewRequest(method, uri, nil)
-	req.Header = make(http.Header)
-
-	ctx := context.WithValue(req.Context(), waitingKey, false)
+	ctx := context.WithValue(p.ctx, waitingKey, false)
 	ctx = context.WithValue(ctx, invalidKey, false)
 
-	req = req.WithContext(ctx)
-
+	req, err := http.NewRequestWithContext(ctx, method, uri, nil)
 	if err != nil {
+		_ = p.idBuff.put(id)
 		return 0, err
 	}
+	req.Header = make(http.Header)
 
 	p.reqs[id] = &Request{
 		Request: req,
@@ -138,6 +341,11 @@ func (p *HTTPSet) Remove(id int16) error {
 	p.Lock()
 	defer p.Unlock()
 
+	req, ok := p.reqs[id]
+	if ok && req.responseReceived() && req.response != nil {
+		_ = req.response.Body.Close()
+	}
+
 	delete(p.reqs, id)
 
 	return p.idBuff.put(id)
@@ -150,3 +358,176 @@ func (p *HTTPSet) Get(id int16) *Request {
 
 	return p.reqs[id]
 }
+
+// WriteBody appends the chunk to the body of the request. Writing an empty chunk
+// completes the body and sends the request, after which no more chunks can be written.
+// A nil deadline means no deadline.
+func (p *HTTPSet) WriteBody(id int16, chunk []byte, deadline *time.Time) error {
+	req := p.Get(id)
+	if req == nil {
+		return fmt.Errorf("%w: id %d", errRequestNotFound, id)
+	}
+
+	if req.sent {
+		return fmt.Errorf("%w: cannot write body", errRequestAlreadySent)
+	}
+
+	if deadline != nil && !time.Now().Before(*deadline) {
+		return errDeadlineReached
+	}
+
+	if len(chunk) == 0 {
+		p.send(req)
+		return nil
+	}
+
+	req.body.Write(chunk)
+	return nil
+}
+
+// send sends the request in the background, with the body written so far.
+func (p *HTTPSet) send(req *Request) {
+	req.sent = true
+	req.done = make(chan struct{})
+
+	if req.body.Len() > 0 {
+		body := req.body.Bytes()
+		req.Request.ContentLength = int64(len(body))
+		req.Request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	go func() {
+		defer close(req.done)
+		req.response, req.err = p.client.Do(req.Request) //nolint:bodyclose
+	}()
+}
+
+// ResponseWait sends the requests not sent yet and waits for their responses until
+// the deadline is reached, returning the status of each request.
+// A nil deadline means no deadline.
+func (p *HTTPSet) ResponseWait(ids []int16, deadline *time.Time) (statuses []HTTPRequestStatus) {
+	reqs := make([]*Request, len(ids))
+	for i, id := range ids {
+		reqs[i] = p.Get(id)
+		if reqs[i] != nil && !reqs[i].sent {
+			p.send(reqs[i])
+		}
+	}
+
+	var timeout <-chan time.Time
+	if deadline != nil {
+		timer := time.NewTimer(time.Until(*deadline))
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	deadlineReached := false
+	statuses = make([]HTTPRequestStatus, len(ids))
+	for i, req := range reqs {
+		if req == nil {
+			statuses[i] = NewHTTPRequestStatus(InvalidStatus{})
+			continue
+		}
+
+		if !deadlineReached {
+			select {
+			case <-req.done:
+			case <-timeout:
+				deadlineReached = true
+			case <-p.interrupt:
+				deadlineReached = true
+			}
+		}
+
+		switch {
+		case !req.responseReceived():
+			statuses[i] = NewHTTPRequestStatus(DeadlineReachedStatus{})
+		case req.err != nil:
+			statuses[i] = NewHTTPRequestStatus(IOErrorStatus{})
+		default:
+			statuses[i] = NewHTTPRequestStatus(FinishedStatus(req.response.StatusCode))
+		}
+	}
+
+	return statuses
+}
+
+// ResponseHeaders returns the headers of the response of the request, sorted by name.
+// No header is returned if the response was not received yet.
+func (p *HTTPSet) ResponseHeaders(id int16) (headers []HTTPHeader, err error) {
+	req := p.Get(id)
+	if req == nil {
+		return nil, fmt.Errorf("%w: id %d", errRequestNotFound, id)
+	}
+
+	if !req.responseReceived() || req.err != nil {
+		return []HTTPHeader{}, nil
+	}
+
+	names := make([]string, 0, len(req.response.Header))
+	for name := range req.response.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	headers = make([]HTTPHeader, 0, len(names))
+	for _, name := range names {
+		for _, value := range req.response.Header[name] {
+			headers = append(headers, HTTPHeader{
+				Name:  []byte(name),
+				Value: []byte(value),
+			})
+		}
+	}
+
+	return headers, nil
+}
+
+// ReadBody reads a chunk of the response body of the request into buf, waiting
+// for the response if needed, until the deadline is reached. It returns the number
+// of bytes read, and zero once the body is fully read. The request is removed and
+// its id can be reused once the body is fully read or if an I/O error occurs.
+// A nil deadline means no deadline.
+func (p *HTTPSet) ReadBody(id int16, buf []byte, deadline *time.Time) (n int, err error) {
+	req := p.Get(id)
+	if req == nil {
+		return 0, fmt.Errorf("%w: id %d", errRequestNotFound, id)
+	}
+
+	if !req.sent {
+		p.send(req)
+	}
+
+	var timeout <-chan time.Time
+	if deadline != nil {
+		timer := time.NewTimer(time.Until(*deadline))
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-req.done:
+	case <-timeout:
+		return 0, errDeadlineReached
+	case <-p.interrupt:
+		return 0, errDeadlineReached
+	}
+
+	if req.err != nil {
+		_ = p.Remove(id)
+		return 0, fmt.Errorf("sending request: %w", req.err)
+	}
+
+	n, err = req.readBody(buf, timeout, p.interrupt)
+	switch {
+	case errors.Is(err, errDeadlineReached):
+		return 0, err
+	case errors.Is(err, io.EOF):
+		return 0, p.Remove(id)
+	case err != nil:
+		_ = p.Remove(id)
+		return 0, fmt.Errorf("reading response body: %w", err)
+	}
+
+	return n, nil
+}
@@ -5,10 +5,16 @@
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,7 +23,7 @@
 func TestHTTPSetLimit(t *testing.T) {
 	t.Parallel()
 
-	set := NewHTTPSet()
+	set := NewHTTPSet(nil)
 	var err error
 	for i := 0; i < maxConcurrentRequests+1; i++ {
 		_, err = set.StartRequest(http.MethodGet, defaultTestURI)
@@ -29,7 +35,7 @@ func TestHTTPSetLimit(t *testing.T) {
 func TestHTTPSet_StartRequest_NotAvailableID(t *testing.T) {
 	t.Parallel()
 
-	set := NewHTTPSet()
+	set := NewHTTPSet(nil)
 	set.reqs[1] = &Request{}
 
 	_, err := set.StartRequest(http.MethodGet, defaultTestURI)
@@ -39,7 +45,7 @@ func TestHTTPSet_StartRequest_NotAvailableID(t *testing.T) {
 func TestHTTPSetGet(t *testing.T) {
 	t.Parallel()
 
-	set := NewHTTPSet()
+	set := NewHTTPSet(nil)
 
 	id, err := set.StartRequest(http.MethodGet, defaultTestURI)
 	require.NoError(t, err)
@@ -64,7 +70,7 @@ func TestOffchainRequest_AddHeader(t *testing.T) {
 		headerK, headerV string
 	}{
 		"should return invalid request": {
-			offReq: Request{invalidReq},
+			offReq: Request{Request: invalidReq},
 			err:    errRequestInvalid,
 		},
 		"should add header": {
@@ -101,3 +107,300 @@ func TestOffchainRequest_AddHeader(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPSet_RequestLifecycle(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("X-Request-Header", r.Header.Get("X-Request-Header"))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	set := NewHTTPSet(nil)
+
+	id, err := set.StartRequest(http.MethodPost, server.URL)
+	require.NoError(t, err)
+
+	err = set.Get(id).AddHeader("X-Request-Header", "header value")
+	require.NoError(t, err)
+
+	err = set.WriteBody(id, []byte("hello "), nil)
+	require.NoError(t, err)
+	err = set.WriteBody(id, []byte("world"), nil)
+	require.NoError(t, err)
+	err = set.WriteBody(id, nil, nil)
+	require.NoError(t, err)
+
+	err = set.WriteBody(id, []byte("too late"), nil)
+	require.ErrorIs(t, err, errRequestAlreadySent)
+	err = set.Get(id).AddHeader("X-Too-Late", "value")
+	require.ErrorIs(t, err, errRequestAlreadySent)
+
+	statuses := set.ResponseWait([]int16{id}, nil)
+	expectedStatuses := []HTTPRequestStatus{NewHTTPRequestStatus(FinishedStatus(http.StatusCreated))}
+	require.Equal(t, expectedStatuses, statuses)
+
+	headers, err := set.ResponseHeaders(id)
+	require.NoError(t, err)
+	var headerValue []byte
+	for _, header := range headers {
+		if string(header.Name) == "X-Request-Header" {
+			headerValue = header.Value
+		}
+	}
+	require.Equal(t, []byte("header value"), headerValue)
+
+	var body []byte
+	buf := make([]byte, 4)
+	for {
+		n, err := set.ReadBody(id, buf, nil)
+		require.NoError(t, err)
+		if n == 0 {
+			break
+		}
+		body = append(body, buf[:n]...)
+	}
+	require.Equal(t, []byte("hello world"), body)
+
+	// the request is removed and its id can be reused
+	require.Nil(t, set.Get(id))
+	require.Len(t, set.idBuff, maxConcurrentRequests)
+}
+
+func TestHTTPSet_ResponseWait(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	set := NewHTTPSet(nil)
+
+	id, err := set.StartRequest(http.MethodGet, server.URL)
+	require.NoError(t, err)
+
+	deadline := time.Now().Add(10 * time.Millisecond)
+	statuses := set.ResponseWait([]int16{id, id + 1}, &deadline)
+
+	expectedStatuses := []HTTPRequestStatus{
+		NewHTTPRequestStatus(DeadlineReachedStatus{}),
+		NewHTTPRequestStatus(InvalidStatus{}),
+	}
+	require.Equal(t, expectedStatuses, statuses)
+
+	headers, err := set.ResponseHeaders(id)
+	require.NoError(t, err)
+	require.Empty(t, headers)
+
+	_, err = set.ResponseHeaders(id + 1)
+	require.ErrorIs(t, err, errRequestNotFound)
+}
+
+func TestHTTPSet_ResponseWait_Interrupt(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	interrupt := make(chan struct{})
+	set := NewHTTPSet(interrupt)
+
+	id, err := set.StartRequest(http.MethodGet, server.URL)
+	require.NoError(t, err)
+
+	close(interrupt)
+	statuses := set.ResponseWait([]int16{id}, nil)
+
+	expectedStatuses := []HTTPRequestStatus{NewHTTPRequestStatus(DeadlineReachedStatus{})}
+	require.Equal(t, expectedStatuses, statuses)
+
+	_, err = set.ReadBody(id, make([]byte, 1), nil)
+	require.ErrorIs(t, err, errDeadlineReached)
+}
+
+func TestHTTPSet_ReadBody_Deadline(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("first"))
+		w.(http.Flusher).Flush()
+		<-release
+		_, _ = w.Write([]byte("second"))
+	}))
+	defer server.Close()
+
+	set := NewHTTPSet(nil)
+
+	id, err := set.StartRequest(http.MethodGet, server.URL)
+	require.NoError(t, err)
+
+	buf := make([]byte, 16)
+	n, err := set.ReadBody(id, buf, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("first"), buf[:n])
+
+	deadline := time.Now().Add(10 * time.Millisecond)
+	_, err = set.ReadBody(id, buf, &deadline)
+	require.ErrorIs(t, err, errDeadlineReached)
+
+	close(release)
+
+	n, err = set.ReadBody(id, buf, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("second"), buf[:n])
+
+	n, err = set.ReadBody(id, buf, nil)
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.Nil(t, set.Get(id))
+}
+
+func TestHTTPSet_Close(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/pending" {
+			<-release
+			return
+		}
+		_, _ = w.Write([]byte("body"))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	set := NewHTTPSet(nil)
+
+	receivedID, err := set.StartRequest(http.MethodGet, server.URL)
+	require.NoError(t, err)
+	statuses := set.ResponseWait([]int16{receivedID}, nil)
+	expectedStatuses := []HTTPRequestStatus{NewHTTPRequestStatus(FinishedStatus(http.StatusOK))}
+	require.Equal(t, expectedStatuses, statuses)
+	received := set.Get(receivedID)
+
+	pendingID, err := set.StartRequest(http.MethodGet, server.URL+"/pending")
+	require.NoError(t, err)
+	pending := set.Get(pendingID)
+	err = set.WriteBody(pendingID, nil, nil)
+	require.NoError(t, err)
+
+	set.Close()
+
+	require.Nil(t, set.Get(receivedID))
+	require.Nil(t, set.Get(pendingID))
+
+	_, err = received.response.Body.Read(make([]byte, 1))
+	require.Error(t, err)
+
+	// the pending request is canceled
+	<-pending.done
+	require.ErrorIs(t, pending.err, context.Canceled)
+}
+
+func TestHTTPSet_WriteBody(t *testing.T) {
+	t.Parallel()
+
+	set := NewHTTPSet(nil)
+
+	err := set.WriteBody(1, []byte{1}, nil)
+	require.ErrorIs(t, err, errRequestNotFound)
+
+	id, err := set.StartRequest(http.MethodPost, defaultTestURI)
+	require.NoError(t, err)
+
+	deadline := time.Now().Add(-time.Second)
+	err = set.WriteBody(id, []byte{1}, &deadline)
+	require.ErrorIs(t, err, errDeadlineReached)
+}
+
+func Test_NewHTTPError(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		err       error
+		httpError HTTPError
+	}{
+		"deadline_reached": {
+			err:       fmt.Errorf("wrapped: %w", errDeadlineReached),
+			httpError: HTTPErrorDeadlineReached,
+		},
+		"request_not_found": {
+			err:       errRequestNotFound,
+			httpError: HTTPErrorInvalid,
+		},
+		"request_already_sent": {
+			err:       errRequestAlreadySent,
+			httpError: HTTPErrorInvalid,
+		},
+		"io_error": {
+			err:       errors.New("connection refused"),
+			httpError: HTTPErrorIO,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			httpError := NewHTTPError(testCase.err)
+			assert.Equal(t, testCase.httpError, httpError)
+		})
+	}
+}
+
+func Test_HTTPError_encoding(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		httpError HTTPError
+		encoded   []byte
+	}{
+		"deadline_reached": {
+			httpError: HTTPErrorDeadlineReached,
+			encoded:   []byte{1},
+		},
+		"io_error": {
+			httpError: HTTPErrorIO,
+			encoded:   []byte{2},
+		},
+		"invalid": {
+			httpError: HTTPErrorInvalid,
+			encoded:   []byte{3},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := scale.Marshal(testCase.httpError)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.encoded, encoded)
+
+			result := scale.NewResult(nil, HTTPError(0))
+			err = result.Set(scale.Err, testCase.httpError)
+			require.NoError(t, err)
+			encoded, err = scale.Marshal(result)
+			require.NoError(t, err)
+			assert.Equal(t, append([]byte{1}, testCase.encoded...), encoded)
+		})
+	}
+}
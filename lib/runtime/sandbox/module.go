@@ -0,0 +1,222 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sandbox
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// importSectionID is the id of the import section of the wasm binary format.
+const importSectionID byte = 2
+
+// Import kinds of the wasm binary format
+const (
+	functionExternKind byte = 0
+	tableExternKind    byte = 1
+	memoryExternKind   byte = 2
+	globalExternKind   byte = 3
+)
+
+var wasmMagicAndVersion = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+var (
+	errInvalidModuleHeader = errors.New("invalid module header")
+	errUnexpectedEndOfData = errors.New("unexpected end of data")
+	errLEB128Overflow      = errors.New("LEB128 value overflows 32 bits")
+)
+
+// importName is the module and field names of a wasm import.
+type importName struct {
+	module string
+	field  string
+}
+
+type section struct {
+	id      byte
+	content []byte
+}
+
+// moduleReader reads values encoded in the wasm binary format.
+type moduleReader struct {
+	data   []byte
+	offset int
+}
+
+func (r *moduleReader) done() bool {
+	return r.offset >= len(r.data)
+}
+
+func (r *moduleReader) readByte() (b byte, err error) {
+	if r.offset >= len(r.data) {
+		return 0, errUnexpectedEndOfData
+	}
+	b = r.data[r.offset]
+	r.offset++
+	return b, nil
+}
+
+func (r *moduleReader) readBytes(n uint32) (b []byte, err error) {
+	if uint64(r.offset)+uint64(n) > uint64(len(r.data)) {
+		return nil, errUnexpectedEndOfData
+	}
+	b = r.data[r.offset : r.offset+int(n)]
+	r.offset += int(n)
+	return b, nil
+}
+
+// readU32 reads an unsigned LEB128 encoded 32 bits integer.
+func (r *moduleReader) readU32() (value uint32, err error) {
+	for shift := uint(0); shift < 35; shift += 7 {
+		b, err := r.readByte()
+		if err != nil {
+			return 0, err
+		}
+		value |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return value, nil
+		}
+	}
+	return 0, errLEB128Overflow
+}
+
+func (r *moduleReader) readName() (name string, err error) {
+	length, err := r.readU32()
+	if err != nil {
+		return "", err
+	}
+	b, err := r.readBytes(length)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (r *moduleReader) skipLimits() (err error) {
+	flags, err := r.readByte()
+	if err != nil {
+		return err
+	}
+	_, err = r.readU32()
+	if err != nil {
+		return err
+	}
+	if flags&1 != 0 {
+		_, err = r.readU32()
+	}
+	return err
+}
+
+func parseSections(code []byte) (sections []section, err error) {
+	if !bytes.HasPrefix(code, wasmMagicAndVersion) {
+		return nil, errInvalidModuleHeader
+	}
+
+	reader := &moduleReader{data: code, offset: len(wasmMagicAndVersion)}
+	for !reader.done() {
+		id, err := reader.readByte()
+		if err != nil {
+			return nil, err
+		}
+		size, err := reader.readU32()
+		if err != nil {
+			return nil, fmt.Errorf("reading size of section %d: %w", id, err)
+		}
+		content, err := reader.readBytes(size)
+		if err != nil {
+			return nil, fmt.Errorf("reading content of section %d: %w", id, err)
+		}
+		sections = append(sections, section{id: id, content: content})
+	}
+	return sections, nil
+}
+
+func findSection(sections []section, id byte) (content []byte, ok bool) {
+	for _, section := range sections {
+		if section.id == id {
+			return section.content, true
+		}
+	}
+	return nil, false
+}
+
+// moduleImport is an import of a wasm module.
+type moduleImport struct {
+	name importName
+	kind byte
+}
+
+func parseImports(content []byte) (imports []moduleImport, err error) {
+	reader := &moduleReader{data: content}
+	count, err := reader.readU32()
+	if err != nil {
+		return nil, err
+	}
+
+	imports = make([]moduleImport, 0, count)
+	for i := uint32(0); i < count; i++ {
+		var entry moduleImport
+		entry.name.module, err = reader.readName()
+		if err != nil {
+			return nil, err
+		}
+		entry.name.field, err = reader.readName()
+		if err != nil {
+			return nil, err
+		}
+		entry.kind, err = reader.readByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch entry.kind {
+		case functionExternKind:
+			_, err = reader.readU32() // type index
+		case tableExternKind:
+			_, err = reader.readByte() // element type
+			if err == nil {
+				err = reader.skipLimits()
+			}
+		case memoryExternKind:
+			err = reader.skipLimits()
+		case globalExternKind:
+			_, err = reader.readBytes(2) // value type and mutability
+		default:
+			err = fmt.Errorf("%w: %d", errUnknownEntityKind, entry.kind)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading import %s.%s: %w", entry.name.module, entry.name.field, err)
+		}
+		imports = append(imports, entry)
+	}
+	return imports, nil
+}
+
+// functionImportNames returns the names of the functions imported
+// by the wasm module given.
+func functionImportNames(code []byte) (names map[importName]struct{}, err error) {
+	sections, err := parseSections(code)
+	if err != nil {
+		return nil, err
+	}
+
+	names = make(map[importName]struct{})
+	content, ok := findSection(sections, importSectionID)
+	if !ok {
+		return names, nil
+	}
+
+	imports, err := parseImports(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing import section: %w", err)
+	}
+
+	for _, entry := range imports {
+		if entry.kind == functionExternKind {
+			names[entry.name] = struct{}{}
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sandbox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_functionImportNames(t *testing.T) {
+	t.Parallel()
+
+	names, err := functionImportNames(callModule)
+	require.NoError(t, err)
+	expected := map[importName]struct{}{
+		{module: "env", field: "mul"}: {},
+	}
+	assert.Equal(t, expected, names)
+
+	// memory imports are not returned
+	names, err = functionImportNames(loadModule)
+	require.NoError(t, err)
+	assert.Empty(t, names)
+
+	_, err = functionImportNames([]byte{1, 2, 3})
+	assert.ErrorIs(t, err, errInvalidModuleHeader)
+
+	_, err = functionImportNames(callModule[:25])
+	assert.ErrorIs(t, err, errUnexpectedEndOfData)
+}
@@ -0,0 +1,259 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	wasm "github.com/wasmerio/go-ext-wasm/wasmer"
+)
+
+// Result codes returned to the runtime by the sandbox host functions,
+// matching the constants of the sp-sandbox crate.
+const (
+	// CodeOK is returned on success
+	CodeOK uint32 = 0
+	// CodeErrModule is returned when a module cannot be instantiated
+	CodeErrModule uint32 = math.MaxUint32
+	// CodeErrOutOfBounds is returned when a memory access is out of bounds
+	CodeErrOutOfBounds uint32 = math.MaxUint32 - 1
+	// CodeErrExecution is returned when the execution of a function fails
+	CodeErrExecution uint32 = math.MaxUint32 - 2
+)
+
+// unboundedMemory is the maximum number of pages value
+// for a memory without maximum.
+const unboundedMemory = math.MaxUint32
+
+// maxMemoryPages is the maximum number of 64KiB pages of a wasm memory.
+const maxMemoryPages = 65536
+
+var (
+	// ErrOutOfBounds is returned when accessing a sandbox memory out of its bounds.
+	ErrOutOfBounds = errors.New("memory access out of bounds")
+
+	errMemoryNotFound              = errors.New("memory not found")
+	errInstanceNotFound            = errors.New("instance not found")
+	errExportNotFound              = errors.New("export function not found")
+	errFunctionImportsNotSupported = errors.New("function imports are not supported")
+	errUnknownEntityKind           = errors.New("unknown extern entity kind")
+	errUnsupportedValueType        = errors.New("unsupported value type")
+)
+
+// Store holds the memories and module instances of the sandbox.
+// Memories and instances are referenced by the runtime using their index.
+type Store struct {
+	mutex     sync.Mutex
+	memories  map[uint32]*wasm.Memory
+	instances map[uint32]*wasm.Instance
+
+	nextMemoryIndex   uint32
+	nextInstanceIndex uint32
+}
+
+// NewStore creates an empty sandbox store.
+func NewStore() *Store {
+	return &Store{
+		memories:  make(map[uint32]*wasm.Memory),
+		instances: make(map[uint32]*wasm.Instance),
+	}
+}
+
+// NewMemory creates a new sandbox memory with the initial and maximum number of
+// pages given, and returns its index. A maximum of math.MaxUint32 means no maximum.
+func (s *Store) NewMemory(initial, maximum uint32) (index uint32, err error) {
+	if maximum == unboundedMemory {
+		maximum = maxMemoryPages
+	}
+
+	memory, err := wasm.NewMemory(initial, maximum)
+	if err != nil {
+		return 0, fmt.Errorf("creating memory: %w", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	index = s.nextMemoryIndex
+	s.nextMemoryIndex++
+	s.memories[index] = memory
+	return index, nil
+}
+
+// MemoryGet copies the data of the sandbox memory starting at offset into buf.
+func (s *Store) MemoryGet(index, offset uint32, buf []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	memory, ok := s.memories[index]
+	if !ok {
+		return fmt.Errorf("%w: index %d", errMemoryNotFound, index)
+	}
+
+	data := memory.Data()
+	end := uint64(offset) + uint64(len(buf))
+	if end > uint64(len(data)) {
+		return fmt.Errorf("%w: reading %d bytes at offset %d of memory of size %d",
+			ErrOutOfBounds, len(buf), offset, len(data))
+	}
+
+	copy(buf, data[offset:end])
+	return nil
+}
+
+// MemorySet copies the data given to the sandbox memory starting at offset.
+func (s *Store) MemorySet(index, offset uint32, data []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	memory, ok := s.memories[index]
+	if !ok {
+		return fmt.Errorf("%w: index %d", errMemoryNotFound, index)
+	}
+
+	memoryData := memory.Data()
+	end := uint64(offset) + uint64(len(data))
+	if end > uint64(len(memoryData)) {
+		return fmt.Errorf("%w: writing %d bytes at offset %d of memory of size %d",
+			ErrOutOfBounds, len(data), offset, len(memoryData))
+	}
+
+	copy(memoryData[offset:end], data)
+	return nil
+}
+
+// TeardownMemory frees the sandbox memory at the index given.
+func (s *Store) TeardownMemory(index uint32) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	memory, ok := s.memories[index]
+	if !ok {
+		return fmt.Errorf("%w: index %d", errMemoryNotFound, index)
+	}
+
+	memory.Close()
+	delete(s.memories, index)
+	return nil
+}
+
+// Instantiate instantiates the wasm code given with the guest environment
+// definition given, and returns the index of the new instance.
+// Note the guest environment can only provide memories: the wasmer bindings
+// in use cannot define host functions at runtime, so guest modules cannot
+// import supervisor functions. Supervisor functions of the environment which
+// are not imported by the guest module are ignored.
+func (s *Store) Instantiate(code []byte, environment EnvironmentDefinition) (index uint32, err error) {
+	functionImports, err := functionImportNames(code)
+	if err != nil {
+		return 0, fmt.Errorf("parsing module: %w", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	imports := wasm.NewImports()
+	for _, entry := range environment.Entries {
+		switch entry.Entity.Kind {
+		case FunctionEntityKind:
+			name := importName{module: string(entry.ModuleName), field: string(entry.FieldName)}
+			if _, ok := functionImports[name]; !ok {
+				continue
+			}
+			return 0, fmt.Errorf("%w: %s.%s imports supervisor function %d",
+				errFunctionImportsNotSupported, entry.ModuleName, entry.FieldName, entry.Entity.Index)
+		case MemoryEntityKind:
+			memory, ok := s.memories[entry.Entity.Index]
+			if !ok {
+				return 0, fmt.Errorf("%w: index %d for %s.%s",
+					errMemoryNotFound, entry.Entity.Index, entry.ModuleName, entry.FieldName)
+			}
+
+			_, err = imports.Namespace(string(entry.ModuleName)).
+				AppendMemory(string(entry.FieldName), memory)
+			if err != nil {
+				return 0, fmt.Errorf("appending memory import %s.%s: %w",
+					entry.ModuleName, entry.FieldName, err)
+			}
+		default:
+			return 0, fmt.Errorf("%w: %d", errUnknownEntityKind, entry.Entity.Kind)
+		}
+	}
+
+	instance, err := wasm.NewInstanceWithImports(code, imports)
+	if err != nil {
+		return 0, fmt.Errorf("instantiating module: %w", err)
+	}
+
+	index = s.nextInstanceIndex
+	s.nextInstanceIndex++
+	s.instances[index] = &instance
+	return index, nil
+}
+
+// Invoke calls the exported function of the sandbox instance with the arguments given.
+// It returns a nil return value if the function returns no value.
+func (s *Store) Invoke(index uint32, function string, args []Value) (
+	returnValue *Value, err error) {
+	s.mutex.Lock()
+	instance, ok := s.instances[index]
+	s.mutex.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: index %d", errInstanceNotFound, index)
+	}
+
+	export, ok := instance.Exports[function]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errExportNotFound, function)
+	}
+
+	wasmArgs := make([]interface{}, len(args))
+	for i, arg := range args {
+		wasmArgs[i], err = toWasm(arg)
+		if err != nil {
+			return nil, fmt.Errorf("converting argument %d: %w", i, err)
+		}
+	}
+
+	result, err := export(wasmArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("calling %s: %w", function, err)
+	}
+
+	return newValueFromWasm(result)
+}
+
+// TeardownInstance frees the sandbox instance at the index given.
+func (s *Store) TeardownInstance(index uint32) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instance, ok := s.instances[index]
+	if !ok {
+		return fmt.Errorf("%w: index %d", errInstanceNotFound, index)
+	}
+
+	instance.Close()
+	delete(s.instances, index)
+	return nil
+}
+
+// Close frees all the sandbox instances and memories.
+func (s *Store) Close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for index, instance := range s.instances {
+		instance.Close()
+		delete(s.instances, index)
+	}
+
+	for index, memory := range s.memories {
+		memory.Close()
+		delete(s.memories, index)
+	}
+}
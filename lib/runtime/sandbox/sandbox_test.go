@@ -0,0 +1,234 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sandbox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// addModule exports the function `add(i32, i32) -> i32`.
+var addModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x07, 0x01, 0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7f,
+	0x03, 0x02, 0x01, 0x00,
+	0x07, 0x07, 0x01, 0x03, 0x61, 0x64, 0x64, 0x00, 0x00,
+	0x0a, 0x09, 0x01, 0x07, 0x00, 0x20, 0x00, 0x20, 0x01, 0x6a, 0x0b,
+}
+
+// loadModule imports the memory `env.memory` and exports the function
+// `load(i32) -> i32` loading the i32 stored at the address given.
+var loadModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x06, 0x01, 0x60, 0x01, 0x7f, 0x01, 0x7f,
+	0x02, 0x0f, 0x01, 0x03, 0x65, 0x6e, 0x76, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x02, 0x00, 0x01,
+	0x03, 0x02, 0x01, 0x00,
+	0x07, 0x08, 0x01, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x00, 0x00,
+	0x0a, 0x09, 0x01, 0x07, 0x00, 0x20, 0x00, 0x28, 0x02, 0x00, 0x0b,
+}
+
+// callModule imports the supervisor function `env.mul(i32, i32) -> i32` and
+// exports the function `call(i32, i32) -> i32` returning `mul(a, b) + 1`.
+var callModule = []byte{
+	0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00,
+	0x01, 0x07, 0x01, 0x60, 0x02, 0x7f, 0x7f, 0x01, 0x7f,
+	0x02, 0x0b, 0x01, 0x03, 0x65, 0x6e, 0x76, 0x03, 0x6d, 0x75, 0x6c, 0x00, 0x00,
+	0x03, 0x02, 0x01, 0x00,
+	0x07, 0x08, 0x01, 0x04, 0x63, 0x61, 0x6c, 0x6c, 0x00, 0x01,
+	0x0a, 0x0d, 0x01, 0x0b, 0x00, 0x20, 0x00, 0x20, 0x01, 0x10, 0x00, 0x41, 0x01, 0x6a, 0x0b,
+}
+
+func newValue(t *testing.T, value interface{ Index() uint }) Value {
+	t.Helper()
+	v, err := NewValue(value)
+	require.NoError(t, err)
+	return v
+}
+
+func Test_Store_Memory(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore()
+	defer store.Close()
+
+	index, err := store.NewMemory(1, unboundedMemory)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), index)
+
+	err = store.MemorySet(index, 10, []byte{1, 2, 3})
+	require.NoError(t, err)
+
+	buf := make([]byte, 4)
+	err = store.MemoryGet(index, 9, buf)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 1, 2, 3}, buf)
+
+	const pageSize = 65536
+	err = store.MemoryGet(index, pageSize-1, buf)
+	assert.ErrorIs(t, err, ErrOutOfBounds)
+	err = store.MemorySet(index, pageSize, []byte{1})
+	assert.ErrorIs(t, err, ErrOutOfBounds)
+
+	err = store.TeardownMemory(index)
+	require.NoError(t, err)
+	err = store.MemoryGet(index, 0, buf)
+	assert.ErrorIs(t, err, errMemoryNotFound)
+	err = store.TeardownMemory(index)
+	assert.ErrorIs(t, err, errMemoryNotFound)
+}
+
+func Test_Store_Instantiate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("function import", func(t *testing.T) {
+		t.Parallel()
+		store := NewStore()
+		defer store.Close()
+
+		environment := EnvironmentDefinition{Entries: []EnvironmentEntry{{
+			ModuleName: []byte("env"),
+			FieldName:  []byte("mul"),
+			Entity:     ExternEntity{Kind: FunctionEntityKind, Index: 1},
+		}}}
+		_, err := store.Instantiate(callModule, environment)
+		assert.ErrorIs(t, err, errFunctionImportsNotSupported)
+	})
+
+	t.Run("function not imported", func(t *testing.T) {
+		t.Parallel()
+		store := NewStore()
+		defer store.Close()
+
+		environment := EnvironmentDefinition{Entries: []EnvironmentEntry{{
+			ModuleName: []byte("env"),
+			FieldName:  []byte("ext_print"),
+			Entity:     ExternEntity{Kind: FunctionEntityKind, Index: 1},
+		}}}
+		_, err := store.Instantiate(addModule, environment)
+		assert.NoError(t, err)
+	})
+
+	t.Run("memory not found", func(t *testing.T) {
+		t.Parallel()
+		store := NewStore()
+		defer store.Close()
+
+		environment := EnvironmentDefinition{Entries: []EnvironmentEntry{{
+			ModuleName: []byte("env"),
+			FieldName:  []byte("memory"),
+			Entity:     ExternEntity{Kind: MemoryEntityKind, Index: 0},
+		}}}
+		_, err := store.Instantiate(loadModule, environment)
+		assert.ErrorIs(t, err, errMemoryNotFound)
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		t.Parallel()
+		store := NewStore()
+		defer store.Close()
+
+		_, err := store.Instantiate([]byte{1, 2, 3}, EnvironmentDefinition{})
+		assert.Error(t, err)
+	})
+}
+
+func Test_Store_Invoke(t *testing.T) {
+	t.Parallel()
+
+	t.Run("add", func(t *testing.T) {
+		t.Parallel()
+		store := NewStore()
+		defer store.Close()
+
+		index, err := store.Instantiate(addModule, EnvironmentDefinition{})
+		require.NoError(t, err)
+
+		args := []Value{newValue(t, I32(3)), newValue(t, I32(4))}
+		returnValue, err := store.Invoke(index, "add", args)
+		require.NoError(t, err)
+		require.NotNil(t, returnValue)
+		value, err := returnValue.Value()
+		require.NoError(t, err)
+		assert.Equal(t, I32(7), value)
+
+		_, err = store.Invoke(index, "sub", args)
+		assert.ErrorIs(t, err, errExportNotFound)
+
+		err = store.TeardownInstance(index)
+		require.NoError(t, err)
+		_, err = store.Invoke(index, "add", args)
+		assert.ErrorIs(t, err, errInstanceNotFound)
+	})
+
+	t.Run("shared memory", func(t *testing.T) {
+		t.Parallel()
+		store := NewStore()
+		defer store.Close()
+
+		memoryIndex, err := store.NewMemory(1, 1)
+		require.NoError(t, err)
+		err = store.MemorySet(memoryIndex, 8, []byte{0x2a, 0, 0, 0})
+		require.NoError(t, err)
+
+		environment := EnvironmentDefinition{Entries: []EnvironmentEntry{{
+			ModuleName: []byte("env"),
+			FieldName:  []byte("memory"),
+			Entity:     ExternEntity{Kind: MemoryEntityKind, Index: memoryIndex},
+		}}}
+		index, err := store.Instantiate(loadModule, environment)
+		require.NoError(t, err)
+
+		returnValue, err := store.Invoke(index, "load", []Value{newValue(t, I32(8))})
+		require.NoError(t, err)
+		require.NotNil(t, returnValue)
+		value, err := returnValue.Value()
+		require.NoError(t, err)
+		assert.Equal(t, I32(42), value)
+	})
+}
+
+func Test_codes(t *testing.T) {
+	t.Parallel()
+
+	// values of the sp-sandbox crate constants
+	assert.Equal(t, uint32(0), CodeOK)
+	assert.Equal(t, uint32(0xffffffff), CodeErrModule)
+	assert.Equal(t, uint32(0xfffffffe), CodeErrOutOfBounds)
+	assert.Equal(t, uint32(0xfffffffd), CodeErrExecution)
+}
+
+func Test_DecodeValues(t *testing.T) {
+	t.Parallel()
+
+	encoded := []byte{
+		2 << 2,
+		0, 3, 0, 0, 0,
+		1, 4, 0, 0, 0, 0, 0, 0, 0,
+	}
+	values, err := DecodeValues(encoded)
+	require.NoError(t, err)
+	require.Len(t, values, 2)
+
+	first, err := values[0].Value()
+	require.NoError(t, err)
+	assert.Equal(t, I32(3), first)
+	second, err := values[1].Value()
+	require.NoError(t, err)
+	assert.Equal(t, I64(4), second)
+}
+
+func Test_EncodeReturnValue(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := EncodeReturnValue(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0}, encoded)
+
+	value := newValue(t, I32(7))
+	encoded, err = EncodeReturnValue(&value)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 0, 7, 0, 0, 0}, encoded)
+}
@@ -0,0 +1,152 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sandbox
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	wasm "github.com/wasmerio/go-ext-wasm/wasmer"
+)
+
+// Kinds of the extern entities of an environment definition
+const (
+	// FunctionEntityKind is the kind of a supervisor function entity
+	FunctionEntityKind uint8 = 1
+	// MemoryEntityKind is the kind of a sandbox memory entity
+	MemoryEntityKind uint8 = 2
+)
+
+// ExternEntity is an entity provided to the guest module, either a supervisor
+// function or a sandbox memory. Both variants of the SCALE encoded enum hold
+// an u32 index, so it is decoded as its variant kind followed by its index.
+type ExternEntity struct {
+	Kind  uint8
+	Index uint32
+}
+
+// EnvironmentEntry is an entity provided to the guest module for the import
+// of the module and field names given.
+type EnvironmentEntry struct {
+	ModuleName []byte
+	FieldName  []byte
+	Entity     ExternEntity
+}
+
+// EnvironmentDefinition is the definition of the environment of a guest module.
+type EnvironmentDefinition struct {
+	Entries []EnvironmentEntry
+}
+
+// I32 is a 32 bits integer value
+type I32 int32
+
+// Index returns the VDT index
+func (I32) Index() uint { return 0 }
+
+// I64 is a 64 bits integer value
+type I64 int64
+
+// Index returns the VDT index
+func (I64) Index() uint { return 1 }
+
+// F32 is a 32 bits float value, as its IEEE 754 binary representation
+type F32 uint32
+
+// Index returns the VDT index
+func (F32) Index() uint { return 2 }
+
+// F64 is a 64 bits float value, as its IEEE 754 binary representation
+type F64 uint64
+
+// Index returns the VDT index
+func (F64) Index() uint { return 3 }
+
+// Value is a wasm value passed to or returned from a sandbox function.
+type Value = scale.VaryingDataType
+
+// NewValue returns a new Value set with the value given.
+func NewValue(value scale.VaryingDataTypeValue) (v Value, err error) {
+	v = scale.MustNewVaryingDataType(I32(0), I64(0), F32(0), F64(0))
+	err = v.Set(value)
+	if err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// DecodeValues decodes the SCALE encoded values given.
+func DecodeValues(encoded []byte) (values []Value, err error) {
+	prototype, err := NewValue(I32(0))
+	if err != nil {
+		return nil, err
+	}
+
+	valuesSlice := scale.NewVaryingDataTypeSlice(prototype)
+	err = scale.Unmarshal(encoded, &valuesSlice)
+	if err != nil {
+		return nil, err
+	}
+
+	return valuesSlice.Types, nil
+}
+
+// EncodeReturnValue SCALE encodes the optional return value given.
+func EncodeReturnValue(returnValue *Value) (encoded []byte, err error) {
+	if returnValue == nil {
+		return []byte{0}, nil
+	}
+
+	encodedValue, err := scale.Marshal(*returnValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{1}, encodedValue...), nil
+}
+
+func toWasm(value Value) (wasmValue interface{}, err error) {
+	inner, err := value.Value()
+	if err != nil {
+		return nil, err
+	}
+
+	switch inner := inner.(type) {
+	case I32:
+		return int32(inner), nil
+	case I64:
+		return int64(inner), nil
+	case F32:
+		return math.Float32frombits(uint32(inner)), nil
+	case F64:
+		return math.Float64frombits(uint64(inner)), nil
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnsupportedValueType, inner)
+	}
+}
+
+func newValueFromWasm(wasmValue wasm.Value) (value *Value, err error) {
+	var inner scale.VaryingDataTypeValue
+	switch wasmValue.GetType() {
+	case wasm.TypeVoid:
+		return nil, nil
+	case wasm.TypeI32:
+		inner = I32(wasmValue.ToI32())
+	case wasm.TypeI64:
+		inner = I64(wasmValue.ToI64())
+	case wasm.TypeF32:
+		inner = F32(math.Float32bits(wasmValue.ToF32()))
+	case wasm.TypeF64:
+		inner = F64(math.Float64bits(wasmValue.ToF64()))
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnsupportedValueType, wasmValue.GetType())
+	}
+
+	v, err := NewValue(inner)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package storage
+
+import (
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// TransactionIndexOperation is a transaction index operation requested by the
+// runtime during the execution of a block.
+type TransactionIndexOperation struct {
+	// Extrinsic is the index of the extrinsic in the block body.
+	Extrinsic uint32
+	// Hash is the blake2b 256 bits hash of the indexed data.
+	Hash common.Hash
+	// Size is the size of the indexed data, which is located at
+	// the end of the encoded extrinsic. It is zero for renewals.
+	Size uint32
+	// Renew is true if the operation renews the storage period
+	// of data indexed previously.
+	Renew bool
+}
+
+// IndexTransaction records that the last size bytes of the extrinsic at the
+// given index of the block body are to be indexed with the given hash.
+func (s *TrieState) IndexTransaction(extrinsic, size uint32, hash common.Hash) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.indexOperations = append(s.indexOperations, TransactionIndexOperation{
+		Extrinsic: extrinsic,
+		Hash:      hash,
+		Size:      size,
+	})
+}
+
+// RenewTransactionIndex records that the data indexed with the given hash is
+// renewed by the extrinsic at the given index of the block body.
+func (s *TrieState) RenewTransactionIndex(extrinsic uint32, hash common.Hash) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.indexOperations = append(s.indexOperations, TransactionIndexOperation{
+		Extrinsic: extrinsic,
+		Hash:      hash,
+		Renew:     true,
+	})
+}
+
+// TransactionIndexOperations returns the transaction index operations
+// recorded since the TrieState was created.
+func (s *TrieState) TransactionIndexOperations() (operations []TransactionIndexOperation) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if len(s.indexOperations) == 0 {
+		return nil
+	}
+	operations = make([]TransactionIndexOperation, len(s.indexOperations))
+	copy(operations, s.indexOperations)
+	return operations
+}
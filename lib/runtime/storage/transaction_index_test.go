@@ -0,0 +1,35 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrieState_TransactionIndexOperations(t *testing.T) {
+	t.Parallel()
+
+	ts := NewTrieState(trie.NewEmptyTrie())
+	assert.Nil(t, ts.TransactionIndexOperations())
+
+	ts.IndexTransaction(0, 10, common.Hash{1})
+
+	ts.BeginStorageTransaction()
+	ts.RenewTransactionIndex(1, common.Hash{2})
+	ts.RollbackStorageTransaction()
+
+	ts.BeginStorageTransaction()
+	ts.RenewTransactionIndex(2, common.Hash{3})
+	ts.CommitStorageTransaction()
+
+	expected := []TransactionIndexOperation{
+		{Extrinsic: 0, Hash: common.Hash{1}, Size: 10},
+		{Extrinsic: 2, Hash: common.Hash{3}, Renew: true},
+	}
+	assert.Equal(t, expected, ts.TransactionIndexOperations())
+}
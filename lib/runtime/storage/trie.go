@@ -18,6 +18,10 @@ type TrieState struct {
 	t       *trie.Trie
 	oldTrie *trie.Trie // this is the trie before BeginStorageTransaction is called. set to nil if it isn't called
 	lock    sync.RWMutex
+
+	indexOperations []TransactionIndexOperation
+	// oldIndexOperationsCount is the number of index operations before BeginStorageTransaction is called.
+	oldIndexOperationsCount int
 }
 
 // NewTrieState returns a new TrieState with the given trie
@@ -50,6 +54,7 @@ func (s *TrieState) BeginStorageTransaction() {
 	defer s.lock.Unlock()
 	s.oldTrie = s.t
 	s.t = s.t.Snapshot()
+	s.oldIndexOperationsCount = len(s.indexOperations)
 }
 
 // CommitStorageTransaction commits all storage changes made since BeginStorageTransaction was called.
@@ -65,6 +70,7 @@ func (s *TrieState) RollbackStorageTransaction() {
 	defer s.lock.Unlock()
 	s.t = s.oldTrie
 	s.oldTrie = nil
+	s.indexOperations = s.indexOperations[:s.oldIndexOperationsCount]
 }
 
 // Set sets a key-value pair in the trie
@@ -91,6 +97,14 @@ func (s *TrieState) Root() (common.Hash, error) {
 	return s.t.Hash()
 }
 
+// SetVersion sets the state trie version used to compute the
+// root hash and to encode the nodes modified in the trie.
+func (s *TrieState) SetVersion(version trie.Version) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.t.SetVersion(version)
+}
+
 // Has returns whether or not a key exists
 func (s *TrieState) Has(key []byte) bool {
 	return s.Get(key) != nil
@@ -69,6 +69,17 @@ func TestTrieState_Root(t *testing.T) {
 	testFunc(ts)
 }
 
+func TestTrieState_SetVersion(t *testing.T) {
+	ts := &TrieState{t: trie.NewEmptyTrie()}
+	ts.Set([]byte("key"), bytes.Repeat([]byte{1}, trie.V1MaxInlineValueSize+1))
+
+	rootV0 := ts.MustRoot()
+
+	ts.SetVersion(trie.V1)
+	require.Equal(t, trie.V1, ts.Trie().Version())
+	require.NotEqual(t, rootV0, ts.MustRoot())
+}
+
 func TestTrieState_ClearPrefix(t *testing.T) {
 	ts := &TrieState{t: trie.NewEmptyTrie()}
 
@@ -4,9 +4,11 @@
 package runtime
 
 import (
+	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime/offchain"
+	"github.com/ChainSafe/gossamer/lib/runtime/sandbox"
 )
 
 // NodeStorageType type to identify offchain storage type
@@ -56,5 +58,10 @@ type Context struct {
 	Transaction     TransactionState
 	SigVerifier     *crypto.SignatureVerifier
 	OffchainHTTPSet *offchain.HTTPSet
+	Sandbox         *sandbox.Store
 	Version         Version
+	// LogLevel is the maximum level of the messages logged by the runtime.
+	LogLevel log.Level
+	// Interrupt is closed to interrupt the blocking offchain host functions.
+	Interrupt <-chan struct{}
 }
@@ -23,6 +23,9 @@ type Config struct {
 	Network     runtime.BasicNetwork
 	Transaction runtime.TransactionState
 	CodeHash    common.Hash
+	// Interrupt is closed to interrupt the blocking offchain host
+	// functions, such as sleeps and HTTP waits, of the instance.
+	Interrupt   <-chan struct{}
 	testVersion *runtime.Version
 }
 
@@ -4,14 +4,24 @@
 package wasmer
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/offchain"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
+var (
+	ErrKeyOwnershipProofNotFound      = errors.New("key ownership proof not found")
+	ErrEquivocationReportNotSubmitted = errors.New("equivocation report not submitted")
+	errInvalidSubmitReportResult      = errors.New("invalid submit report result")
+)
+
 // ValidateTransaction runs the extrinsic through the runtime function
 // TaggedTransactionQueue_validate_transaction and returns **transaction.Validity. The error can
 // be a VDT of either transaction.InvalidTransaction or transaction.UnknownTransaction, or can represent
@@ -70,6 +80,84 @@ func (in *Instance) BabeConfiguration() (*types.BabeConfiguration, error) {
 	return bc, nil
 }
 
+// BabeCurrentEpoch returns the current BABE epoch from the runtime
+func (in *Instance) BabeCurrentEpoch() (*types.BabeEpoch, error) {
+	return in.babeEpoch(runtime.BabeAPICurrentEpoch)
+}
+
+// BabeNextEpoch returns the next BABE epoch from the runtime
+func (in *Instance) BabeNextEpoch() (*types.BabeEpoch, error) {
+	return in.babeEpoch(runtime.BabeAPINextEpoch)
+}
+
+func (in *Instance) babeEpoch(function string) (*types.BabeEpoch, error) {
+	data, err := in.Exec(function, []byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	epoch := new(types.BabeEpoch)
+	err = scale.Unmarshal(data, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	return epoch, nil
+}
+
+// BabeGenerateKeyOwnershipProof returns the proof that the given BABE authority key
+// belongs to a validator at the session of the given slot. An error wrapping
+// ErrKeyOwnershipProofNotFound is returned if the runtime cannot generate the proof.
+func (in *Instance) BabeGenerateKeyOwnershipProof(slot uint64, authorityID [sr25519.PublicKeyLength]byte) (
+	types.BabeOpaqueKeyOwnershipProof, error) {
+	encodedSlot, err := scale.Marshal(slot)
+	if err != nil {
+		return nil, fmt.Errorf("encoding slot: %w", err)
+	}
+
+	ret, err := in.Exec(runtime.BabeAPIGenerateKeyOwnershipProof, append(encodedSlot, authorityID[:]...))
+	if err != nil {
+		return nil, err
+	}
+
+	var keyOwnershipProof *types.BabeOpaqueKeyOwnershipProof
+	err = scale.Unmarshal(ret, &keyOwnershipProof)
+	if err != nil {
+		return nil, fmt.Errorf("decoding key ownership proof: %w", err)
+	}
+
+	if keyOwnershipProof == nil {
+		return nil, fmt.Errorf("%w: for authority 0x%x at slot %d",
+			ErrKeyOwnershipProofNotFound, authorityID, slot)
+	}
+
+	return *keyOwnershipProof, nil
+}
+
+// BabeSubmitReportEquivocationUnsignedExtrinsic submits an unsigned extrinsic
+// reporting the given BABE equivocation, so the authority can be slashed.
+func (in *Instance) BabeSubmitReportEquivocationUnsignedExtrinsic(
+	equivocationProof types.BabeEquivocationProof, keyOwnershipProof types.BabeOpaqueKeyOwnershipProof,
+) error {
+	encodedEquivocationProof, err := scale.Marshal(equivocationProof)
+	if err != nil {
+		return fmt.Errorf("encoding equivocation proof: %w", err)
+	}
+
+	encodedKeyOwnershipProof, err := scale.Marshal(keyOwnershipProof)
+	if err != nil {
+		return fmt.Errorf("encoding key ownership proof: %w", err)
+	}
+
+	args := append(encodedEquivocationProof, encodedKeyOwnershipProof...)
+	ret, err := in.Exec(runtime.BabeAPISubmitReportEquivocation, args)
+	if err != nil {
+		return err
+	}
+
+	return decodeSubmitReportResult(ret)
+}
+
 // GrandpaAuthorities returns the genesis authorities from the runtime
 func (in *Instance) GrandpaAuthorities() ([]types.Authority, error) {
 	ret, err := in.Exec(runtime.GrandpaAuthorities, []byte{})
@@ -86,6 +174,73 @@ func (in *Instance) GrandpaAuthorities() ([]types.Authority, error) {
 	return types.GrandpaAuthoritiesRawToAuthorities(gar)
 }
 
+// GrandpaGenerateKeyOwnershipProof returns the proof that the given authority key of the
+// given authority set belongs to a validator. An error wrapping ErrKeyOwnershipProofNotFound
+// is returned if the runtime cannot generate the proof.
+func (in *Instance) GrandpaGenerateKeyOwnershipProof(authSetID uint64, authorityID ed25519.PublicKeyBytes) (
+	types.GrandpaOpaqueKeyOwnershipProof, error) {
+	encodedSetID, err := scale.Marshal(authSetID)
+	if err != nil {
+		return nil, fmt.Errorf("encoding authority set id: %w", err)
+	}
+
+	ret, err := in.Exec(runtime.GrandpaGenerateKeyOwnershipProof, append(encodedSetID, authorityID[:]...))
+	if err != nil {
+		return nil, err
+	}
+
+	var keyOwnershipProof *types.GrandpaOpaqueKeyOwnershipProof
+	err = scale.Unmarshal(ret, &keyOwnershipProof)
+	if err != nil {
+		return nil, fmt.Errorf("decoding key ownership proof: %w", err)
+	}
+
+	if keyOwnershipProof == nil {
+		return nil, fmt.Errorf("%w: for authority %s in set id %d",
+			ErrKeyOwnershipProofNotFound, authorityID, authSetID)
+	}
+
+	return *keyOwnershipProof, nil
+}
+
+// GrandpaSubmitReportEquivocationUnsignedExtrinsic submits an unsigned extrinsic
+// reporting the given equivocation, so the authority can be slashed.
+func (in *Instance) GrandpaSubmitReportEquivocationUnsignedExtrinsic(
+	equivocationProof types.GrandpaEquivocationProof, keyOwnershipProof types.GrandpaOpaqueKeyOwnershipProof,
+) error {
+	encodedEquivocationProof, err := scale.Marshal(equivocationProof)
+	if err != nil {
+		return fmt.Errorf("encoding equivocation proof: %w", err)
+	}
+
+	encodedKeyOwnershipProof, err := scale.Marshal(keyOwnershipProof)
+	if err != nil {
+		return fmt.Errorf("encoding key ownership proof: %w", err)
+	}
+
+	args := append(encodedEquivocationProof, encodedKeyOwnershipProof...)
+	ret, err := in.Exec(runtime.GrandpaSubmitReportEquivocation, args)
+	if err != nil {
+		return err
+	}
+
+	return decodeSubmitReportResult(ret)
+}
+
+// decodeSubmitReportResult decodes the `Option<()>` returned by the runtime
+// functions submitting an equivocation report, which is `None` if the
+// extrinsic could not be submitted to the transaction pool.
+func decodeSubmitReportResult(encoded []byte) error {
+	switch {
+	case len(encoded) == 1 && encoded[0] == 1:
+		return nil
+	case len(encoded) == 1 && encoded[0] == 0:
+		return ErrEquivocationReportNotSubmitted
+	default:
+		return fmt.Errorf("%w: 0x%x", errInvalidSubmitReportResult, encoded)
+	}
+}
+
 // InitializeBlock calls runtime API function Core_initialise_block
 func (in *Instance) InitializeBlock(header *types.Header) error {
 	encodedHeader, err := scale.Marshal(*header)
@@ -163,6 +318,29 @@ func (in *Instance) DecodeSessionKeys(enc []byte) ([]byte, error) {
 	return in.Exec(runtime.DecodeSessionKeys, enc)
 }
 
+// GenerateSessionKeys calls runtime function SessionKeys_generate_session_keys, which generates
+// a set of session keys, inserts them into the keystore and returns the SCALE encoded public keys.
+// An optional seed can be given, which is used as the mnemonic to derive the keys from.
+func (in *Instance) GenerateSessionKeys(seed *[]byte) ([]byte, error) {
+	encodedSeed, err := scale.Marshal(seed)
+	if err != nil {
+		return nil, fmt.Errorf("encoding seed: %w", err)
+	}
+
+	ret, err := in.Exec(runtime.GenerateSessionKeys, encodedSeed)
+	if err != nil {
+		return nil, err
+	}
+
+	var publicKeys []byte
+	err = scale.Unmarshal(ret, &publicKeys)
+	if err != nil {
+		return nil, fmt.Errorf("decoding public keys: %w", err)
+	}
+
+	return publicKeys, nil
+}
+
 // PaymentQueryInfo returns information of a given extrinsic
 func (in *Instance) PaymentQueryInfo(ext []byte) (*types.TransactionPaymentQueryInfo, error) {
 	encLen, err := scale.Marshal(uint32(len(ext)))
@@ -183,7 +361,23 @@ func (in *Instance) PaymentQueryInfo(ext []byte) (*types.TransactionPaymentQuery
 	return i, nil
 }
 
-func (in *Instance) CheckInherents()      {} //nolint:revive
-func (in *Instance) RandomSeed()          {} //nolint:revive
-func (in *Instance) OffchainWorker()      {} //nolint:revive
-func (in *Instance) GenerateSessionKeys() {} //nolint:revive
+// OffchainWorker runs the offchain worker of the runtime for the given block header.
+// As in Substrate, each run gets a new offchain HTTP set, and the requests
+// left by the previous run are closed.
+func (in *Instance) OffchainWorker(header *types.Header) error {
+	encodedHeader, err := scale.Marshal(*header)
+	if err != nil {
+		return fmt.Errorf("encoding header: %w", err)
+	}
+
+	in.mutex.Lock()
+	in.ctx.OffchainHTTPSet.Close()
+	in.ctx.OffchainHTTPSet = offchain.NewHTTPSet(in.ctx.Interrupt)
+	in.mutex.Unlock()
+
+	_, err = in.Exec(runtime.OffchainWorkerAPIOffchainWorker, encodedHeader)
+	return err
+}
+
+func (in *Instance) CheckInherents() {} //nolint:revive
+func (in *Instance) RandomSeed()     {} //nolint:revive
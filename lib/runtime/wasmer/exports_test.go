@@ -1006,6 +1006,42 @@ func TestInstance_DecodeSessionKeys(t *testing.T) {
 	require.Len(t, *decodedKeys, 4)
 }
 
+func TestInstance_GenerateSessionKeys(t *testing.T) {
+	instance := NewTestInstance(t, runtime.NODE_RUNTIME_v098)
+
+	publicKeys, err := instance.GenerateSessionKeys(nil)
+	require.NoError(t, err)
+	// grandpa, babe, im_online and authority_discovery public keys
+	require.Len(t, publicKeys, 4*32)
+
+	ks := instance.Keystore()
+	require.Equal(t, 1, ks.Gran.Size())
+	require.Equal(t, 1, ks.Babe.Size())
+	require.Equal(t, 1, ks.Imon.Size())
+	require.Equal(t, 1, ks.Audi.Size())
+
+	encodedPublicKeys, err := scale.Marshal(publicKeys)
+	require.NoError(t, err)
+
+	decoded, err := instance.DecodeSessionKeys(encodedPublicKeys)
+	require.NoError(t, err)
+
+	var decodedKeys *[]struct {
+		Data []uint8
+		Type [4]uint8
+	}
+
+	err = scale.Unmarshal(decoded, &decodedKeys)
+	require.NoError(t, err)
+	require.Len(t, *decodedKeys, 4)
+
+	for _, key := range *decodedKeys {
+		ks, err := instance.Keystore().GetKeystore(key.Type[:])
+		require.NoError(t, err)
+		require.Equal(t, key.Data, ks.PublicKeys()[0].Encode())
+	}
+}
+
 func TestInstance_PaymentQueryInfo(t *testing.T) {
 	tests := []struct {
 		extB       []byte
@@ -1087,3 +1123,45 @@ func newTrieFromPairs(t *testing.T, filename string) *trie.Trie {
 	require.NoError(t, err)
 	return &tr
 }
+
+func Test_decodeSubmitReportResult(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		encoded    []byte
+		errWrapped error
+		errMessage string
+	}{
+		"submitted": {
+			encoded: []byte{1},
+		},
+		"not submitted": {
+			encoded:    []byte{0},
+			errWrapped: ErrEquivocationReportNotSubmitted,
+			errMessage: "equivocation report not submitted",
+		},
+		"empty result": {
+			errWrapped: errInvalidSubmitReportResult,
+			errMessage: "invalid submit report result: 0x",
+		},
+		"invalid option": {
+			encoded:    []byte{2},
+			errWrapped: errInvalidSubmitReportResult,
+			errMessage: "invalid submit report result: 0x02",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := decodeSubmitReportResult(testCase.encoded)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
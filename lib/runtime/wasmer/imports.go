@@ -34,9 +34,11 @@
 // extern void ext_crypto_start_batch_verify_version_1(void *context);
 //
 // extern int32_t ext_trie_blake2_256_root_version_1(void *context, int64_t a);
+// extern int32_t ext_trie_blake2_256_root_version_2(void *context, int64_t a, int32_t b);
 // extern int32_t ext_trie_blake2_256_ordered_root_version_1(void *context, int64_t a);
 // extern int32_t ext_trie_blake2_256_ordered_root_version_2(void *context, int64_t a, int32_t b);
 // extern int32_t ext_trie_blake2_256_verify_proof_version_1(void *context, int32_t a, int64_t b, int64_t c, int64_t d);
+// extern int32_t ext_trie_blake2_256_verify_proof_version_2(void *context, int32_t a, int64_t b, int64_t c, int64_t d, int32_t e);
 //
 // extern int64_t ext_misc_runtime_version_version_1(void *context, int64_t a);
 // extern void ext_misc_print_hex_version_1(void *context, int64_t a);
@@ -48,6 +50,7 @@
 // extern int64_t ext_default_child_storage_next_key_version_1(void *context, int64_t a, int64_t b);
 // extern int64_t ext_default_child_storage_read_version_1(void *context, int64_t a, int64_t b, int64_t c, int32_t d);
 // extern int64_t ext_default_child_storage_root_version_1(void *context, int64_t a);
+// extern int64_t ext_default_child_storage_root_version_2(void *context, int64_t a, int32_t b);
 // extern void ext_default_child_storage_set_version_1(void *context, int64_t a, int64_t b, int64_t c);
 // extern void ext_default_child_storage_storage_kill_version_1(void *context, int64_t a);
 // extern int32_t ext_default_child_storage_storage_kill_version_2(void *context, int64_t a, int64_t b);
@@ -79,6 +82,10 @@
 // extern void ext_offchain_sleep_until_version_1(void *context, int64_t a);
 // extern int64_t ext_offchain_http_request_start_version_1(void *context, int64_t a, int64_t b, int64_t c);
 // extern int64_t ext_offchain_http_request_add_header_version_1(void *context, int32_t a, int64_t k, int64_t v);
+// extern int64_t ext_offchain_http_request_write_body_version_1(void *context, int32_t a, int64_t b, int64_t c);
+// extern int64_t ext_offchain_http_response_wait_version_1(void *context, int64_t a, int64_t b);
+// extern int64_t ext_offchain_http_response_headers_version_1(void *context, int32_t a);
+// extern int64_t ext_offchain_http_response_read_body_version_1(void *context, int32_t a, int64_t b, int64_t c);
 //
 // extern void ext_storage_append_version_1(void *context, int64_t a, int64_t b);
 // extern int64_t ext_storage_changes_root_version_1(void *context, int64_t a);
@@ -115,6 +122,8 @@
 	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/offchain"
+	"github.com/ChainSafe/gossamer/lib/runtime/sandbox"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/ChainSafe/gossamer/lib/trie/proof"
@@ -131,89 +140,229 @@
 func ext_logging_log_version_1(context unsafe.Pointer, level C.int32_t, targetData, msgData C.int64_t) {
 	logger.Trace("executing...")
 	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	if fromRuntimeLogLevel(int32(level)) > runtimeCtx.LogLevel {
+		return
+	}
 
 	target := string(asMemorySlice(instanceContext, targetData))
 	msg := string(asMemorySlice(instanceContext, msgData))
+	targetLogger := getTargetLogger(target)
 
 	switch int(level) {
 	case 0:
-		logger.Critical("target=" + target + " message=" + msg)
+		targetLogger.Error(msg)
 	case 1:
-		logger.Warn("target=" + target + " message=" + msg)
+		targetLogger.Warn(msg)
 	case 2:
-		logger.Info("target=" + target + " message=" + msg)
+		targetLogger.Info(msg)
 	case 3:
-		logger.Debug("target=" + target + " message=" + msg)
+		targetLogger.Debug(msg)
 	case 4:
-		logger.Trace("target=" + target + " message=" + msg)
+		targetLogger.Trace(msg)
 	default:
-		logger.Errorf("level=%d target=%s message=%s", int(level), target, msg)
+		targetLogger.Errorf("level=%d message=%s", int(level), msg)
 	}
 }
 
 //export ext_logging_max_level_version_1
 func ext_logging_max_level_version_1(context unsafe.Pointer) C.int32_t {
 	logger.Trace("executing...")
-	return 4
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+	return C.int32_t(toLogLevelFilter(runtimeCtx.LogLevel))
 }
 
 //export ext_transaction_index_index_version_1
-func ext_transaction_index_index_version_1(context unsafe.Pointer, a, b, c C.int32_t) {
+func ext_transaction_index_index_version_1(context unsafe.Pointer, extrinsic, size, hashPtr C.int32_t) {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
+	instanceContext := wasm.IntoInstanceContext(context)
+	memory := instanceContext.Memory().Data()
+	storage := instanceContext.Data().(*runtime.Context).Storage
+
+	var hash common.Hash
+	copy(hash[:], memory[hashPtr:hashPtr+32])
+	logger.Debugf("indexing %d bytes of extrinsic %d with hash %s", uint32(size), uint32(extrinsic), hash)
+
+	storage.IndexTransaction(uint32(extrinsic), uint32(size), hash)
 }
 
 //export ext_transaction_index_renew_version_1
-func ext_transaction_index_renew_version_1(context unsafe.Pointer, a, b C.int32_t) {
+func ext_transaction_index_renew_version_1(context unsafe.Pointer, extrinsic, hashPtr C.int32_t) {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
+	instanceContext := wasm.IntoInstanceContext(context)
+	memory := instanceContext.Memory().Data()
+	storage := instanceContext.Data().(*runtime.Context).Storage
+
+	var hash common.Hash
+	copy(hash[:], memory[hashPtr:hashPtr+32])
+	logger.Debugf("renewing index of hash %s for extrinsic %d", hash, uint32(extrinsic))
+
+	storage.RenewTransactionIndex(uint32(extrinsic), hash)
 }
 
 //export ext_sandbox_instance_teardown_version_1
-func ext_sandbox_instance_teardown_version_1(context unsafe.Pointer, a C.int32_t) {
+func ext_sandbox_instance_teardown_version_1(context unsafe.Pointer, instanceIndex C.int32_t) {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	err := runtimeCtx.Sandbox.TeardownInstance(uint32(instanceIndex))
+	if err != nil {
+		logger.Errorf("failed to teardown sandbox instance: %s", err)
+	}
 }
 
 //export ext_sandbox_instantiate_version_1
-func ext_sandbox_instantiate_version_1(context unsafe.Pointer, a C.int32_t, x, y C.int64_t, z C.int32_t) C.int32_t {
+func ext_sandbox_instantiate_version_1(context unsafe.Pointer, dispatchThunk C.int32_t,
+	wasmCodeSpan, envDefSpan C.int64_t, statePtr C.int32_t) C.int32_t {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
-	return 0
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	var envDef sandbox.EnvironmentDefinition
+	err := scale.Unmarshal(asMemorySlice(instanceContext, envDefSpan), &envDef)
+	if err != nil {
+		logger.Errorf("failed to decode sandbox environment definition: %s", err)
+		return toSandboxCode(sandbox.CodeErrModule)
+	}
+
+	// the code is copied since the supervisor memory can grow during the guest lifetime
+	code := asMemorySlice(instanceContext, wasmCodeSpan)
+	code = append([]byte(nil), code...)
+
+	index, err := runtimeCtx.Sandbox.Instantiate(code, envDef)
+	if err != nil {
+		logger.Errorf("failed to instantiate sandbox module: %s", err)
+		return toSandboxCode(sandbox.CodeErrModule)
+	}
+
+	return C.int32_t(index)
 }
 
 //export ext_sandbox_invoke_version_1
-func ext_sandbox_invoke_version_1(context unsafe.Pointer, a C.int32_t, x, y C.int64_t, z, d, e C.int32_t) C.int32_t {
+func ext_sandbox_invoke_version_1(context unsafe.Pointer, instanceIndex C.int32_t,
+	functionSpan, argsSpan C.int64_t, returnValuePtr, returnValueLen, statePtr C.int32_t) C.int32_t {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
-	return 0
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	function := string(asMemorySlice(instanceContext, functionSpan))
+
+	args, err := sandbox.DecodeValues(asMemorySlice(instanceContext, argsSpan))
+	if err != nil {
+		logger.Errorf("failed to decode sandbox invoke arguments: %s", err)
+		return toSandboxCode(sandbox.CodeErrExecution)
+	}
+
+	returnValue, err := runtimeCtx.Sandbox.Invoke(uint32(instanceIndex), function, args)
+	if err != nil {
+		logger.Errorf("failed to invoke sandbox function %s: %s", function, err)
+		return toSandboxCode(sandbox.CodeErrExecution)
+	}
+
+	encodedReturnValue, err := sandbox.EncodeReturnValue(returnValue)
+	if err != nil {
+		logger.Errorf("failed to encode sandbox return value: %s", err)
+		return toSandboxCode(sandbox.CodeErrExecution)
+	}
+
+	if len(encodedReturnValue) > int(uint32(returnValueLen)) {
+		logger.Errorf("sandbox return value of %d bytes does not fit in buffer of %d bytes",
+			len(encodedReturnValue), uint32(returnValueLen))
+		return toSandboxCode(sandbox.CodeErrExecution)
+	}
+
+	// Note the memory is read after the invocation, since it can grow during it.
+	memory := instanceContext.Memory().Data()
+	start := uint64(uint32(returnValuePtr))
+	end := start + uint64(len(encodedReturnValue))
+	if end > uint64(len(memory)) {
+		logger.Errorf("sandbox return value buffer is out of the supervisor memory bounds")
+		return toSandboxCode(sandbox.CodeErrOutOfBounds)
+	}
+
+	copy(memory[start:end], encodedReturnValue)
+	return toSandboxCode(sandbox.CodeOK)
 }
 
 //export ext_sandbox_memory_get_version_1
-func ext_sandbox_memory_get_version_1(context unsafe.Pointer, a, z, d, e C.int32_t) C.int32_t {
+func ext_sandbox_memory_get_version_1(context unsafe.Pointer, memoryIndex, offset,
+	bufferPtr, bufferLen C.int32_t) C.int32_t {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
-	return 0
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	memory := instanceContext.Memory().Data()
+	start, end := uint64(uint32(bufferPtr)), uint64(uint32(bufferPtr))+uint64(uint32(bufferLen))
+	if end > uint64(len(memory)) {
+		logger.Errorf("sandbox memory get buffer is out of the supervisor memory bounds")
+		return toSandboxCode(sandbox.CodeErrOutOfBounds)
+	}
+
+	err := runtimeCtx.Sandbox.MemoryGet(uint32(memoryIndex), uint32(offset), memory[start:end])
+	if err != nil {
+		logger.Errorf("failed to get sandbox memory: %s", err)
+		return toSandboxCode(sandbox.CodeErrOutOfBounds)
+	}
+
+	return toSandboxCode(sandbox.CodeOK)
 }
 
 //export ext_sandbox_memory_new_version_1
-func ext_sandbox_memory_new_version_1(context unsafe.Pointer, a, z C.int32_t) C.int32_t {
+func ext_sandbox_memory_new_version_1(context unsafe.Pointer, initial, maximum C.int32_t) C.int32_t {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
-	return 0
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	index, err := runtimeCtx.Sandbox.NewMemory(uint32(initial), uint32(maximum))
+	if err != nil {
+		logger.Errorf("failed to create sandbox memory: %s", err)
+		return toSandboxCode(sandbox.CodeErrModule)
+	}
+
+	return C.int32_t(index)
 }
 
 //export ext_sandbox_memory_set_version_1
-func ext_sandbox_memory_set_version_1(context unsafe.Pointer, a, z, d, e C.int32_t) C.int32_t {
+func ext_sandbox_memory_set_version_1(context unsafe.Pointer, memoryIndex, offset,
+	valuePtr, valueLen C.int32_t) C.int32_t {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
-	return 0
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	memory := instanceContext.Memory().Data()
+	start, end := uint64(uint32(valuePtr)), uint64(uint32(valuePtr))+uint64(uint32(valueLen))
+	if end > uint64(len(memory)) {
+		logger.Errorf("sandbox memory set value is out of the supervisor memory bounds")
+		return toSandboxCode(sandbox.CodeErrOutOfBounds)
+	}
+
+	err := runtimeCtx.Sandbox.MemorySet(uint32(memoryIndex), uint32(offset), memory[start:end])
+	if err != nil {
+		logger.Errorf("failed to set sandbox memory: %s", err)
+		return toSandboxCode(sandbox.CodeErrOutOfBounds)
+	}
+
+	return toSandboxCode(sandbox.CodeOK)
 }
 
 //export ext_sandbox_memory_teardown_version_1
-func ext_sandbox_memory_teardown_version_1(context unsafe.Pointer, a C.int32_t) {
+func ext_sandbox_memory_teardown_version_1(context unsafe.Pointer, memoryIndex C.int32_t) {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	err := runtimeCtx.Sandbox.TeardownMemory(uint32(memoryIndex))
+	if err != nil {
+		logger.Errorf("failed to teardown sandbox memory: %s", err)
+	}
+}
+
+// toSandboxCode converts the sandbox result code given to its wasm i32 representation.
+func toSandboxCode(code uint32) C.int32_t {
+	return C.int32_t(code)
 }
 
 //export ext_crypto_ed25519_generate_version_1
@@ -368,6 +517,19 @@ func ext_crypto_ed25519_sign_version_1(context unsafe.Pointer, keyTypeID, key C.
 	return C.int64_t(ret)
 }
 
+// newSignatureInfo returns the signature to verify in a batch. The signature and
+// message are copied since they are slices of the runtime memory, which can be
+// overwritten by the runtime before the batch verifies them in the background.
+func newSignatureInfo(pubKey, signature, message []byte,
+	verifyFunc crypto.SigVerifyFunc) *crypto.SignatureInfo {
+	return &crypto.SignatureInfo{
+		PubKey:     pubKey,
+		Sign:       append([]byte(nil), signature...),
+		Msg:        append([]byte(nil), message...),
+		VerifyFunc: verifyFunc,
+	}
+}
+
 //export ext_crypto_ed25519_verify_version_1
 func ext_crypto_ed25519_verify_version_1(context unsafe.Pointer, sig C.int32_t,
 	msg C.int64_t, key C.int32_t) C.int32_t {
@@ -388,13 +550,7 @@ func ext_crypto_ed25519_verify_version_1(context unsafe.Pointer, sig C.int32_t,
 	}
 
 	if sigVerifier.IsStarted() {
-		signature := crypto.SignatureInfo{
-			PubKey:     pubKey.Encode(),
-			Sign:       signature,
-			Msg:        message,
-			VerifyFunc: ed25519.VerifySignature,
-		}
-		sigVerifier.Add(&signature)
+		sigVerifier.Add(newSignatureInfo(pubKey.Encode(), signature, message, ed25519.VerifySignature))
 		return 1
 	}
 
@@ -479,13 +635,7 @@ func ext_crypto_ecdsa_verify_version_2(context unsafe.Pointer, sig C.int32_t, ms
 	}
 
 	if sigVerifier.IsStarted() {
-		signature := crypto.SignatureInfo{
-			PubKey:     pub.Encode(),
-			Sign:       signature,
-			Msg:        hash[:],
-			VerifyFunc: secp256k1.VerifySignature,
-		}
-		sigVerifier.Add(&signature)
+		sigVerifier.Add(newSignatureInfo(pub.Encode(), signature, hash[:], secp256k1.VerifySignature))
 		return C.int32_t(1)
 	}
 
@@ -711,13 +861,7 @@ func ext_crypto_sr25519_verify_version_1(context unsafe.Pointer, sig C.int32_t,
 		pub.Hex(), message, signature)
 
 	if sigVerifier.IsStarted() {
-		signature := crypto.SignatureInfo{
-			PubKey:     pub.Encode(),
-			Sign:       signature,
-			Msg:        message,
-			VerifyFunc: sr25519.VerifySignature,
-		}
-		sigVerifier.Add(&signature)
+		sigVerifier.Add(newSignatureInfo(pub.Encode(), signature, message, sr25519.VerifyDeprecatedSignature))
 		return 1
 	}
 
@@ -727,9 +871,8 @@ func ext_crypto_sr25519_verify_version_1(context unsafe.Pointer, sig C.int32_t,
 		if err != nil {
 			message += ": " + err.Error()
 		}
-		logger.Debugf(message)
-		// this fails at block 3876, which seems to be expected, based on discussions
-		return 1
+		logger.Errorf(message)
+		return 0
 	}
 
 	logger.Debug("verified sr25519 signature")
@@ -759,13 +902,7 @@ func ext_crypto_sr25519_verify_version_2(context unsafe.Pointer, sig C.int32_t,
 		pub.Hex(), message, signature)
 
 	if sigVerifier.IsStarted() {
-		signature := crypto.SignatureInfo{
-			PubKey:     pub.Encode(),
-			Sign:       signature,
-			Msg:        message,
-			VerifyFunc: sr25519.VerifySignature,
-		}
-		sigVerifier.Add(&signature)
+		sigVerifier.Add(newSignatureInfo(pub.Encode(), signature, message, sr25519.VerifySignature))
 		return 1
 	}
 
@@ -787,16 +924,34 @@ func ext_crypto_sr25519_verify_version_2(context unsafe.Pointer, sig C.int32_t,
 func ext_crypto_start_batch_verify_version_1(context unsafe.Pointer) {
 	logger.Debug("executing...")
 
-	// TODO: fix and re-enable signature verification (#1405)
-	// beginBatchVerify(context)
+	instanceContext := wasm.IntoInstanceContext(context)
+	sigVerifier := instanceContext.Data().(*runtime.Context).SigVerifier
+
+	if sigVerifier.IsStarted() {
+		logger.Error("batch verification already started")
+		return
+	}
+
+	sigVerifier.Start()
 }
 
 //export ext_crypto_finish_batch_verify_version_1
 func ext_crypto_finish_batch_verify_version_1(context unsafe.Pointer) C.int32_t {
 	logger.Debug("executing...")
 
-	// TODO: fix and re-enable signature verification (#1405)
-	// return finishBatchVerify(context)
+	instanceContext := wasm.IntoInstanceContext(context)
+	sigVerifier := instanceContext.Data().(*runtime.Context).SigVerifier
+
+	if !sigVerifier.IsStarted() {
+		logger.Error("batch verification not started")
+		return 0
+	}
+
+	if !sigVerifier.Finish() {
+		logger.Error("batch verification failed")
+		return 0
+	}
+
 	return 1
 }
 
@@ -804,12 +959,34 @@ func ext_crypto_finish_batch_verify_version_1(context unsafe.Pointer) C.int32_t
 func ext_trie_blake2_256_root_version_1(context unsafe.Pointer, dataSpan C.int64_t) C.int32_t {
 	logger.Debug("executing...")
 
+	return trieRoot(context, dataSpan, trie.V0)
+}
+
+//export ext_trie_blake2_256_root_version_2
+func ext_trie_blake2_256_root_version_2(context unsafe.Pointer,
+	dataSpan C.int64_t, version C.int32_t) C.int32_t {
+	logger.Debug("executing...")
+
+	stateVersion, err := trie.ParseVersionNumber(uint32(version))
+	if err != nil {
+		logger.Errorf("failed parsing state version: %s", err)
+		return 0
+	}
+
+	return trieRoot(context, dataSpan, stateVersion)
+}
+
+// trieRoot builds a trie from the SCALE encoded (key, value) tuples at the data
+// span given, and writes its root hash for the state trie version given to the
+// runtime memory. It returns the pointer to the root hash, or 0 on failure.
+func trieRoot(context unsafe.Pointer, dataSpan C.int64_t, version trie.Version) C.int32_t {
 	instanceContext := wasm.IntoInstanceContext(context)
 	memory := instanceContext.Memory().Data()
 	runtimeCtx := instanceContext.Data().(*runtime.Context)
 	data := asMemorySlice(instanceContext, dataSpan)
 
 	t := trie.NewEmptyTrie()
+	t.SetVersion(version)
 
 	type kv struct {
 		Key, Value []byte
@@ -848,12 +1025,35 @@ type kv struct {
 func ext_trie_blake2_256_ordered_root_version_1(context unsafe.Pointer, dataSpan C.int64_t) C.int32_t {
 	logger.Debug("executing...")
 
+	return trieOrderedRoot(context, dataSpan, trie.V0)
+}
+
+//export ext_trie_blake2_256_ordered_root_version_2
+func ext_trie_blake2_256_ordered_root_version_2(context unsafe.Pointer,
+	dataSpan C.int64_t, version C.int32_t) C.int32_t {
+	logger.Debug("executing...")
+
+	stateVersion, err := trie.ParseVersionNumber(uint32(version))
+	if err != nil {
+		logger.Errorf("failed parsing state version: %s", err)
+		return 0
+	}
+
+	return trieOrderedRoot(context, dataSpan, stateVersion)
+}
+
+// trieOrderedRoot builds a trie from the SCALE encoded values at the data span
+// given, keyed by their SCALE encoded index, and writes its root hash for the
+// state trie version given to the runtime memory. It returns the pointer to the
+// root hash, or 0 on failure.
+func trieOrderedRoot(context unsafe.Pointer, dataSpan C.int64_t, version trie.Version) C.int32_t {
 	instanceContext := wasm.IntoInstanceContext(context)
 	memory := instanceContext.Memory().Data()
 	runtimeCtx := instanceContext.Data().(*runtime.Context)
 	data := asMemorySlice(instanceContext, dataSpan)
 
 	t := trie.NewEmptyTrie()
+	t.SetVersion(version)
 	var values [][]byte
 	err := scale.Unmarshal(data, &values)
 	if err != nil {
@@ -892,13 +1092,6 @@ func ext_trie_blake2_256_ordered_root_version_1(context unsafe.Pointer, dataSpan
 	return C.int32_t(ptr)
 }
 
-//export ext_trie_blake2_256_ordered_root_version_2
-func ext_trie_blake2_256_ordered_root_version_2(context unsafe.Pointer,
-	dataSpan C.int64_t, version C.int32_t) C.int32_t {
-	// TODO: update to use state trie version 1 (#2418)
-	return ext_trie_blake2_256_ordered_root_version_1(context, dataSpan)
-}
-
 //export ext_trie_blake2_256_verify_proof_version_1
 func ext_trie_blake2_256_verify_proof_version_1(context unsafe.Pointer,
 	rootSpan C.int32_t, proofSpan, keySpan, valueSpan C.int64_t) C.int32_t {
@@ -929,6 +1122,22 @@ func ext_trie_blake2_256_verify_proof_version_1(context unsafe.Pointer,
 	return C.int32_t(1)
 }
 
+//export ext_trie_blake2_256_verify_proof_version_2
+func ext_trie_blake2_256_verify_proof_version_2(context unsafe.Pointer,
+	rootSpan C.int32_t, proofSpan, keySpan, valueSpan C.int64_t, version C.int32_t) C.int32_t {
+	logger.Debug("executing...")
+
+	_, err := trie.ParseVersionNumber(uint32(version))
+	if err != nil {
+		logger.Errorf("failed parsing state version: %s", err)
+		return C.int32_t(0)
+	}
+
+	// Note proof nodes of both state trie versions are verified the same way,
+	// since the proof node encoding indicates if the node value is hashed.
+	return ext_trie_blake2_256_verify_proof_version_1(context, rootSpan, proofSpan, keySpan, valueSpan)
+}
+
 //export ext_misc_print_hex_version_1
 func ext_misc_print_hex_version_1(context unsafe.Pointer, dataSpan C.int64_t) {
 	logger.Trace("executing...")
@@ -1131,18 +1340,34 @@ func ext_default_child_storage_root_version_1(context unsafe.Pointer,
 	childStorageKey C.int64_t) (ptrSize C.int64_t) {
 	logger.Debug("executing...")
 
-	instanceContext := wasm.IntoInstanceContext(context)
-	storage := instanceContext.Data().(*runtime.Context).Storage
+	return defaultChildStorageRoot(context, childStorageKey, trie.V0)
+}
 
-	child, err := storage.GetChild(asMemorySlice(instanceContext, childStorageKey))
+//export ext_default_child_storage_root_version_2
+func ext_default_child_storage_root_version_2(context unsafe.Pointer,
+	childStorageKey C.int64_t, version C.int32_t) (ptrSize C.int64_t) {
+	logger.Debug("executing...")
+
+	stateVersion, err := trie.ParseVersionNumber(uint32(version))
 	if err != nil {
-		logger.Errorf("failed to retrieve child: %s", err)
+		logger.Errorf("failed parsing state version: %s", err)
 		return 0
 	}
 
-	childRoot, err := child.Hash()
+	return defaultChildStorageRoot(context, childStorageKey, stateVersion)
+}
+
+// defaultChildStorageRoot writes the root hash of the child trie at the child
+// storage key span given, for the state trie version given, to the runtime memory.
+// It returns the pointer size to the root hash, or 0 on failure.
+func defaultChildStorageRoot(context unsafe.Pointer,
+	childStorageKey C.int64_t, version trie.Version) (ptrSize C.int64_t) {
+	instanceContext := wasm.IntoInstanceContext(context)
+	storage := instanceContext.Data().(*runtime.Context).Storage
+
+	childRoot, err := childStorageRoot(storage, asMemorySlice(instanceContext, childStorageKey), version)
 	if err != nil {
-		logger.Errorf("failed to encode child root: %s", err)
+		logger.Errorf("failed to compute child root: %s", err)
 		return 0
 	}
 
@@ -1155,6 +1380,25 @@ func ext_default_child_storage_root_version_1(context unsafe.Pointer,
 	return C.int64_t(root)
 }
 
+// childStorageRoot returns the root hash of the child trie at the child
+// storage key given, computed for the state trie version given.
+func childStorageRoot(storage runtime.Storage, childStorageKey []byte,
+	version trie.Version) (root common.Hash, err error) {
+	child, err := storage.GetChild(childStorageKey)
+	if err != nil {
+		return root, fmt.Errorf("getting child: %w", err)
+	}
+
+	child.SetVersion(version)
+
+	root, err = child.Hash()
+	if err != nil {
+		return root, fmt.Errorf("hashing child: %w", err)
+	}
+
+	return root, nil
+}
+
 //export ext_default_child_storage_set_version_1
 func ext_default_child_storage_set_version_1(context unsafe.Pointer,
 	childStorageKeySpan, keySpan, valueSpan C.int64_t) {
@@ -1708,12 +1952,23 @@ func ext_offchain_timestamp_version_1(_ unsafe.Pointer) C.int64_t {
 }
 
 //export ext_offchain_sleep_until_version_1
-func ext_offchain_sleep_until_version_1(_ unsafe.Pointer, deadline C.int64_t) {
+func ext_offchain_sleep_until_version_1(context unsafe.Pointer, deadline C.int64_t) {
 	logger.Trace("executing...")
 
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
 	dur := time.Until(time.UnixMilli(int64(deadline)))
-	if dur > 0 {
-		time.Sleep(dur)
+	if dur <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(dur)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-runtimeCtx.Interrupt:
 	}
 }
 
@@ -1775,18 +2030,184 @@ func ext_offchain_http_request_add_header_version_1(context unsafe.Pointer,
 	result := scale.NewResult(nil, nil)
 	resultMode := scale.OK
 
-	err := offchainReq.AddHeader(string(name), string(value))
-	if err != nil {
-		logger.Errorf("failed to add request header: %s", err)
+	if offchainReq == nil {
+		logger.Errorf("failed to add request header: request id %d not found", int16(reqID))
 		resultMode = scale.Err
+	} else {
+		err := offchainReq.AddHeader(string(name), string(value))
+		if err != nil {
+			logger.Errorf("failed to add request header: %s", err)
+			resultMode = scale.Err
+		}
+	}
+
+	err := result.Set(resultMode, nil)
+	if err != nil {
+		logger.Errorf("failed to set the result data: %s", err)
+		return C.int64_t(0)
+	}
+
+	enc, err := scale.Marshal(result)
+	if err != nil {
+		logger.Errorf("failed to scale marshal the result: %s", err)
+		return C.int64_t(0)
+	}
+
+	ptr, err := toWasmMemory(instanceContext, enc)
+	if err != nil {
+		logger.Errorf("failed to allocate result on memory: %s", err)
+		return C.int64_t(0)
+	}
+
+	return C.int64_t(ptr)
+}
+
+//export ext_offchain_http_request_write_body_version_1
+func ext_offchain_http_request_write_body_version_1(context unsafe.Pointer,
+	reqID C.int32_t, chunkSpan, deadlineSpan C.int64_t) (pointerSize C.int64_t) {
+	logger.Debug("executing...")
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	chunk := asMemorySlice(instanceContext, chunkSpan)
+
+	result := scale.NewResult(nil, offchain.HTTPError(0))
+
+	deadline, err := offchainDeadline(asMemorySlice(instanceContext, deadlineSpan))
+	if err == nil {
+		err = runtimeCtx.OffchainHTTPSet.WriteBody(int16(reqID), chunk, deadline)
+	}
+
+	if err != nil {
+		logger.Errorf("failed to write request body: %s", err)
+		err = result.Set(scale.Err, offchain.NewHTTPError(err))
+	} else {
+		err = result.Set(scale.OK, nil)
+	}
+
+	if err != nil {
+		logger.Errorf("failed to set the result data: %s", err)
+		return C.int64_t(0)
+	}
+
+	return offchainResultToWasmMemory(instanceContext, result)
+}
+
+//export ext_offchain_http_response_wait_version_1
+func ext_offchain_http_response_wait_version_1(context unsafe.Pointer,
+	idsSpan, deadlineSpan C.int64_t) (pointerSize C.int64_t) {
+	logger.Debug("executing...")
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	var ids []int16
+	err := scale.Unmarshal(asMemorySlice(instanceContext, idsSpan), &ids)
+	if err != nil {
+		logger.Errorf("failed to decode request ids: %s", err)
+		return C.int64_t(0)
+	}
+
+	deadline, err := offchainDeadline(asMemorySlice(instanceContext, deadlineSpan))
+	if err != nil {
+		logger.Errorf("failed to decode deadline: %s", err)
+		return C.int64_t(0)
+	}
+
+	statuses := runtimeCtx.OffchainHTTPSet.ResponseWait(ids, deadline)
+
+	enc, err := scale.Marshal(statuses)
+	if err != nil {
+		logger.Errorf("failed to scale marshal the request statuses: %s", err)
+		return C.int64_t(0)
+	}
+
+	ptr, err := toWasmMemory(instanceContext, enc)
+	if err != nil {
+		logger.Errorf("failed to allocate result on memory: %s", err)
+		return C.int64_t(0)
+	}
+
+	return C.int64_t(ptr)
+}
+
+//export ext_offchain_http_response_headers_version_1
+func ext_offchain_http_response_headers_version_1(context unsafe.Pointer,
+	reqID C.int32_t) (pointerSize C.int64_t) {
+	logger.Debug("executing...")
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	headers, err := runtimeCtx.OffchainHTTPSet.ResponseHeaders(int16(reqID))
+	if err != nil {
+		logger.Errorf("failed to get response headers: %s", err)
+		headers = []offchain.HTTPHeader{}
+	}
+
+	enc, err := scale.Marshal(headers)
+	if err != nil {
+		logger.Errorf("failed to scale marshal the response headers: %s", err)
+		return C.int64_t(0)
+	}
+
+	ptr, err := toWasmMemory(instanceContext, enc)
+	if err != nil {
+		logger.Errorf("failed to allocate result on memory: %s", err)
+		return C.int64_t(0)
+	}
+
+	return C.int64_t(ptr)
+}
+
+//export ext_offchain_http_response_read_body_version_1
+func ext_offchain_http_response_read_body_version_1(context unsafe.Pointer,
+	reqID C.int32_t, bufferSpan, deadlineSpan C.int64_t) (pointerSize C.int64_t) {
+	logger.Debug("executing...")
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	buffer := asMemorySlice(instanceContext, bufferSpan)
+
+	result := scale.NewResult(uint32(0), offchain.HTTPError(0))
+	var n int
+
+	deadline, err := offchainDeadline(asMemorySlice(instanceContext, deadlineSpan))
+	if err == nil {
+		n, err = runtimeCtx.OffchainHTTPSet.ReadBody(int16(reqID), buffer, deadline)
+	}
+
+	if err != nil {
+		logger.Errorf("failed to read response body: %s", err)
+		err = result.Set(scale.Err, offchain.NewHTTPError(err))
+	} else {
+		err = result.Set(scale.OK, uint32(n))
 	}
 
-	err = result.Set(resultMode, nil)
 	if err != nil {
 		logger.Errorf("failed to set the result data: %s", err)
 		return C.int64_t(0)
 	}
 
+	return offchainResultToWasmMemory(instanceContext, result)
+}
+
+// offchainDeadline decodes the optional deadline given in milliseconds since the Unix epoch.
+func offchainDeadline(encodedDeadline []byte) (deadline *time.Time, err error) {
+	var timestamp *uint64
+	err = scale.Unmarshal(encodedDeadline, &timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("decoding deadline: %w", err)
+	}
+
+	if timestamp == nil {
+		return nil, nil
+	}
+
+	t := time.UnixMilli(int64(*timestamp))
+	return &t, nil
+}
+
+// offchainResultToWasmMemory encodes the result given and copies it to the wasm memory.
+func offchainResultToWasmMemory(instanceContext wasm.InstanceContext, result scale.Result) C.int64_t {
 	enc, err := scale.Marshal(result)
 	if err != nil {
 		logger.Errorf("failed to scale marshal the result: %s", err)
@@ -2035,8 +2456,34 @@ func ext_storage_root_version_1(context unsafe.Pointer) C.int64_t {
 
 //export ext_storage_root_version_2
 func ext_storage_root_version_2(context unsafe.Pointer, version C.int32_t) C.int64_t {
-	// TODO: update to use state trie version 1 (#2418)
-	return ext_storage_root_version_1(context)
+	logger.Trace("executing...")
+
+	instanceContext := wasm.IntoInstanceContext(context)
+	storage := instanceContext.Data().(*runtime.Context).Storage
+
+	stateVersion, err := trie.ParseVersionNumber(uint32(version))
+	if err != nil {
+		logger.Errorf("failed parsing state version: %s", err)
+		return 0
+	}
+
+	storage.SetVersion(stateVersion)
+
+	root, err := storage.Root()
+	if err != nil {
+		logger.Errorf("failed to get storage root: %s", err)
+		return 0
+	}
+
+	logger.Debugf("root hash is: %s", root)
+
+	rootSpan, err := toWasmMemory(instanceContext, root[:])
+	if err != nil {
+		logger.Errorf("failed to allocate: %s", err)
+		return 0
+	}
+
+	return C.int64_t(rootSpan)
 }
 
 //export ext_storage_set_version_1
@@ -2115,6 +2562,7 @@ func importsNodeRuntime() (imports *wasm.Imports, err error) {
 		{"ext_default_child_storage_next_key_version_1", ext_default_child_storage_next_key_version_1, C.ext_default_child_storage_next_key_version_1},
 		{"ext_default_child_storage_read_version_1", ext_default_child_storage_read_version_1, C.ext_default_child_storage_read_version_1},
 		{"ext_default_child_storage_root_version_1", ext_default_child_storage_root_version_1, C.ext_default_child_storage_root_version_1},
+		{"ext_default_child_storage_root_version_2", ext_default_child_storage_root_version_2, C.ext_default_child_storage_root_version_2},
 		{"ext_default_child_storage_set_version_1", ext_default_child_storage_set_version_1, C.ext_default_child_storage_set_version_1},
 		{"ext_default_child_storage_storage_kill_version_1", ext_default_child_storage_storage_kill_version_1, C.ext_default_child_storage_storage_kill_version_1},
 		{"ext_default_child_storage_storage_kill_version_2", ext_default_child_storage_storage_kill_version_2, C.ext_default_child_storage_storage_kill_version_2},
@@ -2134,6 +2582,10 @@ func importsNodeRuntime() (imports *wasm.Imports, err error) {
 		{"ext_misc_runtime_version_version_1", ext_misc_runtime_version_version_1, C.ext_misc_runtime_version_version_1},
 		{"ext_offchain_http_request_add_header_version_1", ext_offchain_http_request_add_header_version_1, C.ext_offchain_http_request_add_header_version_1},
 		{"ext_offchain_http_request_start_version_1", ext_offchain_http_request_start_version_1, C.ext_offchain_http_request_start_version_1},
+		{"ext_offchain_http_request_write_body_version_1", ext_offchain_http_request_write_body_version_1, C.ext_offchain_http_request_write_body_version_1},
+		{"ext_offchain_http_response_headers_version_1", ext_offchain_http_response_headers_version_1, C.ext_offchain_http_response_headers_version_1},
+		{"ext_offchain_http_response_read_body_version_1", ext_offchain_http_response_read_body_version_1, C.ext_offchain_http_response_read_body_version_1},
+		{"ext_offchain_http_response_wait_version_1", ext_offchain_http_response_wait_version_1, C.ext_offchain_http_response_wait_version_1},
 		{"ext_offchain_index_set_version_1", ext_offchain_index_set_version_1, C.ext_offchain_index_set_version_1},
 		{"ext_offchain_is_validator_version_1", ext_offchain_is_validator_version_1, C.ext_offchain_is_validator_version_1},
 		{"ext_offchain_local_storage_clear_version_1", ext_offchain_local_storage_clear_version_1, C.ext_offchain_local_storage_clear_version_1},
@@ -2172,7 +2624,9 @@ func importsNodeRuntime() (imports *wasm.Imports, err error) {
 		{"ext_trie_blake2_256_ordered_root_version_1", ext_trie_blake2_256_ordered_root_version_1, C.ext_trie_blake2_256_ordered_root_version_1},
 		{"ext_trie_blake2_256_ordered_root_version_2", ext_trie_blake2_256_ordered_root_version_2, C.ext_trie_blake2_256_ordered_root_version_2},
 		{"ext_trie_blake2_256_root_version_1", ext_trie_blake2_256_root_version_1, C.ext_trie_blake2_256_root_version_1},
+		{"ext_trie_blake2_256_root_version_2", ext_trie_blake2_256_root_version_2, C.ext_trie_blake2_256_root_version_2},
 		{"ext_trie_blake2_256_verify_proof_version_1", ext_trie_blake2_256_verify_proof_version_1, C.ext_trie_blake2_256_verify_proof_version_1},
+		{"ext_trie_blake2_256_verify_proof_version_2", ext_trie_blake2_256_verify_proof_version_2, C.ext_trie_blake2_256_verify_proof_version_2},
 	} {
 		_, err = imports.AppendFunction(toRegister.importName, toRegister.implementation, toRegister.cgoPointer)
 		if err != nil {
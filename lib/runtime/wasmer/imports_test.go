@@ -6,6 +6,7 @@
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
 	"net/http"
 	"sort"
 	"testing"
@@ -367,6 +368,35 @@ func Test_ext_offchain_http_request_add_header(t *testing.T) {
 	}
 }
 
+func Test_ext_offchain_http_request_add_header_request_not_found(t *testing.T) {
+	t.Parallel()
+
+	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME)
+
+	encID, err := scale.Marshal(uint32(1))
+	require.NoError(t, err)
+
+	encHeaderKey, err := scale.Marshal("SOME_HEADER_KEY")
+	require.NoError(t, err)
+
+	encHeaderValue, err := scale.Marshal("SOME_HEADER_VALUE")
+	require.NoError(t, err)
+
+	params := append([]byte{}, encID...)
+	params = append(params, encHeaderKey...)
+	params = append(params, encHeaderValue...)
+
+	ret, err := inst.Exec("rtm_ext_offchain_http_request_add_header_version_1", params)
+	require.NoError(t, err)
+
+	gotResult := scale.NewResult(nil, nil)
+	err = scale.Unmarshal(ret, &gotResult)
+	require.NoError(t, err)
+
+	_, err = gotResult.Unwrap()
+	require.Error(t, err)
+}
+
 func Test_ext_storage_clear_prefix_version_1_hostAPI(t *testing.T) {
 	t.Parallel()
 	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME)
@@ -1188,6 +1218,101 @@ func Test_ext_crypto_sr25519_verify_version_1(t *testing.T) {
 	require.NotNil(t, read)
 }
 
+func Test_newSignatureInfo(t *testing.T) {
+	t.Parallel()
+
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	message := []byte("message")
+	signature, err := kp.Private().Sign(message)
+	require.NoError(t, err)
+
+	sigVerifier := crypto.NewSignatureVerifier(logger)
+	sigVerifier.Start()
+
+	sigVerifier.Add(newSignatureInfo(kp.Public().Encode(), signature, message, sr25519.VerifySignature))
+
+	// overwrite the memory backing the signature and message
+	// before the batch is verified.
+	for i := range signature {
+		signature[i] = 0
+	}
+	for i := range message {
+		message[i] = 0
+	}
+
+	assert.True(t, sigVerifier.Finish())
+}
+
+func Test_ext_crypto_verify_batch_memory_overwritten(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		function string
+		sign     func(message []byte) (publicKey, signature []byte)
+	}{
+		"ed25519": {
+			function: "rtm_ext_crypto_ed25519_verify_version_1",
+			sign: func(message []byte) (publicKey, signature []byte) {
+				kp, err := ed25519.GenerateKeypair()
+				require.NoError(t, err)
+				signature, err = kp.Private().Sign(message)
+				require.NoError(t, err)
+				return kp.Public().Encode(), signature
+			},
+		},
+		"sr25519": {
+			function: "rtm_ext_crypto_sr25519_verify_version_1",
+			sign: func(message []byte) (publicKey, signature []byte) {
+				kp, err := sr25519.GenerateKeypair()
+				require.NoError(t, err)
+				signature, err = kp.Private().Sign(message)
+				require.NoError(t, err)
+				return kp.Public().Encode(), signature
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME)
+
+			sigVerifier := inst.ctx.SigVerifier
+			sigVerifier.Start()
+
+			const signatures = 20
+			for i := 0; i < signatures; i++ {
+				message := []byte(fmt.Sprintf("message %d", i))
+				publicKey, signature := testCase.sign(message)
+
+				encSign, err := scale.Marshal(signature)
+				require.NoError(t, err)
+				encMsg, err := scale.Marshal(message)
+				require.NoError(t, err)
+				encPubKey, err := scale.Marshal(publicKey)
+				require.NoError(t, err)
+
+				_, err = inst.Exec(testCase.function, append(append(encSign, encMsg...), encPubKey...))
+				require.NoError(t, err)
+
+				// The signature is added to the batch and the heap is freed,
+				// so the runtime can overwrite the signature and message
+				// before they are verified in the background.
+				memory := inst.vm.Memory.Data()
+				heap := memory[runtime.DefaultHeapBase:]
+				for j := range heap {
+					heap[j] = 0xff
+				}
+			}
+
+			assert.True(t, sigVerifier.Finish())
+		})
+	}
+}
+
 func Test_ext_default_child_storage_read_version_1(t *testing.T) {
 	t.Parallel()
 	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME)
@@ -1420,6 +1545,69 @@ func Test_ext_default_child_storage_root_version_1(t *testing.T) {
 	require.Equal(t, rootHash, actualValue)
 }
 
+func Test_childStorageRoot(t *testing.T) {
+	t.Parallel()
+
+	largeValue := bytes.Repeat([]byte{1}, trie.V1MaxInlineValueSize+1)
+	newChild := func(version trie.Version) *trie.Trie {
+		child := trie.NewEmptyTrie()
+		child.SetVersion(version)
+		child.Put(testKey, largeValue)
+		return child
+	}
+
+	v0Root, err := newChild(trie.V0).Hash()
+	require.NoError(t, err)
+	v1Root, err := newChild(trie.V1).Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, v0Root, v1Root)
+
+	testCases := map[string]struct {
+		childStorageKey []byte
+		version         trie.Version
+		root            common.Hash
+		errWrapped      error
+		errMessage      string
+	}{
+		"child does not exist": {
+			childStorageKey: []byte("other"),
+			version:         trie.V0,
+			errWrapped:      trie.ErrChildTrieDoesNotExist,
+			errMessage: "getting child: child trie does not exist at key " +
+				"0x3a6368696c645f73746f726167653a64656661756c743a6f74686572",
+		},
+		"version 0": {
+			childStorageKey: testChildKey,
+			version:         trie.V0,
+			root:            v0Root,
+		},
+		"version 1": {
+			childStorageKey: testChildKey,
+			version:         trie.V1,
+			root:            v1Root,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			trieState := storage.NewTrieState(trie.NewEmptyTrie())
+			err := trieState.SetChild(testChildKey, newChild(trie.V0))
+			require.NoError(t, err)
+
+			root, err := childStorageRoot(trieState, testCase.childStorageKey, testCase.version)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.root, root)
+		})
+	}
+}
+
 func Test_ext_default_child_storage_set_version_1(t *testing.T) {
 	t.Parallel()
 	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME)
@@ -14,6 +14,7 @@
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/runtime/offchain"
+	"github.com/ChainSafe/gossamer/lib/runtime/sandbox"
 	"github.com/ChainSafe/gossamer/lib/trie"
 
 	"github.com/ChainSafe/gossamer/lib/crypto"
@@ -85,6 +86,11 @@ func NewInstanceFromFile(fp string, cfg Config) (*Instance, error) {
 func NewInstance(code []byte, cfg Config) (instance *Instance, err error) {
 	logger.Patch(log.SetLevel(cfg.LogLvl), log.SetCallerFunc(true))
 
+	logLevel := cfg.LogLvl
+	if logLevel == log.DoNotChange {
+		logLevel = runtimeLogger.Level()
+	}
+
 	wasmInstance, allocator, err := setupVM(code)
 	if err != nil {
 		return nil, fmt.Errorf("setting up VM: %w", err)
@@ -99,7 +105,10 @@ func NewInstance(code []byte, cfg Config) (instance *Instance, err error) {
 		Network:         cfg.Network,
 		Transaction:     cfg.Transaction,
 		SigVerifier:     crypto.NewSignatureVerifier(logger),
-		OffchainHTTPSet: offchain.NewHTTPSet(),
+		OffchainHTTPSet: offchain.NewHTTPSet(cfg.Interrupt),
+		Sandbox:         sandbox.NewStore(),
+		LogLevel:        logLevel,
+		Interrupt:       cfg.Interrupt,
 	}
 	wasmInstance.SetContextData(runtimeCtx)
 
@@ -263,6 +272,13 @@ func (in *Instance) SetContextStorage(s runtime.Storage) {
 	in.ctx.Storage = s
 }
 
+// ContextStorage returns the runtime's storage.
+func (in *Instance) ContextStorage() runtime.Storage {
+	in.mutex.Lock()
+	defer in.mutex.Unlock()
+	return in.ctx.Storage
+}
+
 // Stop closes the WASM instance, its imports and clears
 // the context allocator in a thread-safe way.
 func (in *Instance) Stop() {
@@ -282,6 +298,8 @@ func (in *Instance) close() {
 
 	in.vm.Close()
 	in.ctx.Allocator.Clear()
+	in.ctx.Sandbox.Close()
+	in.ctx.OffchainHTTPSet.Close()
 	in.isClosed = true
 }
 
@@ -0,0 +1,84 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package wasmer
+
+import (
+	"sync"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+)
+
+// Log level filters as expected by the runtime from ext_logging_max_level
+const (
+	logLevelFilterOff uint32 = iota
+	logLevelFilterError
+	logLevelFilterWarn
+	logLevelFilterInfo
+	logLevelFilterDebug
+	logLevelFilterTrace
+)
+
+var (
+	// runtimeLogger is the parent logger of the loggers of the runtime log targets.
+	runtimeLogger = log.NewFromGlobal(
+		log.AddContext("pkg", "runtime"),
+		log.AddContext("module", "wasm"),
+		log.SetCallerFunc(false),
+	)
+
+	targetLoggersMutex sync.Mutex
+	targetLoggers      = make(map[string]*log.Logger)
+)
+
+// getTargetLogger returns the logger for the runtime log target given.
+// Target loggers are cached since the runtime only uses a few distinct targets.
+// They log at every level, since the messages are filtered by the log level
+// of the runtime instance logging them.
+func getTargetLogger(target string) *log.Logger {
+	targetLoggersMutex.Lock()
+	defer targetLoggersMutex.Unlock()
+
+	targetLogger, ok := targetLoggers[target]
+	if !ok {
+		targetLogger = runtimeLogger.New(log.AddContext("target", target), log.SetLevel(log.Trace))
+		targetLoggers[target] = targetLogger
+	}
+	return targetLogger
+}
+
+// toLogLevelFilter converts a logger level to the runtime log level filter.
+func toLogLevelFilter(level log.Level) uint32 {
+	switch level {
+	case log.Error:
+		return logLevelFilterError
+	case log.Warn:
+		return logLevelFilterWarn
+	case log.Info:
+		return logLevelFilterInfo
+	case log.Debug:
+		return logLevelFilterDebug
+	case log.Trace:
+		return logLevelFilterTrace
+	default:
+		// the runtime never logs at the critical level
+		return logLevelFilterOff
+	}
+}
+
+// fromRuntimeLogLevel converts a log level sent by the runtime to ext_logging_log
+// to a logger level. Unknown levels are logged at the error level.
+func fromRuntimeLogLevel(level int32) log.Level {
+	switch level {
+	case 1:
+		return log.Warn
+	case 2:
+		return log.Info
+	case 3:
+		return log.Debug
+	case 4:
+		return log.Trace
+	default:
+		return log.Error
+	}
+}
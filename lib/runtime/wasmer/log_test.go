@@ -0,0 +1,72 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package wasmer
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getTargetLogger(t *testing.T) {
+	t.Parallel()
+
+	targetLogger := getTargetLogger("test_target")
+	assert.Equal(t, log.Trace, targetLogger.Level())
+	assert.Same(t, targetLogger, getTargetLogger("test_target"))
+	assert.NotSame(t, targetLogger, getTargetLogger("other_test_target"))
+}
+
+func Test_toLogLevelFilter(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		level  log.Level
+		filter uint32
+	}{
+		"critical": {level: log.Critical, filter: logLevelFilterOff},
+		"error":    {level: log.Error, filter: logLevelFilterError},
+		"warn":     {level: log.Warn, filter: logLevelFilterWarn},
+		"info":     {level: log.Info, filter: logLevelFilterInfo},
+		"debug":    {level: log.Debug, filter: logLevelFilterDebug},
+		"trace":    {level: log.Trace, filter: logLevelFilterTrace},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			filter := toLogLevelFilter(testCase.level)
+			assert.Equal(t, testCase.filter, filter)
+		})
+	}
+}
+
+func Test_fromRuntimeLogLevel(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		level    int32
+		logLevel log.Level
+	}{
+		"error":   {level: 0, logLevel: log.Error},
+		"warn":    {level: 1, logLevel: log.Warn},
+		"info":    {level: 2, logLevel: log.Info},
+		"debug":   {level: 3, logLevel: log.Debug},
+		"trace":   {level: 4, logLevel: log.Trace},
+		"unknown": {level: 5, logLevel: log.Error},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			logLevel := fromRuntimeLogLevel(testCase.level)
+			assert.Equal(t, testCase.logLevel, logLevel)
+		})
+	}
+}
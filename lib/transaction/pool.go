@@ -63,12 +63,14 @@ func (p *Pool) Insert(tx *ValidTransaction) common.Hash {
 	return hash
 }
 
-// Remove removes a transaction from the pool
-func (p *Pool) Remove(hash common.Hash) {
+// Remove removes a transaction from the pool and returns true if it was present in the pool
+func (p *Pool) Remove(hash common.Hash) (removed bool) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	_, removed = p.transactions[hash]
 	delete(p.transactions, hash)
 	transactionPoolGauge.Set(float64(len(p.transactions)))
+	return removed
 }
 
 // Len return the current length of the pool
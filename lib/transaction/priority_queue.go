@@ -98,17 +98,23 @@ func NewPriorityQueue() *PriorityQueue {
 
 // RemoveExtrinsic removes an extrinsic from the queue
 func (spq *PriorityQueue) RemoveExtrinsic(ext types.Extrinsic) {
+	spq.RemoveExtrinsicByHash(ext.Hash())
+}
+
+// RemoveExtrinsicByHash removes the extrinsic with the given hash from the queue
+// and returns true if it was present in the queue.
+func (spq *PriorityQueue) RemoveExtrinsicByHash(hash common.Hash) (removed bool) {
 	spq.Lock()
 	defer spq.Unlock()
 
-	hash := ext.Hash()
 	item, ok := spq.txs[hash]
 	if !ok {
-		return
+		return false
 	}
 
 	heap.Remove(&spq.pq, item.index)
 	delete(spq.txs, hash)
+	return true
 }
 
 // Exists returns true if a hash is in the txs map, false otherwise
@@ -23,6 +23,8 @@ type Database interface {
 // Store stores each trie node in the database,
 // where the key is the hash of the encoded node
 // and the value is the encoded node.
+// Values hashed in their node encoding, as per the
+// state trie version 1, are stored keyed by their hash.
 // Generally, this will only be used for the genesis trie.
 func (t *Trie) Store(db chaindb.Database) error {
 	for _, v := range t.childTries {
@@ -46,11 +48,12 @@ func (t *Trie) storeNode(db chaindb.Batch, n *Node) (err error) {
 		return nil
 	}
 
+	maxInlineValue := t.version.MaxInlineValue()
 	var encoding, hash []byte
 	if n == t.root {
-		encoding, hash, err = n.EncodeAndHashRoot()
+		encoding, hash, err = n.EncodeAndHashRoot(maxInlineValue)
 	} else {
-		encoding, hash, err = n.EncodeAndHash()
+		encoding, hash, err = n.EncodeAndHash(maxInlineValue)
 	}
 	if err != nil {
 		return err
@@ -61,6 +64,11 @@ func (t *Trie) storeNode(db chaindb.Batch, n *Node) (err error) {
 		return err
 	}
 
+	err = storeHashedValue(db, n, encoding)
+	if err != nil {
+		return err
+	}
+
 	if n.Kind() == node.Branch {
 		for _, child := range n.Children {
 			if child == nil {
@@ -101,6 +109,11 @@ func (t *Trie) Load(db Database, rootHash common.Hash) error {
 		return fmt.Errorf("cannot decode root node: %w", err)
 	}
 
+	err = loadValue(db, root)
+	if err != nil {
+		return fmt.Errorf("loading root node value: %w", err)
+	}
+
 	t.root = root
 	t.root.SetClean()
 	t.root.Encoding = encodedNode
@@ -125,7 +138,7 @@ func (t *Trie) loadNode(db Database, n *Node) error {
 		if len(merkleValue) == 0 {
 			// node has already been loaded inline
 			// just set encoding + hash digest
-			_, err := child.CalculateMerkleValue()
+			_, err := child.CalculateMerkleValue(t.version.MaxInlineValue())
 			if err != nil {
 				return fmt.Errorf("merkle value: %w", err)
 			}
@@ -144,6 +157,11 @@ func (t *Trie) loadNode(db Database, n *Node) error {
 			return fmt.Errorf("decoding node with Merkle value 0x%x: %w", merkleValue, err)
 		}
 
+		err = loadValue(db, decodedNode)
+		if err != nil {
+			return fmt.Errorf("loading value of node with Merkle value 0x%x: %w", merkleValue, err)
+		}
+
 		decodedNode.SetClean()
 		decodedNode.Encoding = encodedNode
 		decodedNode.MerkleValue = merkleValue
@@ -168,6 +186,7 @@ func (t *Trie) loadNode(db Database, n *Node) error {
 
 	for _, key := range t.GetKeysWithPrefix(ChildStorageKeyPrefix) {
 		childTrie := NewEmptyTrie()
+		childTrie.SetVersion(t.version)
 		value := t.Get(key)
 		rootHash := common.BytesToHash(value)
 		err := childTrie.Load(db, rootHash)
@@ -238,7 +257,7 @@ func getFromDBAtNode(db chaindb.Database, n *Node, key []byte) (
 	value []byte, err error) {
 	if n.Kind() == node.Leaf {
 		if bytes.Equal(n.Key, key) {
-			return n.SubValue, nil
+			return getValue(db, n)
 		}
 		return nil, nil
 	}
@@ -246,7 +265,7 @@ func getFromDBAtNode(db chaindb.Database, n *Node, key []byte) (
 	branch := n
 	// Key is equal to the key of this branch or is empty
 	if len(key) == 0 || bytes.Equal(branch.Key, key) {
-		return branch.SubValue, nil
+		return getValue(db, branch)
 	}
 
 	commonPrefixLength := lenCommonPrefix(branch.Key, key)
@@ -306,11 +325,12 @@ func (t *Trie) writeDirtyNode(db chaindb.Batch, n *Node) (err error) {
 		return nil
 	}
 
+	maxInlineValue := t.version.MaxInlineValue()
 	var encoding, merkleValue []byte
 	if n == t.root {
-		encoding, merkleValue, err = n.EncodeAndHashRoot()
+		encoding, merkleValue, err = n.EncodeAndHashRoot(maxInlineValue)
 	} else {
-		encoding, merkleValue, err = n.EncodeAndHash()
+		encoding, merkleValue, err = n.EncodeAndHash(maxInlineValue)
 	}
 	if err != nil {
 		return fmt.Errorf(
@@ -325,6 +345,13 @@ func (t *Trie) writeDirtyNode(db chaindb.Batch, n *Node) (err error) {
 			merkleValue, err)
 	}
 
+	err = storeHashedValue(db, n, encoding)
+	if err != nil {
+		return fmt.Errorf(
+			"putting value of node with Merkle value 0x%x in database: %w",
+			merkleValue, err)
+	}
+
 	if n.Kind() != node.Branch {
 		n.SetClean()
 		return nil
@@ -369,11 +396,12 @@ func (t *Trie) getInsertedNodeHashesAtNode(n *Node, merkleValues map[string]stru
 		return nil
 	}
 
+	maxInlineValue := t.version.MaxInlineValue()
 	var merkleValue []byte
 	if n == t.root {
-		merkleValue, err = n.CalculateRootMerkleValue()
+		merkleValue, err = n.CalculateRootMerkleValue(maxInlineValue)
 	} else {
-		merkleValue, err = n.CalculateMerkleValue()
+		merkleValue, err = n.CalculateMerkleValue(maxInlineValue)
 	}
 	if err != nil {
 		return fmt.Errorf(
@@ -412,3 +440,56 @@ func (t *Trie) GetDeletedMerkleValues() (merkleValues map[string]struct{}) {
 	}
 	return merkleValues
 }
+
+// storeHashedValue puts the value of the node in the database, keyed
+// by its Blake2b hash, if the node encoding given contains only the
+// hash of the value, as for the state trie version 1.
+// Hashed values are not reported in the inserted and deleted Merkle
+// values given to the online pruner, so they are never pruned: the
+// same value can be referenced by nodes of different keys, tries and
+// blocks, and the pruner does not keep track of references.
+func storeHashedValue(db chaindb.Batch, n *Node, encoding []byte) (err error) {
+	if n.IsHashedValue || !node.HasHashedValue(encoding) {
+		return nil
+	}
+
+	valueHash, err := common.Blake2bHash(n.SubValue)
+	if err != nil {
+		return fmt.Errorf("hashing value: %w", err)
+	}
+
+	return db.Put(valueHash.ToBytes(), n.SubValue)
+}
+
+// loadValue replaces the hash of the value of a node decoded from the
+// database with the value itself, read from the database.
+func loadValue(db Database, n *Node) (err error) {
+	if !n.IsHashedValue {
+		return nil
+	}
+
+	value, err := getValue(db, n)
+	if err != nil {
+		return err
+	}
+
+	n.SubValue = value
+	n.IsHashedValue = false
+	return nil
+}
+
+// getValue returns the value of a node, reading it from the
+// database if the node only contains the hash of the value.
+func getValue(db Database, n *Node) (value []byte, err error) {
+	if !n.IsHashedValue {
+		return n.SubValue, nil
+	}
+
+	value, err = db.Get(n.SubValue)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find value with hash 0x%x in database: %w",
+			n.SubValue, err)
+	}
+
+	return value, nil
+}
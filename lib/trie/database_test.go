@@ -4,9 +4,11 @@
 package trie
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/ChainSafe/chaindb"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -38,6 +40,93 @@ func Test_Trie_Store_Load(t *testing.T) {
 	assert.Equal(t, trie.String(), trieFromDB.String())
 }
 
+func Test_Trie_Store_Load_V1(t *testing.T) {
+	t.Parallel()
+
+	keyValues := map[string][]byte{
+		"small":       []byte("value"),
+		"large":       bytes.Repeat([]byte{1}, V1MaxInlineValueSize+1),
+		"large_other": bytes.Repeat([]byte{2}, V1MaxInlineValueSize+10),
+	}
+
+	trieV0 := NewEmptyTrie()
+	trie := NewEmptyTrie()
+	trie.SetVersion(V1)
+	for key, value := range keyValues {
+		trieV0.Put([]byte(key), value)
+		trie.Put([]byte(key), value)
+	}
+
+	rootHash := trie.MustHash()
+	assert.NotEqual(t, trieV0.MustHash(), rootHash)
+
+	db := newTestDB(t)
+	err := trie.Store(db)
+	require.NoError(t, err)
+
+	trieFromDB := NewEmptyTrie()
+	err = trieFromDB.Load(db, rootHash)
+	require.NoError(t, err)
+	assert.Equal(t, rootHash, trieFromDB.MustHash())
+
+	for key, expectedValue := range keyValues {
+		value := trieFromDB.Get([]byte(key))
+		assert.Equal(t, expectedValue, value)
+
+		value, err = GetFromDB(db, rootHash, []byte(key))
+		require.NoError(t, err)
+		assert.Equal(t, expectedValue, value)
+	}
+}
+
+func Test_Trie_HashedValues_NotPruned(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("key")
+	oldValue := bytes.Repeat([]byte{1}, V1MaxInlineValueSize+1)
+	newValue := bytes.Repeat([]byte{2}, V1MaxInlineValueSize+1)
+	oldValueHash := common.MustBlake2bHash(oldValue)
+	newValueHash := common.MustBlake2bHash(newValue)
+
+	db := newTestDB(t)
+	trie := NewEmptyTrie()
+	trie.SetVersion(V1)
+	trie.Put(key, oldValue)
+	err := trie.WriteDirty(db)
+	require.NoError(t, err)
+
+	trie = trie.Snapshot()
+	trie.Put(key, newValue)
+
+	insertedMerkleValues, err := trie.GetInsertedMerkleValues()
+	require.NoError(t, err)
+	assert.NotContains(t, insertedMerkleValues, string(newValueHash.ToBytes()))
+
+	err = trie.WriteDirty(db)
+	require.NoError(t, err)
+
+	deletedMerkleValues := trie.GetDeletedMerkleValues()
+	require.NotEmpty(t, deletedMerkleValues)
+	assert.NotContains(t, deletedMerkleValues, string(oldValueHash.ToBytes()))
+
+	// Prune the deleted nodes as the online pruner would do.
+	batch := db.NewBatch()
+	for merkleValue := range deletedMerkleValues {
+		err = batch.Del([]byte(merkleValue))
+		require.NoError(t, err)
+	}
+	err = batch.Flush()
+	require.NoError(t, err)
+
+	value, err := db.Get(oldValueHash.ToBytes())
+	require.NoError(t, err)
+	assert.Equal(t, oldValue, value)
+
+	value, err = GetFromDB(db, trie.MustHash(), key)
+	require.NoError(t, err)
+	assert.Equal(t, newValue, value)
+}
+
 func Test_Trie_WriteDirty_Put(t *testing.T) {
 	t.Parallel()
 
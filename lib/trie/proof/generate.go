@@ -29,7 +29,24 @@ type Database interface {
 // for the trie corresponding to the root hash given, and for
 // the slice of (Little Endian) full keys given. The database given
 // is used to load the trie using the root hash given.
+// For nodes containing only the hash of their value, as for the
+// state trie version 1, the value of each key given is added
+// to the proof as well.
 func Generate(rootHash []byte, fullKeys [][]byte, database Database) (
+	encodedProofNodes [][]byte, err error) {
+	return generate(rootHash, fullKeys, database, false)
+}
+
+// GenerateWithAbsentKeys is like Generate, except the full keys given
+// may be absent from the trie. For each absent key, the nodes on the path
+// to where the key would be in the trie are added to the proof, so the
+// proof proves the absence of the key.
+func GenerateWithAbsentKeys(rootHash []byte, fullKeys [][]byte, database Database) (
+	encodedProofNodes [][]byte, err error) {
+	return generate(rootHash, fullKeys, database, true)
+}
+
+func generate(rootHash []byte, fullKeys [][]byte, database Database, absentKeysAllowed bool) (
 	encodedProofNodes [][]byte, err error) {
 	trie := trie.NewEmptyTrie()
 	if err := trie.Load(database, common.BytesToHash(rootHash)); err != nil {
@@ -43,7 +60,7 @@ func Generate(rootHash []byte, fullKeys [][]byte, database Database) (
 	merkleValuesSeen := make(map[string]struct{})
 	for _, fullKey := range fullKeys {
 		fullKeyNibbles := codec.KeyLEToNibbles(fullKey)
-		newEncodedProofNodes, err := walkRoot(rootNode, fullKeyNibbles)
+		newEncodedProofNodes, err := walkRoot(rootNode, fullKeyNibbles, absentKeysAllowed)
 		if err != nil {
 			// Note we wrap the full key context here since walk is recursive and
 			// may not be aware of the initial full key.
@@ -71,10 +88,14 @@ func Generate(rootHash []byte, fullKeys [][]byte, database Database) (
 	return encodedProofNodes, nil
 }
 
-func walkRoot(root *node.Node, fullKey []byte) (
+// walkRoot returns the encoded proof nodes on the path to the full key given,
+// starting from the root node given. If absentKeysAllowed is true, the
+// encoded proof nodes on the path to where the key would be are returned
+// if the key is absent, instead of the error ErrKeyNotFound.
+func walkRoot(root *node.Node, fullKey []byte, absentKeysAllowed bool) (
 	encodedProofNodes [][]byte, err error) {
 	if root == nil {
-		if len(fullKey) == 0 {
+		if len(fullKey) == 0 || absentKeysAllowed {
 			return nil, nil
 		}
 		return nil, ErrKeyNotFound
@@ -83,7 +104,7 @@ func walkRoot(root *node.Node, fullKey []byte) (
 	// Note we do not use sync.Pool buffers since we would have
 	// to copy it so it persists in encodedProofNodes.
 	encodingBuffer := bytes.NewBuffer(nil)
-	err = root.Encode(encodingBuffer)
+	err = root.Encode(encodingBuffer, node.NoMaxInlineValueSize)
 	if err != nil {
 		return nil, fmt.Errorf("encode node: %w", err)
 	}
@@ -91,15 +112,19 @@ func walkRoot(root *node.Node, fullKey []byte) (
 
 	nodeFound := len(fullKey) == 0 || bytes.Equal(root.Key, fullKey)
 	if nodeFound {
+		if node.HasHashedValue(encodingBuffer.Bytes()) {
+			encodedProofNodes = append(encodedProofNodes, root.SubValue)
+		}
 		return encodedProofNodes, nil
 	}
 
-	if root.Kind() == node.Leaf && !nodeFound {
-		return nil, ErrKeyNotFound
-	}
-
 	nodeIsDeeper := len(fullKey) > len(root.Key)
-	if !nodeIsDeeper {
+	keyIsAbsent := root.Kind() == node.Leaf || !nodeIsDeeper ||
+		!bytes.HasPrefix(fullKey, root.Key)
+	if keyIsAbsent {
+		if absentKeysAllowed {
+			return encodedProofNodes, nil
+		}
 		return nil, ErrKeyNotFound
 	}
 
@@ -107,7 +132,7 @@ func walkRoot(root *node.Node, fullKey []byte) (
 	childIndex := fullKey[commonLength]
 	nextChild := root.Children[childIndex]
 	nextFullKey := fullKey[commonLength+1:]
-	deeperEncodedProofNodes, err := walk(nextChild, nextFullKey)
+	deeperEncodedProofNodes, err := walk(nextChild, nextFullKey, absentKeysAllowed)
 	if err != nil {
 		return nil, err // note: do not wrap since this is recursive
 	}
@@ -116,10 +141,10 @@ func walkRoot(root *node.Node, fullKey []byte) (
 	return encodedProofNodes, nil
 }
 
-func walk(parent *node.Node, fullKey []byte) (
+func walk(parent *node.Node, fullKey []byte, absentKeysAllowed bool) (
 	encodedProofNodes [][]byte, err error) {
 	if parent == nil {
-		if len(fullKey) == 0 {
+		if len(fullKey) == 0 || absentKeysAllowed {
 			return nil, nil
 		}
 		return nil, ErrKeyNotFound
@@ -128,7 +153,7 @@ func walk(parent *node.Node, fullKey []byte) (
 	// Note we do not use sync.Pool buffers since we would have
 	// to copy it so it persists in encodedProofNodes.
 	encodingBuffer := bytes.NewBuffer(nil)
-	err = parent.Encode(encodingBuffer)
+	err = parent.Encode(encodingBuffer, node.NoMaxInlineValueSize)
 	if err != nil {
 		return nil, fmt.Errorf("encode node: %w", err)
 	}
@@ -143,15 +168,19 @@ func walk(parent *node.Node, fullKey []byte) (
 
 	nodeFound := len(fullKey) == 0 || bytes.Equal(parent.Key, fullKey)
 	if nodeFound {
+		if node.HasHashedValue(encodingBuffer.Bytes()) {
+			encodedProofNodes = append(encodedProofNodes, parent.SubValue)
+		}
 		return encodedProofNodes, nil
 	}
 
-	if parent.Kind() == node.Leaf && !nodeFound {
-		return nil, ErrKeyNotFound
-	}
-
 	nodeIsDeeper := len(fullKey) > len(parent.Key)
-	if !nodeIsDeeper {
+	keyIsAbsent := parent.Kind() == node.Leaf || !nodeIsDeeper ||
+		!bytes.HasPrefix(fullKey, parent.Key)
+	if keyIsAbsent {
+		if absentKeysAllowed {
+			return encodedProofNodes, nil
+		}
 		return nil, ErrKeyNotFound
 	}
 
@@ -159,7 +188,7 @@ func walk(parent *node.Node, fullKey []byte) (
 	childIndex := fullKey[commonLength]
 	nextChild := parent.Children[childIndex]
 	nextFullKey := fullKey[commonLength+1:]
-	deeperEncodedProofNodes, err := walk(nextChild, nextFullKey)
+	deeperEncodedProofNodes, err := walk(nextChild, nextFullKey, absentKeysAllowed)
 	if err != nil {
 		return nil, err // note: do not wrap since this is recursive
 	}
@@ -275,6 +275,7 @@ func Test_walkRoot(t *testing.T) {
 	testCases := map[string]struct {
 		parent            *node.Node
 		fullKey           []byte // nibbles
+		absentKeysAllowed bool
 		encodedProofNodes [][]byte
 		errWrapped        error
 		errMessage        string
@@ -285,6 +286,61 @@ func Test_walkRoot(t *testing.T) {
 			errWrapped: ErrKeyNotFound,
 			errMessage: "key not found",
 		},
+		"nil parent and absent key allowed": {
+			fullKey:           []byte{1},
+			absentKeysAllowed: true,
+		},
+		"parent leaf and absent key allowed": {
+			parent: &node.Node{
+				Key:      []byte{1, 2},
+				SubValue: []byte{1},
+			},
+			fullKey:           []byte{1, 3},
+			absentKeysAllowed: true,
+			encodedProofNodes: [][]byte{encodeNode(t, node.Node{
+				Key:      []byte{1, 2},
+				SubValue: []byte{1},
+			})},
+		},
+		"branch key not prefix of full key": {
+			parent: &node.Node{
+				Key:      []byte{1, 2},
+				SubValue: []byte{1},
+				Children: padRightChildren([]*node.Node{
+					{
+						Key:      []byte{4},
+						SubValue: []byte{5},
+					},
+				}),
+			},
+			fullKey:    []byte{1, 3, 0, 4},
+			errWrapped: ErrKeyNotFound,
+			errMessage: "key not found",
+		},
+		"branch and absent key allowed": {
+			parent: &node.Node{
+				Key:      []byte{1, 2},
+				SubValue: []byte{1},
+				Children: padRightChildren([]*node.Node{
+					{
+						Key:      []byte{4},
+						SubValue: []byte{5},
+					},
+				}),
+			},
+			fullKey:           []byte{1, 2, 1, 4},
+			absentKeysAllowed: true,
+			encodedProofNodes: [][]byte{encodeNode(t, node.Node{
+				Key:      []byte{1, 2},
+				SubValue: []byte{1},
+				Children: padRightChildren([]*node.Node{
+					{
+						Key:      []byte{4},
+						SubValue: []byte{5},
+					},
+				}),
+			})},
+		},
 		// The parent encode error cannot be triggered here
 		// since it can only be caused by a buffer.Write error.
 		"parent leaf and empty full key": {
@@ -506,7 +562,7 @@ func Test_walkRoot(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			encodedProofNodes, err := walkRoot(testCase.parent, testCase.fullKey)
+			encodedProofNodes, err := walkRoot(testCase.parent, testCase.fullKey, testCase.absentKeysAllowed)
 
 			assert.ErrorIs(t, err, testCase.errWrapped)
 			if testCase.errWrapped != nil {
@@ -526,6 +582,7 @@ func Test_walk(t *testing.T) {
 	testCases := map[string]struct {
 		parent            *node.Node
 		fullKey           []byte // nibbles
+		absentKeysAllowed bool
 		encodedProofNodes [][]byte
 		errWrapped        error
 		errMessage        string
@@ -536,6 +593,49 @@ func Test_walk(t *testing.T) {
 			errWrapped: ErrKeyNotFound,
 			errMessage: "key not found",
 		},
+		"nil parent and absent key allowed": {
+			fullKey:           []byte{1},
+			absentKeysAllowed: true,
+		},
+		"parent leaf and absent key allowed": {
+			parent: &node.Node{
+				Key:      []byte{1, 2},
+				SubValue: []byte{1},
+			},
+			fullKey:           []byte{1, 3},
+			absentKeysAllowed: true,
+			// the leaf encoding is inlined in its parent since it is less than 32 bytes
+		},
+		"branch key not prefix of full key": {
+			parent: &node.Node{
+				Key:      []byte{1, 2},
+				SubValue: []byte{1},
+				Children: padRightChildren([]*node.Node{
+					{
+						Key:      []byte{4},
+						SubValue: []byte{5},
+					},
+				}),
+			},
+			fullKey:    []byte{1, 3, 0, 4},
+			errWrapped: ErrKeyNotFound,
+			errMessage: "key not found",
+		},
+		"branch and absent key allowed": {
+			parent: &node.Node{
+				Key:      []byte{1, 2},
+				SubValue: []byte{1},
+				Children: padRightChildren([]*node.Node{
+					{
+						Key:      []byte{4},
+						SubValue: []byte{5},
+					},
+				}),
+			},
+			fullKey:           []byte{1, 2, 1, 4},
+			absentKeysAllowed: true,
+			// the branch encoding is inlined in its parent since it is less than 32 bytes
+		},
 		// The parent encode error cannot be triggered here
 		// since it can only be caused by a buffer.Write error.
 		"parent leaf and empty full key": {
@@ -757,7 +857,7 @@ func Test_walk(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			encodedProofNodes, err := walk(testCase.parent, testCase.fullKey)
+			encodedProofNodes, err := walk(testCase.parent, testCase.fullKey, testCase.absentKeysAllowed)
 
 			assert.ErrorIs(t, err, testCase.errWrapped)
 			if testCase.errWrapped != nil {
@@ -845,12 +945,12 @@ func Benchmark_walkRoot(b *testing.B) {
 	longestKeyNibbles := codec.KeyLEToNibbles(longestKeyLE)
 
 	rootNode := trie.RootNode()
-	encodedProofNodes, err := walkRoot(rootNode, longestKeyNibbles)
+	encodedProofNodes, err := walkRoot(rootNode, longestKeyNibbles, false)
 	require.NoError(b, err)
 	require.Equal(b, len(encodedProofNodes), trieDepth)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = walkRoot(rootNode, longestKeyNibbles)
+		_, _ = walkRoot(rootNode, longestKeyNibbles, false)
 	}
 }
@@ -20,10 +20,10 @@ func padRightChildren(slice []*node.Node) (paddedSlice []*node.Node) {
 	return paddedSlice
 }
 
-func encodeNode(t *testing.T, node node.Node) (encoded []byte) {
+func encodeNode(t *testing.T, n node.Node) (encoded []byte) {
 	t.Helper()
 	buffer := bytes.NewBuffer(nil)
-	err := node.Encode(buffer)
+	err := n.Encode(buffer, node.NoMaxInlineValueSize)
 	require.NoError(t, err)
 	return buffer.Bytes()
 }
@@ -4,6 +4,7 @@
 package proof
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
@@ -50,3 +51,129 @@ func Test_Generate_Verify(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func Test_Generate_Verify_V1(t *testing.T) {
+	t.Parallel()
+
+	keyValues := map[string][]byte{
+		"cat":       []byte("small"),
+		"catapulta": bytes.Repeat([]byte{1}, trie.V1MaxInlineValueSize+1),
+		"dog":       bytes.Repeat([]byte{2}, trie.V1MaxInlineValueSize+10),
+	}
+	const version = trie.V1
+
+	trie := trie.NewEmptyTrie()
+	trie.SetVersion(version)
+	for key, value := range keyValues {
+		trie.Put([]byte(key), value)
+	}
+
+	rootHash, err := trie.Hash()
+	require.NoError(t, err)
+
+	database, err := chaindb.NewBadgerDB(&chaindb.Config{
+		InMemory: true,
+	})
+	require.NoError(t, err)
+	err = trie.Store(database)
+	require.NoError(t, err)
+
+	for key, expectedValue := range keyValues {
+		fullKeys := [][]byte{[]byte(key)}
+		proof, err := Generate(rootHash.ToBytes(), fullKeys, database)
+		require.NoError(t, err)
+
+		err = Verify(proof, rootHash.ToBytes(), []byte(key), expectedValue)
+		require.NoError(t, err)
+	}
+}
+
+func Test_Generate_VerifyEntries(t *testing.T) {
+	t.Parallel()
+
+	keys := [][]byte{
+		[]byte("cat"),
+		[]byte("catapulta"),
+		[]byte("catapora"),
+		[]byte("dog"),
+		[]byte("doguinho"),
+	}
+
+	trie := trie.NewEmptyTrie()
+
+	values := make([][]byte, len(keys))
+	for i, key := range keys {
+		values[i] = []byte(fmt.Sprintf("%x-%d", key, i))
+		trie.Put(key, values[i])
+	}
+
+	rootHash, err := trie.Hash()
+	require.NoError(t, err)
+
+	database, err := chaindb.NewBadgerDB(&chaindb.Config{
+		InMemory: true,
+	})
+	require.NoError(t, err)
+	err = trie.Store(database)
+	require.NoError(t, err)
+
+	proof, err := Generate(rootHash.ToBytes(), keys, database)
+	require.NoError(t, err)
+
+	err = VerifyEntries(proof, rootHash.ToBytes(), keys, values)
+	require.NoError(t, err)
+
+	err = VerifyEntries(proof, rootHash.ToBytes(), keys, values[1:])
+	require.ErrorIs(t, err, ErrKeysValuesLengthMismatch)
+
+	badValues := append([][]byte{[]byte("bad")}, values[1:]...)
+	err = VerifyEntries(proof, rootHash.ToBytes(), keys, badValues)
+	require.ErrorIs(t, err, ErrValueMismatchProofTrie)
+
+	err = VerifyEntries(proof, rootHash.ToBytes(), [][]byte{[]byte("cow")}, [][]byte{nil})
+	require.ErrorIs(t, err, ErrKeyNotFoundInProofTrie)
+}
+
+func Test_GenerateWithAbsentKeys_Verify(t *testing.T) {
+	t.Parallel()
+
+	keys := []string{
+		"cat",
+		"catapulta",
+		"catapora",
+		"dog",
+		"doguinho",
+	}
+
+	trie := trie.NewEmptyTrie()
+	for _, key := range keys {
+		trie.Put([]byte(key), []byte(key))
+	}
+
+	rootHash, err := trie.Hash()
+	require.NoError(t, err)
+
+	database, err := chaindb.NewBadgerDB(&chaindb.Config{
+		InMemory: true,
+	})
+	require.NoError(t, err)
+	err = trie.Store(database)
+	require.NoError(t, err)
+
+	fullKeys := [][]byte{[]byte("catapultb"), []byte("dog"), []byte("zebra")}
+
+	_, err = Generate(rootHash.ToBytes(), fullKeys, database)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	proof, err := GenerateWithAbsentKeys(rootHash.ToBytes(), fullKeys, database)
+	require.NoError(t, err)
+
+	err = Verify(proof, rootHash.ToBytes(), []byte("dog"), []byte("dog"))
+	require.NoError(t, err)
+
+	// the proof trie contains the path to the absent keys, proving their absence
+	for _, absentKey := range []string{"catapultb", "zebra"} {
+		err = Verify(proof, rootHash.ToBytes(), []byte(absentKey), nil)
+		require.ErrorIs(t, err, ErrKeyNotFoundInProofTrie)
+	}
+}
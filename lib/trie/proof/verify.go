@@ -16,8 +16,9 @@
 )
 
 var (
-	ErrKeyNotFoundInProofTrie = errors.New("key not found in proof trie")
-	ErrValueMismatchProofTrie = errors.New("value found in proof trie does not match")
+	ErrKeyNotFoundInProofTrie   = errors.New("key not found in proof trie")
+	ErrValueMismatchProofTrie   = errors.New("value found in proof trie does not match")
+	ErrKeysValuesLengthMismatch = errors.New("keys and values lengths do not match")
 )
 
 // Verify verifies a given key and value belongs to the trie by creating
@@ -46,6 +47,39 @@ func Verify(encodedProofNodes [][]byte, rootHash, key, value []byte) (err error)
 	return nil
 }
 
+// VerifyEntries verifies the given keys and values all belong to the trie
+// by creating a single proof trie based on the encoded proof nodes given.
+// The keys and values slices must have the same length, and the values
+// are compared only if they are not empty, as for Verify.
+// A nil error is returned on success.
+func VerifyEntries(encodedProofNodes [][]byte, rootHash []byte, keys, values [][]byte) (err error) {
+	if len(keys) != len(values) {
+		return fmt.Errorf("%w: %d keys and %d values",
+			ErrKeysValuesLengthMismatch, len(keys), len(values))
+	}
+
+	proofTrie, err := buildTrie(encodedProofNodes, rootHash)
+	if err != nil {
+		return fmt.Errorf("building trie from proof encoded nodes: %w", err)
+	}
+
+	for i, key := range keys {
+		proofTrieValue := proofTrie.Get(key)
+		if proofTrieValue == nil {
+			return fmt.Errorf("%w: %s in proof trie for root hash 0x%x",
+				ErrKeyNotFoundInProofTrie, bytesToString(key), rootHash)
+		}
+
+		value := values[i]
+		if len(value) > 0 && !bytes.Equal(value, proofTrieValue) {
+			return fmt.Errorf("%w: expected value %s but got value %s from proof trie for key %s",
+				ErrValueMismatchProofTrie, bytesToString(value), bytesToString(proofTrieValue), bytesToString(key))
+		}
+	}
+
+	return nil
+}
+
 var (
 	ErrEmptyProof       = errors.New("proof slice empty")
 	ErrRootNodeNotFound = errors.New("root node not found in proof")
@@ -77,6 +111,7 @@ func buildTrie(encodedProofNodes [][]byte, rootHash []byte) (t *trie.Trie, err e
 		// - trie root node
 		// - child trie root node
 		// - child node with an encoding larger than 32 bytes
+		// - node value hashed in its node, as for the state trie version 1
 		// In all cases, their Merkle value is the encoding hash digest,
 		// so we use MerkleValueRoot to force hashing the node in case
 		// it is a root node smaller or equal to 32 bytes.
@@ -110,6 +145,8 @@ func buildTrie(encodedProofNodes [][]byte, rootHash []byte) (t *trie.Trie, err e
 			ErrRootNodeNotFound, rootHash, strings.Join(proofHashDigests, ", "))
 	}
 
+	loadHashedValue(digestToEncoding, root)
+
 	err = loadProof(digestToEncoding, root)
 	if err != nil {
 		return nil, fmt.Errorf("loading proof: %w", err)
@@ -154,6 +191,8 @@ func loadProof(digestToEncoding map[string][]byte, n *node.Node) (err error) {
 				merkleValue, err)
 		}
 
+		loadHashedValue(digestToEncoding, child)
+
 		branch.Children[i] = child
 		branch.Descendants += child.Descendants
 		err = loadProof(digestToEncoding, child)
@@ -165,6 +204,23 @@ func loadProof(digestToEncoding map[string][]byte, n *node.Node) (err error) {
 	return nil
 }
 
+// loadHashedValue sets the value of the node given from the map from
+// hash digest to encoding, if the node contains only the hash of its
+// value and this one is part of the proof.
+func loadHashedValue(digestToEncoding map[string][]byte, n *node.Node) {
+	if !n.IsHashedValue {
+		return
+	}
+
+	value, ok := digestToEncoding[string(n.SubValue)]
+	if !ok {
+		return
+	}
+
+	n.SubValue = value
+	n.IsHashedValue = false
+}
+
 func bytesToString(b []byte) (s string) {
 	switch {
 	case b == nil:
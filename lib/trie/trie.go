@@ -21,6 +21,9 @@ type Trie struct {
 	generation uint64
 	root       *Node
 	childTries map[common.Hash]*Trie
+	// version is the state trie version used to encode
+	// the nodes modified in the trie.
+	version Version
 	// deletedMerkleValues are the node Merkle values that were deleted
 	// from this trie since the last snapshot. These are used by the online
 	// pruner to detect with database keys (trie node Merkle values) can
@@ -56,6 +59,7 @@ func (t *Trie) Snapshot() (newTrie *Trie) {
 		childTries[rootHash] = &Trie{
 			generation:          childTrie.generation + 1,
 			root:                childTrie.root.Copy(rootCopySettings),
+			version:             childTrie.version,
 			deletedMerkleValues: make(map[string]struct{}),
 		}
 	}
@@ -64,10 +68,23 @@ func (t *Trie) Snapshot() (newTrie *Trie) {
 		generation:          t.generation + 1,
 		root:                t.root,
 		childTries:          childTries,
+		version:             t.version,
 		deletedMerkleValues: make(map[string]struct{}),
 	}
 }
 
+// SetVersion sets the state trie version used to encode the
+// nodes modified in the trie. Nodes which are not modified keep
+// the encoding they were created with.
+func (t *Trie) SetVersion(version Version) {
+	t.version = version
+}
+
+// Version returns the state trie version of the trie.
+func (t *Trie) Version() (version Version) {
+	return t.version
+}
+
 // handleTrackedDeltas sets the pending deleted Merkle values in
 // the trie deleted merkle values set if and only if success is true.
 func (t *Trie) handleTrackedDeltas(success bool, pendingDeletedMerkleValues map[string]struct{}) {
@@ -140,6 +157,7 @@ func (t *Trie) DeepCopy() (trieCopy *Trie) {
 
 	trieCopy = &Trie{
 		generation: t.generation,
+		version:    t.version,
 	}
 
 	if t.deletedMerkleValues != nil {
@@ -172,7 +190,8 @@ func (t *Trie) RootNode() *Node {
 }
 
 // encodeRoot writes the encoding of the root node to the buffer.
-func encodeRoot(root *Node, buffer node.Buffer) (err error) {
+// Values larger than maxInlineValue bytes are encoded as their hash.
+func encodeRoot(root *Node, maxInlineValue int, buffer node.Buffer) (err error) {
 	if root == nil {
 		_, err = buffer.Write([]byte{0})
 		if err != nil {
@@ -180,7 +199,7 @@ func encodeRoot(root *Node, buffer node.Buffer) (err error) {
 		}
 		return nil
 	}
-	return root.Encode(buffer)
+	return root.Encode(buffer, maxInlineValue)
 }
 
 // MustHash returns the hashed root of the trie.
@@ -200,7 +219,7 @@ func (t *Trie) Hash() (rootHash common.Hash, err error) {
 	buffer.Reset()
 	defer pools.EncodingBuffers.Put(buffer)
 
-	err = encodeRoot(t.root, buffer)
+	err = encodeRoot(t.root, t.version.MaxInlineValue(), buffer)
 	if err != nil {
 		return [32]byte{}, err
 	}
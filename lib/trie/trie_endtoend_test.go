@@ -16,6 +16,7 @@
 	"github.com/stretchr/testify/require"
 
 	"github.com/ChainSafe/gossamer/internal/trie/codec"
+	"github.com/ChainSafe/gossamer/internal/trie/node"
 	"github.com/ChainSafe/gossamer/lib/common"
 )
 
@@ -1030,7 +1031,7 @@ func Test_encodeRoot_fuzz(t *testing.T) {
 			assert.Equal(t, value, retrievedValue)
 		}
 		buffer := bytes.NewBuffer(nil)
-		err := trie.root.Encode(buffer)
+		err := trie.root.Encode(buffer, node.NoMaxInlineValueSize)
 		require.NoError(t, err)
 		require.NotEmpty(t, buffer.Bytes())
 	}
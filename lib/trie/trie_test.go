@@ -401,7 +401,7 @@ type bufferCalls struct {
 					Return(testCase.bufferCalls.bytesReturn)
 			}
 
-			err := encodeRoot(testCase.root, buffer)
+			err := encodeRoot(testCase.root, node.NoMaxInlineValueSize, buffer)
 
 			assert.ErrorIs(t, err, testCase.errWrapped)
 			if testCase.errWrapped != nil {
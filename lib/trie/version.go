@@ -7,6 +7,8 @@
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/ChainSafe/gossamer/internal/trie/node"
 )
 
 // Version is the state trie version which dictates how a
@@ -14,22 +16,65 @@
 // https://spec.polkadot.network/#defn-state-version
 type Version uint8
 
+// The version constants are only referred to by name: they are never
+// persisted or sent over the network, and version numbers from the runtime
+// are converted with ParseVersionNumber. V0 is the zero value so tries
+// created without a version use the state trie version 0.
 const (
 	// V0 is the state trie version 0 where the values of the keys are
 	// inserted into the trie directly.
-	// TODO set to iota once CI passes
-	V0 Version = 1
+	V0 Version = iota
+	// V1 is the state trie version 1 where the values of the keys
+	// larger than V1MaxInlineValueSize bytes are hashed, and only their
+	// hash is inserted into the trie.
+	V1
 )
 
+// V1MaxInlineValueSize is the maximum size of a value to be inlined
+// in its trie node for the state trie version 1. Larger values are
+// hashed and stored separately.
+const V1MaxInlineValueSize = 32
+
 func (v Version) String() string {
 	switch v {
 	case V0:
 		return "v0"
+	case V1:
+		return "v1"
 	default:
 		panic(fmt.Sprintf("unknown version %d", v))
 	}
 }
 
+// MaxInlineValue returns the maximum size of a value to be
+// inlined in its trie node for the state trie version.
+func (v Version) MaxInlineValue() int {
+	switch v {
+	case V0:
+		return node.NoMaxInlineValueSize
+	case V1:
+		return V1MaxInlineValueSize
+	default:
+		panic(fmt.Sprintf("unknown version %d", v))
+	}
+}
+
+var ErrVersionNotValid = errors.New("version not valid")
+
+// ParseVersionNumber returns the state trie version
+// corresponding to the version number given, for example by
+// the runtime host functions.
+func ParseVersionNumber(number uint32) (version Version, err error) {
+	switch number {
+	case 0:
+		return V0, nil
+	case 1:
+		return V1, nil
+	default:
+		return version, fmt.Errorf("%w: %d", ErrVersionNotValid, number)
+	}
+}
+
 var ErrParseVersion = errors.New("parsing version failed")
 
 // ParseVersion parses a state trie version string.
@@ -37,8 +82,10 @@ func ParseVersion(s string) (version Version, err error) {
 	switch {
 	case strings.EqualFold(s, V0.String()):
 		return V0, nil
+	case strings.EqualFold(s, V1.String()):
+		return V1, nil
 	default:
-		return version, fmt.Errorf("%w: %q must be %s",
-			ErrParseVersion, s, V0)
+		return version, fmt.Errorf("%w: %q must be one of [%s, %s]",
+			ErrParseVersion, s, V0, V1)
 	}
 }
@@ -6,6 +6,7 @@
 import (
 	"testing"
 
+	"github.com/ChainSafe/gossamer/internal/trie/node"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,6 +22,10 @@ func Test_Version_String(t *testing.T) {
 			version:       V0,
 			versionString: "v0",
 		},
+		"v1": {
+			version:       V1,
+			versionString: "v1",
+		},
 		"invalid": {
 			version:      Version(99),
 			panicMessage: "unknown version 99",
@@ -62,10 +67,14 @@ func Test_ParseVersion(t *testing.T) {
 			s:       "V0",
 			version: V0,
 		},
+		"v1": {
+			s:       "v1",
+			version: V1,
+		},
 		"invalid": {
 			s:          "xyz",
 			errWrapped: ErrParseVersion,
-			errMessage: "parsing version failed: \"xyz\" must be v0",
+			errMessage: "parsing version failed: \"xyz\" must be one of [v0, v1]",
 		},
 	}
 
@@ -84,3 +93,92 @@ func Test_ParseVersion(t *testing.T) {
 		})
 	}
 }
+
+func Test_Version_MaxInlineValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		version        Version
+		maxInlineValue int
+		panicMessage   string
+	}{
+		"v0": {
+			version:        V0,
+			maxInlineValue: node.NoMaxInlineValueSize,
+		},
+		"v1": {
+			version:        V1,
+			maxInlineValue: V1MaxInlineValueSize,
+		},
+		"invalid": {
+			version:      Version(99),
+			panicMessage: "unknown version 99",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if testCase.panicMessage != "" {
+				assert.PanicsWithValue(t, testCase.panicMessage, func() {
+					_ = testCase.version.MaxInlineValue()
+				})
+				return
+			}
+
+			maxInlineValue := testCase.version.MaxInlineValue()
+			assert.Equal(t, testCase.maxInlineValue, maxInlineValue)
+		})
+	}
+}
+
+func Test_ParseVersionNumber(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		number     uint32
+		version    Version
+		errWrapped error
+		errMessage string
+	}{
+		"v0": {
+			number:  0,
+			version: V0,
+		},
+		"v1": {
+			number:  1,
+			version: V1,
+		},
+		"invalid": {
+			number:     2,
+			errWrapped: ErrVersionNotValid,
+			errMessage: "version not valid: 2",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			version, err := ParseVersionNumber(testCase.number)
+
+			assert.Equal(t, testCase.version, version)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
+
+func Test_Version_zeroValue(t *testing.T) {
+	t.Parallel()
+
+	var version Version
+	assert.Equal(t, V0, version)
+	assert.Equal(t, V0, NewEmptyTrie().Version())
+	assert.Equal(t, V0, (&Trie{}).Version())
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+// BitVec is a vector of bits, SCALE encoded as `BitVec<u8, Lsb0>` in Rust.
+// Its encoding is the compact encoded number of bits, followed by the
+// bytes holding the bits, where bit i is the (i % 8)th least significant
+// bit of byte i / 8.
+type BitVec struct {
+	size  uint
+	bytes []byte
+}
+
+// NewBitVec returns a BitVec containing the bits given.
+func NewBitVec(bits []bool) BitVec {
+	bv := BitVec{
+		size:  uint(len(bits)),
+		bytes: make([]byte, bitVecBytesLength(uint(len(bits)))),
+	}
+	for i, bit := range bits {
+		if bit {
+			bv.bytes[i/8] |= 1 << (i % 8)
+		}
+	}
+	return bv
+}
+
+// bitVecBytesLength returns the number of bytes needed to hold the number of bits given.
+func bitVecBytesLength(size uint) uint {
+	return (size + 7) / 8
+}
+
+// Size returns the number of bits in the bit vector.
+func (bv BitVec) Size() uint {
+	return bv.size
+}
+
+// Bits returns the bits of the bit vector.
+func (bv BitVec) Bits() (bits []bool) {
+	bits = make([]bool, bv.size)
+	for i := range bits {
+		bits[i] = bv.bytes[i/8]&(1<<(i%8)) != 0
+	}
+	return bits
+}
+
+// Bytes returns a copy of the bytes holding the bits of the bit vector
+// in Lsb0 order. The unused bits of the last byte are zero.
+func (bv BitVec) Bytes() (b []byte) {
+	b = make([]byte, len(bv.bytes))
+	copy(b, bv.bytes)
+	return b
+}
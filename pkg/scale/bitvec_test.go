@@ -0,0 +1,73 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BitVec(t *testing.T) {
+	t.Parallel()
+
+	bits := []bool{true, false, false, true, false, false, false, false, false, true}
+
+	bitVec := NewBitVec(bits)
+
+	assert.Equal(t, uint(10), bitVec.Size())
+	assert.Equal(t, bits, bitVec.Bits())
+	assert.Equal(t, []byte{0x09, 0x02}, bitVec.Bytes())
+}
+
+func Test_Decoder_Decode_BitVec(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		encoding   []byte
+		options    []DecoderOption
+		decoded    BitVec
+		errWrapped error
+		errMessage string
+	}{
+		"unused bits ignored": {
+			encoding: []byte{0x0c, 0xfd},
+			decoded:  NewBitVec([]bool{true, false, true}),
+		},
+		"missing bytes": {
+			encoding:   []byte{0x24, 0x01},
+			errWrapped: io.ErrUnexpectedEOF,
+			errMessage: "unexpected EOF",
+		},
+		"allocation exceeded": {
+			encoding:   []byte{0x24, 0x01, 0x01},
+			options:    []DecoderOption{MaxAllocationSize(1)},
+			errWrapped: ErrMaxAllocationSizeExceeded,
+			errMessage: "maximum allocation size exceeded: 2 bytes is larger than 1 bytes",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			decoder := NewDecoder(bytes.NewReader(testCase.encoding), testCase.options...)
+
+			var bitVec BitVec
+			err := decoder.Decode(&bitVec)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.decoded, bitVec)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// The functions below encode and decode primitive values without
+// reflection. They are used by the MarshalSCALE and UnmarshalSCALE
+// methods generated by cmd/scalegen, and produce the same encodings
+// as Marshal and Unmarshal.
+
+// EncodeCompactUint writes the compact encoding of the unsigned integer to the writer.
+func EncodeCompactUint(writer io.Writer, value uint) (err error) {
+	es := encodeState{Writer: writer}
+	return es.encodeUint(value)
+}
+
+// DecodeCompactUint reads a compact encoded unsigned integer from the reader.
+func DecodeCompactUint(reader io.Reader) (value uint, err error) {
+	ds := newDecodeState(reader)
+	value64, err := ds.decodeCompactUint()
+	if err != nil {
+		return 0, err
+	}
+	return uint(value64), nil
+}
+
+// DecodeLength reads the compact encoded length of a collection from the reader.
+// If the reader is given by a Decoder, the length is checked against its
+// maximum collection length.
+func DecodeLength(reader io.Reader) (length uint, err error) {
+	ds := newDecodeState(reader)
+	return ds.decodeLength()
+}
+
+// EncodeBytes writes the compact encoded length of the byte slice,
+// followed by the byte slice itself, to the writer.
+func EncodeBytes(writer io.Writer, b []byte) (err error) {
+	es := encodeState{Writer: writer}
+	return es.encodeBytes(b)
+}
+
+// DecodeBytes reads a byte slice prefixed with its compact encoded length from the reader.
+// If the reader is given by a Decoder, the length is checked against its limits.
+func DecodeBytes(reader io.Reader) (b []byte, err error) {
+	ds := newDecodeState(reader)
+	length, err := ds.decodeLength()
+	if err != nil {
+		return nil, err
+	}
+
+	err = ds.allocate(length, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	b = make([]byte, length)
+	_, err = io.ReadFull(reader, b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// EncodeBool writes the encoding of the boolean to the writer.
+func EncodeBool(writer io.Writer, value bool) (err error) {
+	es := encodeState{Writer: writer}
+	return es.encodeBool(value)
+}
+
+// DecodeBool reads an encoded boolean from the reader.
+func DecodeBool(reader io.Reader) (value bool, err error) {
+	b, err := DecodeUint8(reader)
+	if err != nil {
+		return false, err
+	}
+
+	switch b {
+	case 0x00:
+		return false, nil
+	case 0x01:
+		return true, nil
+	default:
+		return false, fmt.Errorf("could not decode invalid bool")
+	}
+}
+
+// EncodeUint8 writes the byte to the writer.
+func EncodeUint8(writer io.Writer, value uint8) (err error) {
+	_, err = writer.Write([]byte{value})
+	return err
+}
+
+// DecodeUint8 reads a byte from the reader.
+func DecodeUint8(reader io.Reader) (value uint8, err error) {
+	buffer := make([]byte, 1)
+	_, err = io.ReadFull(reader, buffer)
+	if err != nil {
+		return 0, err
+	}
+	return buffer[0], nil
+}
+
+// EncodeUint16 writes the little endian encoding of the integer to the writer.
+func EncodeUint16(writer io.Writer, value uint16) (err error) {
+	buffer := make([]byte, 2)
+	binary.LittleEndian.PutUint16(buffer, value)
+	_, err = writer.Write(buffer)
+	return err
+}
+
+// DecodeUint16 reads a little endian encoded integer from the reader.
+func DecodeUint16(reader io.Reader) (value uint16, err error) {
+	buffer := make([]byte, 2)
+	_, err = io.ReadFull(reader, buffer)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(buffer), nil
+}
+
+// EncodeUint32 writes the little endian encoding of the integer to the writer.
+func EncodeUint32(writer io.Writer, value uint32) (err error) {
+	buffer := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buffer, value)
+	_, err = writer.Write(buffer)
+	return err
+}
+
+// DecodeUint32 reads a little endian encoded integer from the reader.
+func DecodeUint32(reader io.Reader) (value uint32, err error) {
+	buffer := make([]byte, 4)
+	_, err = io.ReadFull(reader, buffer)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(buffer), nil
+}
+
+// EncodeUint64 writes the little endian encoding of the integer to the writer.
+func EncodeUint64(writer io.Writer, value uint64) (err error) {
+	buffer := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buffer, value)
+	_, err = writer.Write(buffer)
+	return err
+}
+
+// DecodeUint64 reads a little endian encoded integer from the reader.
+func DecodeUint64(reader io.Reader) (value uint64, err error) {
+	buffer := make([]byte, 8)
+	_, err = io.ReadFull(reader, buffer)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(buffer), nil
+}
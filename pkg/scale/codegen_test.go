@@ -0,0 +1,70 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_codegen_helpers(t *testing.T) {
+	t.Parallel()
+
+	buffer := bytes.NewBuffer(nil)
+	require.NoError(t, EncodeCompactUint(buffer, 1<<30))
+	require.NoError(t, EncodeBytes(buffer, []byte{1, 2}))
+	require.NoError(t, EncodeBool(buffer, true))
+	require.NoError(t, EncodeUint8(buffer, 3))
+	require.NoError(t, EncodeUint16(buffer, 4))
+	require.NoError(t, EncodeUint32(buffer, 5))
+	require.NoError(t, EncodeUint64(buffer, 6))
+
+	expected, err := Marshal(struct {
+		Compact uint
+		Bytes   []byte
+		Bool    bool
+		Uint8   uint8
+		Uint16  uint16
+		Uint32  uint32
+		Uint64  uint64
+	}{1 << 30, []byte{1, 2}, true, 3, 4, 5, 6})
+	require.NoError(t, err)
+	assert.Equal(t, expected, buffer.Bytes())
+
+	compact, err := DecodeCompactUint(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1<<30), compact)
+	b, err := DecodeBytes(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, b)
+	boolean, err := DecodeBool(buffer)
+	require.NoError(t, err)
+	assert.True(t, boolean)
+	u8, err := DecodeUint8(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(3), u8)
+	u16, err := DecodeUint16(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(4), u16)
+	u32, err := DecodeUint32(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(5), u32)
+	u64, err := DecodeUint64(buffer)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), u64)
+
+	_, err = DecodeUint8(buffer)
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func Test_DecodeBool_invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := DecodeBool(bytes.NewReader([]byte{2}))
+	assert.EqualError(t, err, "could not decode invalid bool")
+}
@@ -70,7 +70,7 @@ func Unmarshal(data []byte, dst interface{}) (err error) {
 	}
 
 	buf := &bytes.Buffer{}
-	ds := decodeState{}
+	ds := &decodeState{}
 	_, err = buf.Write(data)
 	if err != nil {
 		return
@@ -86,7 +86,7 @@ func Unmarshal(data []byte, dst interface{}) (err error) {
 
 // Decoder is used to decode from an io.Reader
 type Decoder struct {
-	decodeState
+	*decodeState
 }
 
 // Decode accepts a pointer to a destination and decodes into supplied destination
@@ -97,6 +97,11 @@ func (d *Decoder) Decode(dst interface{}) (err error) {
 		return
 	}
 
+	if d.depth == 0 {
+		// the allocation limit applies to each top level value decoded
+		d.allocated = 0
+	}
+
 	elem := indirect(dstv)
 	if err != nil {
 		return
@@ -104,25 +109,49 @@ func (d *Decoder) Decode(dst interface{}) (err error) {
 	return d.unmarshal(elem)
 }
 
-// NewDecoder is constructor for Decoder
-func NewDecoder(r io.Reader) (d *Decoder) {
+// NewDecoder is constructor for Decoder. The options given can be used to
+// limit the resources used to decode values from untrusted readers.
+// If the reader is the one given to an UnmarshalSCALE method by a Decoder,
+// the Decoder returned shares the limits of this Decoder, and the options
+// given are ignored.
+func NewDecoder(r io.Reader, options ...DecoderOption) (d *Decoder) {
+	if ds, ok := r.(*decodeState); ok {
+		return &Decoder{ds}
+	}
+
 	d = &Decoder{
-		decodeState{r},
+		&decodeState{
+			Reader:   r,
+			settings: newDecoderSettings(options),
+		},
 	}
 	return
 }
 
 type decodeState struct {
 	io.Reader
+	settings decoderSettings
+	// depth is the nesting depth of the value being decoded.
+	depth uint
+	// allocated is the number of bytes allocated to decode the top level value.
+	allocated uint64
 }
 
 func (ds *decodeState) unmarshal(dstv reflect.Value) (err error) {
+	ds.depth++
+	defer func() { ds.depth-- }()
+	if ds.settings.maxDepth > 0 && ds.depth > ds.settings.maxDepth {
+		return fmt.Errorf("%w: %d", ErrMaxDepthExceeded, ds.settings.maxDepth)
+	}
+
 	in := dstv.Interface()
 	switch in.(type) {
 	case *big.Int:
 		err = ds.decodeBigInt(dstv)
 	case *Uint128:
 		err = ds.decodeUint128(dstv)
+	case BitVec:
+		err = ds.decodeBitVec(dstv)
 	case int, uint:
 		err = ds.decodeUint(dstv)
 	case int8, uint8, int16, uint16, int32, uint32, int64, uint64:
@@ -141,6 +170,11 @@ func (ds *decodeState) unmarshal(dstv reflect.Value) (err error) {
 		err = ds.decodeVaryingDataTypeSlice(dstv)
 	default:
 		t := reflect.TypeOf(in)
+		if isUnmarshaler(t) {
+			err = ds.decodeUnmarshaler(dstv)
+			break
+		}
+
 		switch t.Kind() {
 		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16,
 			reflect.Int32, reflect.Int64, reflect.String, reflect.Uint,
@@ -159,6 +193,8 @@ func (ds *decodeState) unmarshal(dstv reflect.Value) (err error) {
 			err = ds.decodeArray(dstv)
 		case reflect.Slice:
 			err = ds.decodeSlice(dstv)
+		case reflect.Map:
+			err = ds.decodeMap(dstv)
 		default:
 			err = fmt.Errorf("unsupported type: %T", in)
 		}
@@ -335,6 +371,10 @@ func (ds *decodeState) decodeVaryingDataTypeSlice(dstv reflect.Value) (err error
 	if err != nil {
 		return
 	}
+	err = ds.allocate(l, reflect.TypeOf(VaryingDataType{}).Size())
+	if err != nil {
+		return
+	}
 	for i := uint(0); i < l; i++ {
 		vdt := vdts.VaryingDataType
 		vdtv := reflect.New(reflect.TypeOf(vdt))
@@ -396,6 +436,10 @@ func (ds *decodeState) decodeSlice(dstv reflect.Value) (err error) {
 		return
 	}
 	in := dstv.Interface()
+	err = ds.allocate(l, reflect.TypeOf(in).Elem().Size())
+	if err != nil {
+		return
+	}
 	temp := reflect.New(reflect.ValueOf(in).Type())
 	for i := uint(0); i < l; i++ {
 		tempElemType := reflect.TypeOf(in).Elem()
@@ -412,6 +456,38 @@ func (ds *decodeState) decodeSlice(dstv reflect.Value) (err error) {
 	return
 }
 
+// decodeMap decodes a Rust BTreeMap into a map. Entries are inserted
+// in the order they are decoded, so a duplicate key overrides the value
+// of the previous entry with the same key.
+func (ds *decodeState) decodeMap(dstv reflect.Value) (err error) {
+	l, err := ds.decodeLength()
+	if err != nil {
+		return
+	}
+	mapType := reflect.TypeOf(dstv.Interface())
+	keyType, elemType := mapType.Key(), mapType.Elem()
+	err = ds.allocate(l, keyType.Size()+elemType.Size())
+	if err != nil {
+		return
+	}
+	temp := reflect.MakeMap(mapType)
+	for i := uint(0); i < l; i++ {
+		key := reflect.New(keyType).Elem()
+		err = ds.unmarshal(key)
+		if err != nil {
+			return
+		}
+		elem := reflect.New(elemType).Elem()
+		err = ds.unmarshal(elem)
+		if err != nil {
+			return
+		}
+		temp.SetMapIndex(key, elem)
+	}
+	dstv.Set(temp)
+	return
+}
+
 func (ds *decodeState) decodeArray(dstv reflect.Value) (err error) {
 	in := dstv.Interface()
 	temp := reflect.New(reflect.ValueOf(in).Type())
@@ -448,7 +524,7 @@ func (ds *decodeState) decodeStruct(dstv reflect.Value) (err error) {
 		}
 		err = ds.unmarshal(field)
 		if err != nil {
-			err = fmt.Errorf("%s, field: %+v", err, field)
+			err = fmt.Errorf("%w, field: %+v", err, field)
 			return
 		}
 	}
@@ -478,72 +554,81 @@ func (ds *decodeState) decodeBool(dstv reflect.Value) (err error) {
 
 // decodeUint will decode unsigned integer
 func (ds *decodeState) decodeUint(dstv reflect.Value) (err error) {
+	value, err := ds.decodeCompactUint()
+	if err != nil {
+		return err
+	}
+
+	in := dstv.Interface()
+	temp := reflect.New(reflect.TypeOf(in))
+	temp.Elem().Set(reflect.ValueOf(value).Convert(reflect.TypeOf(in)))
+	dstv.Set(temp.Elem())
+	return
+}
+
+// decodeCompactUint decodes a compact encoded unsigned integer.
+func (ds *decodeState) decodeCompactUint() (value uint64, err error) {
 	const maxUint32 = ^uint32(0)
 	const maxUint64 = ^uint64(0)
 	prefix, err := ds.ReadByte()
 	if err != nil {
-		return fmt.Errorf("reading byte: %w", err)
+		return 0, fmt.Errorf("reading byte: %w", err)
 	}
 
-	in := dstv.Interface()
-	temp := reflect.New(reflect.TypeOf(in))
 	// check mode of encoding, stored at 2 least significant bits
 	mode := prefix % 4
-	var value uint64
 	switch mode {
 	case 0:
 		value = uint64(prefix >> 2)
 	case 1:
 		buf, err := ds.ReadByte()
 		if err != nil {
-			return fmt.Errorf("reading byte: %w", err)
+			return 0, fmt.Errorf("reading byte: %w", err)
 		}
 		value = uint64(binary.LittleEndian.Uint16([]byte{prefix, buf}) >> 2)
 		if value <= 0b0011_1111 || value > 0b0111_1111_1111_1111 {
-			return fmt.Errorf("%w: %d (%b)", ErrU16OutOfRange, value, value)
+			return 0, fmt.Errorf("%w: %d (%b)", ErrU16OutOfRange, value, value)
 		}
 	case 2:
 		buf := make([]byte, 3)
 		_, err = ds.Read(buf)
 		if err != nil {
-			return fmt.Errorf("reading bytes: %w", err)
+			return 0, fmt.Errorf("reading bytes: %w", err)
 		}
 		value = uint64(binary.LittleEndian.Uint32(append([]byte{prefix}, buf...)) >> 2)
 		if value <= 0b0011_1111_1111_1111 || value > uint64(maxUint32>>2) {
-			return fmt.Errorf("%w: %d (%b)", ErrU32OutOfRange, value, value)
+			return 0, fmt.Errorf("%w: %d (%b)", ErrU32OutOfRange, value, value)
 		}
 	case 3:
 		byteLen := (prefix >> 2) + 4
+		if byteLen != 4 && byteLen != 8 {
+			return 0, fmt.Errorf("%w: %d", ErrCompactUintPrefixUnknown, prefix)
+		}
 		buf := make([]byte, byteLen)
 		_, err = ds.Read(buf)
 		if err != nil {
-			return fmt.Errorf("reading bytes: %w", err)
+			return 0, fmt.Errorf("reading bytes: %w", err)
 		}
 		switch byteLen {
 		case 4:
 			value = uint64(binary.LittleEndian.Uint32(buf))
 			if value <= uint64(maxUint32>>2) {
-				return fmt.Errorf("%w: %d (%b)", ErrU32OutOfRange, value, value)
+				return 0, fmt.Errorf("%w: %d (%b)", ErrU32OutOfRange, value, value)
 			}
 		case 8:
 			const uintSize = 32 << (^uint(0) >> 32 & 1)
 			if uintSize == 32 {
-				return ErrU64NotSupported
+				return 0, ErrU64NotSupported
 			}
 			tmp := make([]byte, 8)
 			copy(tmp, buf)
 			value = binary.LittleEndian.Uint64(tmp)
 			if value <= maxUint64>>8 {
-				return fmt.Errorf("%w: %d (%b)", ErrU64OutOfRange, value, value)
+				return 0, fmt.Errorf("%w: %d (%b)", ErrU64OutOfRange, value, value)
 			}
-		default:
-			return fmt.Errorf("%w: %d", ErrCompactUintPrefixUnknown, prefix)
-
 		}
 	}
-	temp.Elem().Set(reflect.ValueOf(value).Convert(reflect.TypeOf(in)))
-	dstv.Set(temp.Elem())
-	return
+	return value, nil
 }
 
 var (
@@ -554,14 +639,15 @@ func (ds *decodeState) decodeUint(dstv reflect.Value) (err error) {
 	ErrCompactUintPrefixUnknown = errors.New("unknown prefix for compact uint")
 )
 
-// decodeLength is helper method which calls decodeUint and casts to int
+// decodeLength is helper method which calls decodeCompactUint and casts to uint,
+// and checks the length against the maximum collection length of the decoder.
 func (ds *decodeState) decodeLength() (l uint, err error) {
-	dstv := reflect.New(reflect.TypeOf(l))
-	err = ds.decodeUint(dstv.Elem())
+	value, err := ds.decodeCompactUint()
 	if err != nil {
 		return
 	}
-	l = dstv.Elem().Interface().(uint)
+	l = uint(value)
+	err = ds.checkLength(l)
 	return
 }
 
@@ -571,6 +657,10 @@ func (ds *decodeState) decodeBytes(dstv reflect.Value) (err error) {
 	if err != nil {
 		return
 	}
+	err = ds.allocate(length, 1)
+	if err != nil {
+		return
+	}
 
 	b := make([]byte, length)
 	_, err = ds.Read(b)
@@ -730,3 +820,31 @@ func (ds *decodeState) decodeUint128(dstv reflect.Value) (err error) {
 	dstv.Set(reflect.ValueOf(ui128))
 	return
 }
+
+// decodeBitVec decodes a BitVec from the compact encoded number of bits
+// followed by the bytes holding the bits. The unused bits of the last
+// byte are ignored.
+func (ds *decodeState) decodeBitVec(dstv reflect.Value) (err error) {
+	size, err := ds.decodeLength()
+	if err != nil {
+		return
+	}
+	bytesLength := bitVecBytesLength(size)
+	err = ds.allocate(bytesLength, 1)
+	if err != nil {
+		return
+	}
+	buf := make([]byte, bytesLength)
+	_, err = io.ReadFull(ds, buf)
+	if err != nil {
+		return
+	}
+	if size%8 != 0 {
+		buf[bytesLength-1] &= 1<<(size%8) - 1
+	}
+	dstv.Set(reflect.ValueOf(BitVec{
+		size:  size,
+		bytes: buf,
+	}))
+	return
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+var (
+	ErrMaxAllocationSizeExceeded   = errors.New("maximum allocation size exceeded")
+	ErrMaxCollectionLengthExceeded = errors.New("maximum collection length exceeded")
+	ErrMaxDepthExceeded            = errors.New("maximum depth exceeded")
+)
+
+// DecoderOption is a functional option for the Decoder.
+type DecoderOption func(s *decoderSettings)
+
+// decoderSettings contains the limits of a Decoder,
+// where a zero value means there is no limit.
+type decoderSettings struct {
+	maxAllocationSize   uint64
+	maxCollectionLength uint
+	maxDepth            uint
+}
+
+func newDecoderSettings(options []DecoderOption) (settings decoderSettings) {
+	for _, option := range options {
+		option(&settings)
+	}
+	return settings
+}
+
+// MaxAllocationSize sets the maximum number of bytes the decoder can
+// allocate for the byte slices, strings and slices of a decoded value,
+// according to their length prefixes, before reading their content.
+// This limit applies to each value decoded with Decode.
+// The default is no limit.
+func MaxAllocationSize(size uint64) DecoderOption {
+	return func(s *decoderSettings) {
+		s.maxAllocationSize = size
+	}
+}
+
+// MaxCollectionLength sets the maximum length prefix accepted by the
+// decoder for byte slices, strings, slices and varying data type slices.
+// The default is no limit.
+func MaxCollectionLength(length uint) DecoderOption {
+	return func(s *decoderSettings) {
+		s.maxCollectionLength = length
+	}
+}
+
+// MaxDepth sets the maximum nesting depth of values decoded by the decoder,
+// where the value given to Decode has a depth of 1, and each struct field,
+// slice or array item, option value or varying data type value increases
+// the depth by 1. The default is no limit.
+func MaxDepth(depth uint) DecoderOption {
+	return func(s *decoderSettings) {
+		s.maxDepth = depth
+	}
+}
+
+// newDecodeState returns the decode state given as reader to an
+// UnmarshalSCALE method, to keep the limits of its decoder, or
+// returns a new decode state without limits for the reader.
+func newDecodeState(reader io.Reader) *decodeState {
+	ds, ok := reader.(*decodeState)
+	if ok {
+		return ds
+	}
+	return &decodeState{Reader: reader}
+}
+
+// checkLength returns an error if the length given
+// is larger than the maximum collection length.
+func (ds *decodeState) checkLength(length uint) (err error) {
+	if ds.settings.maxCollectionLength > 0 && length > ds.settings.maxCollectionLength {
+		return fmt.Errorf("%w: %d is larger than %d",
+			ErrMaxCollectionLengthExceeded, length, ds.settings.maxCollectionLength)
+	}
+	return nil
+}
+
+// allocate adds the size of the collection with the length and item size
+// given to the number of bytes allocated, and returns an error if this
+// exceeds the maximum allocation size.
+func (ds *decodeState) allocate(length uint, itemSize uintptr) (err error) {
+	if ds.settings.maxAllocationSize == 0 {
+		return nil
+	}
+
+	maxAllocationSize := ds.settings.maxAllocationSize
+	if itemSize > 0 && uint64(length) > (math.MaxUint64-ds.allocated)/uint64(itemSize) {
+		return fmt.Errorf("%w: allocating %d items of %d bytes",
+			ErrMaxAllocationSizeExceeded, length, itemSize)
+	}
+
+	allocated := ds.allocated + uint64(length)*uint64(itemSize)
+	if allocated > maxAllocationSize {
+		return fmt.Errorf("%w: %d bytes is larger than %d bytes",
+			ErrMaxAllocationSizeExceeded, allocated, maxAllocationSize)
+	}
+	ds.allocated = allocated
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type nestedLimits struct {
+	Items [][]byte
+}
+
+func Test_Decoder_limits(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		encoding   []byte
+		options    []DecoderOption
+		dst        interface{}
+		decoded    interface{}
+		errWrapped error
+		errMessage string
+	}{
+		"no limit": {
+			encoding: []byte{8, 4, 1, 8, 2, 3},
+			dst:      new(nestedLimits),
+			decoded:  nestedLimits{Items: [][]byte{{1}, {2, 3}}},
+		},
+		"within limits": {
+			encoding: []byte{8, 4, 1, 8, 2, 3},
+			options: []DecoderOption{
+				MaxAllocationSize(2*24 + 3),
+				MaxCollectionLength(2),
+				MaxDepth(3),
+			},
+			dst:     new(nestedLimits),
+			decoded: nestedLimits{Items: [][]byte{{1}, {2, 3}}},
+		},
+		"bytes length exceeded": {
+			// compact encoding of 1 << 30 followed by no data
+			encoding:   []byte{0x03, 0x00, 0x00, 0x00, 0x40},
+			options:    []DecoderOption{MaxCollectionLength(1024)},
+			dst:        new([]byte),
+			errWrapped: ErrMaxCollectionLengthExceeded,
+			errMessage: "maximum collection length exceeded: 1073741824 is larger than 1024",
+		},
+		"slice length exceeded": {
+			encoding:   []byte{12, 1, 2, 3},
+			options:    []DecoderOption{MaxCollectionLength(2)},
+			dst:        new([]uint8),
+			errWrapped: ErrMaxCollectionLengthExceeded,
+			errMessage: "maximum collection length exceeded: 3 is larger than 2",
+		},
+		"string allocation exceeded": {
+			encoding:   []byte{0x03, 0x00, 0x00, 0x00, 0x40},
+			options:    []DecoderOption{MaxAllocationSize(1 << 20)},
+			dst:        new(string),
+			errWrapped: ErrMaxAllocationSizeExceeded,
+			errMessage: "maximum allocation size exceeded: 1073741824 bytes is larger than 1048576 bytes",
+		},
+		"nested allocations exceeded": {
+			encoding:   []byte{8, 4, 1, 8, 2, 3},
+			options:    []DecoderOption{MaxAllocationSize(2*24 + 2)},
+			dst:        new(nestedLimits),
+			errWrapped: ErrMaxAllocationSizeExceeded,
+			errMessage: "maximum allocation size exceeded: 51 bytes is larger than 50 bytes, field: []",
+		},
+		"depth exceeded": {
+			encoding:   []byte{8, 4, 1, 8, 2, 3},
+			options:    []DecoderOption{MaxDepth(2)},
+			dst:        new(nestedLimits),
+			errWrapped: ErrMaxDepthExceeded,
+			errMessage: "maximum depth exceeded: 2, field: []",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			decoder := NewDecoder(bytes.NewReader(testCase.encoding), testCase.options...)
+
+			err := decoder.Decode(testCase.dst)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.decoded, dereference(testCase.dst))
+		})
+	}
+}
@@ -132,6 +132,34 @@ func Test_decodeState_decodeSlice(t *testing.T) {
 	}
 }
 
+func Test_decodeState_decodeMap(t *testing.T) {
+	for _, tt := range mapTests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := reflect.New(reflect.TypeOf(tt.in)).Elem().Interface()
+			if err := Unmarshal(tt.want, &dst); (err != nil) != tt.wantErr {
+				t.Errorf("decodeState.unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(dst, tt.in) {
+				t.Errorf("decodeState.unmarshal() = %v, want %v", dst, tt.in)
+			}
+		})
+	}
+}
+
+func Test_decodeState_decodeBitVec(t *testing.T) {
+	for _, tt := range bitVecTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dst BitVec
+			if err := Unmarshal(tt.want, &dst); (err != nil) != tt.wantErr {
+				t.Errorf("decodeState.unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(dst, tt.in) {
+				t.Errorf("decodeState.unmarshal() = %v, want %v", dst, tt.in)
+			}
+		})
+	}
+}
+
 func Test_unmarshal_optionality(t *testing.T) {
 	var ptrTests tests
 	for _, t := range append(tests{}, allTests...) {
@@ -243,7 +271,7 @@ func Test_unmarshal_optionality_nil_case(t *testing.T) {
 
 func Test_Decoder_Decode(t *testing.T) {
 	for _, tt := range newTests(fixedWidthIntegerTests, variableWidthIntegerTests, stringTests,
-		boolTests, sliceTests, arrayTests,
+		boolTests, sliceTests, arrayTests, mapTests, bitVecTests,
 	) {
 		t.Run(tt.name, func(t *testing.T) {
 			dst := reflect.New(reflect.TypeOf(tt.in)).Elem().Interface()
@@ -10,6 +10,8 @@
 	"io"
 	"math/big"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 // Encoder scale encodes to a given io.Writer.
@@ -64,6 +66,8 @@ func (es *encodeState) marshal(in interface{}) (err error) {
 		err = es.encodeBigInt(in)
 	case *Uint128:
 		err = es.encodeUint128(in)
+	case BitVec:
+		err = es.encodeBitVec(in)
 	case []byte:
 		err = es.encodeBytes(in)
 	case string:
@@ -77,6 +81,11 @@ func (es *encodeState) marshal(in interface{}) (err error) {
 	case VaryingDataTypeSlice:
 		err = es.encodeVaryingDataTypeSlice(in)
 	default:
+		if isMarshaler(reflect.TypeOf(in)) {
+			err = es.encodeMarshaler(in)
+			break
+		}
+
 		switch reflect.TypeOf(in).Kind() {
 		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16,
 			reflect.Int32, reflect.Int64, reflect.String, reflect.Uint,
@@ -106,6 +115,8 @@ func (es *encodeState) marshal(in interface{}) (err error) {
 			err = es.encodeArray(in)
 		case reflect.Slice:
 			err = es.encodeSlice(in)
+		case reflect.Map:
+			err = es.encodeMap(in)
 		default:
 			err = fmt.Errorf("unsupported type: %T", in)
 		}
@@ -210,6 +221,119 @@ func (es *encodeState) encodeSlice(in interface{}) (err error) {
 	return
 }
 
+// encodeMap encodes a map as a Rust BTreeMap, which is the encoded length
+// of the map followed by the encoded key and value of each entry, in the
+// order of the keys.
+func (es *encodeState) encodeMap(in interface{}) (err error) {
+	v := reflect.ValueOf(in)
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return compareMapKeys(keys[i], keys[j]) < 0
+	})
+
+	err = es.encodeLength(len(keys))
+	if err != nil {
+		return
+	}
+	for _, key := range keys {
+		err = es.marshal(key.Interface())
+		if err != nil {
+			return
+		}
+		err = es.marshal(v.MapIndex(key).Interface())
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// compareMapKeys returns -1, 0 or 1 if the map key a is respectively lower than,
+// equal to or greater than the map key b, following the ordering of the
+// corresponding Rust types: integers are ordered by value, strings, arrays and
+// structs are ordered lexicographically and None is lower than any Some value.
+// Keys of other types are ordered by their SCALE encoding.
+func compareMapKeys(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Bool:
+		switch {
+		case a.Bool() == b.Bool():
+			return 0
+		case b.Bool():
+			return -1
+		default:
+			return 1
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch {
+		case a.Int() < b.Int():
+			return -1
+		case a.Int() > b.Int():
+			return 1
+		default:
+			return 0
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch {
+		case a.Uint() < b.Uint():
+			return -1
+		case a.Uint() > b.Uint():
+			return 1
+		default:
+			return 0
+		}
+	case reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			result := compareMapKeys(a.Index(i), b.Index(i))
+			if result != 0 {
+				return result
+			}
+		}
+		return 0
+	case reflect.Struct:
+		_, indices, err := cache.fieldScaleIndices(a.Interface())
+		if err != nil {
+			break
+		}
+		for _, i := range indices {
+			fieldA, fieldB := a.Field(i.fieldIndex), b.Field(i.fieldIndex)
+			if !fieldA.CanInterface() {
+				continue
+			}
+			result := compareMapKeys(fieldA, fieldB)
+			if result != 0 {
+				return result
+			}
+		}
+		return 0
+	case reflect.Ptr:
+		switch {
+		case a.IsNil() && b.IsNil():
+			return 0
+		case a.IsNil():
+			return -1
+		case b.IsNil():
+			return 1
+		}
+
+		switch in := a.Interface().(type) {
+		case *big.Int:
+			return in.Cmp(b.Interface().(*big.Int))
+		case *Uint128:
+			return in.Compare(b.Interface().(*Uint128))
+		default:
+			return compareMapKeys(a.Elem(), b.Elem())
+		}
+	}
+
+	// errors are ignored since they are returned when encoding the keys.
+	encodedA, _ := Marshal(a.Interface())
+	encodedB, _ := Marshal(b.Interface())
+	return bytes.Compare(encodedA, encodedB)
+}
+
 // encodeArray encodes an interface where the underlying type is an array
 // it writes the encoded length of the Array to the Encoder, then encodes and writes each value in the Array
 func (es *encodeState) encodeArray(in interface{}) (err error) {
@@ -371,6 +495,17 @@ func (es *encodeState) encodeUint(i uint) (err error) {
 	return
 }
 
+// encodeBitVec encodes a BitVec as the compact encoded number of bits
+// followed by the bytes holding the bits.
+func (es *encodeState) encodeBitVec(bv BitVec) (err error) {
+	err = es.encodeUint(bv.size)
+	if err != nil {
+		return
+	}
+	_, err = es.Write(bv.bytes)
+	return
+}
+
 // encodeUint128 encodes a Uint128
 func (es *encodeState) encodeUint128(i *Uint128) (err error) {
 	if i == nil {
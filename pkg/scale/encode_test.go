@@ -909,9 +909,82 @@ func newBoolPtr(in bool) (ptr *bool) {
 		},
 	}
 
+	// encodings produced by parity-scale-codec for the equivalent BTreeMap
+	mapTests = tests{
+		{
+			name: "map[uint64]uint64{}",
+			in:   map[uint64]uint64{},
+			want: []byte{0x00},
+		},
+		{
+			name: "map[uint32]bool{2: false, 1: true}",
+			in:   map[uint32]bool{2: false, 1: true},
+			want: []byte{0x08, 0x01, 0x00, 0x00, 0x00, 0x01, 0x02, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name: "map[uint16]bool{256: true, 1: false}",
+			in:   map[uint16]bool{256: true, 1: false},
+			want: []byte{0x08, 0x01, 0x00, 0x00, 0x00, 0x01, 0x01},
+		},
+		{
+			name: "map[int8]string{1: \"y\", -1: \"x\"}",
+			in:   map[int8]string{1: "y", -1: "x"},
+			want: []byte{0x08, 0xff, 0x04, 'x', 0x01, 0x04, 'y'},
+		},
+		{
+			name: "map[string]uint8{\"b\": 2, \"ab\": 3, \"a\": 1}",
+			in:   map[string]uint8{"b": 2, "ab": 3, "a": 1},
+			want: []byte{0x0c, 0x04, 'a', 0x01, 0x08, 'a', 'b', 0x03, 0x04, 'b', 0x02},
+		},
+		{
+			name: "map[[2]uint16][]byte{{2, 1}: {0x02}, {1, 2}: {0x01}}",
+			in:   map[[2]uint16][]byte{{2, 1}: {0x02}, {1, 2}: {0x01}},
+			want: []byte{0x08, 0x01, 0x00, 0x02, 0x00, 0x04, 0x01, 0x02, 0x00, 0x01, 0x00, 0x04, 0x02},
+		},
+		{
+			name: "map[mapKey]uint8{{A: 1, B: \"b\"}: 2, {A: 1, B: \"a\"}: 1, {A: 0, B: \"c\"}: 0}",
+			in: map[mapKey]uint8{
+				{A: 1, B: "b"}: 2,
+				{A: 1, B: "a"}: 1,
+				{A: 0, B: "c"}: 0,
+			},
+			want: []byte{0x0c, 0x00, 0x00, 0x04, 'c', 0x00, 0x01, 0x00, 0x04, 'a', 0x01, 0x01, 0x00, 0x04, 'b', 0x02},
+		},
+	}
+
+	// encodings produced by parity-scale-codec for the equivalent BitVec<u8, Lsb0>
+	bitVecTests = tests{
+		{
+			name: "NewBitVec(nil)",
+			in:   NewBitVec(nil),
+			want: []byte{0x00},
+		},
+		{
+			name: "NewBitVec([]bool{true})",
+			in:   NewBitVec([]bool{true}),
+			want: []byte{0x04, 0x01},
+		},
+		{
+			name: "NewBitVec([]bool{true, false, true, true})",
+			in:   NewBitVec([]bool{true, false, true, true}),
+			want: []byte{0x10, 0x0d},
+		},
+		{
+			name: "NewBitVec([]bool{true, false, false, false, false, false, false, true, true})",
+			in:   NewBitVec([]bool{true, false, false, false, false, false, false, true, true}),
+			want: []byte{0x24, 0x81, 0x01},
+		},
+		{
+			name: "NewBitVec(16 true bits)",
+			in: NewBitVec([]bool{true, true, true, true, true, true, true, true,
+				true, true, true, true, true, true, true, true}),
+			want: []byte{0x40, 0xff, 0xff},
+		},
+	}
+
 	allTests = newTests(
 		fixedWidthIntegerTests, variableWidthIntegerTests, stringTests,
-		boolTests, structTests, sliceTests, arrayTests,
+		boolTests, structTests, sliceTests, arrayTests, mapTests,
 		varyingDataTypeTests,
 	)
 )
@@ -930,6 +1003,11 @@ type MyStructWithIgnore struct {
 		fields int
 	}
 }
+type mapKey struct {
+	A uint16
+	B string
+}
+
 type MyStructWithPrivate struct {
 	priv0 string
 	Baz   bool   `scale:"3"`
@@ -1096,6 +1174,42 @@ func Test_encodeState_encodeArray(t *testing.T) {
 	}
 }
 
+func Test_encodeState_encodeMap(t *testing.T) {
+	for _, tt := range mapTests {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := bytes.NewBuffer(nil)
+			es := &encodeState{
+				Writer:                 buffer,
+				fieldScaleIndicesCache: cache,
+			}
+			if err := es.marshal(tt.in); (err != nil) != tt.wantErr {
+				t.Errorf("encodeState.encodeMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(buffer.Bytes(), tt.want) {
+				t.Errorf("encodeState.encodeMap() = %v, want %v", buffer.Bytes(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_encodeState_encodeBitVec(t *testing.T) {
+	for _, tt := range bitVecTests {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := bytes.NewBuffer(nil)
+			es := &encodeState{
+				Writer:                 buffer,
+				fieldScaleIndicesCache: cache,
+			}
+			if err := es.marshal(tt.in); (err != nil) != tt.wantErr {
+				t.Errorf("encodeState.encodeBitVec() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(buffer.Bytes(), tt.want) {
+				t.Errorf("encodeState.encodeBitVec() = %v, want %v", buffer.Bytes(), tt.want)
+			}
+		})
+	}
+}
+
 func Test_marshal_optionality(t *testing.T) {
 	var ptrTests tests
 	for i := range allTests {
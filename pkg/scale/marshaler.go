@@ -0,0 +1,78 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Marshaler is the interface implemented by types that
+// can SCALE encode themselves.
+type Marshaler interface {
+	MarshalSCALE() ([]byte, error)
+}
+
+// Unmarshaler is the interface implemented by types that
+// can SCALE decode themselves. UnmarshalSCALE must read
+// exactly the bytes of its encoding from the reader given,
+// since the reader may contain the encoding of other values
+// after it.
+type Unmarshaler interface {
+	UnmarshalSCALE(reader io.Reader) error
+}
+
+var (
+	marshalerType   = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+)
+
+// isMarshaler returns true if the type given or a pointer to it implements
+// the Marshaler interface. Pointer types are not considered, since they
+// are encoded as an Option of their element type.
+func isMarshaler(t reflect.Type) bool {
+	return t.Kind() != reflect.Ptr && reflect.PtrTo(t).Implements(marshalerType)
+}
+
+// isUnmarshaler returns true if a pointer to the type given implements
+// the Unmarshaler interface. Pointer types are not considered, since they
+// are decoded as an Option of their element type.
+func isUnmarshaler(t reflect.Type) bool {
+	return t.Kind() != reflect.Ptr && reflect.PtrTo(t).Implements(unmarshalerType)
+}
+
+func (es *encodeState) encodeMarshaler(in interface{}) (err error) {
+	marshaler, ok := in.(Marshaler)
+	if !ok {
+		// MarshalSCALE has a pointer receiver, so copy the value
+		// to an addressable value to call it.
+		ptr := reflect.New(reflect.TypeOf(in))
+		ptr.Elem().Set(reflect.ValueOf(in))
+		marshaler = ptr.Interface().(Marshaler)
+	}
+
+	b, err := marshaler.MarshalSCALE()
+	if err != nil {
+		return fmt.Errorf("marshaling %T: %w", in, err)
+	}
+
+	_, err = es.Write(b)
+	return
+}
+
+func (ds *decodeState) decodeUnmarshaler(dstv reflect.Value) (err error) {
+	// copy the destination value to keep any value set in it,
+	// as done for VaryingDataType.
+	temp := reflect.New(dstv.Type())
+	temp.Elem().Set(dstv)
+	// the decode state is given as reader so decoders created with
+	// NewDecoder in UnmarshalSCALE share the limits of this decoder.
+	err = temp.Interface().(Unmarshaler).UnmarshalSCALE(ds)
+	if err != nil {
+		return fmt.Errorf("unmarshaling %s: %w", dstv.Type(), err)
+	}
+	dstv.Set(temp.Elem())
+	return
+}
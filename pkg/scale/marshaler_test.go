@@ -0,0 +1,166 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bitFlags is bit packed in a single byte by its
+// MarshalSCALE and UnmarshalSCALE methods.
+type bitFlags struct {
+	First  bool
+	Second bool
+}
+
+func (b bitFlags) MarshalSCALE() ([]byte, error) {
+	var packed byte
+	if b.First {
+		packed |= 1
+	}
+	if b.Second {
+		packed |= 2
+	}
+	return []byte{packed}, nil
+}
+
+func (b *bitFlags) UnmarshalSCALE(reader io.Reader) error {
+	buffer := make([]byte, 1)
+	_, err := io.ReadFull(reader, buffer)
+	if err != nil {
+		return err
+	}
+	b.First = buffer[0]&1 != 0
+	b.Second = buffer[0]&2 != 0
+	return nil
+}
+
+// pointerMarshaler has its MarshalSCALE method defined on its pointer receiver.
+type pointerMarshaler uint16
+
+func (p *pointerMarshaler) MarshalSCALE() ([]byte, error) {
+	return []byte{byte(*p)}, nil
+}
+
+func (p *pointerMarshaler) UnmarshalSCALE(reader io.Reader) error {
+	buffer := make([]byte, 1)
+	_, err := io.ReadFull(reader, buffer)
+	if err != nil {
+		return err
+	}
+	*p = pointerMarshaler(buffer[0])
+	return nil
+}
+
+var errTest = errors.New("test error")
+
+type failingMarshaler struct{}
+
+func (failingMarshaler) MarshalSCALE() ([]byte, error) { return nil, errTest }
+
+func (*failingMarshaler) UnmarshalSCALE(io.Reader) error { return errTest }
+
+type structWithMarshalers struct {
+	Flags   bitFlags
+	Number  uint16
+	Pointer pointerMarshaler
+}
+
+func Test_Marshaler(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		in       interface{}
+		encoding []byte
+		dst      interface{}
+	}{
+		"value receiver": {
+			in:       bitFlags{First: true, Second: true},
+			encoding: []byte{3},
+			dst:      new(bitFlags),
+		},
+		"pointer receiver": {
+			in:       pointerMarshaler(7),
+			encoding: []byte{7},
+			dst:      new(pointerMarshaler),
+		},
+		"option": {
+			in:       &bitFlags{Second: true},
+			encoding: []byte{1, 2},
+			dst:      new(*bitFlags),
+		},
+		"slice": {
+			in:       []bitFlags{{First: true}, {Second: true}},
+			encoding: []byte{8, 1, 2},
+			dst:      new([]bitFlags),
+		},
+		"array": {
+			in:       [2]pointerMarshaler{1, 2},
+			encoding: []byte{1, 2},
+			dst:      new([2]pointerMarshaler),
+		},
+		"struct fields": {
+			in: structWithMarshalers{
+				Flags:   bitFlags{First: true},
+				Number:  5,
+				Pointer: 9,
+			},
+			encoding: []byte{1, 5, 0, 9},
+			dst:      new(structWithMarshalers),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			encoding, err := Marshal(testCase.in)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.encoding, encoding)
+
+			buffer := bytes.NewBuffer(nil)
+			err = NewEncoder(buffer).Encode(testCase.in)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.encoding, buffer.Bytes())
+
+			err = Unmarshal(testCase.encoding, testCase.dst)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.in, dereference(testCase.dst))
+
+			// append a trailing byte to check the decoder
+			// only reads the encoding of the value.
+			reader := bytes.NewReader(append(testCase.encoding, 0xff))
+			err = NewDecoder(reader).Decode(testCase.dst)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.in, dereference(testCase.dst))
+			assert.Equal(t, 1, reader.Len())
+		})
+	}
+}
+
+func Test_Marshaler_errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Marshal([]failingMarshaler{{}})
+	assert.ErrorIs(t, err, errTest)
+	assert.EqualError(t, err, "marshaling scale.failingMarshaler: test error")
+
+	var dst []failingMarshaler
+	err = Unmarshal([]byte{4, 0}, &dst)
+	assert.ErrorIs(t, err, errTest)
+	assert.EqualError(t, err, "unmarshaling scale.failingMarshaler: test error")
+}
+
+// dereference returns the value pointed to by the pointer given.
+func dereference(ptr interface{}) interface{} {
+	return reflect.ValueOf(ptr).Elem().Interface()
+}
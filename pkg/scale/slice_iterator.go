@@ -0,0 +1,61 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNoMoreItems = errors.New("no more items to decode")
+
+// SliceIterator decodes the items of a SCALE encoded slice one by one,
+// without holding all the items in memory.
+type SliceIterator struct {
+	decoder *Decoder
+	length  uint
+	decoded uint
+}
+
+// SliceIterator reads the length prefix of a SCALE encoded slice and returns
+// an iterator to decode its items one by one. The length is checked against
+// the maximum collection length of the decoder, and the other limits of the
+// decoder apply to each item decoded. The decoder must not be used to decode
+// other values until all the items are decoded.
+func (d *Decoder) SliceIterator() (iterator *SliceIterator, err error) {
+	length, err := d.decodeLength()
+	if err != nil {
+		return nil, fmt.Errorf("decoding slice length: %w", err)
+	}
+
+	return &SliceIterator{
+		decoder: d,
+		length:  length,
+	}, nil
+}
+
+// Len returns the number of items of the slice.
+func (s *SliceIterator) Len() uint {
+	return s.length
+}
+
+// Next returns true if there is an item left to decode.
+func (s *SliceIterator) Next() bool {
+	return s.decoded < s.length
+}
+
+// Decode decodes the next item of the slice into the destination pointer given.
+// It returns an error wrapping ErrNoMoreItems if all the items are already decoded.
+func (s *SliceIterator) Decode(dst interface{}) (err error) {
+	if !s.Next() {
+		return fmt.Errorf("%w: all %d items are decoded", ErrNoMoreItems, s.length)
+	}
+
+	err = s.decoder.Decode(dst)
+	if err != nil {
+		return fmt.Errorf("decoding item %d of %d: %w", s.decoded, s.length, err)
+	}
+	s.decoded++
+	return nil
+}
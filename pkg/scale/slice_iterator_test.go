@@ -0,0 +1,82 @@
+// Copyright 2022 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SliceIterator(t *testing.T) {
+	t.Parallel()
+
+	encoding, err := Marshal([][]byte{{1}, {2, 3}, {4, 5, 6}})
+	require.NoError(t, err)
+
+	// the allocation limit applies to each item decoded
+	decoder := NewDecoder(bytes.NewReader(encoding), MaxAllocationSize(3))
+
+	iterator, err := decoder.SliceIterator()
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), iterator.Len())
+
+	var items [][]byte
+	for iterator.Next() {
+		var item []byte
+		err = iterator.Decode(&item)
+		require.NoError(t, err)
+		items = append(items, item)
+	}
+	assert.Equal(t, [][]byte{{1}, {2, 3}, {4, 5, 6}}, items)
+
+	var item []byte
+	err = iterator.Decode(&item)
+	assert.ErrorIs(t, err, ErrNoMoreItems)
+	assert.EqualError(t, err, "no more items to decode: all 3 items are decoded")
+}
+
+func Test_SliceIterator_errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		encoding   []byte
+		options    []DecoderOption
+		errWrapped error
+		errMessage string
+	}{
+		"length exceeded": {
+			encoding:   []byte{12, 4, 1, 4, 2, 4, 3},
+			options:    []DecoderOption{MaxCollectionLength(2)},
+			errWrapped: ErrMaxCollectionLengthExceeded,
+			errMessage: "decoding slice length: maximum collection length exceeded: 3 is larger than 2",
+		},
+		"item allocation exceeded": {
+			encoding:   []byte{8, 4, 1, 8, 2, 3},
+			options:    []DecoderOption{MaxAllocationSize(1)},
+			errWrapped: ErrMaxAllocationSizeExceeded,
+			errMessage: "decoding item 1 of 2: maximum allocation size exceeded: 2 bytes is larger than 1 bytes",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			decoder := NewDecoder(bytes.NewReader(testCase.encoding), testCase.options...)
+
+			iterator, err := decoder.SliceIterator()
+			for err == nil && iterator.Next() {
+				var item []byte
+				err = iterator.Decode(&item)
+			}
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.EqualError(t, err, testCase.errMessage)
+		})
+	}
+}
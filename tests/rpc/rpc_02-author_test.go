@@ -12,6 +12,8 @@
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 
+	"github.com/ChainSafe/gossamer/dot/rpc/modules"
+	"github.com/ChainSafe/gossamer/lib/common"
 	libutils "github.com/ChainSafe/gossamer/lib/utils"
 	"github.com/ChainSafe/gossamer/tests/utils/config"
 	"github.com/ChainSafe/gossamer/tests/utils/node"
@@ -146,10 +148,13 @@ func TestAuthorRPC(t *testing.T) { //nolint:tparallel
 
 	t.Run("author_rotateKeys", func(t *testing.T) {
 		t.Parallel()
-		t.SkipNow() // TODO
 
-		var target interface{} // TODO
-		fetchWithTimeout(ctx, t, "author_rotateKeys", "", target)
+		var target modules.KeyRotateResponse
+		fetchWithTimeout(ctx, t, "author_rotateKeys", "", &target)
+
+		publicKeys, err := common.HexToBytes(string(target))
+		require.NoError(t, err)
+		require.NotEmpty(t, publicKeys)
 	})
 
 	t.Run("author_hasSessionKeys", func(t *testing.T) {
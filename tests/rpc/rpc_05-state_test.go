@@ -35,12 +35,14 @@ func TestStateRPCResponseValidation(t *testing.T) { //nolint:tparallel
 	t.Run("state_call", func(t *testing.T) {
 		t.Parallel()
 
-		const params = `["", "","0x580d77a9136035a0bc3c3cd86286172f7f81291164c5914266073a30466fba21"]`
+		params := fmt.Sprintf(`["Core_version", "0x", "%s"]`, blockHash)
 		var response modules.StateCallResponse
 
 		fetchWithTimeout(ctx, t, "state_call", params, &response)
 
-		// TODO assert stateCallResponse
+		encodedVersion, err := common.HexToBytes(string(response))
+		require.NoError(t, err)
+		require.NotEmpty(t, encodedVersion)
 	})
 
 	t.Run("state_getKeysPaged", func(t *testing.T) {